@@ -0,0 +1,76 @@
+//go:build js && wasm
+
+// Command playground is goenums compiled to WebAssembly for the browser,
+// so an online playground can preview the code generated from pasted Go
+// source without a server.
+//
+// It registers a global goenumsGenerate(source, options) function returning
+// {files: [{name, content}], error}. The options object is optional:
+//
+//	filename     the name the source is parsed as (default "enums.go")
+//	output       comma-separated output formats, like -output (default "go")
+//	legacy       generate code without Go 1.23+ iterators, like -legacy
+//	insensitive  parse names case-insensitively, like -insensitive
+//
+// Per-type options are set with "// goenums:" directives in the source.
+// Build it with make build-wasm, which also copies the wasm_exec.js
+// support file next to it.
+package main
+
+import (
+	"context"
+	"strings"
+	"syscall/js"
+	"testing/fstest"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/pkg/goenums"
+	"github.com/donutnomad/goenums/source"
+)
+
+func main() {
+	js.Global().Set("goenumsGenerate", js.FuncOf(generate))
+	// Keep the functions callable for the life of the page
+	select {}
+}
+
+// generate is the JS-facing wrapper of goenums.Generate. The source is
+// kept in memory, so nothing is read from or written to a filesystem.
+func generate(_ js.Value, args []js.Value) any {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return map[string]any{"files": []any{}, "error": "goenumsGenerate(source, options): source must be a string"}
+	}
+	filename := "enums.go"
+	var cfg config.Configuration
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		opts := args[1]
+		if v := opts.Get("filename"); v.Type() == js.TypeString && v.String() != "" {
+			filename = v.String()
+		}
+		if v := opts.Get("output"); v.Type() == js.TypeString {
+			cfg.OutputFormat = v.String()
+		}
+		cfg.Legacy = opts.Get("legacy").Truthy()
+		cfg.Insensitive = opts.Get("insensitive").Truthy()
+	}
+	// Relative, as generated files are named after their source
+	filename = strings.TrimLeft(filename, "/")
+	fsys := file.NewOverlayFS(fstest.MapFS{filename: {Data: []byte(args[0].String())}})
+	files, err := goenums.Generate(context.Background(), goenums.Options{
+		Sources: []enum.Source{source.FromFileSystem(fsys, filename)},
+		Config:  cfg,
+	})
+	result := map[string]any{"files": []any{}, "error": ""}
+	if err != nil {
+		result["error"] = err.Error()
+		return result
+	}
+	out := make([]any, 0, len(files))
+	for _, f := range files {
+		out = append(out, map[string]any{"name": f.Name, "content": string(f.Content)})
+	}
+	result["files"] = out
+	return result
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/donutnomad/goenums/generator"
+	"github.com/donutnomad/goenums/generator/compat"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/strings"
+)
+
+// compatArgs finds the -compat flag in args, as "-compat=mode" or
+// "-compat mode" with one or two dashes, and returns its mode and the
+// remaining arguments. The other flags are those of the replaced tool,
+// which the goenums flag set does not know, so they are split off before
+// it parses anything.
+func compatArgs(args []string) (string, []string, bool) {
+	for i, arg := range args {
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if name == arg {
+			continue
+		}
+		if mode, ok := strings.CutPrefix(name, "compat="); ok {
+			return mode, append(append([]string{}, args[:i]...), args[i+1:]...), true
+		}
+		if name == "compat" && i+1 < len(args) {
+			return args[i+1], append(append([]string{}, args[:i]...), args[i+2:]...), true
+		}
+	}
+	return "", nil, false
+}
+
+// compatOptions are the flags of stringer and enumer goenums accepts, and
+// the inputs following them.
+type compatOptions struct {
+	typeNames   string
+	output      string
+	trimPrefix  string
+	lineComment bool
+	tags        string
+	transform   string
+	handlers    config.Handlers
+	inputs      []string
+}
+
+// parseCompatFlags parses the arguments of the tool named by mode, which
+// must set -type.
+func parseCompatFlags(mode string, args []string, errorHandling flag.ErrorHandling) (compatOptions, error) {
+	var opts compatOptions
+	fs := flag.NewFlagSet(mode, errorHandling)
+	if errorHandling == flag.ContinueOnError {
+		fs.SetOutput(io.Discard)
+	}
+	fs.StringVar(&opts.typeNames, "type", "", "Comma-separated list of type names; must be set")
+	fs.StringVar(&opts.output, "output", "", "Output file name; default srcdir/<type>_string.go, or <type>_enumer.go with enumer")
+	fs.StringVar(&opts.trimPrefix, "trimprefix", "", "Trim the prefix from the generated constant names")
+	fs.BoolVar(&opts.lineComment, "linecomment", false, "Use line comment text as printed text when present")
+	fs.StringVar(&opts.tags, "tags", "", "Comma-separated list of build tags to apply")
+	if mode == compat.ModeEnumer {
+		fs.StringVar(&opts.transform, "transform", "noop", "Case transform applied to the names: snake, snake-upper, kebab, kebab-upper, lower, upper, title, title-lower, first, first-upper, first-lower, whitespace")
+		fs.BoolVar(&opts.handlers.JSON, "json", false, "Generate JSON marshaling methods")
+		fs.BoolVar(&opts.handlers.Text, "text", false, "Generate text marshaling methods")
+		fs.BoolVar(&opts.handlers.YAML, "yaml", false, "Generate YAML marshaling methods")
+		fs.BoolVar(&opts.handlers.SQL, "sql", false, "Generate the sql.Scanner and driver.Valuer methods")
+	}
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if opts.typeNames == "" {
+		return opts, errors.New("-type must be set")
+	}
+	opts.inputs = fs.Args()
+	if len(opts.inputs) == 0 {
+		opts.inputs = []string{"."}
+	}
+	return opts, nil
+}
+
+// runCompat generates the methods of stringer or enumer, accepting the
+// flags of the tool named by mode so their go:generate lines only need the
+// command swapped. It exits with status 1 when nothing could be generated.
+func runCompat(ctx context.Context, mode string, args []string) {
+	if err := compat.ValidateMode(mode); err != nil {
+		slog.Default().ErrorContext(ctx, "invalid -compat", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	opts, err := parseCompatFlags(mode, args, flag.ExitOnError)
+	if err == nil {
+		err = compatGenerate(ctx, mode, opts)
+	}
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "could not generate", slog.String("mode", mode), slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+// compatGenerate writes the methods of the tool named by mode.
+func compatGenerate(ctx context.Context, mode string, opts compatOptions) error {
+	cfg := config.Configuration{
+		Defaults: config.EnumTypeConfig{Handlers: opts.handlers},
+	}
+	if opts.tags != "" {
+		cfg.BuildTags = strings.Split(opts.tags, ",")
+	}
+	gen := generator.New(
+		generator.WithConfig(cfg),
+		generator.WithParser(compat.NewParser(
+			compat.WithParserConfiguration(cfg),
+			compat.WithInputs(opts.inputs...),
+			compat.WithTypes(strings.Split(opts.typeNames, ",")...),
+			compat.WithTrimPrefix(opts.trimPrefix),
+			compat.WithTransform(opts.transform),
+			compat.WithLineComment(opts.lineComment),
+		)),
+		generator.WithWriter(compat.NewWriter(
+			compat.WithWriterConfiguration(cfg),
+			compat.WithMode(mode),
+			compat.WithOutput(opts.output),
+		)),
+	)
+	return gen.ParseAndWrite(ctx)
+}
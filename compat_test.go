@@ -0,0 +1,49 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompatArgs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		wantMode string
+		wantRest []string
+		wantOK   bool
+	}{
+		{
+			name:     "equals",
+			args:     []string{"-compat=stringer", "-type=Pill"},
+			wantMode: "stringer",
+			wantRest: []string{"-type=Pill"},
+			wantOK:   true,
+		},
+		{
+			name:     "separate value with two dashes",
+			args:     []string{"-type", "Pill", "--compat", "enumer", "-json", "."},
+			wantMode: "enumer",
+			wantRest: []string{"-type", "Pill", "-json", "."},
+			wantOK:   true,
+		},
+		{
+			name: "zarldev compatibility is a directive",
+			args: []string{"-compat/zarldev", "status.go"},
+		},
+		{
+			name: "missing value",
+			args: []string{"-compat"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mode, rest, ok := compatArgs(tt.args)
+			if ok != tt.wantOK || mode != tt.wantMode || !slices.Equal(rest, tt.wantRest) {
+				t.Errorf("compatArgs(%q) = %q, %q, %v", tt.args, mode, rest, ok)
+			}
+		})
+	}
+}
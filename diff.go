@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/internal/enumdiff"
+	"github.com/donutnomad/goenums/source"
+	"github.com/donutnomad/goenums/strings"
+)
+
+// runDiff prints the changes between the enums of two revisions given in
+// args, as text or with -json as JSON. A revision is a file, or "rev:path"
+// naming a file in a git revision; a single file is compared with its
+// version at HEAD. It exits with status 1 when a change is breaking.
+func runDiff(ctx context.Context, cfg config.Configuration, args []string) {
+	// Keep stdout for the changes
+	configureLogging(os.Stderr, cfg)
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Print the changes as JSON")
+	_ = fs.Parse(args)
+	var oldRef, newRef string
+	switch fs.NArg() {
+	case 1:
+		newRef = fs.Arg(0)
+		oldRef = headRevision(newRef)
+	case 2:
+		oldRef, newRef = fs.Arg(0), fs.Arg(1)
+	default:
+		slog.Default().ErrorContext(ctx, "usage: goenums diff [-json] [old] new")
+		os.Exit(1)
+	}
+	changes, err := diffEnums(ctx, cfg, oldRef, newRef)
+	if err == nil {
+		if *asJSON {
+			err = writeDiffJSON(os.Stdout, changes)
+		} else {
+			err = writeDiffText(os.Stdout, changes)
+		}
+	}
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "could not compare enums", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	if enumdiff.Breaking(changes) {
+		os.Exit(1)
+	}
+}
+
+// headRevision returns the revision naming the file at HEAD. Paths
+// starting with "./" are resolved by git relative to the working directory.
+func headRevision(filename string) string {
+	if filepath.IsAbs(filename) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, filename); err == nil {
+				filename = rel
+			}
+		}
+	}
+	return "HEAD:./" + filepath.ToSlash(filename)
+}
+
+// diffEnums parses both revisions and compares their enums.
+func diffEnums(ctx context.Context, cfg config.Configuration, oldRef, newRef string) ([]enumdiff.Change, error) {
+	old, err := parseRevision(ctx, cfg, oldRef)
+	if err != nil {
+		return nil, err
+	}
+	current, err := parseRevision(ctx, cfg, newRef)
+	if err != nil {
+		return nil, err
+	}
+	return enumdiff.Compare(old, current), nil
+}
+
+// parseRevision returns the enums of a revision: a file, a URL, or
+// "rev:path" read with git show.
+func parseRevision(ctx context.Context, cfg config.Configuration, ref string) ([]enum.GenerationRequest, error) {
+	src, err := revisionSource(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	// Parsers record the configuration of the types they find
+	cfg.EnumTypeConfigs = maps.Clone(cfg.EnumTypeConfigs)
+	if cfg.EnumTypeConfigs == nil {
+		cfg.EnumTypeConfigs = make(map[string]config.EnumTypeConfig)
+	}
+	parser, _, err := newSourceParser(cfg, src)
+	if err != nil {
+		return nil, err
+	}
+	reqs, err := parser.Parse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ref, err)
+	}
+	return reqs, nil
+}
+
+func revisionSource(ctx context.Context, ref string) (enum.Source, error) {
+	if source.IsURL(ref) {
+		src := source.FromURL(ref)
+		if _, err := src.Fetch(ctx); err != nil {
+			return nil, err
+		}
+		return src, nil
+	}
+	rev, path, ok := strings.Cut(ref, ":")
+	if _, err := os.Stat(ref); err == nil || !ok || rev == "" {
+		return source.FromFile(ref), nil
+	}
+	out, err := exec.CommandContext(ctx, "git", "show", ref).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			err = errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git show %s: %w", ref, err)
+	}
+	// Named after the path, so a Go file still on disk is checked with the
+	// rest of its package
+	return source.FromNamedReader(bytes.NewReader(out), filepath.FromSlash(strings.TrimPrefix(path, "./"))), nil
+}
+
+func writeDiffJSON(w io.Writer, changes []enumdiff.Change) error {
+	if changes == nil {
+		changes = []enumdiff.Change{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(changes)
+}
+
+func writeDiffText(w io.Writer, changes []enumdiff.Change) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "no enum changes")
+		return err
+	}
+	for _, c := range changes {
+		if _, err := fmt.Fprintln(w, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
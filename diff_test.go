@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/donutnomad/goenums/generator/config"
+)
+
+func TestDiffEnums(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "old", "status.go")
+	writeFile(t, oldFile, `package orders
+
+// goenums: -serde/value
+type status int
+
+const (
+	pending status = iota
+	shipped
+)
+`)
+	newFile := filepath.Join(dir, "new", "status.go")
+	writeFile(t, newFile, `package orders
+
+// goenums: -serde/value
+type status int
+
+const (
+	shipped status = iota
+	pending
+)
+`)
+	changes, err := diffEnums(t.Context(), config.Configuration{}, oldFile, newFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var b bytes.Buffer
+	if err := writeDiffText(&b, changes); err != nil {
+		t.Fatal(err)
+	}
+	want := "status: renumbered pending from 0 to 1 [breaking]\nstatus: renumbered shipped from 1 to 0 [breaking]\n"
+	if b.String() != want {
+		t.Errorf("unexpected changes:\n%s", b.String())
+	}
+
+	if _, err := diffEnums(t.Context(), config.Configuration{}, oldFile, filepath.Join(dir, "status.txt")); err == nil {
+		t.Errorf("expected an error for an unsupported file")
+	}
+}
+
+func TestHeadRevision(t *testing.T) {
+	t.Parallel()
+	if got := headRevision(filepath.Join("orders", "status.go")); got != "HEAD:./orders/status.go" {
+		t.Errorf("headRevision() = %q", got)
+	}
+}
@@ -0,0 +1,166 @@
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrCommentSyntax indicates a malformed enum comment, such as an
+	// unterminated quoted string, unbalanced brackets or an empty item of
+	// a list.
+	ErrCommentSyntax = errors.New("invalid comment syntax")
+)
+
+// CommentSyntaxError is an ErrCommentSyntax found at an offset of the text
+// tokenized.
+type CommentSyntaxError struct {
+	// Offset is the byte offset of the problem in the text tokenized
+	Offset int
+	// Msg describes the problem
+	Msg string
+}
+
+func (e *CommentSyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrCommentSyntax, e.Msg)
+}
+
+func (e *CommentSyntaxError) Unwrap() error {
+	return ErrCommentSyntax
+}
+
+// Token is an item of a list in an enum comment.
+type Token struct {
+	// Raw is the item as written, without the spaces around it
+	Raw string
+	// Offset is the byte offset of Raw in the text tokenized
+	Offset int
+}
+
+// Unquoted returns the value of the token when it is a double-quoted
+// string, whose escape sequences are those of Go string literals.
+func (t Token) Unquoted() (string, bool) {
+	if len(t.Raw) < 2 || t.Raw[0] != '"' || t.Raw[len(t.Raw)-1] != '"' {
+		return "", false
+	}
+	s, err := strconv.Unquote(t.Raw)
+	return s, err == nil
+}
+
+// Text returns the value of a quoted token, or the token as written. The
+// quotes of a quoted token with invalid escape sequences are dropped.
+func (t Token) Text() string {
+	if len(t.Raw) < 2 || t.Raw[0] != '"' || t.Raw[len(t.Raw)-1] != '"' {
+		return t.Raw
+	}
+	if s, ok := t.Unquoted(); ok {
+		return s
+	}
+	return t.Raw[1 : len(t.Raw)-1]
+}
+
+// closers maps the brackets opening nested values to those closing them.
+var closers = map[byte]byte{'[': ']', '(': ')', '{': '}'}
+
+// scanComment calls sep with the offset of every byte of s outside quoted
+// strings and brackets that may separate items, stopping when it returns
+// false. It returns the first syntax error of s, having scanned it all.
+func scanComment(s string, isSep func(byte) bool, sep func(int) bool) error {
+	var (
+		firstErr error
+		stack    []int // offsets of the open brackets
+		quote    = -1  // offset of the open quote
+	)
+	fail := func(offset int, format string, args ...any) {
+		if firstErr == nil {
+			firstErr = &CommentSyntaxError{Offset: offset, Msg: fmt.Sprintf(format, args...)}
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote >= 0:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				if _, err := strconv.Unquote(s[quote : i+1]); err != nil {
+					fail(quote, "invalid quoted string %s", s[quote:i+1])
+				}
+				quote = -1
+			}
+		case c == '"':
+			quote = i
+		case closers[c] != 0:
+			stack = append(stack, i)
+		case c == ']' || c == ')' || c == '}':
+			if len(stack) == 0 || closers[s[stack[len(stack)-1]]] != c {
+				fail(i, "unbalanced %q", c)
+				continue
+			}
+			stack = stack[:len(stack)-1]
+		case len(stack) == 0 && isSep(c):
+			if !sep(i) {
+				return firstErr
+			}
+		}
+	}
+	if quote >= 0 {
+		fail(quote, "unterminated quoted string")
+	}
+	if len(stack) > 0 {
+		fail(stack[0], "unbalanced %q", s[stack[0]])
+	}
+	return firstErr
+}
+
+// SplitComment splits s at the sep bytes outside double-quoted strings and
+// brackets, so items may hold the separator, as in "Acme, Inc." or [a, b].
+// Quoted strings may escape quotes and other characters like Go string
+// literals do. Spaces around the items are trimmed.
+//
+// Every item is returned, empty ones included, along with the first syntax
+// error of s: an unterminated or invalid quoted string, unbalanced
+// brackets, or an empty item left by a stray separator. An empty s has no
+// items.
+func SplitComment(s string, sep byte) ([]Token, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var tokens []Token
+	start := 0
+	add := func(end int) {
+		raw := s[start:end]
+		trimmed := strings.TrimLeft(raw, " \t")
+		offset := start + len(raw) - len(trimmed)
+		tokens = append(tokens, Token{Raw: strings.TrimRight(trimmed, " \t"), Offset: offset})
+	}
+	err := scanComment(s, func(c byte) bool { return c == sep }, func(i int) bool {
+		add(i)
+		start = i + 1
+		return true
+	})
+	add(len(s))
+	if err == nil {
+		for _, t := range tokens {
+			if t.Raw == "" {
+				err = &CommentSyntaxError{Offset: t.Offset, Msg: fmt.Sprintf("empty item in %q", strings.TrimSpace(s))}
+				break
+			}
+		}
+	}
+	return tokens, err
+}
+
+// CutComment cuts s around its first space outside double-quoted strings
+// and brackets, separating the aliases of a value from its fields. It
+// returns the first syntax error of s along with the parts.
+func CutComment(s string) (before, after string, err error) {
+	before = s
+	err = scanComment(s, func(c byte) bool { return c == ' ' }, func(i int) bool {
+		before, after = s[:i], s[i+1:]
+		return false
+	})
+	return before, after, err
+}
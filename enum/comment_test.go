@@ -0,0 +1,96 @@
+package enum_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+)
+
+func TestSplitComment(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr string
+		offset  int
+	}{
+		{name: "empty", input: "  "},
+		{name: "items", input: "a, b ,c", want: []string{"a", "b", "c"}},
+		{name: "quoted separator", input: `"Acme, Inc.",b`, want: []string{`"Acme, Inc."`, "b"}},
+		{name: "escaped quote", input: `"say \"hi\", bye",b`, want: []string{`"say \"hi\", bye"`, "b"}},
+		{name: "brackets", input: "[a, b],(1, 2),{x, y}", want: []string{"[a, b]", "(1, 2)", "{x, y}"}},
+		{name: "nested brackets", input: "[[a, b], [c]],d", want: []string{"[[a, b], [c]]", "d"}},
+		{name: "stray separator", input: "a,,b", want: []string{"a", "", "b"}, wantErr: `empty item in "a,,b"`, offset: 2},
+		{name: "trailing separator", input: "a,b,", want: []string{"a", "b", ""}, wantErr: `empty item in "a,b,"`, offset: 4},
+		{name: "unterminated quote", input: `a,"b,c`, want: []string{"a", `"b,c`}, wantErr: "unterminated quoted string", offset: 2},
+		{name: "invalid escape", input: `"a\qb"`, want: []string{`"a\qb"`}, wantErr: `invalid quoted string "a\qb"`},
+		{name: "unclosed bracket", input: "a,[b,c", want: []string{"a", "[b,c"}, wantErr: `unbalanced '['`, offset: 2},
+		{name: "unopened bracket", input: "a],b", want: []string{"a]", "b"}, wantErr: `unbalanced ']'`, offset: 1},
+		{name: "mismatched brackets", input: "[a)", want: []string{"[a)"}, wantErr: `unbalanced ')'`, offset: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tokens, err := enum.SplitComment(tt.input, ',')
+			var got []string
+			for _, tok := range tokens {
+				got = append(got, tok.Raw)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var syntaxErr *enum.CommentSyntaxError
+			if !errors.As(err, &syntaxErr) || !errors.Is(err, enum.ErrCommentSyntax) {
+				t.Fatalf("got error %v, want a comment syntax error", err)
+			}
+			if syntaxErr.Msg != tt.wantErr || syntaxErr.Offset != tt.offset {
+				t.Errorf("got %q at %d, want %q at %d", syntaxErr.Msg, syntaxErr.Offset, tt.wantErr, tt.offset)
+			}
+		})
+	}
+}
+
+func TestSplitComment_Offsets(t *testing.T) {
+	t.Parallel()
+	tokens, err := enum.SplitComment(` a , "b" `, ',')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 || tokens[0].Offset != 1 || tokens[1].Offset != 5 {
+		t.Errorf("unexpected tokens %+v", tokens)
+	}
+	if got := tokens[1].Text(); got != "b" {
+		t.Errorf("got text %q, want %q", got, "b")
+	}
+}
+
+func TestCutComment(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input, before, after string
+		err                  bool
+	}{
+		{input: "Mercury 0.378,2439.7", before: "Mercury", after: "0.378,2439.7"},
+		{input: `"Red Planet" 0.377`, before: `"Red Planet"`, after: "0.377"},
+		{input: `"say \" hi" 1`, before: `"say \" hi"`, after: "1"},
+		{input: "[a b],1", before: "[a b],1"},
+		{input: "Mercury", before: "Mercury"},
+		{input: `"Mercury 1`, before: `"Mercury 1`, err: true},
+	}
+	for _, tt := range tests {
+		before, after, err := enum.CutComment(tt.input)
+		if before != tt.before || after != tt.after || (err != nil) != tt.err {
+			t.Errorf("CutComment(%q) = %q, %q, %v, want %q, %q, error %v",
+				tt.input, before, after, err, tt.before, tt.after, tt.err)
+		}
+	}
+}
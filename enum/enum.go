@@ -8,6 +8,7 @@
 //   - Parser: Extracts enum definitions from source content
 //   - Writer: Generates output artifacts from enum representations
 //   - Source: Provides raw content for parsing
+//   - Plugin: Appends custom code to the generated Go files
 //
 // This design enables a modular system where different input formats and output targets
 // can be supported without modifying the core workflow.
@@ -18,6 +19,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"go/token"
+	"io"
+	"reflect"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -43,14 +48,60 @@ type Parser interface {
 // including the package name, imports, enum type and value information,
 // and configuration options.
 type GenerationRequest struct {
-	Package        string
-	Imports        []string
-	EnumIota       EnumIota   // For backward compatibility - single enum
-	EnumIotas      []EnumIota // For multiple enums from the same file
-	Version        string
-	SourceFilename string
-	OutputFilename string
-	Configuration  config.Configuration
+	Package         string
+	BuildConstraint string // The //go:build expression guarding the output, empty when unconstrained
+	Imports         []string
+	FieldImports    []Import   // Non-standard packages referenced by field types
+	EnumIota        EnumIota   // For backward compatibility - single enum
+	EnumIotas       []EnumIota // For multiple enums from the same file
+	Version         string
+	SourceFilename  string
+	OutputFilename  string
+	Configuration   config.Configuration
+}
+
+// Import is a package imported by the generated code.
+// Name is the local package name used to qualify identifiers from the package;
+// it is only written to the import declaration when it differs from the last
+// element of Path.
+type Import struct {
+	Name string
+	Path string
+}
+
+// DefaultImportName returns the package name assumed for an import path
+// without an explicit name: its last element, skipping a major version
+// suffix such as /v2 or gopkg.in's .v3.
+func DefaultImportName(path string) string {
+	isVersion := func(s string) bool {
+		return len(s) > 1 && s[0] == 'v' && strings.Trim(s[1:], "0123456789") == ""
+	}
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isVersion(name) {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.LastIndex(name, "."); i > 0 && isVersion(name[i+1:]) {
+		name = name[:i]
+	}
+	return name
+}
+
+// Expr is a field value of a type goenums cannot evaluate itself, such as a
+// type declared in another package. Type is the qualified type name and Value
+// the source expression, both written to the generated code verbatim.
+type Expr struct {
+	Type  string
+	Value string
+}
+
+// Qualifier returns the package name qualifying Type, or "" for unqualified types.
+func (e Expr) Qualifier() string {
+	t := strings.TrimLeft(e.Type, "*[]")
+	if i := strings.Index(t, "."); i > 0 {
+		return t[:i]
+	}
+	return ""
 }
 
 // GetEnumIotas returns all enum iotas, supporting both single and multiple enums
@@ -130,6 +181,50 @@ func (r GenerationRequest) Command() string {
 		b.WriteString(" -o ")
 		b.WriteString(r.Configuration.OutputFormat)
 	}
+	if r.Configuration.MigrationsDir != "" {
+		b.WriteString(" -migrations ")
+		b.WriteString(r.Configuration.MigrationsDir)
+	}
+	if r.Configuration.MigrationFormat != "" {
+		b.WriteString(" -migration-format ")
+		b.WriteString(r.Configuration.MigrationFormat)
+	}
+	if r.Configuration.YAMLLibrary != "" {
+		b.WriteString(" -yaml-library ")
+		b.WriteString(r.Configuration.YAMLLibrary)
+	}
+	if len(r.Configuration.SectionOrder) > 0 {
+		b.WriteString(" -section-order ")
+		b.WriteString(strings.Join(r.Configuration.SectionOrder, ","))
+	}
+	if len(r.Configuration.SkipSections) > 0 {
+		b.WriteString(" -skip ")
+		b.WriteString(strings.Join(r.Configuration.SkipSections, ","))
+	}
+	if len(r.Configuration.OnlySections) > 0 {
+		b.WriteString(" -only ")
+		b.WriteString(strings.Join(r.Configuration.OnlySections, ","))
+	}
+	if len(r.Configuration.Plurals) > 0 {
+		plurals := make([]string, 0, len(r.Configuration.Plurals))
+		for singular, plural := range r.Configuration.Plurals {
+			plurals = append(plurals, singular+":"+plural)
+		}
+		slices.Sort(plurals)
+		b.WriteString(" -plurals ")
+		b.WriteString(strings.Join(plurals, ","))
+	}
+	if r.Configuration.Stdout {
+		b.WriteString(" -stdout")
+	}
+	if len(r.Configuration.BuildTags) > 0 {
+		b.WriteString(" -tags ")
+		b.WriteString(strings.Join(r.Configuration.BuildTags, ","))
+	}
+	for _, directive := range r.Configuration.Defaults.Directives() {
+		b.WriteString(" ")
+		b.WriteString(directive)
+	}
 
 	// Add source filename
 	if r.SourceFilename != "" {
@@ -161,17 +256,30 @@ func (r GenerationRequest) Command() string {
 type EnumIota struct {
 	// Type is the name of the enum type (e.g., "Status", "Color")
 	Type string
+	// ImportedType is the qualified name of the type, such as
+	// "types.Status", when it is declared in another package than its
+	// constants. Type is then an alias of it declared by the generated code,
+	// and the package is among the field imports of the request.
+	ImportedType string
 	// UnderlyingType is the underlying type (e.g., "int", "float32", "string")
 	UnderlyingType string
-	// Comment is the documentation comment associated with the enum type
+	// Comment is the line comment associated with the enum type, which
+	// declares its fields in Go sources
 	Comment string
+	// Doc is the documentation of the enum type, without goenums directives
+	Doc string
+	// Default is the name of the value declared with a "default:" line in the
+	// documentation of the type, which unmarshalers generated with
+	// -default-on-error fall back to
+	Default string
 	// Fields defines custom fields that each enum value can have
 	Fields []Field
 	// Opener is the opening delimiter for field values (e.g., "[", "(")
 	Opener string
 	// Closer is the closing delimiter for field values (e.g., "]", ")")
 	Closer string
-	// StartIndex is the starting index for enum values (usually 0)
+	// StartIndex is the value of the first enum value (usually 0), which
+	// may be negative
 	StartIndex int
 	// Enums contains all the individual enum values for this type
 	Enums []Enum
@@ -224,6 +332,10 @@ type Enum struct {
 	Name string
 	// Index is the numeric position of this enum in the sequence (0-based)
 	Index int
+	// Value is the exact value of the constant as a Go literal, such as
+	// 1000, 1.5 or "pending", when known. Unlike Index it holds values
+	// beyond the int range, such as uint64 values from 1<<63.
+	Value string
 	// Fields contains any custom field values associated with this enum
 	Fields []Field
 	// Aliases are alternative names that can be used to reference this enum
@@ -234,8 +346,48 @@ type Enum struct {
 	CustomComment string
 	// StateTransitions contains the allowed next states for state machine support
 	StateTransitions []string
+	// StateEvents are the names of the events firing the transitions of
+	// StateTransitions at the same index, empty for transitions without
+	// one. It is nil when no transition names an event.
+	StateEvents []string
 	// IsFinalState indicates if this is a terminal state in the state machine
 	IsFinalState bool
+	// SerdeName overrides the name used when serializing by name, leaving
+	// the display name returned by String unchanged
+	SerdeName string
+	// SerdeFormat overrides the serialization format of the type for this
+	// value, SerdeFormatName or SerdeFormatValue from a "serde:" annotation.
+	// It is empty when the value follows its type.
+	SerdeFormat string
+	// LegacyAliases are historic names that still parse to this value but
+	// are never used when serializing
+	LegacyAliases []string
+	// Deprecated indicates the value is documented with a "Deprecated:" notice.
+	// Deprecated values remain parseable but are left out of All.
+	Deprecated bool
+	// Groups are the names of the groups the value belongs to, from "group:"
+	// annotations, such as "terminal" or "failure"
+	Groups []string
+	// Tags are arbitrary labels of the value from "tags:" annotations, which
+	// callers filter the values by at runtime
+	Tags []string
+	// Position is where the value is declared, when parsed from Go source
+	Position token.Position
+}
+
+// The per-value serialization formats of Enum.SerdeFormat.
+const (
+	SerdeFormatName  = "name"
+	SerdeFormatValue = "value"
+)
+
+// Literal returns the value of e as a Go literal: Value when known and
+// Index otherwise.
+func (e Enum) Literal() string {
+	if e.Value != "" {
+		return e.Value
+	}
+	return strconv.Itoa(e.Index)
 }
 
 // Source abstracts the origin of input content to be parsed for enum definitions.
@@ -263,40 +415,49 @@ type Writer interface {
 	Write(ctx context.Context, enums []GenerationRequest) error
 }
 
+// Plugin defines the contract for components that append code of their own,
+// such as company-specific methods, to the Go files generated for enums
+// without forking the templates. A plugin is given each request once per
+// generated file, and its code follows the generated sections.
+type Plugin interface {
+	// Imports returns the paths of the packages the code written for req
+	// uses, which are added to the imports of the generated file.
+	Imports(req GenerationRequest) []string
+	// Write writes Go declarations for the enum types of req.
+	Write(w io.Writer, req GenerationRequest) error
+}
+
+// ParseEnumAliases parses the comma-separated aliases of an enum value.
+// Aliases may be double-quoted to hold commas, spaces or escaped quotes.
+// Empty aliases are dropped; use SplitComment to report them.
 func ParseEnumAliases(s string) []string {
 	if !strings.Contains(s, ",") {
-		// Handle single case without slice allocation
-		trimmed := strings.TrimSpace(s)
-		if len(trimmed) >= 2 && trimmed[0] == '"' && trimmed[len(trimmed)-1] == '"' {
-			trimmed = trimmed[1 : len(trimmed)-1]
-		}
-		return []string{trimmed}
+		// Handle single case without tokenizing
+		return []string{Token{Raw: strings.TrimSpace(s)}.Text()}
 	}
 
-	aliases := strings.Split(s, ",")
-	// Process in-place to avoid second allocation
-	j := 0
-	for _, alias := range aliases {
-		alias = strings.TrimSpace(alias)
-		if len(alias) == 0 {
+	tokens, _ := SplitComment(s, ',')
+	aliases := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if t.Raw == "" {
 			continue
 		}
-		if len(alias) >= 2 && alias[0] == '"' && alias[len(alias)-1] == '"' {
-			alias = alias[1 : len(alias)-1]
-		}
-		aliases[j] = alias
-		j++
+		aliases = append(aliases, t.Text())
 	}
-	return aliases[:j] // Return slice of actual length
+	return aliases
 }
 
 var (
 	ErrFieldEmptyValue = errors.New("empty field value")
 )
 
+// ParseEnumFields parses the comma-separated field values of an enum value
+// into the fields of enumIota. Values may be double-quoted, and commas
+// within quotes or brackets do not separate values. Values beyond the
+// fields of enumIota are ignored; use SplitComment to count them.
 func ParseEnumFields(s string, enumIota EnumIota) ([]Field, error) {
-	fieldValues := strings.Split(s, ",")
-	if len(fieldValues) == 1 && fieldValues[0] == "" {
+	fieldValues, _ := SplitComment(s, ',')
+	if len(fieldValues) == 0 {
 		return []Field{}, nil
 	}
 
@@ -307,12 +468,18 @@ func ParseEnumFields(s string, enumIota EnumIota) ([]Field, error) {
 	enumFields := make([]Field, 0, minLen)
 
 	for i := range minLen {
-		valRaw := strings.TrimSpace(fieldValues[i])
+		valRaw := fieldValues[i].Raw
 		if valRaw == "" {
 			return []Field{}, ErrFieldEmptyValue
 		}
 
 		val, err := ParseValue(valRaw, enumIota.Fields[i].Value)
+		// Quoted strings may escape characters, including quotes
+		if unquoted, ok := fieldValues[i].Unquoted(); ok {
+			if _, isString := enumIota.Fields[i].Value.(string); isString {
+				val, err = unquoted, nil
+			}
+		}
 		if err != nil {
 			return []Field{}, err
 		}
@@ -346,7 +513,11 @@ var (
 
 func ParseValue[T any](valRaw string, defaultVal T) (T, error) {
 	var zero T
-	switch any(defaultVal).(type) {
+	switch d := any(defaultVal).(type) {
+	case Expr:
+		if v, ok := any(Expr{Type: d.Type, Value: valRaw}).(T); ok {
+			return v, nil
+		}
 	case bool:
 		val, err := strconv.ParseBool(valRaw)
 		if err != nil {
@@ -477,11 +648,42 @@ func ParseValue[T any](valRaw string, defaultVal T) (T, error) {
 			return v, nil
 		}
 	default:
-		return zero, fmt.Errorf("%w: %w", ErrParseValue, ErrUnsupportedType)
+		rv := reflect.ValueOf(defaultVal)
+		if rv.Kind() != reflect.Slice {
+			return zero, fmt.Errorf("%w: %w", ErrParseValue, ErrUnsupportedType)
+		}
+		val, err := parseSlice(valRaw, rv.Type())
+		if err != nil {
+			return zero, err
+		}
+		if v, ok := val.(T); ok {
+			return v, nil
+		}
 	}
 	return zero, fmt.Errorf("%w: %w", ErrParseValue, ErrUnsupportedType)
 }
 
+// parseSlice parses a slice value written like fmt prints slices, as
+// "[a b c]", into a slice of type typ. Elements are separated by spaces or
+// "|", as commas separate the fields of an enum value.
+func parseSlice(valRaw string, typ reflect.Type) (any, error) {
+	inner, hasPrefix := strings.CutPrefix(valRaw, "[")
+	inner, hasSuffix := strings.CutSuffix(inner, "]")
+	if !hasPrefix || !hasSuffix {
+		return nil, fmt.Errorf("%w: %s is not a slice, want [a b c]", ErrParseValue, valRaw)
+	}
+	elemZero := reflect.Zero(typ.Elem()).Interface()
+	s := reflect.MakeSlice(typ, 0, 0)
+	for _, raw := range strings.FieldsFunc(inner, func(r rune) bool { return r == ' ' || r == '|' }) {
+		elem, err := ParseValue(raw, elemZero)
+		if err != nil {
+			return nil, err
+		}
+		s = reflect.Append(s, reflect.ValueOf(elem))
+	}
+	return s.Interface(), nil
+}
+
 func ExtractImports(enumIotas []EnumIota) []string {
 	totalFields := 0
 	for _, enumIota := range enumIotas {
@@ -490,7 +692,10 @@ func ExtractImports(enumIotas []EnumIota) []string {
 	imports := make([]string, 0, totalFields)
 	for _, enumIota := range enumIotas {
 		for _, field := range enumIota.Fields {
-			str := fmt.Sprintf("%T", field.Value)
+			if _, ok := field.Value.(Expr); ok {
+				continue
+			}
+			str := strings.TrimPrefix(fmt.Sprintf("%T", field.Value), "[]")
 			if strings.Contains(str, ".") {
 				imports = append(imports, strings.Split(str, ".")[0])
 			}
@@ -534,8 +739,9 @@ func ExtractFields(comment string) (string, string, []Field) {
 		if nO == -1 {
 			continue
 		}
-		nC = strings.Index(field[nO:], closer) + nO
-		if nC == -1 {
+		// The last closer ends the type, which may hold brackets itself, as in Tags[[]string]
+		nC = strings.LastIndex(field, closer)
+		if nC <= nO {
 			continue
 		}
 		tO = nO + len(open)
@@ -554,17 +760,18 @@ func ExtractFields(comment string) (string, string, []Field) {
 	return open, closer, fields
 }
 
+// OpenCloser returns the delimiters of the type in a field declaration: the
+// first of a space, "[" or "(" following the field name. Types may contain
+// brackets themselves, as in "Tags []string" or "Tags([]string)".
 func OpenCloser(field string) (string, string) {
-	open := " "
-	closer := " "
-	if strings.Contains(field, "[") {
-		open = "["
-		closer = "]"
-	} else if strings.Contains(field, "(") {
-		open = "("
-		closer = ")"
-	}
-	return open, closer
+	switch i := strings.IndexAny(field, " [("); {
+	case i <= 0 || field[i] == ' ':
+		return " ", " "
+	case field[i] == '[':
+		return "[", "]"
+	default:
+		return "(", ")"
+	}
 }
 
 func FieldToType(field string) any {
@@ -613,6 +820,19 @@ func FieldToType(field string) any {
 	case "uintptr":
 		return uintptr(0)
 	default:
+		if elem, ok := strings.CutPrefix(f, "[]"); ok {
+			if v := FieldToType(elem); v != nil {
+				if _, isExpr := v.(Expr); !isExpr {
+					return reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(v)), 0, 0).Interface()
+				}
+			}
+		}
+		if qualifiedType.MatchString(f) {
+			return Expr{Type: f}
+		}
 		return nil
 	}
 }
+
+// qualifiedType matches exported types from other packages, e.g. money.Currency or *geo.Point.
+var qualifiedType = regexp.MustCompile(`^[*]?(\[\])?[*]?[A-Za-z_][A-Za-z0-9_]*\.[A-Z][A-Za-z0-9_]*$`)
@@ -79,6 +79,21 @@ func TestParseValue(t *testing.T) {
 			nil,
 		},
 		{"time.Time invalid", "not-a-time", time.Time{}, time.Time{}, enum.ErrParseValue},
+		{
+			"time.Time offset",
+			"2024-01-02T15:04:05+02:00",
+			time.Time{},
+			time.Date(2024, 1, 2, 15, 4, 5, 0, time.FixedZone("", 2*60*60)),
+			nil,
+		},
+
+		// Slice tests
+		{"[]string", "[nightly db]", []string{}, []string{"nightly", "db"}, nil},
+		{"[]int pipes", "[1|2|3]", []int{}, []int{1, 2, 3}, nil},
+		{"[]time.Duration", "[1s 90m]", []time.Duration{}, []time.Duration{time.Second, 90 * time.Minute}, nil},
+		{"[]int empty", "[]", []int{}, []int{}, nil},
+		{"[]int invalid element", "[1 two]", []int{}, []int(nil), enum.ErrParseValue},
+		{"[]int missing brackets", "1 2", []int{}, []int(nil), enum.ErrParseValue},
 
 		// Unsupported type
 		{"unsupported type", "value", struct{}{}, struct{}{}, enum.ErrParseValue},
@@ -119,6 +134,10 @@ func TestParseEnumAliases(t *testing.T) {
 		{"leading comma", ",alias1,alias2", []string{"alias1", "alias2"}},
 		{"only commas", ",,", []string{}},
 		{"quoted with spaces", `"alias with spaces","another alias"`, []string{"alias with spaces", "another alias"}},
+		{"quoted with comma", `"Not Found, Retryable",nf`, []string{"Not Found, Retryable", "nf"}},
+		{"single quoted with comma", `"Not Found, Retryable"`, []string{"Not Found, Retryable"}},
+		{"escaped quotes", `"say \"hi\""`, []string{`say "hi"`}},
+		{"unicode", `"Café ☕",café`, []string{"Café ☕", "café"}},
 	}
 
 	for _, tt := range tests {
@@ -337,6 +356,17 @@ func TestExtractFields(t *testing.T) {
 				{Name: "Timestamp", Value: time.Time{}},
 			},
 		},
+		{
+			name:       "slice types",
+			comment:    "Timeout[time.Duration], Tags[[]string], Backoff[[]time.Duration]",
+			wantOpener: "[",
+			wantCloser: "]",
+			wantFields: []enum.Field{
+				{Name: "Timeout", Value: time.Duration(0)},
+				{Name: "Tags", Value: []string{}},
+				{Name: "Backoff", Value: []time.Duration{}},
+			},
+		},
 		{
 			name:       "single field no name",
 			comment:    "string",
@@ -396,6 +426,11 @@ func TestFieldToType(t *testing.T) {
 		{"complex64", "complex64", complex64(0)},
 		{"complex128", "complex128", complex128(0)},
 		{"uintptr", "uintptr", uintptr(0)},
+		{"slice", "[]string", []string{}},
+		{"duration slice", "[]time.Duration", []time.Duration{}},
+		{"qualified", "money.Currency", enum.Expr{Type: "money.Currency"}},
+		{"qualified pointer", "*geo.Point", enum.Expr{Type: "*geo.Point"}},
+		{"qualified unexported", "money.currency", nil},
 		{"unknown", "unknown", nil},
 		{"empty", "", nil},
 		{"with spaces", "  string  ", ""},
@@ -412,6 +447,27 @@ func TestFieldToType(t *testing.T) {
 	}
 }
 
+func TestDefaultImportName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"time", "time"},
+		{"example.com/shop/money", "money"},
+		{"github.com/jackc/pgx/v5", "pgx"},
+		{"gopkg.in/yaml.v3", "yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			if got := enum.DefaultImportName(tt.path); got != tt.want {
+				t.Errorf("DefaultImportName(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestOpenCloser(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -424,6 +480,9 @@ func TestOpenCloser(t *testing.T) {
 		{"square brackets", "field[type]", "[", "]"},
 		{"parentheses", "field(type)", "(", ")"},
 		{"both brackets and parens", "field[type](something)", "[", "]"}, // Should prefer brackets
+		{"slice in parentheses", "Tags([]string)", "(", ")"},
+		{"slice in brackets", "Tags[[]string]", "[", "]"},
+		{"slice after space", "Tags []string", " ", " "},
 		{"empty", "", " ", " "},
 	}
 
@@ -510,6 +569,17 @@ func TestExtractImports(t *testing.T) {
 			},
 			want: []string{"sync"},
 		},
+		{
+			name: "expressions are resolved by the parser",
+			enumIotas: []enum.EnumIota{
+				{
+					Fields: []enum.Field{
+						{Name: "Currency", Value: enum.Expr{Type: "money.Currency"}},
+					},
+				},
+			},
+			want: []string{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -603,6 +673,29 @@ func TestGenerationRequestCommand(t *testing.T) {
 			},
 			want: "goenums -f",
 		},
+		{
+			name: "command with directive defaults",
+			req: enum.GenerationRequest{
+				SourceFilename: "status.go",
+				Configuration: config.Configuration{
+					Defaults: config.EnumTypeConfig{
+						Handlers:          config.Handlers{JSON: true, SQL: true},
+						SerializationType: config.SerdeValue,
+					},
+				},
+			},
+			want: "goenums -json -sql -serde/value status.go",
+		},
+		{
+			name: "command with build tags",
+			req: enum.GenerationRequest{
+				SourceFilename: "mode.go",
+				Configuration: config.Configuration{
+					BuildTags: []string{"linux", "cgo"},
+				},
+			},
+			want: "goenums -tags linux,cgo mode.go",
+		},
 	}
 
 	for _, tt := range tests {
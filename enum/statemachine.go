@@ -0,0 +1,117 @@
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownState indicates a state annotation names a transition target
+// that is not a value of the enum type.
+var ErrUnknownState = errors.New("transition to unknown state")
+
+// State returns the value a state annotation names, either by the name of
+// its constant or by one of its aliases. Names prefixed with "Order" also
+// match the aliases of values without the prefix.
+func (e EnumIota) State(name string) (Enum, bool) {
+	for _, en := range e.Enums {
+		if en.Name == name {
+			return en, true
+		}
+	}
+	for _, en := range e.Enums {
+		if slices.Contains(en.Aliases, name) {
+			return en, true
+		}
+	}
+	if withoutPrefix, ok := strings.CutPrefix(name, "Order"); ok {
+		for _, en := range e.Enums {
+			if slices.Contains(en.Aliases, withoutPrefix) {
+				return en, true
+			}
+		}
+	}
+	return Enum{}, false
+}
+
+// CheckStateMachine validates the state annotations of the values of e.
+// It returns an error wrapping ErrUnknownState for the transitions to
+// states e does not declare, and warnings for the states the first valid
+// value cannot reach and, when e declares final states, for the states
+// that reach none of them and so never terminate. Messages start with the
+// position of the offending value when it is known.
+func CheckStateMachine(e EnumIota) (warnings []string, err error) {
+	var errs []error
+	next := make(map[string][]string, len(e.Enums))
+	for _, en := range e.Enums {
+		for _, t := range en.StateTransitions {
+			to, ok := e.State(t)
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s%w: %s -> %s", position(en), ErrUnknownState, en.Name, t))
+				continue
+			}
+			next[en.Name] = append(next[en.Name], to.Name)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	var states, finals []string
+	for _, en := range e.Enums {
+		if en.Valid {
+			states = append(states, en.Name)
+		}
+		if en.IsFinalState {
+			finals = append(finals, en.Name)
+		}
+	}
+	// Types without transitions yet have no machine to check
+	if len(states) == 0 || len(next) == 0 {
+		return nil, nil
+	}
+	reachable := reach(states[:1], func(s string) []string { return next[s] })
+	prev := make(map[string][]string, len(next))
+	for from, targets := range next {
+		for _, to := range targets {
+			prev[to] = append(prev[to], from)
+		}
+	}
+	terminating := reach(finals, func(s string) []string { return prev[s] })
+	for _, en := range e.Enums {
+		if !en.Valid {
+			continue
+		}
+		if !reachable[en.Name] {
+			warnings = append(warnings, fmt.Sprintf("%sstate %s is unreachable from %s", position(en), en.Name, states[0]))
+		}
+		if len(finals) > 0 && !terminating[en.Name] {
+			warnings = append(warnings, fmt.Sprintf("%sstate %s cannot reach a final state", position(en), en.Name))
+		}
+	}
+	return warnings, nil
+}
+
+// reach returns the states reachable from start by following edges.
+func reach(start []string, edges func(string) []string) map[string]bool {
+	seen := make(map[string]bool)
+	queue := slices.Clone(start)
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		queue = append(queue, edges(s)...)
+	}
+	return seen
+}
+
+func position(en Enum) string {
+	if !en.Position.IsValid() {
+		return ""
+	}
+	return en.Position.String() + ": "
+}
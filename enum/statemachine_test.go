@@ -0,0 +1,89 @@
+package enum_test
+
+import (
+	"errors"
+	"go/token"
+	"slices"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+)
+
+func TestCheckStateMachine(t *testing.T) {
+	t.Parallel()
+	state := func(name string, final bool, transitions ...string) enum.Enum {
+		return enum.Enum{Name: name, Valid: true, IsFinalState: final, StateTransitions: transitions}
+	}
+	tests := []struct {
+		name     string
+		enums    []enum.Enum
+		warnings []string
+		wantErr  error
+	}{
+		{
+			name: "valid",
+			enums: []enum.Enum{
+				{Name: "unknown"},
+				state("pending", false, "paid", "OrderCanceled"),
+				{Name: "paid", Aliases: []string{"Paid"}, Valid: true, StateTransitions: []string{"shipped"}},
+				state("shipped", true),
+				{Name: "canceled", Aliases: []string{"Canceled"}, Valid: true, IsFinalState: true},
+			},
+		},
+		{
+			name: "unknown target",
+			enums: []enum.Enum{
+				state("pending", false, "payed"),
+				state("paid", true),
+			},
+			wantErr: enum.ErrUnknownState,
+		},
+		{
+			name: "unreachable and nonterminating",
+			enums: []enum.Enum{
+				state("pending", false, "paid", "retrying"),
+				state("paid", true),
+				state("retrying", false, "failed"),
+				state("failed", false, "retrying"),
+				state("archived", true),
+			},
+			warnings: []string{
+				"state retrying cannot reach a final state",
+				"state failed cannot reach a final state",
+				"state archived is unreachable from pending",
+			},
+		},
+		{
+			name: "no final states",
+			enums: []enum.Enum{
+				state("on", false, "off"),
+				state("off", false, "on"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			warnings, err := enum.CheckStateMachine(enum.EnumIota{Type: "orderStatus", Enums: tt.enums})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !slices.Equal(warnings, tt.warnings) {
+				t.Errorf("expected warnings %q, got %q", tt.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestCheckStateMachine_Position(t *testing.T) {
+	t.Parallel()
+	_, err := enum.CheckStateMachine(enum.EnumIota{Enums: []enum.Enum{{
+		Name:             "pending",
+		Valid:            true,
+		StateTransitions: []string{"payed"},
+		Position:         token.Position{Filename: "orders.go", Line: 7, Column: 2},
+	}}})
+	if want := "orders.go:7:2: transition to unknown state: pending -> payed"; err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
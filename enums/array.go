@@ -0,0 +1,148 @@
+package enums
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SliceValue returns the Postgres array literal of values, such as
+// {"pending","shipped"}, for storing them in a text[] or int[] column.
+// Elements are written with their Value method when they implement
+// driver.Valuer, and as SQLValue writes single values otherwise, so by name
+// or by value following the serialization format of the enum. A nil slice
+// is stored as NULL.
+func SliceValue[R comparable, T comparable, E Enum[R, T]](values []E) (driver.Value, error) {
+	if values == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		elem, err := elementValue(v)
+		if err != nil {
+			return nil, err
+		}
+		switch elem := elem.(type) {
+		case int64:
+			b.WriteString(strconv.FormatInt(elem, 10))
+		case float64:
+			b.WriteString(strconv.FormatFloat(elem, 'g', -1, 64))
+		case bool:
+			b.WriteString(strconv.FormatBool(elem))
+		case []byte:
+			b.WriteString(quoteArrayElement(string(elem)))
+		default:
+			b.WriteString(quoteArrayElement(fmt.Sprint(elem)))
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// SliceScan parses the Postgres array literal in src, as returned for
+// text[] and int[] columns, into enum values, scanning each element with
+// its Scan method when it implements sql.Scanner, and as SQLScan scans
+// single values otherwise. NULL scans into a nil slice; NULL elements and
+// multidimensional arrays are rejected.
+func SliceScan[R comparable, T comparable, E Enum[R, T]](e E, src any) ([]E, error) {
+	var literal string
+	switch v := src.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		literal = v
+	case []byte:
+		literal = string(v)
+	default:
+		return nil, fmt.Errorf("cannot scan %T into an enum array", src)
+	}
+	elems, err := parseArrayLiteral(literal)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]E, 0, len(elems))
+	for _, elem := range elems {
+		v := e
+		if scanner, ok := any(&v).(sql.Scanner); ok {
+			if err := scanner.Scan(elem); err != nil {
+				return nil, err
+			}
+		} else {
+			scanned, err := SQLScan(e, elem)
+			if err != nil {
+				return nil, err
+			}
+			v = *scanned
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// elementValue returns the database representation of the array element v.
+func elementValue[R comparable, T comparable, E Enum[R, T]](v E) (driver.Value, error) {
+	if valuer, ok := any(v).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	return SQLValue(v)
+}
+
+// quoteArrayElement quotes s as an element of an array literal.
+func quoteArrayElement(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// parseArrayLiteral splits a one-dimensional Postgres array literal into
+// its unquoted elements.
+func parseArrayLiteral(literal string) ([]string, error) {
+	inner, ok := strings.CutPrefix(literal, "{")
+	if inner, ok = strings.CutSuffix(inner, "}"); !ok {
+		return nil, fmt.Errorf("invalid array %q", literal)
+	}
+	var elems []string
+	for len(inner) > 0 {
+		var elem string
+		if inner[0] == '"' {
+			var b strings.Builder
+			i := 1
+			for ; i < len(inner) && inner[i] != '"'; i++ {
+				if inner[i] == '\\' && i+1 < len(inner) {
+					i++
+				}
+				b.WriteByte(inner[i])
+			}
+			if i == len(inner) {
+				return nil, fmt.Errorf("invalid array %q: unterminated element", literal)
+			}
+			elem, inner = b.String(), inner[i+1:]
+		} else {
+			if inner[0] == '{' {
+				return nil, errors.New("multidimensional arrays are not supported")
+			}
+			end := strings.IndexByte(inner, ',')
+			if end < 0 {
+				end = len(inner)
+			}
+			elem, inner = strings.TrimSpace(inner[:end]), inner[end:]
+			if strings.EqualFold(elem, "NULL") {
+				return nil, fmt.Errorf("invalid array %q: NULL elements are not supported", literal)
+			}
+		}
+		elems = append(elems, elem)
+		if len(inner) > 0 {
+			if inner[0] != ',' || len(inner) == 1 {
+				return nil, fmt.Errorf("invalid array %q", literal)
+			}
+			inner = inner[1:]
+		}
+	}
+	return elems, nil
+}
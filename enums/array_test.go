@@ -0,0 +1,51 @@
+package enums
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSliceValue(t *testing.T) {
+	t.Parallel()
+	got, err := SliceValue([]testColor{testColors[1], testColors[0]})
+	if err != nil || got != `{"Green","Red"}` {
+		t.Errorf(`expected {"Green","Red"}, got %v, %v`, got, err)
+	}
+	if got, err := SliceValue([]testColor(nil)); err != nil || got != nil {
+		t.Errorf("expected a nil slice to be NULL, got %v, %v", got, err)
+	}
+}
+
+func TestSliceScan(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		src     any
+		want    []testColor
+		wantErr bool
+	}{
+		{name: "unquoted", src: "{Red,Green}", want: []testColor{testColors[0], testColors[1]}},
+		{name: "quoted", src: []byte(`{"Green", Red}`), want: []testColor{testColors[1], testColors[0]}},
+		{name: "empty", src: "{}", want: []testColor{}},
+		{name: "null", src: nil},
+		{name: "unknown element", src: "{Red,Blue}", wantErr: true},
+		{name: "null element", src: "{Red,NULL}", wantErr: true},
+		{name: "multidimensional", src: "{{Red},{Green}}", wantErr: true},
+		{name: "trailing comma", src: "{Red,}", wantErr: true},
+		{name: "unterminated", src: `{"Red}`, wantErr: true},
+		{name: "not an array", src: "Red", wantErr: true},
+		{name: "unsupported type", src: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := SliceScan(testColor{}, tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
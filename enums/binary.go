@@ -0,0 +1,83 @@
+package enums
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// BinaryOptions configures the encoding of MarshalBinary and UnmarshalBinary.
+// The zero value encodes numbers as big-endian fixed-width values and
+// strings, including names, as their bytes.
+type BinaryOptions struct {
+	// ByteOrder is the byte order of fixed-width numbers, binary.BigEndian
+	// when nil
+	ByteOrder binary.ByteOrder
+	// Varint encodes integers as varints, zig-zag encoded when signed, so
+	// small values take a single byte whatever their type. It takes
+	// precedence over ByteOrder.
+	Varint bool
+	// LengthPrefixed prefixes strings with their length as a uvarint, for
+	// values embedded in larger binary messages
+	LengthPrefixed bool
+}
+
+// WithBinaryOptions decodes binary input encoded with o, as the generated
+// UnmarshalBinary methods of enums generated with -binary=<encoding> do.
+func WithBinaryOptions(o BinaryOptions) UnmarshalOption {
+	return func(uo *unmarshalOptions) {
+		uo.binary = o
+	}
+}
+
+func (o BinaryOptions) byteOrder() binary.ByteOrder {
+	if o.ByteOrder == nil {
+		return binary.BigEndian
+	}
+	return o.ByteOrder
+}
+
+func (o BinaryOptions) putUint16(v uint16) []byte {
+	buf := make([]byte, 2)
+	o.byteOrder().PutUint16(buf, v)
+	return buf
+}
+
+func (o BinaryOptions) putUint32(v uint32) []byte {
+	buf := make([]byte, 4)
+	o.byteOrder().PutUint32(buf, v)
+	return buf
+}
+
+func (o BinaryOptions) putUint64(v uint64) []byte {
+	buf := make([]byte, 8)
+	o.byteOrder().PutUint64(buf, v)
+	return buf
+}
+
+// appendString appends s to b, prefixed with its length if o says so.
+func (o BinaryOptions) appendString(b []byte, s string) []byte {
+	if o.LengthPrefixed {
+		b = binary.AppendUvarint(b, uint64(len(s)))
+	}
+	return append(b, s...)
+}
+
+// readString returns the string encoded by appendString in data. A
+// length-prefixed string must span the rest of data; trailing bytes are an
+// error rather than silently dropped.
+func (o BinaryOptions) readString(data []byte) (string, error) {
+	if !o.LengthPrefixed {
+		return string(data), nil
+	}
+	n, k := binary.Uvarint(data)
+	if k <= 0 {
+		return "", fmt.Errorf("invalid length prefix")
+	}
+	if n > uint64(len(data)-k) {
+		return "", fmt.Errorf("insufficient data for string of length %d", n)
+	}
+	if k+int(n) != len(data) {
+		return "", fmt.Errorf("%d trailing bytes after string of length %d", len(data)-k-int(n), n)
+	}
+	return string(data[k : k+int(n)]), nil
+}
@@ -0,0 +1,71 @@
+package enums
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestBinaryOptions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		value any
+		opts  BinaryOptions
+		want  []byte
+	}{
+		{name: "big-endian by default", value: int32(258), want: []byte{0, 0, 1, 2}},
+		{name: "little-endian", value: int32(258), opts: BinaryOptions{ByteOrder: binary.LittleEndian}, want: []byte{2, 1, 0, 0}},
+		{name: "varint", value: int64(3), opts: BinaryOptions{Varint: true}, want: []byte{6}},
+		{name: "negative varint", value: -2, opts: BinaryOptions{Varint: true}, want: []byte{3}},
+		{name: "uvarint", value: uint(300), opts: BinaryOptions{Varint: true, ByteOrder: binary.LittleEndian}, want: []byte{0xac, 0x02}},
+		{name: "float ignores varint", value: float32(1), opts: BinaryOptions{Varint: true}, want: []byte{0x3f, 0x80, 0, 0}},
+		{name: "length-prefixed string", value: "ok", opts: BinaryOptions{LengthPrefixed: true}, want: []byte{2, 'o', 'k'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := encodeBinary(tt.value, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Fatalf("encodeBinary(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	var n int8
+	if err := decodeBinary([]byte{0x80, 0x02}, &n, BinaryOptions{Varint: true}); err == nil {
+		t.Errorf("expected an error decoding a varint overflowing int8, got %d", n)
+	}
+	var s string
+	if err := decodeBinary([]byte{5, 'o', 'k'}, &s, BinaryOptions{LengthPrefixed: true}); err == nil {
+		t.Errorf("expected an error decoding a truncated string, got %q", s)
+	}
+	if err := decodeBinary([]byte{2, 'o', 'k', '!'}, &s, BinaryOptions{LengthPrefixed: true}); err == nil {
+		t.Errorf("expected an error decoding a string followed by trailing bytes, got %q", s)
+	}
+}
+
+func TestMarshalBinaryOptions(t *testing.T) {
+	t.Parallel()
+	opts := BinaryOptions{LengthPrefixed: true}
+	b, err := MarshalBinary(testColors[1], testColors[1].raw, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte("\x05Green"); !bytes.Equal(b, want) {
+		t.Fatalf("MarshalBinary = %q, want %q", b, want)
+	}
+	got, err := UnmarshalBinary(testColor{}, b, WithBinaryOptions(opts))
+	if err != nil || *got != testColors[1] {
+		t.Errorf("UnmarshalBinary(%q) = %v, %v", b, got, err)
+	}
+	if _, err := UnmarshalBinary(testColor{}, b); err == nil {
+		t.Error("expected an error decoding a length-prefixed name without the option")
+	}
+	if _, err := UnmarshalBinary(testColor{}, append(b, 0), WithBinaryOptions(opts)); err == nil {
+		t.Error("expected an error decoding a length-prefixed name followed by trailing bytes")
+	}
+}
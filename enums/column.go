@@ -0,0 +1,211 @@
+package enums
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Dialects of ColumnDefinition and CheckColumnDrift.
+const (
+	DialectMySQL    = "mysql"
+	DialectPostgres = "postgres"
+	DialectSQLite   = "sqlite"
+)
+
+// ErrUnsupportedDialect is returned by CheckColumnDrift for dialects whose
+// schema it cannot read.
+var ErrUnsupportedDialect = errors.New("unsupported dialect")
+
+// Querier runs queries, as *sql.DB, *sql.Conn and *sql.Tx do.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// ColumnDrift lists the differences between the values of an enum and the
+// values its column accepts in the database.
+type ColumnDrift struct {
+	// Missing are the values of the enum the column does not accept
+	Missing []string
+	// Extra are the values the column accepts that the enum does not have
+	Extra []string
+}
+
+// Drifted reports whether the enum and its column disagree.
+func (d ColumnDrift) Drifted() bool {
+	return len(d.Missing) > 0 || len(d.Extra) > 0
+}
+
+// ColumnDefinition returns what the column storing values is declared
+// with in dialect: the type ENUM('a','b') for DialectMySQL when the values
+// are stored as strings, and the constraint CHECK (column IN ('a', 'b'))
+// otherwise. Values are written as their Value method returns them.
+func ColumnDefinition[E driver.Valuer](dialect, column string, values []E) string {
+	lits := make([]string, len(values))
+	quoted := true
+	for i, v := range values {
+		lits[i] = columnLiteral(columnValue(v))
+		quoted = quoted && strings.HasPrefix(lits[i], "'")
+	}
+	if dialect == DialectMySQL && quoted {
+		return "ENUM(" + strings.Join(lits, ",") + ")"
+	}
+	return "CHECK (" + column + " IN (" + strings.Join(lits, ", ") + "))"
+}
+
+// CheckColumnDrift compares values with the values the column of table
+// accepts in the database: the members of an ENUM column for DialectMySQL,
+// and the labels of the native enum type of the column for
+// DialectPostgres. Schemas with CHECK constraints are not read.
+func CheckColumnDrift[E driver.Valuer](ctx context.Context, db Querier, dialect, table, column string, values []E) (ColumnDrift, error) {
+	var (
+		accepted []string
+		err      error
+	)
+	switch dialect {
+	case DialectMySQL:
+		accepted, err = mysqlEnumValues(ctx, db, table, column)
+	case DialectPostgres:
+		accepted, err = postgresEnumValues(ctx, db, table, column)
+	default:
+		return ColumnDrift{}, fmt.Errorf("%w: %s", ErrUnsupportedDialect, dialect)
+	}
+	if err != nil {
+		return ColumnDrift{}, err
+	}
+	var drift ColumnDrift
+	expected := make([]string, len(values))
+	for i, v := range values {
+		expected[i] = fmt.Sprint(columnValue(v))
+		if !slices.Contains(accepted, expected[i]) {
+			drift.Missing = append(drift.Missing, expected[i])
+		}
+	}
+	for _, a := range accepted {
+		if !slices.Contains(expected, a) {
+			drift.Extra = append(drift.Extra, a)
+		}
+	}
+	return drift, nil
+}
+
+// SQLLiteral returns the SQL literal of the value v is stored as, such as
+// 'active', 1 or TRUE, with quotes escaped, for the places queries cannot
+// take placeholders.
+func SQLLiteral(v driver.Valuer) string {
+	return columnLiteral(columnValue(v))
+}
+
+// columnValue returns the value v is stored as, or its string form if its
+// Value method fails.
+func columnValue(v driver.Valuer) driver.Value {
+	value, err := v.Value()
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return value
+}
+
+// columnLiteral returns the SQL literal of value.
+func columnLiteral(value driver.Value) string {
+	switch v := value.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strings.ToUpper(strconv.FormatBool(v))
+	default:
+		return "'" + strings.ReplaceAll(fmt.Sprint(v), "'", "''") + "'"
+	}
+}
+
+func mysqlEnumValues(ctx context.Context, db Querier, table, column string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT COLUMN_TYPE FROM information_schema.COLUMNS "+
+		"WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?", table, column)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("column %s.%s not found", table, column)
+	}
+	var columnType string
+	if err := rows.Scan(&columnType); err != nil {
+		return nil, err
+	}
+	return parseMySQLEnum(columnType)
+}
+
+// parseMySQLEnum returns the members of a MySQL column type such as
+// enum('a','b'), in which quotes are doubled.
+func parseMySQLEnum(columnType string) ([]string, error) {
+	if len(columnType) < 6 || !strings.EqualFold(columnType[:5], "enum(") || !strings.HasSuffix(columnType, ")") {
+		return nil, fmt.Errorf("column type %s is not an ENUM", columnType)
+	}
+	list := columnType[5 : len(columnType)-1]
+	var values []string
+	for list != "" {
+		if list[0] != '\'' {
+			return nil, fmt.Errorf("invalid ENUM column type %s", columnType)
+		}
+		var b strings.Builder
+		i := 1
+		for ; i < len(list); i++ {
+			if list[i] != '\'' {
+				b.WriteByte(list[i])
+				continue
+			}
+			if i+1 < len(list) && list[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			break
+		}
+		if i == len(list) {
+			return nil, fmt.Errorf("invalid ENUM column type %s", columnType)
+		}
+		values = append(values, b.String())
+		list = strings.TrimPrefix(list[i+1:], ",")
+	}
+	return values, nil
+}
+
+func postgresEnumValues(ctx context.Context, db Querier, table, column string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT e.enumlabel FROM information_schema.columns c "+
+		"JOIN pg_type t ON t.typname = c.udt_name "+
+		"JOIN pg_enum e ON e.enumtypid = t.oid "+
+		"WHERE c.table_schema = current_schema() AND c.table_name = $1 AND c.column_name = $2 "+
+		"ORDER BY e.enumsortorder", table, column)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var values []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, err
+		}
+		values = append(values, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if values == nil {
+		return nil, fmt.Errorf("column %s.%s not found or not of an enum type", table, column)
+	}
+	return values, nil
+}
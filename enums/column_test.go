@@ -0,0 +1,185 @@
+package enums
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type storedValue struct{ v driver.Value }
+
+func (s storedValue) Value() (driver.Value, error) { return s.v, nil }
+
+func stored(values ...driver.Value) []storedValue {
+	s := make([]storedValue, len(values))
+	for i, v := range values {
+		s[i] = storedValue{v}
+	}
+	return s
+}
+
+func TestColumnDefinition(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		dialect string
+		values  []storedValue
+		want    string
+	}{
+		{name: "mysql enum", dialect: DialectMySQL, values: stored("red", "it's"), want: "ENUM('red','it''s')"},
+		{name: "mysql bytes", dialect: DialectMySQL, values: stored([]byte("red")), want: "ENUM('red')"},
+		{name: "mysql numbers", dialect: DialectMySQL, values: stored(int64(1), int64(2)), want: "CHECK (color IN (1, 2))"},
+		{name: "postgres", dialect: DialectPostgres, values: stored("red", "green"), want: "CHECK (color IN ('red', 'green'))"},
+		{name: "sqlite bools", dialect: DialectSQLite, values: stored(true, false), want: "CHECK (color IN (TRUE, FALSE))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ColumnDefinition(tt.dialect, "color", tt.values); got != tt.want {
+				t.Errorf("ColumnDefinition = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLLiteral(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		value driver.Value
+		want  string
+	}{
+		{value: "it's", want: "'it''s'"},
+		{value: int64(-3), want: "-3"},
+		{value: 1.5, want: "1.5"},
+		{value: true, want: "TRUE"},
+	} {
+		if got := SQLLiteral(storedValue{tt.value}); got != tt.want {
+			t.Errorf("SQLLiteral(%v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseMySQLEnum(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		columnType string
+		want       []string
+		wantErr    bool
+	}{
+		{columnType: "enum('red','green')", want: []string{"red", "green"}},
+		{columnType: "ENUM('it''s','a,b')", want: []string{"it's", "a,b"}},
+		{columnType: "enum('')", want: []string{""}},
+		{columnType: "varchar(16)", wantErr: true},
+		{columnType: "enum('red)", wantErr: true},
+		{columnType: "enum(red)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.columnType, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseMySQLEnum(tt.columnType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMySQLEnum(%s) error = %v, wantErr %v", tt.columnType, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseMySQLEnum(%s) = %q, want %q", tt.columnType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckColumnDrift(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		dialect string
+		rows    string
+		want    ColumnDrift
+		wantErr error
+	}{
+		{name: "mysql in sync", dialect: DialectMySQL, rows: "enum('red','green')"},
+		{name: "mysql drift", dialect: DialectMySQL, rows: "enum('red','blue')", want: ColumnDrift{Missing: []string{"green"}, Extra: []string{"blue"}}},
+		{name: "mysql missing column", dialect: DialectMySQL, wantErr: errAny},
+		{name: "postgres drift", dialect: DialectPostgres, rows: "red|green|blue", want: ColumnDrift{Extra: []string{"blue"}}},
+		{name: "postgres not an enum", dialect: DialectPostgres, wantErr: errAny},
+		{name: "sqlite", dialect: DialectSQLite, wantErr: ErrUnsupportedDialect},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			db, err := sql.Open("enums-fake", tt.rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			got, err := CheckColumnDrift(t.Context(), db, tt.dialect, "colors", "color", stored("red", "green"))
+			if tt.wantErr != nil {
+				if err == nil || (tt.wantErr != errAny && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got.Missing, tt.want.Missing) || !slices.Equal(got.Extra, tt.want.Extra) {
+				t.Errorf("CheckColumnDrift = %+v, want %+v", got, tt.want)
+			}
+			if got.Drifted() != (tt.want.Missing != nil || tt.want.Extra != nil) {
+				t.Errorf("Drifted() = %v for %+v", got.Drifted(), got)
+			}
+		})
+	}
+}
+
+var errAny = errors.New("any error")
+
+func init() {
+	sql.Register("enums-fake", fakeDriver{})
+}
+
+// fakeDriver answers every query with the rows of a single column listed,
+// separated by "|", in the data source name.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	var rows []string
+	if name != "" {
+		rows = strings.Split(name, "|")
+	}
+	return fakeConn{rows: rows}, nil
+}
+
+type fakeConn struct{ rows []string }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (fakeConn) Close() error                          { return nil }
+func (fakeConn) Begin() (driver.Tx, error)             { return nil, errors.New("not supported") }
+
+type fakeStmt fakeConn
+
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, errors.New("not supported") }
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.rows}, nil
+}
+
+type fakeRows struct {
+	rows []string
+	next int
+}
+
+func (*fakeRows) Columns() []string { return []string{"value"} }
+func (*fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.rows) {
+		return io.EOF
+	}
+	dest[0] = r.rows[r.next]
+	r.next++
+	return nil
+}
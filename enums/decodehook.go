@@ -0,0 +1,42 @@
+package enums
+
+import "reflect"
+
+// DecodeHookFunc has the signature of mapstructure.DecodeHookFuncType, so
+// the hooks below can be passed wherever github.com/go-viper/mapstructure/v2
+// or github.com/mitchellh/mapstructure accept a DecodeHookFunc, such as
+// viper.DecodeHook, without this package importing either.
+type DecodeHookFunc = func(from, to reflect.Type, data any) (any, error)
+
+// DecodeHook returns a decode hook converting the data decoded into a T
+// with parse, typically the generated Parse function of the enum. Names
+// and values read from configuration files thus decode into the enum, and
+// invalid ones fail decoding. Data decoded into other types is returned
+// unchanged.
+func DecodeHook[T any](parse func(input any) (T, error)) DecodeHookFunc {
+	target := reflect.TypeFor[T]()
+	return func(_, to reflect.Type, data any) (any, error) {
+		if to != target {
+			return data, nil
+		}
+		return parse(data)
+	}
+}
+
+// ComposeDecodeHooks returns a decode hook running hooks in order, each on
+// the result of the previous one, so the hooks of several enums can be
+// registered at once.
+func ComposeDecodeHooks(hooks ...DecodeHookFunc) DecodeHookFunc {
+	return func(from, to reflect.Type, data any) (any, error) {
+		var err error
+		for _, hook := range hooks {
+			if data, err = hook(from, to, data); err != nil {
+				return nil, err
+			}
+			if data != nil {
+				from = reflect.TypeOf(data)
+			}
+		}
+		return data, nil
+	}
+}
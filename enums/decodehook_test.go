@@ -0,0 +1,58 @@
+package enums
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type level int
+
+func parseLevel(input any) (level, error) {
+	switch input {
+	case "low", 1:
+		return 1, nil
+	case "high", 2:
+		return 2, nil
+	}
+	return 0, errors.New("invalid level")
+}
+
+type shade string
+
+func parseShade(input any) (shade, error) {
+	if input == "dark" {
+		return "dark", nil
+	}
+	return "", errors.New("invalid shade")
+}
+
+func TestDecodeHook(t *testing.T) {
+	t.Parallel()
+	hook := ComposeDecodeHooks(DecodeHook(parseLevel), DecodeHook(parseShade))
+	tests := []struct {
+		name    string
+		to      reflect.Type
+		data    any
+		want    any
+		wantErr bool
+	}{
+		{name: "name", to: reflect.TypeFor[level](), data: "high", want: level(2)},
+		{name: "value", to: reflect.TypeFor[level](), data: 1, want: level(1)},
+		{name: "second hook", to: reflect.TypeFor[shade](), data: "dark", want: shade("dark")},
+		{name: "invalid", to: reflect.TypeFor[level](), data: "medium", wantErr: true},
+		{name: "other type", to: reflect.TypeFor[string](), data: "medium", want: "medium"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := hook(reflect.TypeOf(tt.data), tt.to, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
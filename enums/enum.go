@@ -11,6 +11,16 @@ const (
 	FormatValue               // Serialize as value (e.g. 0)
 )
 
+// FormatOverrider is implemented by the enums whose values override the
+// serialization format of their type with "serde:" annotations. MarshalJSON,
+// MarshalText, MarshalYAML and MarshalBinary write each value in its own
+// format, and the matching unmarshalers accept both formats. SQL keeps the
+// format of the type, as a column holds either names or values.
+type FormatOverrider interface {
+	// ValueFormat returns the format the value is serialized in
+	ValueFormat() Format
+}
+
 // Enum interface definition
 type Enum[R comparable, Self comparable] interface {
 	Val() R
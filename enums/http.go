@@ -0,0 +1,34 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrMissingValue is returned when a query or form value is absent.
+var ErrMissingValue = errors.New("missing value")
+
+// FromValues parses the first value of key in values, such as a query
+// string or a parsed form, with parse, typically the generated Parse
+// function of the enum, so invalid values fail with its typed error. It
+// returns an error wrapping ErrMissingValue when key is absent.
+func FromValues[E any](values url.Values, key string, parse func(input any) (E, error)) (E, error) {
+	if !values.Has(key) {
+		var zero E
+		return zero, fmt.Errorf("%w: %s", ErrMissingValue, key)
+	}
+	return parse(values.Get(key))
+}
+
+// FromRequest parses the value of key in the query string or the
+// URL-encoded form body of r with parse, like FromValues. Multipart
+// forms are only read if r.ParseMultipartForm was called beforehand.
+func FromRequest[E any](r *http.Request, key string, parse func(input any) (E, error)) (E, error) {
+	if err := r.ParseForm(); err != nil {
+		var zero E
+		return zero, err
+	}
+	return FromValues(r.Form, key, parse)
+}
@@ -0,0 +1,52 @@
+package enums
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFromValues(t *testing.T) {
+	t.Parallel()
+	values := url.Values{"level": {"high", "low"}, "bad": {"medium"}}
+	tests := []struct {
+		name    string
+		key     string
+		want    level
+		wantErr bool
+		missing bool
+	}{
+		{name: "first value", key: "level", want: 2},
+		{name: "invalid", key: "bad", wantErr: true},
+		{name: "missing", key: "other", wantErr: true, missing: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FromValues(values, tt.key, parseLevel)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if errors.Is(err, ErrMissingValue) != tt.missing {
+				t.Errorf("expected missing %v, got %v", tt.missing, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFromRequest(t *testing.T) {
+	t.Parallel()
+	r := httptest.NewRequest("POST", "/?level=low", strings.NewReader("other=high"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if got, err := FromRequest(r, "level", parseLevel); err != nil || got != 1 {
+		t.Errorf("expected the query value, got %v, %v", got, err)
+	}
+	if got, err := FromRequest(r, "other", parseLevel); err != nil || got != 2 {
+		t.Errorf("expected the form value, got %v, %v", got, err)
+	}
+}
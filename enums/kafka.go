@@ -0,0 +1,135 @@
+package enums
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	// ErrWireFormat is returned when a Kafka payload is not in the
+	// Confluent wire format or does not hold a value of the enum.
+	ErrWireFormat = errors.New("invalid confluent wire format")
+	// ErrUnknownSymbol is returned when serializing a value that is not
+	// one of the symbols of the registered schema, such as an invalid one.
+	ErrUnknownSymbol = errors.New("unknown schema symbol")
+)
+
+// SchemaType is the type of a schema in a Confluent Schema Registry.
+type SchemaType string
+
+const (
+	// SchemaTypeAvro is an Avro enum schema
+	SchemaTypeAvro SchemaType = "AVRO"
+	// SchemaTypeJSON is a JSON Schema of the names
+	SchemaTypeJSON SchemaType = "JSON"
+)
+
+// RegistrySchema is a schema registered under Subject in a Confluent Schema
+// Registry. Marshaled to JSON, it is the body of the registry's
+// POST /subjects/{subject}/versions request.
+type RegistrySchema struct {
+	Subject    string     `json:"-"`
+	SchemaType SchemaType `json:"schemaType"`
+	Schema     string     `json:"schema"`
+}
+
+// KafkaSerde serializes enum values as Kafka record keys or values in the
+// Confluent wire format: a zero magic byte, the big-endian ID of the
+// registered schema, then the Avro encoding of the index of the value's
+// symbol or the JSON string of its name. Its Serialize, Deserialize and
+// DeserializeInto methods have the signatures of those of the Serializer and
+// Deserializer interfaces of
+// github.com/confluentinc/confluent-kafka-go/v2/schemaregistry/serde, but it
+// does not implement the interfaces, whose Configure and Close methods take
+// types of that module; call its methods from a serde that does.
+type KafkaSerde[T interface {
+	comparable
+	Name() string
+}] struct {
+	// SchemaID is the ID the schema was registered with, written in the
+	// payloads. Deserialize accepts any ID, as payloads written with
+	// earlier versions of the schema stay readable.
+	SchemaID   int
+	SchemaType SchemaType
+	// Symbols are the symbols of the Avro schema, whose indexes are encoded
+	Symbols []string
+	// FromName looks up the value of a decoded name
+	FromName func(name string) (T, bool)
+}
+
+// Serialize returns the payload of msg, a T or *T. topic is ignored.
+func (s *KafkaSerde[T]) Serialize(topic string, msg any) ([]byte, error) {
+	var v T
+	switch m := msg.(type) {
+	case T:
+		v = m
+	case *T:
+		if m == nil {
+			return nil, nil
+		}
+		v = *m
+	default:
+		return nil, fmt.Errorf("cannot serialize %T as %T", msg, v)
+	}
+	b := binary.BigEndian.AppendUint32([]byte{0}, uint32(s.SchemaID))
+	if s.SchemaType == SchemaTypeJSON {
+		name, err := json.Marshal(v.Name())
+		if err != nil {
+			return nil, err
+		}
+		return append(b, name...), nil
+	}
+	i := slices.Index(s.Symbols, v.Name())
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, v.Name())
+	}
+	return binary.AppendVarint(b, int64(i)), nil
+}
+
+// Deserialize returns the T payload holds. topic is ignored.
+func (s *KafkaSerde[T]) Deserialize(topic string, payload []byte) (any, error) {
+	return s.deserialize(payload)
+}
+
+// DeserializeInto stores the value payload holds in msg, a *T. topic is
+// ignored.
+func (s *KafkaSerde[T]) DeserializeInto(topic string, payload []byte, msg any) error {
+	p, ok := msg.(*T)
+	if !ok {
+		return fmt.Errorf("cannot deserialize into %T", msg)
+	}
+	v, err := s.deserialize(payload)
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+func (s *KafkaSerde[T]) deserialize(payload []byte) (T, error) {
+	var zero T
+	if len(payload) < 5 || payload[0] != 0 {
+		return zero, fmt.Errorf("%w: missing header", ErrWireFormat)
+	}
+	data := payload[5:]
+	var name string
+	if s.SchemaType == SchemaTypeJSON {
+		if err := json.Unmarshal(data, &name); err != nil {
+			return zero, fmt.Errorf("%w: %w", ErrWireFormat, err)
+		}
+	} else {
+		i, n := binary.Varint(data)
+		if n <= 0 || n != len(data) || i < 0 || i >= int64(len(s.Symbols)) {
+			return zero, fmt.Errorf("%w: invalid symbol index", ErrWireFormat)
+		}
+		name = s.Symbols[i]
+	}
+	v, ok := s.FromName(name)
+	if !ok {
+		return zero, fmt.Errorf("%w: %w: %s", ErrWireFormat, ErrUnknownSymbol, name)
+	}
+	return v, nil
+}
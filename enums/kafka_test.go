@@ -0,0 +1,71 @@
+package enums
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func newColorSerde(schemaType SchemaType) *KafkaSerde[testColor] {
+	return &KafkaSerde[testColor]{
+		SchemaID:   7,
+		SchemaType: schemaType,
+		Symbols:    []string{"Red", "Green"},
+		FromName:   testColor{}.FromName,
+	}
+}
+
+func TestKafkaSerde(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		schemaType SchemaType
+		want       []byte
+	}{
+		// Avro encodes the symbol index 1 as the zigzag varint 2
+		{SchemaTypeAvro, []byte{0, 0, 0, 0, 7, 2}},
+		{SchemaTypeJSON, append([]byte{0, 0, 0, 0, 7}, `"Green"`...)},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.schemaType), func(t *testing.T) {
+			t.Parallel()
+			serde := newColorSerde(tt.schemaType)
+			green := testColors[1]
+			got, err := serde.Serialize("colors", &green)
+			if err != nil || !bytes.Equal(got, tt.want) {
+				t.Fatalf("Serialize(Green) = %v, %v, want %v", got, err, tt.want)
+			}
+			v, err := serde.Deserialize("colors", got)
+			if err != nil || v != green {
+				t.Errorf("Deserialize(%v) = %v, %v, want Green", got, v, err)
+			}
+			var into testColor
+			if err := serde.DeserializeInto("colors", got, &into); err != nil || into != green {
+				t.Errorf("DeserializeInto(%v) = %v, %v, want Green", got, into, err)
+			}
+		})
+	}
+}
+
+func TestKafkaSerde_Errors(t *testing.T) {
+	t.Parallel()
+	serde := newColorSerde(SchemaTypeAvro)
+	if _, err := serde.Serialize("colors", testColor{name: "Blue"}); !errors.Is(err, ErrUnknownSymbol) {
+		t.Errorf("expected ErrUnknownSymbol, got %v", err)
+	}
+	if _, err := serde.Serialize("colors", "Red"); err == nil {
+		t.Error("expected an error serializing a string")
+	}
+	for _, payload := range [][]byte{nil, {1, 0, 0, 0, 7, 0}, {0, 0, 0, 0, 7, 4}, {0, 0, 0, 0, 7}} {
+		if _, err := serde.Deserialize("colors", payload); !errors.Is(err, ErrWireFormat) {
+			t.Errorf("expected ErrWireFormat for %v, got %v", payload, err)
+		}
+	}
+	if err := serde.DeserializeInto("colors", []byte{0, 0, 0, 0, 7, 0}, new(string)); err == nil {
+		t.Error("expected an error deserializing into a string")
+	}
+	b, err := json.Marshal(RegistrySchema{Subject: "colors-value", SchemaType: SchemaTypeAvro, Schema: `{"type":"enum"}`})
+	if want := `{"schemaType":"AVRO","schema":"{\"type\":\"enum\"}"}`; err != nil || string(b) != want {
+		t.Errorf("RegistrySchema marshaled to %s, %v, want %s", b, err, want)
+	}
+}
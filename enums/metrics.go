@@ -0,0 +1,14 @@
+package enums
+
+import "slices"
+
+// InitLabelValues creates the series of every label value of an enum in a
+// metric vector, passed as its WithLabelValues method, such as that of a
+// *prometheus.CounterVec, so that dashboards see every value from the start
+// rather than once it has occurred. The enum must be the last label of the
+// vector; otherLabelValues are the values of the labels before it.
+func InitLabelValues[M any](withLabelValues func(...string) M, labelValues []string, otherLabelValues ...string) {
+	for _, v := range labelValues {
+		withLabelValues(append(slices.Clip(otherLabelValues), v)...)
+	}
+}
@@ -0,0 +1,20 @@
+package enums
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInitLabelValues(t *testing.T) {
+	t.Parallel()
+	var got [][]string
+	withLabelValues := func(lvs ...string) int {
+		got = append(got, lvs)
+		return len(got)
+	}
+	InitLabelValues(withLabelValues, []string{"red", "green"}, "GET")
+	want := [][]string{{"GET", "red"}, {"GET", "green"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("InitLabelValues called WithLabelValues with %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,11 @@
+package enums
+
+// Names returns the name of each of values, as Name returns it, such as
+// the arguments of a SQL IN clause or the allowed values of a flag.
+func Names[E interface{ Name() string }](values []E) []string {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.Name()
+	}
+	return names
+}
@@ -0,0 +1,21 @@
+package enums
+
+import (
+	"slices"
+	"testing"
+)
+
+type namedValue string
+
+func (v namedValue) Name() string { return string(v) }
+
+func TestNames(t *testing.T) {
+	t.Parallel()
+	got := Names([]namedValue{"pending", "shipped"})
+	if want := []string{"pending", "shipped"}; !slices.Equal(got, want) {
+		t.Errorf("Names returned %q, want %q", got, want)
+	}
+	if got := Names[namedValue](nil); len(got) != 0 {
+		t.Errorf("Names returned %q for no values, want none", got)
+	}
+}
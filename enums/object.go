@@ -0,0 +1,96 @@
+package enums
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ObjectField is a custom field of an enum value, written by
+// MarshalJSONObject after its name, value and validity.
+type ObjectField struct {
+	// Name is the key of the field in the JSON object
+	Name string
+	// Value is the value of the field, encoded with encoding/json
+	Value any
+}
+
+// MarshalJSONObject encodes e as a JSON object holding its name, its
+// underlying value, whether it is valid and the given custom fields, in
+// that order, as written by enums generated with -serde/object:
+//
+//	{"name":"Active","value":1,"valid":true,"description":"In use"}
+func MarshalJSONObject[R comparable, T comparable, E Enum[R, T]](e E, fields ...ObjectField) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	fields = append([]ObjectField{
+		{Name: "name", Value: e.Name()},
+		{Name: "value", Value: e.Val()},
+		{Name: "valid", Value: e.IsValid()},
+	}, fields...)
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSONObject decodes the objects written by MarshalJSONObject, as
+// well as bare names and bare values, so producers may keep sending the
+// compact forms. Objects are looked up by name, or by value when they have
+// no name; their other keys are ignored. A JSON string is looked up as a
+// name first and then, for string underlying types, as a value.
+func UnmarshalJSONObject[R comparable, T comparable, E Enum[R, T]](e E, bs []byte, opts ...UnmarshalOption) (*E, error) {
+	trimmed := bytes.TrimSpace(bs)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var obj struct {
+			Name  *string         `json:"name"`
+			Value json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(trimmed, &obj); err != nil {
+			return nil, err
+		}
+		if obj.Name != nil {
+			return lookup(e, *obj.Name, true, string(bs), opts)
+		}
+		if obj.Value == nil {
+			return nil, fmt.Errorf("object %s has neither a name nor a value", bs)
+		}
+		var rawValue R
+		if err := json.Unmarshal(obj.Value, &rawValue); err != nil {
+			return nil, err
+		}
+		return lookup(e, rawValue, false, string(bs), opts)
+	case bytes.HasPrefix(trimmed, []byte(`"`)):
+		var name string
+		if err := json.Unmarshal(trimmed, &name); err != nil {
+			return nil, err
+		}
+		if result, err := findNameOrValue(e, name, true, string(bs)); err == nil {
+			return result, nil
+		}
+		if rawValue, ok := any(name).(R); ok {
+			return lookup(e, rawValue, false, string(bs), opts)
+		}
+		return lookup(e, name, true, string(bs), opts)
+	}
+	var rawValue R
+	if err := json.Unmarshal(trimmed, &rawValue); err != nil {
+		return nil, err
+	}
+	return lookup(e, rawValue, false, string(bs), opts)
+}
@@ -0,0 +1,50 @@
+package enums
+
+import "testing"
+
+func TestMarshalJSONObject(t *testing.T) {
+	t.Parallel()
+	got, err := MarshalJSONObject(testColors[1], ObjectField{Name: "hex", Value: "#00ff00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"Green","value":2,"valid":true,"hex":"#00ff00"}`; string(got) != want {
+		t.Errorf("MarshalJSONObject = %s, want %s", got, want)
+	}
+	if _, err := MarshalJSONObject(testColors[0], ObjectField{Name: "ch", Value: make(chan int)}); err == nil {
+		t.Error("expected an error for a field encoding/json cannot encode")
+	}
+}
+
+func TestUnmarshalJSONObject(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		data    string
+		opts    []UnmarshalOption
+		want    testColor
+		wantErr bool
+	}{
+		{name: "object", data: `{"name":"Green","value":2,"valid":true,"hex":"#00ff00"}`, want: testColors[1]},
+		{name: "object name wins", data: `{"name":"Red","value":2}`, want: testColors[0]},
+		{name: "object value", data: ` {"value":2}`, want: testColors[1]},
+		{name: "bare name", data: `"Red"`, want: testColors[0]},
+		{name: "bare value", data: `2`, want: testColors[1]},
+		{name: "unknown name", data: `{"name":"Blue"}`, wantErr: true},
+		{name: "unknown name lenient", data: `{"name":"Blue"}`, opts: []UnmarshalOption{WithLenient()}},
+		{name: "empty object", data: `{}`, wantErr: true},
+		{name: "malformed", data: `{"name":`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := UnmarshalJSONObject(testColor{}, []byte(tt.data), tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSONObject(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("UnmarshalJSONObject(%s) = %v, want %v", tt.data, *got, tt.want)
+			}
+		})
+	}
+}
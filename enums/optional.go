@@ -0,0 +1,132 @@
+package enums
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Optional holds a value that may be absent, for APIs where enum fields
+// are optional. It is an alternative to pointers that keeps the value
+// comparable and copyable. The zero Optional is absent; it marshals to
+// JSON null, and to SQL NULL, and is left out of JSON objects by fields
+// tagged with omitzero.
+type Optional[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, ok: true}
+}
+
+// None returns an absent Optional.
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// FromPtr returns an Optional holding *p, or an absent one if p is nil.
+func FromPtr[T any](p *T) Optional[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return Some(*p)
+}
+
+// Get returns the value and whether it is present.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// IsPresent reports whether o holds a value.
+func (o Optional[T]) IsPresent() bool {
+	return o.ok
+}
+
+// IsZero reports whether o is absent.
+func (o Optional[T]) IsZero() bool {
+	return !o.ok
+}
+
+// OrElse returns the value, or def if it is absent.
+func (o Optional[T]) OrElse(def T) T {
+	if !o.ok {
+		return def
+	}
+	return o.value
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if it is absent.
+func (o Optional[T]) Ptr() *T {
+	if !o.ok {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// String returns the value formatted with fmt, or "<none>" if it is absent.
+func (o Optional[T]) String() string {
+	if !o.ok {
+		return "<none>"
+	}
+	return fmt.Sprint(o.value)
+}
+
+// MarshalJSON implements the json.Marshaler interface, writing null for an
+// absent value.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, reading null as
+// an absent value.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = None[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning nil for an absent
+// value and the driver value of T, generated with -sql, otherwise.
+func (o Optional[T]) Value() (driver.Value, error) {
+	if !o.ok {
+		return nil, nil
+	}
+	if v, ok := any(o.value).(driver.Valuer); ok {
+		return v.Value()
+	}
+	return o.value, nil
+}
+
+// Scan implements the sql.Scanner interface, reading NULL as an absent value.
+// T must implement sql.Scanner, as enums generated with -sql do.
+func (o *Optional[T]) Scan(src any) error {
+	if src == nil {
+		*o = None[T]()
+		return nil
+	}
+	var v T
+	s, ok := any(&v).(sql.Scanner)
+	if !ok {
+		return fmt.Errorf("enums: cannot scan into Optional[%T]: it does not implement sql.Scanner", v)
+	}
+	if err := s.Scan(src); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
@@ -0,0 +1,76 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	t.Parallel()
+	o := Some(level(2))
+	if v, ok := o.Get(); !ok || v != 2 || o.OrElse(1) != 2 || *o.Ptr() != 2 || o.IsZero() {
+		t.Errorf("unexpected present optional %v", o)
+	}
+	none := None[level]()
+	if _, ok := none.Get(); ok || none.OrElse(1) != 1 || none.Ptr() != nil || !none.IsZero() || none.String() != "<none>" {
+		t.Errorf("unexpected absent optional %v", none)
+	}
+	if FromPtr[level](nil) != none || FromPtr(o.Ptr()) != o {
+		t.Error("expected FromPtr to mirror Ptr")
+	}
+}
+
+func TestOptional_JSON(t *testing.T) {
+	t.Parallel()
+	type payload struct {
+		A Optional[int] `json:"a"`
+		B Optional[int] `json:"b,omitzero"`
+	}
+	b, err := json.Marshal(payload{})
+	if err != nil || string(b) != `{"a":null}` {
+		t.Errorf("Marshal = %s, %v", b, err)
+	}
+	p := payload{A: Some(1)}
+	if err := json.Unmarshal([]byte(`{"a":null,"b":3}`), &p); err != nil || p.A.IsPresent() || p.B != Some(3) {
+		t.Errorf("Unmarshal = %+v, %v", p, err)
+	}
+}
+
+// scannedLevel scans the names of levels, as generated SQL methods do.
+type scannedLevel string
+
+func (l *scannedLevel) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return errors.New("not a string")
+	}
+	*l = scannedLevel(s)
+	return nil
+}
+
+func (l scannedLevel) Value() (driver.Value, error) {
+	return "level:" + string(l), nil
+}
+
+func TestOptional_SQL(t *testing.T) {
+	t.Parallel()
+	var o Optional[scannedLevel]
+	if err := o.Scan("high"); err != nil || o != Some(scannedLevel("high")) {
+		t.Errorf("Scan = %v, %v", o, err)
+	}
+	if v, err := o.Value(); err != nil || v != "level:high" {
+		t.Errorf("Value = %v, %v", v, err)
+	}
+	if err := o.Scan(nil); err != nil || o.IsPresent() {
+		t.Errorf("Scan(nil) = %v, %v", o, err)
+	}
+	if v, err := o.Value(); err != nil || v != nil {
+		t.Errorf("Value = %v, %v", v, err)
+	}
+	var i Optional[int]
+	if err := i.Scan(int64(1)); err == nil {
+		t.Error("expected an error scanning into a type without Scan")
+	}
+}
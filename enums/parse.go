@@ -0,0 +1,58 @@
+package enums
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Parse converts input into an enum value.
+// It accepts enum instances, names given as string, []byte or fmt.Stringer,
+// and any value that can be scanned into the underlying type R.
+// It returns an error if the input does not match a defined enum value.
+func Parse[R comparable, T comparable, E Enum[R, T]](e E, input any) (T, error) {
+	var zero T
+	switch v := input.(type) {
+	case nil:
+		return zero, fmt.Errorf("invalid value %v", input)
+	case T:
+		return v, nil
+	case string:
+		if ret, ok := e.FromName(v); ok {
+			return ret, nil
+		}
+	case []byte:
+		if ret, ok := e.FromName(string(v)); ok {
+			return ret, nil
+		}
+	case fmt.Stringer:
+		if ret, ok := e.FromName(v.String()); ok {
+			return ret, nil
+		}
+	}
+	if raw, ok := input.(R); ok {
+		if ret, ok := e.FromValue(raw); ok {
+			return ret, nil
+		}
+		return zero, fmt.Errorf("invalid value %v", input)
+	}
+	var raw R
+	if err := NewScanner(&raw).Scan(input); err != nil || truncated(input, raw) {
+		return zero, fmt.Errorf("invalid value %v", input)
+	}
+	if ret, ok := e.FromValue(raw); ok {
+		return ret, nil
+	}
+	return zero, fmt.Errorf("invalid value %v", input)
+}
+
+// truncated reports whether scanning a fractional float input into an
+// integer underlying type dropped its fractional part.
+func truncated(input, raw any) bool {
+	in := reflect.ValueOf(input)
+	if !in.CanFloat() {
+		return false
+	}
+	out := reflect.ValueOf(raw)
+	return (out.CanInt() || out.CanUint()) && in.Float() != math.Trunc(in.Float())
+}
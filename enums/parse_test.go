@@ -0,0 +1,88 @@
+package enums
+
+import (
+	"iter"
+	"testing"
+)
+
+type testColor struct {
+	name string
+	raw  int
+}
+
+var testColors = []testColor{{"Red", 1}, {"Green", 2}}
+
+func (c testColor) Val() int { return c.raw }
+
+func (c testColor) All() iter.Seq[testColor] {
+	return func(yield func(testColor) bool) {
+		for _, v := range testColors {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (c testColor) IsValid() bool { return c.name != "" }
+
+func (c testColor) FromName(name string) (testColor, bool) {
+	for v := range c.All() {
+		if v.name == name {
+			return v, true
+		}
+	}
+	return testColor{}, false
+}
+
+func (c testColor) FromValue(value int) (testColor, bool) {
+	for v := range c.All() {
+		if v.raw == value {
+			return v, true
+		}
+	}
+	return testColor{}, false
+}
+
+func (c testColor) SerdeFormat() Format { return FormatName }
+func (c testColor) Name() string        { return c.name }
+func (c testColor) String() string      { return c.name }
+
+type colorName string
+
+func (n colorName) String() string { return string(n) }
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   any
+		want    testColor
+		wantErr bool
+	}{
+		{"enum value", testColors[1], testColors[1], false},
+		{"name", "Red", testColors[0], false},
+		{"bytes", []byte("Green"), testColors[1], false},
+		{"stringer", colorName("Red"), testColors[0], false},
+		{"underlying value", 2, testColors[1], false},
+		{"numeric string", "1", testColors[0], false},
+		{"int64", int64(1), testColors[0], false},
+		{"whole float", 2.0, testColors[1], false},
+		{"fractional float", 1.5, testColor{}, true},
+		{"unknown name", "Blue", testColor{}, true},
+		{"unknown value", 3, testColor{}, true},
+		{"nil", nil, testColor{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Parse(testColor{}, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
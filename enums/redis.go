@@ -0,0 +1,41 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrNilReply is returned by RedisScan for nil replies, such as that of GET
+// on a missing key.
+var ErrNilReply = errors.New("nil reply")
+
+// RedisArg returns the argument e is sent to Redis as: its text form, the
+// name or, with FormatValue, the value b, as MarshalText returns it. Values
+// stored with it read back as strings, so they survive commands such as
+// GET, SET and HGETALL unchanged.
+func RedisArg[R comparable, T comparable, E Enum[R, T]](e E, b any) any {
+	bs, err := MarshalText(e, b)
+	if err != nil {
+		return e.String()
+	}
+	return bs
+}
+
+// RedisScan decodes src, a Redis reply, into a value of e. Bulk strings,
+// as []byte or string, are decoded like UnmarshalText, and integer replies
+// as their decimal text. It returns an error wrapping ErrNilReply for nil
+// replies.
+func RedisScan[R comparable, T comparable, E Enum[R, T]](e E, src any, opts ...UnmarshalOption) (*E, error) {
+	switch v := src.(type) {
+	case []byte:
+		return UnmarshalText(e, v, opts...)
+	case string:
+		return UnmarshalText(e, []byte(v), opts...)
+	case int64:
+		return UnmarshalText(e, strconv.AppendInt(nil, v, 10), opts...)
+	case nil:
+		return nil, ErrNilReply
+	}
+	return nil, fmt.Errorf("cannot scan %T reply", src)
+}
@@ -0,0 +1,81 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// redisString converts an argument to the bulk string Redis stores, as
+// client libraries write arguments in the RESP protocol.
+func redisString(arg any) []byte {
+	switch v := arg.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	}
+	return fmt.Append(nil, arg)
+}
+
+func TestRedisRoundTrip(t *testing.T) {
+	t.Parallel()
+	// A SET followed by a GET of every value, by name and by value
+	store := make(map[string][]byte)
+	for _, c := range testColors {
+		store[c.name] = redisString(RedisArg(c, c.raw))
+	}
+	for _, s := range testShades {
+		store[s.name] = redisString(RedisArg(s, s.raw))
+	}
+	if got := string(store["Red"]); got != "Red" {
+		t.Errorf("SET stored %q for Red, want its name", got)
+	}
+	if got := string(store["Dark"]); got != "2" {
+		t.Errorf("SET stored %q for Dark, want its value", got)
+	}
+	for _, c := range testColors {
+		got, err := RedisScan(testColor{}, store[c.name])
+		if err != nil || *got != c {
+			t.Errorf("RedisScan(GET %s) = %v, %v, want %v", c.name, got, err, c)
+		}
+	}
+	for _, s := range testShades {
+		got, err := RedisScan(testShade{}, store[s.name])
+		if err != nil || *got != s {
+			t.Errorf("RedisScan(GET %s) = %v, %v, want %v", s.name, got, err, s)
+		}
+	}
+}
+
+func TestRedisScan(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		src  any
+		want testShade
+		err  error
+	}{
+		{name: "string", src: "Light", want: testShades[0]},
+		{name: "integer", src: int64(2), want: testShades[1]},
+		{name: "nil", src: nil, err: ErrNilReply},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := RedisScan(testShade{}, tt.src)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("RedisScan(%v) error = %v, want %v", tt.src, err, tt.err)
+			}
+			if tt.err == nil && *got != tt.want {
+				t.Errorf("RedisScan(%v) = %v, want %v", tt.src, *got, tt.want)
+			}
+		})
+	}
+	if _, err := RedisScan(testColor{}, 1.5); err == nil {
+		t.Error("expected an error for a float reply")
+	}
+	if _, err := RedisScan(testColor{}, []byte("Blue")); err == nil {
+		t.Error("expected an error for an unknown name")
+	}
+}
@@ -0,0 +1,124 @@
+package enums
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrDuplicateEnumType is returned when a registry already holds an enum
+// type of the same name.
+var ErrDuplicateEnumType = errors.New("enum type already registered")
+
+// EnumType describes a generated enum type to tooling that does not know
+// it at compile time, such as admin UIs listing the enums of a service.
+type EnumType struct {
+	// Package is the name of the Go package declaring the type
+	Package string
+	// Name is the name of the generated wrapper type
+	Name string
+	// Values are the valid values of the type, in declaration order
+	Values []any
+	// Parse is the generated Parse function of the type
+	Parse func(input any) (any, error)
+}
+
+// Names returns the names of the values, as their String method prints them.
+func (t EnumType) Names() []string {
+	names := make([]string, len(t.Values))
+	for i, v := range t.Values {
+		names[i] = fmt.Sprint(v)
+	}
+	return names
+}
+
+// Registry holds enum types by name. Packages generated with -registry
+// have one, named EnumRegistry, holding their enum types. It is safe for
+// concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	types map[string]EnumType
+}
+
+// registered holds the enum types of every registry, for Types.
+var registered struct {
+	mu    sync.Mutex
+	types []EnumType
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{types: make(map[string]EnumType)}
+}
+
+// Register adds t to the registry. It returns ErrDuplicateEnumType if the
+// registry already holds a type named t.Name.
+func (r *Registry) Register(t EnumType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.types[t.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateEnumType, t.Name)
+	}
+	r.types[t.Name] = t
+	registered.mu.Lock()
+	registered.types = append(registered.types, t)
+	registered.mu.Unlock()
+	return nil
+}
+
+// Register adds the enum type name of package pkg to r, with its values
+// and parse function. It panics if r already holds a type named name, and
+// is called by the init functions generated with -registry.
+func Register[T any](r *Registry, pkg, name string, values []T, parse func(input any) (T, error)) {
+	t := EnumType{
+		Package: pkg,
+		Name:    name,
+		Values:  make([]any, len(values)),
+		Parse: func(input any) (any, error) {
+			return parse(input)
+		},
+	}
+	for i, v := range values {
+		t.Values[i] = v
+	}
+	if err := r.Register(t); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the enum type named name.
+func (r *Registry) Lookup(name string) (EnumType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.types[name]
+	return t, ok
+}
+
+// Types returns the enum types of the registry, sorted by name.
+func (r *Registry) Types() []EnumType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]EnumType, 0, len(r.types))
+	for _, t := range r.types {
+		types = append(types, t)
+	}
+	return sortTypes(types)
+}
+
+// Types returns the enum types of every registry of the program, sorted by
+// package and name: the enums of all packages generated with -registry that
+// are linked in.
+func Types() []EnumType {
+	registered.mu.Lock()
+	defer registered.mu.Unlock()
+	return sortTypes(slices.Clone(registered.types))
+}
+
+func sortTypes(types []EnumType) []EnumType {
+	slices.SortStableFunc(types, func(a, b EnumType) int {
+		return cmp.Or(cmp.Compare(a.Package, b.Package), cmp.Compare(a.Name, b.Name))
+	})
+	return types
+}
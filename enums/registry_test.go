@@ -0,0 +1,48 @@
+package enums
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	Register(r, "registrytest", "Level", []level{1, 2}, parseLevel)
+	Register(r, "registrytest", "Shade", []shade{"dark"}, parseShade)
+
+	lt, ok := r.Lookup("Level")
+	if !ok || lt.Package != "registrytest" || !slices.Equal(lt.Names(), []string{"1", "2"}) {
+		t.Fatalf("unexpected type %+v", lt)
+	}
+	if v, err := lt.Parse("high"); err != nil || v != level(2) {
+		t.Errorf("Parse(high) = %v, %v", v, err)
+	}
+	if _, err := lt.Parse("medium"); err == nil {
+		t.Error("expected an error parsing an invalid name")
+	}
+	if _, ok := r.Lookup("Size"); ok {
+		t.Error("expected Size not to be registered")
+	}
+	if err := r.Register(EnumType{Package: "other", Name: "Level"}); !errors.Is(err, ErrDuplicateEnumType) {
+		t.Errorf("expected ErrDuplicateEnumType, got %v", err)
+	}
+
+	var names []string
+	for _, et := range r.Types() {
+		names = append(names, et.Name)
+	}
+	if !slices.Equal(names, []string{"Level", "Shade"}) {
+		t.Errorf("unexpected registry types %v", names)
+	}
+	var global []string
+	for _, et := range Types() {
+		if et.Package == "registrytest" {
+			global = append(global, et.Name)
+		}
+	}
+	if !slices.Equal(global, names) {
+		t.Errorf("expected Types to list %v, got %v", names, global)
+	}
+}
@@ -0,0 +1,41 @@
+package enums
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrIncompatibleSchema is returned by CheckCompatibility when two binaries
+// disagree on the names or values of an enum.
+var ErrIncompatibleSchema = errors.New("incompatible enum schema")
+
+// SchemaHash returns the fingerprint of an enum whose valid values are
+// serialized as the keys of values and have the underlying values they map
+// to, formatted as Go literals. It does not depend on declaration order, so
+// only adding, removing or renaming values, or changing their underlying
+// values, changes it. It is computed at generation time for the
+// <Type>SchemaHash constants written with -schemahash.
+func SchemaHash(values map[string]string) string {
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		fmt.Fprintf(&b, "%q=%s\n", name, values[name])
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// CheckCompatibility returns an error wrapping ErrIncompatibleSchema if
+// the schema hash of the enum type name in this binary, local, differs
+// from the one reported by a peer, remote. Services check it at startup,
+// or when a connection is established, before exchanging serialized enums.
+func CheckCompatibility(name, local, remote string) error {
+	if local != remote {
+		return fmt.Errorf("%w: %s: local schema %s, remote schema %s", ErrIncompatibleSchema, name, local, remote)
+	}
+	return nil
+}
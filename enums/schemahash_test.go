@@ -0,0 +1,43 @@
+package enums
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSchemaHash(t *testing.T) {
+	t.Parallel()
+	base := SchemaHash(map[string]string{"low": "1", "high": "2"})
+	if len(base) != 16 {
+		t.Errorf("expected a 16 character hash, got %q", base)
+	}
+	tests := []struct {
+		name   string
+		values map[string]string
+		same   bool
+	}{
+		{name: "same values", values: map[string]string{"high": "2", "low": "1"}, same: true},
+		{name: "renamed", values: map[string]string{"lo": "1", "high": "2"}},
+		{name: "renumbered", values: map[string]string{"low": "1", "high": "3"}},
+		{name: "added", values: map[string]string{"low": "1", "high": "2", "max": "3"}},
+		{name: "names are not split", values: map[string]string{"low=1\n\"high\"": "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := SchemaHash(tt.values); (got == base) != tt.same {
+				t.Errorf("SchemaHash(%v) = %s, base %s", tt.values, got, base)
+			}
+		})
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Parallel()
+	if err := CheckCompatibility("Level", "abc", "abc"); err != nil {
+		t.Errorf("expected equal hashes to be compatible, got %v", err)
+	}
+	if err := CheckCompatibility("Level", "abc", "abd"); !errors.Is(err, ErrIncompatibleSchema) {
+		t.Errorf("expected ErrIncompatibleSchema, got %v", err)
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func MarshalJSON[R comparable, T comparable, E Enum[R, T]](e E, b any) ([]byte, error) {
-	if e.SerdeFormat() == FormatName {
+	if valueFormat(e) == FormatName {
 		return json.Marshal(e.Name())
 	}
 	bs, err := anyToString(b)
@@ -19,19 +19,109 @@ func MarshalJSON[R comparable, T comparable, E Enum[R, T]](e E, b any) ([]byte,
 	return []byte(bs), nil
 }
 
-func UnmarshalJSON[R comparable, T comparable, E Enum[R, T]](e E, bs []byte) (*E, error) {
-	if e.SerdeFormat() == FormatName {
-		var name string
-		if err := json.Unmarshal(bs, &name); err != nil {
+// UnmarshalOption configures how UnmarshalJSON, UnmarshalText and
+// UnmarshalBinary decode values.
+type UnmarshalOption func(*unmarshalOptions)
+
+type unmarshalOptions struct {
+	lenient bool
+	binary  BinaryOptions
+}
+
+func newUnmarshalOptions(opts []UnmarshalOption) unmarshalOptions {
+	var o unmarshalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithLenient decodes unknown names and values to the invalid sentinel of
+// generated enums, which FromName and FromValue return for them, instead of
+// returning an error, for consumers that must tolerate values added by newer
+// producers. Malformed input still returns an error.
+func WithLenient() UnmarshalOption {
+	return func(o *unmarshalOptions) {
+		o.lenient = true
+	}
+}
+
+// lookup is findNameOrValue honoring opts.
+func lookup[R comparable, T comparable, E Enum[R, T], V any](e E, value V, isName bool, src any, opts []UnmarshalOption) (*E, error) {
+	result, err := findNameOrValue(e, value, isName, src)
+	if err != nil && newUnmarshalOptions(opts).lenient {
+		return notFound(e, value, isName), nil
+	}
+	return result, err
+}
+
+// notFound returns what FromName or FromValue return for a value they do
+// not find, the invalid sentinel of generated enums, or the zero value.
+func notFound[R comparable, T comparable, E Enum[R, T], V any](e E, value V, isName bool) *E {
+	var ret T
+	if isName {
+		ret, _ = e.FromName(any(value).(string))
+	} else {
+		ret, _ = e.FromValue(any(value).(R))
+	}
+	if en, ok := any(ret).(E); ok {
+		return &en
+	}
+	return new(E)
+}
+
+func UnmarshalJSON[R comparable, T comparable, E Enum[R, T]](e E, bs []byte, opts ...UnmarshalOption) (*E, error) {
+	return unmarshalFormats(e, opts, func(format Format, opts []UnmarshalOption) (*E, error) {
+		if format == FormatName {
+			var name string
+			if err := json.Unmarshal(bs, &name); err != nil {
+				return nil, err
+			}
+			return lookup(e, name, true, string(bs), opts)
+		}
+		var rawValue R
+		if err := json.Unmarshal(bs, &rawValue); err != nil {
 			return nil, err
 		}
-		return findNameOrValue(e, name, true, string(bs))
+		return lookup(e, rawValue, false, string(bs), opts)
+	})
+}
+
+// valueFormat returns the format e is serialized in, its own for enums
+// implementing FormatOverrider and that of its type otherwise.
+func valueFormat[R comparable, T comparable, E Enum[R, T]](e E) Format {
+	if o, ok := any(e).(FormatOverrider); ok {
+		return o.ValueFormat()
 	}
-	var rawValue R
-	if err := json.Unmarshal(bs, &rawValue); err != nil {
-		return nil, err
+	return e.SerdeFormat()
+}
+
+// unmarshalFormats decodes input in the format of the type of e and, for
+// enums implementing FormatOverrider, in the other format, accepting only
+// the values serialized in it. Input neither decodes is decoded again with
+// opts, so that WithLenient applies to unknown names and values alike.
+func unmarshalFormats[R comparable, T comparable, E Enum[R, T]](e E, opts []UnmarshalOption, decode func(Format, []UnmarshalOption) (*E, error)) (*E, error) {
+	if _, ok := any(e).(FormatOverrider); !ok {
+		return decode(e.SerdeFormat(), opts)
+	}
+	result, err := decode(e.SerdeFormat(), nil)
+	if err == nil {
+		return result, nil
+	}
+	other := FormatName
+	if e.SerdeFormat() == FormatName {
+		other = FormatValue
 	}
-	return findNameOrValue(e, rawValue, false, string(bs))
+	if result, err := decode(other, nil); err == nil && valueFormat[R, T](*result) == other {
+		return result, nil
+	}
+	result, err = decode(e.SerdeFormat(), opts)
+	if err != nil && newUnmarshalOptions(opts).lenient {
+		if lenient, otherErr := decode(other, opts); otherErr == nil {
+			return lenient, nil
+		}
+	}
+	return result, err
 }
 
 func SQLValue[R comparable, T comparable, E Enum[R, T]](e E) (driver.Value, error) {
@@ -77,7 +167,14 @@ func SQLValue[R comparable, T comparable, E Enum[R, T]](e E) (driver.Value, erro
 }
 
 func SQLScan[R comparable, T comparable, E Enum[R, T]](e E, src any) (*E, error) {
-	if e.SerdeFormat() == FormatName {
+	return SQLScanFormat(e, e.SerdeFormat(), src)
+}
+
+// SQLScanFormat scans src as SQLScan does, reading a name or a value as
+// format says instead of following the serialization format of the enum.
+// The Scan methods of enums generated with -sqltype call it.
+func SQLScanFormat[R comparable, T comparable, E Enum[R, T]](e E, format Format, src any) (*E, error) {
+	if format == FormatName {
 		var name string
 		err := NewScanner(&name).Scan(src)
 		if err != nil {
@@ -94,8 +191,24 @@ func SQLScan[R comparable, T comparable, E Enum[R, T]](e E, src any) (*E, error)
 	return findNameOrValue(e, rawValue, false, src)
 }
 
+// ScanName looks up the name read from the database value src, as SQLScan
+// does for enums serialized by name. The generated Scan methods call it for
+// string and []byte values, which they convert without reflection, and
+// SQLScan for other values.
+func ScanName[R comparable, T comparable, E Enum[R, T]](e E, name string, src any) (*E, error) {
+	return findNameOrValue(e, name, true, src)
+}
+
+// ScanValue looks up the underlying value read from the database value src,
+// as SQLScan does for enums serialized by value. The generated Scan methods
+// call it for the driver values they convert to the underlying type without
+// reflection, and SQLScan for other values.
+func ScanValue[R comparable, T comparable, E Enum[R, T]](e E, value R, src any) (*E, error) {
+	return findNameOrValue(e, value, false, src)
+}
+
 func MarshalText[R comparable, T comparable, E Enum[R, T]](e E, b any) ([]byte, error) {
-	if e.SerdeFormat() == FormatName {
+	if valueFormat(e) == FormatName {
 		return []byte(e.Name()), nil
 	}
 	bs, err := anyToString(b)
@@ -105,39 +218,51 @@ func MarshalText[R comparable, T comparable, E Enum[R, T]](e E, b any) ([]byte,
 	return []byte(bs), nil
 }
 
-func UnmarshalText[R comparable, T comparable, E Enum[R, T]](e E, bs []byte) (*E, error) {
+func UnmarshalText[R comparable, T comparable, E Enum[R, T]](e E, bs []byte, opts ...UnmarshalOption) (*E, error) {
 	str := string(bs)
-	if e.SerdeFormat() == FormatName {
-		return findNameOrValue(e, str, true, str)
-	}
-
-	var rawValue R
-	err := parseStringValue(str, &rawValue)
-	if err != nil {
-		return nil, err
-	}
-	return findNameOrValue(e, rawValue, false, string(bs))
+	return unmarshalFormats(e, opts, func(format Format, opts []UnmarshalOption) (*E, error) {
+		if format == FormatName {
+			return lookup(e, str, true, str, opts)
+		}
+		var rawValue R
+		err := parseStringValue(str, &rawValue)
+		if err != nil {
+			return nil, err
+		}
+		return lookup(e, rawValue, false, str, opts)
+	})
 }
 
-func MarshalBinary[R comparable, T comparable, E Enum[R, T]](e E, b any) ([]byte, error) {
-	if e.SerdeFormat() == FormatName {
-		return []byte(e.Name()), nil
+// MarshalBinary encodes e as its name or, with FormatValue, as its value b,
+// using the encoding of opts if given and of the zero BinaryOptions otherwise.
+func MarshalBinary[R comparable, T comparable, E Enum[R, T]](e E, b any, opts ...BinaryOptions) ([]byte, error) {
+	var o BinaryOptions
+	if len(opts) > 0 {
+		o = opts[0]
 	}
-	return anyToBinary(b)
-}
-
-func UnmarshalBinary[R comparable, T comparable, E Enum[R, T]](e E, bs []byte) (*E, error) {
-	if e.SerdeFormat() == FormatName {
-		name := string(bs)
-		return findNameOrValue(e, name, true, string(bs))
+	if valueFormat(e) == FormatName {
+		return o.appendString(nil, e.Name()), nil
 	}
+	return encodeBinary(b, o)
+}
 
-	var rawValue R
-	err := parseBinaryValue(bs, &rawValue)
-	if err != nil {
-		return nil, err
-	}
-	return findNameOrValue(e, rawValue, false, string(bs))
+func UnmarshalBinary[R comparable, T comparable, E Enum[R, T]](e E, bs []byte, opts ...UnmarshalOption) (*E, error) {
+	o := newUnmarshalOptions(opts).binary
+	return unmarshalFormats(e, opts, func(format Format, opts []UnmarshalOption) (*E, error) {
+		if format == FormatName {
+			name, err := o.readString(bs)
+			if err != nil {
+				return nil, err
+			}
+			return lookup(e, name, true, string(bs), opts)
+		}
+		var rawValue R
+		err := decodeBinary(bs, &rawValue, o)
+		if err != nil {
+			return nil, err
+		}
+		return lookup(e, rawValue, false, string(bs), opts)
+	})
 }
 
 func findNameOrValue[R comparable, T comparable, E Enum[R, T], V any](e E, value V, isName bool, src any) (*E, error) {
@@ -166,10 +291,20 @@ type YAMLNode interface {
 	Decode(interface{}) error
 }
 
+// YAMLDecodeFunc adapts the decode callback passed to function-based YAML
+// unmarshalers, such as those of github.com/goccy/go-yaml and gopkg.in/yaml.v2,
+// to the YAMLNode interface.
+type YAMLDecodeFunc func(any) error
+
+// Decode calls f(v).
+func (f YAMLDecodeFunc) Decode(v any) error {
+	return f(v)
+}
+
 // MarshalYAML implements YAML marshaling for enums
 // Returns the value that should be marshaled to YAML
 func MarshalYAML[R comparable, T comparable, E Enum[R, T]](e E, b any) (interface{}, error) {
-	if e.SerdeFormat() == FormatName {
+	if valueFormat(e) == FormatName {
 		return e.Name(), nil
 	}
 
@@ -215,28 +350,30 @@ func MarshalYAML[R comparable, T comparable, E Enum[R, T]](e E, b any) (interfac
 
 // UnmarshalYAML implements YAML unmarshaling for enums using the new Node interface
 func UnmarshalYAML[R comparable, T comparable, E Enum[R, T]](e E, node YAMLNode) (*E, error) {
-	if e.SerdeFormat() == FormatName {
-		var name string
-		if err := node.Decode(&name); err != nil {
-			return nil, fmt.Errorf("failed to decode YAML node as string: %w", err)
+	return unmarshalFormats(e, nil, func(format Format, _ []UnmarshalOption) (*E, error) {
+		if format == FormatName {
+			var name string
+			if err := node.Decode(&name); err != nil {
+				return nil, fmt.Errorf("failed to decode YAML node as string: %w", err)
+			}
+			return findNameOrValue(e, name, true, name)
 		}
-		return findNameOrValue(e, name, true, name)
-	}
 
-	// For value format, try to decode as the raw value type
-	var rawValue R
-	if err := node.Decode(&rawValue); err != nil {
-		// If direct decoding fails, try to decode as interface{} and convert
-		var value interface{}
-		if err2 := node.Decode(&value); err2 != nil {
-			return nil, fmt.Errorf("failed to decode YAML node: %w", err)
-		}
+		// For value format, try to decode as the raw value type
+		var rawValue R
+		if err := node.Decode(&rawValue); err != nil {
+			// If direct decoding fails, try to decode as interface{} and convert
+			var value interface{}
+			if err2 := node.Decode(&value); err2 != nil {
+				return nil, fmt.Errorf("failed to decode YAML node: %w", err)
+			}
 
-		// Convert the decoded value to the target type
-		if err := convertToTargetType(value, &rawValue); err != nil {
-			return nil, fmt.Errorf("failed to convert YAML value to target type: %w", err)
+			// Convert the decoded value to the target type
+			if err := convertToTargetType(value, &rawValue); err != nil {
+				return nil, fmt.Errorf("failed to convert YAML value to target type: %w", err)
+			}
 		}
-	}
 
-	return findNameOrValue(e, rawValue, false, rawValue)
+		return findNameOrValue(e, rawValue, false, rawValue)
+	})
 }
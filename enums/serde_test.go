@@ -2,6 +2,8 @@ package enums
 
 import (
 	"fmt"
+	"iter"
+	"slices"
 	"testing"
 )
 
@@ -107,6 +109,128 @@ func TestBinaryVsTextSerialization(t *testing.T) {
 	}
 }
 
+func TestUnmarshalLenient(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		unmarshal func(data []byte, opts ...UnmarshalOption) (*testColor, error)
+		data      string
+		opts      []UnmarshalOption
+		want      testColor
+		wantErr   bool
+	}{
+		{"json strict unknown", jsonColor, `"Blue"`, nil, testColor{}, true},
+		{"json lenient unknown", jsonColor, `"Blue"`, []UnmarshalOption{WithLenient()}, testColor{}, false},
+		{"json lenient known", jsonColor, `"Green"`, []UnmarshalOption{WithLenient()}, testColors[1], false},
+		{"json lenient malformed", jsonColor, `"Blue`, []UnmarshalOption{WithLenient()}, testColor{}, true},
+		{"text strict unknown", textColor, "Blue", nil, testColor{}, true},
+		{"text lenient unknown", textColor, "Blue", []UnmarshalOption{WithLenient()}, testColor{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.unmarshal([]byte(tt.data), tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unmarshal(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("unmarshal(%s) = %v, want %v", tt.data, *got, tt.want)
+			}
+		})
+	}
+}
+
+func jsonColor(data []byte, opts ...UnmarshalOption) (*testColor, error) {
+	return UnmarshalJSON(testColor{}, data, opts...)
+}
+
+func textColor(data []byte, opts ...UnmarshalOption) (*testColor, error) {
+	return UnmarshalText(testColor{}, data, opts...)
+}
+
+// testShade serializes Dark by value, overriding the name format of its
+// type, and Light by name.
+type testShade struct{ testColor }
+
+var testShades = []testShade{{testColor{"Light", 1}}, {testColor{"Dark", 2}}}
+
+func (s testShade) All() iter.Seq[testShade] { return slices.Values(testShades) }
+
+func (s testShade) FromName(name string) (testShade, bool) {
+	i := slices.IndexFunc(testShades, func(v testShade) bool { return v.name == name })
+	if i < 0 {
+		return testShade{}, false
+	}
+	return testShades[i], true
+}
+
+func (s testShade) FromValue(value int) (testShade, bool) {
+	i := slices.IndexFunc(testShades, func(v testShade) bool { return v.raw == value })
+	if i < 0 {
+		return testShade{}, false
+	}
+	return testShades[i], true
+}
+
+func (s testShade) ValueFormat() Format {
+	if s.name == "Dark" {
+		return FormatValue
+	}
+	return FormatName
+}
+
+func TestFormatOverrides(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		shade testShade
+		want  string
+	}{
+		{testShades[0], `"Light"`},
+		{testShades[1], `2`},
+	} {
+		got, err := MarshalJSON(tt.shade, tt.shade.raw)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("MarshalJSON(%v) = %s, %v, want %s", tt.shade, got, err, tt.want)
+		}
+	}
+	tests := []struct {
+		name      string
+		unmarshal func(data []byte, opts ...UnmarshalOption) (*testShade, error)
+		data      string
+		opts      []UnmarshalOption
+		want      testShade
+		wantErr   bool
+	}{
+		{"json name", jsonShade, `"Light"`, nil, testShades[0], false},
+		{"json overridden value", jsonShade, `2`, nil, testShades[1], false},
+		{"json overridden name", jsonShade, `"Dark"`, nil, testShades[1], false},
+		{"json value not overridden", jsonShade, `1`, nil, testShade{}, true},
+		{"json lenient unknown", jsonShade, `5`, []UnmarshalOption{WithLenient()}, testShade{}, false},
+		{"text overridden value", textShade, "2", nil, testShades[1], false},
+		{"text value not overridden", textShade, "1", nil, testShade{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.unmarshal([]byte(tt.data), tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unmarshal(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("unmarshal(%s) = %v, want %v", tt.data, *got, tt.want)
+			}
+		})
+	}
+}
+
+func jsonShade(data []byte, opts ...UnmarshalOption) (*testShade, error) {
+	return UnmarshalJSON(testShade{}, data, opts...)
+}
+
+func textShade(data []byte, opts ...UnmarshalOption) (*testShade, error) {
+	return UnmarshalText(testShade{}, data, opts...)
+}
+
 func TestErrorHandling(t *testing.T) {
 	// 测试错误处理
 
@@ -0,0 +1,67 @@
+package enums
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// Slice is a read-only slice held by an enum field. Enum wrappers must be
+// comparable, which slices are not, so fields declared with a slice type,
+// as in Tags[[]string], are generated as Slices. Slices compare equal only
+// if they were created by the same call to SliceOf.
+type Slice[T any] struct {
+	values *[]T
+}
+
+// SliceOf returns a Slice holding values.
+func SliceOf[T any](values ...T) Slice[T] {
+	return Slice[T]{values: &values}
+}
+
+// Len returns the number of values.
+func (s Slice[T]) Len() int {
+	if s.values == nil {
+		return 0
+	}
+	return len(*s.values)
+}
+
+// At returns the value at index i. It panics if i is out of range.
+func (s Slice[T]) At(i int) T {
+	return (*s.values)[i]
+}
+
+// All returns an iterator over the indexes and values.
+func (s Slice[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range s.Len() {
+			if !yield(i, (*s.values)[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a copy of the values.
+func (s Slice[T]) Values() []T {
+	if s.values == nil {
+		return nil
+	}
+	return slices.Clone(*s.values)
+}
+
+// String formats the values as fmt formats slices.
+func (s Slice[T]) String() string {
+	return fmt.Sprint(s.Values())
+}
+
+// MarshalJSON encodes the values as a JSON array, for the JSON objects of
+// enums generated with -serde/object.
+func (s Slice[T]) MarshalJSON() ([]byte, error) {
+	if s.values == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(*s.values)
+}
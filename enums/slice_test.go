@@ -0,0 +1,44 @@
+package enums
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	t.Parallel()
+	s := SliceOf("nightly", "db")
+	if s.Len() != 2 || s.At(1) != "db" || s.String() != "[nightly db]" {
+		t.Errorf("unexpected slice %v", s)
+	}
+	values := s.Values()
+	values[0] = "weekly"
+	if s.At(0) != "nightly" {
+		t.Error("expected Values to return a copy")
+	}
+	var got []string
+	for i, v := range s.All() {
+		if s.At(i) != v {
+			t.Errorf("All yielded %d, %q", i, v)
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []string{"nightly", "db"}) {
+		t.Errorf("unexpected values %v", got)
+	}
+	if c := s; s == SliceOf("nightly", "db") || c != s {
+		t.Error("expected slices to compare by identity")
+	}
+
+	if b, err := s.MarshalJSON(); err != nil || string(b) != `["nightly","db"]` {
+		t.Errorf("MarshalJSON = %s, %v", b, err)
+	}
+
+	var zero Slice[int]
+	if zero.Len() != 0 || zero.Values() != nil || zero.String() != "[]" {
+		t.Errorf("unexpected zero slice %v", zero)
+	}
+	if b, err := zero.MarshalJSON(); err != nil || string(b) != "[]" {
+		t.Errorf("MarshalJSON of the zero slice = %s, %v", b, err)
+	}
+}
@@ -1,23 +1,60 @@
 package enums
 
 import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// DefaultTimeLayouts are the layouts GenericScanner parses times with,
+// in order, unless configured with WithTimeLayouts.
+var DefaultTimeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
 // GenericScanner is a generic Scanner implementation
 type GenericScanner[T any] struct {
-	value *T
+	value   *T
+	layouts []string
 }
 
-func NewScanner[T any](value *T) *GenericScanner[T] {
-	return &GenericScanner[T]{value: value}
+// ScanOption configures a GenericScanner.
+type ScanOption func(*scanOptions)
+
+type scanOptions struct {
+	layouts []string
 }
 
+// WithTimeLayouts parses times from strings with layouts, tried in order,
+// instead of DefaultTimeLayouts.
+func WithTimeLayouts(layouts ...string) ScanOption {
+	return func(o *scanOptions) {
+		o.layouts = layouts
+	}
+}
+
+func NewScanner[T any](value *T, opts ...ScanOption) *GenericScanner[T] {
+	o := scanOptions{layouts: DefaultTimeLayouts}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &GenericScanner[T]{value: value, layouts: o.layouts}
+}
+
+// Scan stores src in the value of s. Besides the basic types, it accepts
+// sql.RawBytes, JSON strings, numbers and booleans in json.RawMessage, and
+// driver.Valuer implementations such as decimal types, which it scans the
+// value of.
 func (s *GenericScanner[T]) Scan(src any) error {
+	src, err := normalizeSource(src)
+	if err != nil {
+		return err
+	}
 	if src == nil {
 		return nil
 	}
@@ -57,6 +94,52 @@ func (s *GenericScanner[T]) Scan(src any) error {
 	}
 }
 
+// normalizeSource converts the driver-specific representations of src to
+// the basic types the scan methods handle.
+func normalizeSource(src any) (any, error) {
+	switch v := src.(type) {
+	case sql.RawBytes:
+		// The driver reuses the memory of RawBytes on the next scan
+		return bytes.Clone(v), nil
+	case json.RawMessage:
+		dec := json.NewDecoder(bytes.NewReader(v))
+		dec.UseNumber()
+		var decoded any
+		if err := dec.Decode(&decoded); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON source: %v", err)
+		}
+		switch d := decoded.(type) {
+		case json.Number:
+			return d.String(), nil
+		case string, bool, nil:
+			return d, nil
+		default:
+			return nil, fmt.Errorf("cannot convert JSON %s to a scalar", v)
+		}
+	case driver.Valuer:
+		value, err := v.Value()
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+	return src, nil
+}
+
+// parseInteger parses a base 10 integer, accepting decimals without a
+// fractional part, such as the "2.00" drivers return for DECIMAL columns.
+func parseInteger(s string) (int64, error) {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return i, nil
+	}
+	whole, frac, ok := strings.Cut(s, ".")
+	if !ok || strings.Trim(frac, "0") != "" {
+		return 0, err
+	}
+	return strconv.ParseInt(whole, 10, 64)
+}
+
 func (s *GenericScanner[T]) scanString(src any) error {
 	v := reflect.ValueOf(src)
 	if v.Kind() == reflect.Ptr {
@@ -111,14 +194,14 @@ func (s *GenericScanner[T]) scanInt(src any) error {
 		}
 	case reflect.String:
 		var err error
-		i, err = strconv.ParseInt(v.String(), 10, 64)
+		i, err = parseInteger(v.String())
 		if err != nil {
 			return fmt.Errorf("failed to parse integer from string: %v", err)
 		}
 	case reflect.Slice:
 		if v.Type().Elem().Kind() == reflect.Uint8 {
 			var err error
-			i, err = strconv.ParseInt(string(v.Bytes()), 10, 64)
+			i, err = parseInteger(string(v.Bytes()))
 			if err != nil {
 				return fmt.Errorf("failed to parse integer from bytes: %v", err)
 			}
@@ -299,16 +382,24 @@ func (s *GenericScanner[T]) scanTime(src any) error {
 		t = v.Interface().(time.Time)
 	case v.Kind() == reflect.String:
 		var err error
-		t, err = time.Parse(time.RFC3339Nano, v.String())
+		t, err = s.parseTime(v.String())
 		if err != nil {
 			return fmt.Errorf("failed to parse time from string: %v", err)
 		}
 	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
 		var err error
-		t, err = time.Parse(time.RFC3339Nano, string(v.Bytes()))
+		t, err = s.parseTime(string(v.Bytes()))
 		if err != nil {
 			return fmt.Errorf("failed to parse time from bytes: %v", err)
 		}
+	case v.CanInt():
+		// Unix epoch seconds
+		t = time.Unix(v.Int(), 0).UTC()
+	case v.CanUint():
+		if v.Uint() > math.MaxInt64 {
+			return fmt.Errorf("unix time %d overflows int64", v.Uint())
+		}
+		t = time.Unix(int64(v.Uint()), 0).UTC()
 	default:
 		return fmt.Errorf("cannot convert %v to time.Time", v.Type())
 	}
@@ -316,3 +407,18 @@ func (s *GenericScanner[T]) scanTime(src any) error {
 	reflect.ValueOf(s.value).Elem().Set(reflect.ValueOf(t))
 	return nil
 }
+
+// parseTime parses str with the first of the layouts of s that matches it.
+func (s *GenericScanner[T]) parseTime(str string) (time.Time, error) {
+	var err error
+	for _, layout := range s.layouts {
+		var t time.Time
+		if t, err = time.Parse(layout, str); err == nil {
+			return t, nil
+		}
+	}
+	if err == nil {
+		return time.Time{}, fmt.Errorf("no time layouts to parse %q with", str)
+	}
+	return time.Time{}, err
+}
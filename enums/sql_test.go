@@ -1,11 +1,35 @@
 package enums
 
 import (
+	"database/sql"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
 )
 
+func TestScanNameAndValue(t *testing.T) {
+	t.Parallel()
+	if got, err := ScanName(testColor{}, "Green", []byte("Green")); err != nil || *got != testColors[1] {
+		t.Errorf("ScanName(Green) = %v, %v", got, err)
+	}
+	if _, err := ScanName(testColor{}, "Blue", "Blue"); err == nil {
+		t.Error("expected an error scanning an unknown name")
+	}
+	if got, err := ScanValue(testColor{}, 1, int64(1)); err != nil || *got != testColors[0] {
+		t.Errorf("ScanValue(1) = %v, %v", got, err)
+	}
+	if _, err := ScanValue(testColor{}, 3, int64(3)); err == nil {
+		t.Error("expected an error scanning an unknown value")
+	}
+	if got, err := SQLScanFormat(testColor{}, FormatValue, int64(2)); err != nil || *got != testColors[1] {
+		t.Errorf("SQLScanFormat(FormatValue, 2) = %v, %v", got, err)
+	}
+	if _, err := SQLScanFormat(testColor{}, FormatValue, "Green"); err == nil {
+		t.Error("expected an error scanning a name with FormatValue")
+	}
+}
+
 func TestGenericScanner_String(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -475,6 +499,85 @@ func TestGenericScanner_Time(t *testing.T) {
 	}
 }
 
+func TestGenericScanner_TimeLayouts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		src      any
+		opts     []ScanOption
+		expected time.Time
+		wantErr  bool
+	}{
+		{name: "datetime", src: "2023-12-25 10:30:45", expected: time.Date(2023, 12, 25, 10, 30, 45, 0, time.UTC)},
+		{name: "date", src: []byte("2023-12-25"), expected: time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{name: "unix seconds", src: int64(1703500245), expected: time.Date(2023, 12, 25, 10, 30, 45, 0, time.UTC)},
+		{name: "unsigned unix seconds", src: uint32(0), expected: time.Unix(0, 0)},
+		{name: "custom layout", src: "25/12/2023", opts: []ScanOption{WithTimeLayouts("02/01/2006")}, expected: time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{name: "custom layouts replace defaults", src: "2023-12-25", opts: []ScanOption{WithTimeLayouts("02/01/2006")}, wantErr: true},
+		{name: "no layouts", src: "2023-12-25", opts: []ScanOption{WithTimeLayouts()}, wantErr: true},
+		{name: "float", src: 1.5, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var result time.Time
+			err := NewScanner(&result, tt.opts...).Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan(%v) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			}
+			if err == nil && !result.Equal(tt.expected) {
+				t.Errorf("Scan(%v) = %v, want %v", tt.src, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenericScanner_DriverSources(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		scan    func() (any, error)
+		want    any
+		wantErr bool
+	}{
+		{name: "raw bytes to string", scan: scanInto[string](sql.RawBytes("Green")), want: "Green"},
+		{name: "raw bytes to int", scan: scanInto[int](sql.RawBytes("2")), want: 2},
+		{name: "JSON string", scan: scanInto[string](json.RawMessage(`"Green"`)), want: "Green"},
+		{name: "JSON number to int", scan: scanInto[int](json.RawMessage(`2`)), want: 2},
+		{name: "JSON number to float", scan: scanInto[float64](json.RawMessage(`2.5`)), want: 2.5},
+		{name: "JSON bool", scan: scanInto[bool](json.RawMessage(`true`)), want: true},
+		{name: "JSON null", scan: scanInto[string](json.RawMessage(`null`)), want: ""},
+		{name: "JSON object", scan: scanInto[string](json.RawMessage(`{"name":"Green"}`)), wantErr: true},
+		{name: "malformed JSON", scan: scanInto[string](json.RawMessage(`"Green`)), wantErr: true},
+		{name: "decimal string", scan: scanInto[int]("2.00"), want: 2},
+		{name: "decimal bytes", scan: scanInto[uint8]([]byte("-0.0")), want: uint8(0)},
+		{name: "fractional decimal", scan: scanInto[int]("2.50"), wantErr: true},
+		{name: "valuer", scan: scanInto[string](sql.NullString{String: "Green", Valid: true}), want: "Green"},
+		{name: "null valuer", scan: scanInto[int](sql.NullInt64{}), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.scan()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Scan = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+// scanInto returns a function scanning src into a new T.
+func scanInto[T any](src any) func() (any, error) {
+	return func() (any, error) {
+		var result T
+		err := NewScanner(&result).Scan(src)
+		return result, err
+	}
+}
+
 func TestGenericScanner_UnsupportedType(t *testing.T) {
 	type CustomType struct {
 		Value string
@@ -0,0 +1,122 @@
+package enums
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrInvalidTransition is returned by the TransitionTo and Fire methods
+// generated with -statemachine for transitions the state annotations do not
+// allow.
+var ErrInvalidTransition = errors.New("invalid state transition")
+
+// ErrStateNotFound is returned by MemoryStatePersister for entities without
+// a saved state.
+var ErrStateNotFound = errors.New("state not found")
+
+// StatePersister stores the current state of entities, keyed by their ID,
+// for the <Type>Machine types generated with -statemachine.
+type StatePersister[S any] interface {
+	// Load returns the current state of the entity id
+	Load(ctx context.Context, id string) (S, error)
+	// Save stores state as the current state of the entity id
+	Save(ctx context.Context, id string, state S) error
+}
+
+// MemoryStatePersister is a StatePersister keeping states in memory, for
+// tests and single-process services. Its zero value is empty and ready to
+// use, and it is safe for concurrent use.
+type MemoryStatePersister[S any] struct {
+	mu     sync.Mutex
+	states map[string]S
+}
+
+// Load returns the state saved for id, or an error wrapping
+// ErrStateNotFound if there is none.
+func (p *MemoryStatePersister[S]) Load(_ context.Context, id string) (S, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state, ok := p.states[id]
+	if !ok {
+		return state, fmt.Errorf("%w: %s", ErrStateNotFound, id)
+	}
+	return state, nil
+}
+
+// Save stores state for id.
+func (p *MemoryStatePersister[S]) Save(_ context.Context, id string, state S) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.states == nil {
+		p.states = make(map[string]S)
+	}
+	p.states[id] = state
+	return nil
+}
+
+// Transition is a state change recorded by a History.
+type Transition[S fmt.Stringer] struct {
+	From S
+	To   S
+	// Event is the event that fired the transition, empty for transitions
+	// made to an explicit target state
+	Event string
+	At    time.Time
+}
+
+// MarshalJSON writes the states of t as their names, so that the audit
+// trail does not depend on the serialization of S.
+func (t Transition[S]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		From  string    `json:"from"`
+		To    string    `json:"to"`
+		Event string    `json:"event,omitempty"`
+		At    time.Time `json:"at"`
+	}{t.From.String(), t.To.String(), t.Event, t.At})
+}
+
+// History records the transitions of a state machine, for services that
+// audit workflow state changes. Its zero value is an empty history ready to
+// use, and it is safe for concurrent use. It backs the <Type>History types
+// generated with -statemachine/history.
+type History[S fmt.Stringer] struct {
+	// Now returns the time transitions are recorded at, time.Now when nil
+	Now func() time.Time
+
+	mu          sync.Mutex
+	transitions []Transition[S]
+}
+
+// Record appends the transition from from to to, fired by event if not
+// empty.
+func (h *History[S]) Record(from, to S, event string) {
+	now := time.Now
+	if h.Now != nil {
+		now = h.Now
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.transitions = append(h.transitions, Transition[S]{From: from, To: to, Event: event, At: now()})
+}
+
+// Transitions returns a copy of the recorded transitions, oldest first.
+func (h *History[S]) Transitions() []Transition[S] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.transitions)
+}
+
+// MarshalJSON writes the recorded transitions as a JSON array, oldest
+// first.
+func (h *History[S]) MarshalJSON() ([]byte, error) {
+	transitions := h.Transitions()
+	if transitions == nil {
+		transitions = []Transition[S]{}
+	}
+	return json.Marshal(transitions)
+}
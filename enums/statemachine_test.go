@@ -0,0 +1,58 @@
+package enums
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type state string
+
+func (s state) String() string { return string(s) }
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+	var h History[state]
+	if b, err := json.Marshal(&h); err != nil || string(b) != "[]" {
+		t.Fatalf("empty history marshals to %s, %v", b, err)
+	}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	h.Now = func() time.Time { return at }
+	h.Record("pending", "paid", "OnPay")
+	h.Record("paid", "shipped", "")
+
+	transitions := h.Transitions()
+	if len(transitions) != 2 || transitions[0] != (Transition[state]{From: "pending", To: "paid", Event: "OnPay", At: at}) {
+		t.Fatalf("unexpected transitions %+v", transitions)
+	}
+	transitions[0].To = "canceled"
+	if h.Transitions()[0].To != "paid" {
+		t.Error("Transitions returned the recorded slice")
+	}
+
+	b, err := json.Marshal(&h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"from":"pending","to":"paid","event":"OnPay","at":"2024-01-02T03:04:05Z"},` +
+		`{"from":"paid","to":"shipped","at":"2024-01-02T03:04:05Z"}]`
+	if string(b) != want {
+		t.Errorf("History marshals to %s, want %s", b, want)
+	}
+}
+
+func TestMemoryStatePersister(t *testing.T) {
+	t.Parallel()
+	var p MemoryStatePersister[state]
+	var _ StatePersister[state] = &p
+	if _, err := p.Load(t.Context(), "1"); !errors.Is(err, ErrStateNotFound) {
+		t.Fatalf("expected error %v, got %v", ErrStateNotFound, err)
+	}
+	if err := p.Save(t.Context(), "1", "paid"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := p.Load(t.Context(), "1"); err != nil || got != "paid" {
+		t.Errorf("Load = %s, %v, want paid", got, err)
+	}
+}
@@ -0,0 +1,43 @@
+package enums
+
+import "strings"
+
+// Suggest returns the name closest to input by case-insensitive edit
+// distance, or "" if no name is close enough to be a likely typo.
+// Ties are broken in favour of the earliest name.
+func Suggest(input string, names []string) string {
+	in := []rune(strings.ToLower(input))
+	best, bestDist := "", -1
+	for _, name := range names {
+		n := []rune(strings.ToLower(name))
+		d := levenshtein(in, n)
+		if d > max(1, len(n)/3) {
+			continue
+		}
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+// levenshtein computes the edit distance between a and b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,27 @@
+package enums
+
+import "testing"
+
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+	names := []string{"Active", "Inactive", "Pending"}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Actve", "Active"},
+		{"active", "Active"},
+		{"INACTIV", "Inactive"},
+		{"Pendng", "Pending"},
+		{"Shipped", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if got := Suggest(tt.input, names); got != tt.want {
+				t.Errorf("Suggest(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -151,6 +151,11 @@ func parseStringValue[T any](str string, value *T) error {
 // anyToBinary 将任意类型转换为二进制格式
 // 使用大端字节序（network byte order）作为标准
 func anyToBinary(value any) ([]byte, error) {
+	return encodeBinary(value, BinaryOptions{})
+}
+
+// encodeBinary is anyToBinary using the encoding of o.
+func encodeBinary(value any, o BinaryOptions) ([]byte, error) {
 	if value == nil {
 		return nil, fmt.Errorf("nil value")
 	}
@@ -165,57 +170,48 @@ func anyToBinary(value any) ([]byte, error) {
 		v = v.Elem()
 	}
 
+	if o.Varint {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return binary.AppendVarint(nil, v.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return binary.AppendUvarint(nil, v.Uint()), nil
+		}
+	}
+
 	// 获取底层类型的Kind
 	switch v.Kind() {
 	case reflect.Int8:
 		return []byte{byte(v.Int())}, nil
 	case reflect.Int16:
-		buf := make([]byte, 2)
-		binary.BigEndian.PutUint16(buf, uint16(v.Int()))
-		return buf, nil
+		return o.putUint16(uint16(v.Int())), nil
 	case reflect.Int32:
-		buf := make([]byte, 4)
-		binary.BigEndian.PutUint32(buf, uint32(v.Int()))
-		return buf, nil
+		return o.putUint32(uint32(v.Int())), nil
 	case reflect.Int64, reflect.Int:
-		buf := make([]byte, 8)
-		binary.BigEndian.PutUint64(buf, uint64(v.Int()))
-		return buf, nil
+		return o.putUint64(uint64(v.Int())), nil
 	case reflect.Uint8:
 		return []byte{byte(v.Uint())}, nil
 	case reflect.Uint16:
-		buf := make([]byte, 2)
-		binary.BigEndian.PutUint16(buf, uint16(v.Uint()))
-		return buf, nil
+		return o.putUint16(uint16(v.Uint())), nil
 	case reflect.Uint32:
-		buf := make([]byte, 4)
-		binary.BigEndian.PutUint32(buf, uint32(v.Uint()))
-		return buf, nil
+		return o.putUint32(uint32(v.Uint())), nil
 	case reflect.Uint64, reflect.Uint:
-		buf := make([]byte, 8)
-		binary.BigEndian.PutUint64(buf, v.Uint())
-		return buf, nil
+		return o.putUint64(v.Uint()), nil
 	case reflect.Float32:
-		buf := make([]byte, 4)
-		binary.BigEndian.PutUint32(buf, math.Float32bits(float32(v.Float())))
-		return buf, nil
+		return o.putUint32(math.Float32bits(float32(v.Float()))), nil
 	case reflect.Float64:
-		buf := make([]byte, 8)
-		binary.BigEndian.PutUint64(buf, math.Float64bits(v.Float()))
-		return buf, nil
+		return o.putUint64(math.Float64bits(v.Float())), nil
 	case reflect.Bool:
 		if v.Bool() {
 			return []byte{1}, nil
 		}
 		return []byte{0}, nil
 	case reflect.String:
-		return []byte(v.String()), nil
+		return o.appendString(nil, v.String()), nil
 	case reflect.Slice:
 		if v.Type().Elem().Kind() == reflect.Uint8 {
 			// []byte 类型
-			result := make([]byte, v.Len())
-			copy(result, v.Bytes())
-			return result, nil
+			return o.appendString(nil, string(v.Bytes())), nil
 		}
 		fallthrough
 	default:
@@ -226,6 +222,11 @@ func anyToBinary(value any) ([]byte, error) {
 
 // parseBinaryValue 从二进制数据解析为指定类型
 func parseBinaryValue[T any](data []byte, value *T) error {
+	return decodeBinary(data, value, BinaryOptions{})
+}
+
+// decodeBinary is parseBinaryValue using the encoding of o.
+func decodeBinary[T any](data []byte, value *T, o BinaryOptions) error {
 	if len(data) == 0 {
 		return fmt.Errorf("empty binary data")
 	}
@@ -233,72 +234,89 @@ func parseBinaryValue[T any](data []byte, value *T) error {
 	// 使用反射获取目标类型的信息
 	v := reflect.ValueOf(value).Elem()
 
+	order := o.byteOrder()
+	if o.Varint {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, k := binary.Varint(data)
+			if k <= 0 || v.OverflowInt(n) {
+				return fmt.Errorf("invalid varint for %s", v.Kind())
+			}
+			v.SetInt(n)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, k := binary.Uvarint(data)
+			if k <= 0 || v.OverflowUint(n) {
+				return fmt.Errorf("invalid uvarint for %s", v.Kind())
+			}
+			v.SetUint(n)
+			return nil
+		}
+	}
+
 	// 获取底层类型的Kind
 	switch v.Kind() {
 	case reflect.Int8:
-		if len(data) < 1 {
-			return fmt.Errorf("insufficient data for int8")
-		}
-		v.SetInt(int64(data[0]))
+		v.SetInt(int64(int8(data[0])))
 	case reflect.Int16:
 		if len(data) < 2 {
 			return fmt.Errorf("insufficient data for int16")
 		}
-		v.SetInt(int64(binary.BigEndian.Uint16(data)))
+		v.SetInt(int64(int16(order.Uint16(data))))
 	case reflect.Int32:
 		if len(data) < 4 {
 			return fmt.Errorf("insufficient data for int32")
 		}
-		v.SetInt(int64(binary.BigEndian.Uint32(data)))
+		v.SetInt(int64(int32(order.Uint32(data))))
 	case reflect.Int64, reflect.Int:
 		if len(data) < 8 {
 			return fmt.Errorf("insufficient data for int64")
 		}
-		v.SetInt(int64(binary.BigEndian.Uint64(data)))
+		v.SetInt(int64(order.Uint64(data)))
 	case reflect.Uint8:
-		if len(data) < 1 {
-			return fmt.Errorf("insufficient data for uint8")
-		}
 		v.SetUint(uint64(data[0]))
 	case reflect.Uint16:
 		if len(data) < 2 {
 			return fmt.Errorf("insufficient data for uint16")
 		}
-		v.SetUint(uint64(binary.BigEndian.Uint16(data)))
+		v.SetUint(uint64(order.Uint16(data)))
 	case reflect.Uint32:
 		if len(data) < 4 {
 			return fmt.Errorf("insufficient data for uint32")
 		}
-		v.SetUint(uint64(binary.BigEndian.Uint32(data)))
+		v.SetUint(uint64(order.Uint32(data)))
 	case reflect.Uint64, reflect.Uint:
 		if len(data) < 8 {
 			return fmt.Errorf("insufficient data for uint64")
 		}
-		v.SetUint(binary.BigEndian.Uint64(data))
+		v.SetUint(order.Uint64(data))
 	case reflect.Float32:
 		if len(data) < 4 {
 			return fmt.Errorf("insufficient data for float32")
 		}
-		bits := binary.BigEndian.Uint32(data)
-		v.SetFloat(float64(math.Float32frombits(bits)))
+		v.SetFloat(float64(math.Float32frombits(order.Uint32(data))))
 	case reflect.Float64:
 		if len(data) < 8 {
 			return fmt.Errorf("insufficient data for float64")
 		}
-		bits := binary.BigEndian.Uint64(data)
-		v.SetFloat(math.Float64frombits(bits))
+		v.SetFloat(math.Float64frombits(order.Uint64(data)))
 	case reflect.Bool:
-		if len(data) < 1 {
-			return fmt.Errorf("insufficient data for bool")
-		}
 		v.SetBool(data[0] != 0)
 	case reflect.String:
-		v.SetString(string(data))
+		str, err := o.readString(data)
+		if err != nil {
+			return err
+		}
+		v.SetString(str)
 	case reflect.Slice:
 		if v.Type().Elem().Kind() == reflect.Uint8 {
 			// []byte 类型
-			newSlice := reflect.MakeSlice(v.Type(), len(data), len(data))
-			reflect.Copy(newSlice, reflect.ValueOf(data))
+			str, err := o.readString(data)
+			if err != nil {
+				return err
+			}
+			newSlice := reflect.MakeSlice(v.Type(), len(str), len(str))
+			reflect.Copy(newSlice, reflect.ValueOf([]byte(str)))
 			v.Set(newSlice)
 			return nil
 		}
@@ -0,0 +1,62 @@
+package enums
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidValue is wrapped by the errors of ValidateJSON and
+// ValidateJSONObject, for malformed JSON as well as for JSON holding no
+// valid value.
+var ErrInvalidValue = errors.New("invalid enum value")
+
+// ValidateJSON checks that bs is the JSON of a valid value of the enum type
+// of e, decoded as UnmarshalJSON does, without decoding it into an enum
+// value. Names and values UnmarshalJSON accepts for values that are not
+// valid, such as the invalid sentinel, are rejected.
+func ValidateJSON[R comparable, T comparable, E Enum[R, T]](e E, bs []byte) error {
+	if _, ok := any(e).(FormatOverrider); ok {
+		result, err := UnmarshalJSON(e, bs)
+		return validated(result, bs, err)
+	}
+	var (
+		ret T
+		ok  bool
+	)
+	if e.SerdeFormat() == FormatName {
+		var name string
+		if err := json.Unmarshal(bs, &name); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+		}
+		ret, ok = e.FromName(name)
+	} else {
+		var rawValue R
+		if err := json.Unmarshal(bs, &rawValue); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+		}
+		ret, ok = e.FromValue(rawValue)
+	}
+	if v, isEnum := any(ret).(interface{ IsValid() bool }); !ok || !isEnum || !v.IsValid() {
+		return fmt.Errorf("%w %s", ErrInvalidValue, bs)
+	}
+	return nil
+}
+
+// ValidateJSONObject is ValidateJSON for the JSON objects, names and values
+// UnmarshalJSONObject decodes.
+func ValidateJSONObject[R comparable, T comparable, E Enum[R, T]](e E, bs []byte) error {
+	result, err := UnmarshalJSONObject(e, bs)
+	return validated(result, bs, err)
+}
+
+// validated returns the error of ValidateJSON for a value decoded from bs.
+func validated[R comparable, T comparable, E Enum[R, T]](result *E, bs []byte, err error) error {
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+	if !(*result).IsValid() {
+		return fmt.Errorf("%w %s", ErrInvalidValue, bs)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package enums
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		validate func([]byte) error
+		data     string
+		wantErr  bool
+	}{
+		{"name", func(b []byte) error { return ValidateJSON(testColor{}, b) }, `"Green"`, false},
+		{"unknown name", func(b []byte) error { return ValidateJSON(testColor{}, b) }, `"Blue"`, true},
+		{"value", func(b []byte) error { return ValidateJSON(testColor{}, b) }, `2`, true},
+		{"null", func(b []byte) error { return ValidateJSON(testColor{}, b) }, `null`, true},
+		{"null value", func(b []byte) error { return ValidateJSON(testShade{}, b) }, `null`, true},
+		{"malformed", func(b []byte) error { return ValidateJSON(testColor{}, b) }, `"Green`, true},
+		{"overridden value", func(b []byte) error { return ValidateJSON(testShade{}, b) }, `2`, false},
+		{"value not overridden", func(b []byte) error { return ValidateJSON(testShade{}, b) }, `1`, true},
+		{"object", func(b []byte) error { return ValidateJSONObject(testColor{}, b) }, `{"name":"Red"}`, false},
+		{"null object", func(b []byte) error { return ValidateJSONObject(testColor{}, b) }, `null`, true},
+		{"unknown object", func(b []byte) error { return ValidateJSONObject(testColor{}, b) }, `{"value":3}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.validate([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validate(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidValue) {
+				t.Errorf("expected error %v to wrap %v", err, ErrInvalidValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package enums
+
+import "encoding/json"
+
+// JSON-backed YAML libraries such as sigs.k8s.io/yaml convert YAML documents
+// to JSON and only ever call the JSON methods of a type. The helpers below let
+// those methods follow the YAML rules instead, so an enum decodes the same
+// document identically whichever YAML library reads it.
+
+// JSONNode adapts a JSON document to the YAMLNode interface.
+type JSONNode []byte
+
+// Decode unmarshals the JSON document into v.
+func (n JSONNode) Decode(v any) error {
+	return json.Unmarshal(n, v)
+}
+
+// MarshalYAMLJSON encodes e as JSON using the YAML marshaling rules.
+func MarshalYAMLJSON[R comparable, T comparable, E Enum[R, T]](e E, b any) ([]byte, error) {
+	v, err := MarshalYAML(e, b)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalYAMLJSON decodes a JSON document using the YAML unmarshaling rules.
+func UnmarshalYAMLJSON[R comparable, T comparable, E Enum[R, T]](e E, bs []byte) (*E, error) {
+	return UnmarshalYAML(e, JSONNode(bs))
+}
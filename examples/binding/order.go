@@ -0,0 +1,15 @@
+// Package binding shows enums bound from the query parameters of HTTP
+// requests the way Gin and Echo bind handler structs.
+package binding
+
+//go:generate ../../goenums order.go
+
+// goenums: -http
+type orderStatus int
+
+const (
+	unknown   orderStatus = iota // invalid
+	pending                      // Pending
+	shipped                      // Shipped
+	delivered                    // Delivered
+)
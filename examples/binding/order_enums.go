@@ -0,0 +1,368 @@
+// DO NOT EDIT.
+// code generated by goenums v0.5.0 at Oct 16 08:24:30.
+//
+// github.com/donutnomad/goenums
+//
+// using the command:
+// goenums order.go
+
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+
+	"github.com/donutnomad/goenums/enums"
+)
+
+// OrderStatus is a type that represents a single enum value.
+// It combines the core information about the enum constant and it's defined fields.
+type OrderStatus struct {
+	orderStatus
+}
+
+// Verify that OrderStatus implements the Enum interface
+var _ enums.Enum[int, OrderStatus] = OrderStatus{}
+
+// orderStatusesContainer is the container for all enum values.
+// It is private and should not be used directly use the public methods on the OrderStatus type.
+type orderStatusesContainer struct {
+	Unknown   OrderStatus
+	Pending   OrderStatus
+	Shipped   OrderStatus
+	Delivered OrderStatus
+}
+
+// OrderStatusRaw is a type alias for the underlying enum type orderStatus.
+// It provides direct access to the raw enum values for cases where you need
+// to work with the underlying type directly.
+type OrderStatusRaw = orderStatus
+
+// OrderStatuses is a main entry point using the OrderStatus type.
+// It it a container for all enum values and provides a convenient way to access all enum values and perform
+// operations, with convenience methods for common use cases.
+var OrderStatuses = orderStatusesContainer{
+	Unknown: OrderStatus{
+		orderStatus: unknown,
+	},
+	Pending: OrderStatus{
+		orderStatus: pending,
+	},
+	Shipped: OrderStatus{
+		orderStatus: shipped,
+	},
+	Delivered: OrderStatus{
+		orderStatus: delivered,
+	},
+}
+
+// invalidOrderStatus is an invalid sentinel value for OrderStatus
+var invalidOrderStatus = OrderStatus{}
+
+// allSlice returns a slice of all enum values.
+// This method is useful for iterating over all enum values in a loop.
+func (o orderStatusesContainer) allSlice() []OrderStatus {
+	return []OrderStatus{
+		OrderStatuses.Unknown,
+		OrderStatuses.Pending,
+		OrderStatuses.Shipped,
+		OrderStatuses.Delivered,
+	}
+}
+
+// validOrderStatuses is a map of enum values to their validity
+var validOrderStatuses = map[OrderStatus]bool{
+	OrderStatuses.Unknown:   false,
+	OrderStatuses.Pending:   true,
+	OrderStatuses.Shipped:   true,
+	OrderStatuses.Delivered: true,
+}
+
+// IsValid checks whether the OrderStatuses value is valid.
+// A valid value is one that is defined in the original enum and not marked as invalid.
+func (o OrderStatus) IsValid() bool {
+	return validOrderStatuses[o]
+}
+
+// IsZero reports whether the OrderStatus value is invalid, as the zero value
+// is unless it is declared valid. It lets encoding/json leave out fields
+// tagged with omitzero that hold no valid value.
+func (o OrderStatus) IsZero() bool {
+	return !o.IsValid()
+}
+
+// orderstatusNames is a constant string slice containing all enum values cononical absolute names
+const orderstatusNames = "unknownPendingShippedDelivered"
+
+// orderstatusNamesMap is a map of enum values to their canonical absolute
+// name positions within the orderstatusNames string slice
+var orderstatusNamesMap = map[OrderStatus]string{
+	OrderStatuses.Unknown:   orderstatusNames[0:7],
+	OrderStatuses.Pending:   orderstatusNames[7:14],
+	OrderStatuses.Shipped:   orderstatusNames[14:21],
+	OrderStatuses.Delivered: orderstatusNames[21:30],
+}
+
+// String implements the Stringer interface.
+// It returns the canonical absolute name of the enum value.
+func (o OrderStatus) String() string {
+	if str, ok := orderstatusNamesMap[o]; ok {
+		return str
+	}
+	return fmt.Sprintf("orderstatus(%v)", o.orderStatus)
+}
+
+// OrderStatusNames returns the names of the valid OrderStatus values,
+// such as the arguments of a SQL IN clause.
+func OrderStatusNames() []string {
+	values := make([]OrderStatus, 0, len(OrderStatuses.allSlice()))
+	for _, v := range OrderStatuses.allSlice() {
+		if v.IsValid() {
+			values = append(values, v)
+		}
+	}
+	return enums.Names(values)
+}
+
+// ErrInvalidOrderStatus is the sentinel wrapped by every error returned
+// when an input cannot be parsed into a OrderStatus.
+var ErrInvalidOrderStatus = errors.New("invalid OrderStatus")
+
+// InvalidOrderStatusError is returned when an input cannot be parsed into
+// a OrderStatus. It carries the offending input and, when one is known,
+// the closest valid name.
+type InvalidOrderStatusError struct {
+	Input      any
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *InvalidOrderStatusError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("invalid OrderStatus value %v, did you mean %q?", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("invalid OrderStatus value %v", e.Input)
+}
+
+// Unwrap returns ErrInvalidOrderStatus so callers can use errors.Is.
+func (e *InvalidOrderStatusError) Unwrap() error {
+	return ErrInvalidOrderStatus
+}
+
+// ParseOrderStatus parses the input value into an enum value.
+// It returns the parsed enum value or an *InvalidOrderStatusError if the input is invalid.
+// It is a convenience function that can be used to parse enum values from
+// various input types, such as strings, byte slices, or underlying values.
+func ParseOrderStatus(input any) (OrderStatus, error) {
+	res, err := enums.Parse(OrderStatus{}, input)
+	if err != nil {
+		return invalidOrderStatus, &InvalidOrderStatusError{Input: input}
+	}
+	return res, nil
+}
+
+// MustParseOrderStatus parses the input value into an enum value.
+// It panics if the input is invalid, which makes it suitable for
+// initialization code where the input is known to be valid.
+func MustParseOrderStatus(input any) OrderStatus {
+	res, err := ParseOrderStatus(input)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ParseOrderStatusOr parses the input value into an enum value.
+// It returns def if the input is invalid.
+func ParseOrderStatusOr(input any, def OrderStatus) OrderStatus {
+	res, err := ParseOrderStatus(input)
+	if err != nil {
+		return def
+	}
+	return res
+}
+
+// ParseOrderStatusSlice parses each of inputs, such as the values of a
+// comma-separated query parameter, into an enum value. It returns the error
+// of the first invalid input.
+func ParseOrderStatusSlice(inputs []string) ([]OrderStatus, error) {
+	res := make([]OrderStatus, len(inputs))
+	for i, input := range inputs {
+		v, err := ParseOrderStatus(input)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+// Val implements the Enum interface.
+// It returns the underlying enum value.
+func (o OrderStatus) Val() int {
+	return int(o.orderStatus)
+}
+
+// All implements the Enum interface.
+// It returns an iterator over all enum values.
+func (o OrderStatus) All() iter.Seq[OrderStatus] {
+	return func(yield func(OrderStatus) bool) {
+		for _, v := range OrderStatuses.allSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FromName implements the Enum interface.
+// It finds an enum value by name and returns the enum instance and a boolean indicating if found.
+func (o OrderStatus) FromName(name string) (OrderStatus, bool) {
+	for enum, enumName := range orderstatusNamesMap {
+		if enumName == name {
+			return enum, true
+		}
+	}
+	return invalidOrderStatus, false
+}
+
+// FromValue implements the Enum interface.
+// It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
+func (o OrderStatus) FromValue(value int) (OrderStatus, bool) {
+	for _, v := range OrderStatuses.allSlice() {
+		if v.Val() == value {
+			return v, true
+		}
+	}
+	return invalidOrderStatus, false
+}
+
+// SerdeFormat implements the Enum interface.
+// It returns the format used for serialization.
+func (o OrderStatus) SerdeFormat() enums.Format {
+	return enums.FormatName
+}
+
+// Name implements the Enum interface.
+// It returns the name of the current enum value.
+func (o OrderStatus) Name() string {
+	if str, ok := orderstatusNamesMap[o]; ok {
+		return str
+	}
+	return fmt.Sprintf("orderstatus(%v)", o.orderStatus)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for OrderStatus.
+// It returns the text representation of the enum value as a byte slice.
+func (o OrderStatus) MarshalText() ([]byte, error) {
+	return enums.MarshalText(o, o.orderStatus)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for OrderStatus.
+// It parses the text representation of the enum value from the byte slice.
+// It returns an error if the byte slice does not contain a valid enum value.
+func (o *OrderStatus) UnmarshalText(data []byte) error {
+	result, err := enums.UnmarshalText(*o, data)
+	if err != nil {
+		return &InvalidOrderStatusError{Input: string(data)}
+	}
+	*o = *result
+	return nil
+}
+
+// UnmarshalParam implements the echo.BindUnmarshaler interface for OrderStatus,
+// which Gin's form binding uses too, so OrderStatus and *OrderStatus
+// fields can be bound from path, query and form parameters. It accepts the
+// names and values ParseOrderStatus does.
+func (o *OrderStatus) UnmarshalParam(param string) error {
+	result, err := ParseOrderStatus(param)
+	if err != nil {
+		return err
+	}
+	*o = result
+	return nil
+}
+
+// OrderStatusFromQuery parses the value of key in values, such as
+// r.URL.Query() or r.Form of an HTTP request, into a OrderStatus.
+// It returns an *InvalidOrderStatusError if the value is invalid and
+// an error wrapping enums.ErrMissingValue if key is absent.
+func OrderStatusFromQuery(values url.Values, key string) (OrderStatus, error) {
+	return enums.FromValues(values, key, ParseOrderStatus)
+}
+
+// All returns an iterator over all enum values.
+// This is a convenience method that delegates to the zero value enum instance.
+func (o orderStatusesContainer) All() iter.Seq[OrderStatus] {
+	return OrderStatus{}.All()
+}
+
+// Parse parses the input value into an enum value.
+// This is a convenience method that delegates to ParseOrderStatus.
+func (o orderStatusesContainer) Parse(input any) (OrderStatus, error) {
+	return ParseOrderStatus(input)
+}
+
+// MustParse parses the input value into an enum value, panicking if it is invalid.
+// This is a convenience method that delegates to MustParseOrderStatus.
+func (o orderStatusesContainer) MustParse(input any) OrderStatus {
+	return MustParseOrderStatus(input)
+}
+
+// FromName finds an enum value by name and returns the enum instance and a boolean indicating if found.
+// This is a convenience method that delegates to the zero value enum instance.
+func (o orderStatusesContainer) FromName(name string) (OrderStatus, bool) {
+	return OrderStatus{}.FromName(name)
+}
+
+// FromValue finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
+// This is a convenience method that delegates to the zero value enum instance.
+func (o orderStatusesContainer) FromValue(value int) (OrderStatus, bool) {
+	return OrderStatus{}.FromValue(value)
+}
+
+// Ptr returns a pointer to a copy of o, for optional OrderStatus
+// fields and parameters.
+func (o OrderStatus) Ptr() *OrderStatus {
+	return &o
+}
+
+// FromPtr returns the OrderStatus ptr points to, and whether ptr is non-nil and
+// holds a valid value. It returns the invalid OrderStatus if ptr is nil.
+func (o orderStatusesContainer) FromPtr(ptr *OrderStatus) (OrderStatus, bool) {
+	if ptr == nil {
+		return invalidOrderStatus, false
+	}
+	return *ptr, ptr.IsValid()
+}
+
+// Count returns the number of valid OrderStatus values, for sizing arrays
+// and maps indexed by them.
+func (o orderStatusesContainer) Count() int {
+	return 3
+}
+
+// MinOrderStatus returns the valid OrderStatus with the lowest underlying value.
+func (o orderStatusesContainer) MinOrderStatus() OrderStatus {
+	return OrderStatuses.Pending
+}
+
+// MaxOrderStatus returns the valid OrderStatus with the highest underlying value.
+func (o orderStatusesContainer) MaxOrderStatus() OrderStatus {
+	return OrderStatuses.Delivered
+}
+
+// Compile-time check that all enum values are valid.
+// This function is used to ensure that all enum values are defined and valid.
+// It is called by the compiler to verify that the enum values are valid.
+func _() {
+	// A "duplicate key false in map literal" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values
+	_ = map[bool]struct{}{false: {}, unknown == 0: {}}
+	_ = map[bool]struct{}{false: {}, pending == 1: {}}
+	_ = map[bool]struct{}{false: {}, shipped == 2: {}}
+	_ = map[bool]struct{}{false: {}, delivered == 3: {}}
+}
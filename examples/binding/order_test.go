@@ -0,0 +1,117 @@
+package binding_test
+
+import (
+	"encoding"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/donutnomad/goenums/examples/binding"
+)
+
+type listOrdersRequest struct {
+	Status binding.OrderStatus  `query:"status"`
+	Next   *binding.OrderStatus `query:"next"`
+}
+
+// bindUnmarshaler is the interface Echo's DefaultBinder, and Gin's form
+// binding, look for on the fields they bind.
+type bindUnmarshaler interface {
+	UnmarshalParam(param string) error
+}
+
+// bindParam binds a parameter through UnmarshalParam, as Echo and Gin do.
+func bindParam(field any, param string) error {
+	u, ok := field.(bindUnmarshaler)
+	if !ok {
+		return errors.New("field does not implement UnmarshalParam")
+	}
+	return u.UnmarshalParam(param)
+}
+
+// bindText binds a parameter through UnmarshalText, as Gin does for the
+// fields tagged with parser:"encoding.TextUnmarshaler".
+func bindText(field any, param string) error {
+	u, ok := field.(encoding.TextUnmarshaler)
+	if !ok {
+		return errors.New("field does not implement encoding.TextUnmarshaler")
+	}
+	return u.UnmarshalText([]byte(param))
+}
+
+// bindQuery binds the values of the query tags of the fields of dst the
+// way the binders of Gin and Echo do, passing the address of each field to
+// bind, and allocating the values of pointer fields first.
+func bindQuery(dst any, values url.Values, bind func(field any, param string) error) error {
+	v := reflect.ValueOf(dst).Elem()
+	for i := range v.NumField() {
+		param, ok := values[v.Type().Field(i).Tag.Get("query")]
+		if !ok {
+			continue
+		}
+		field := v.Field(i)
+		if field.Kind() == reflect.Pointer {
+			field.Set(reflect.New(field.Type().Elem()))
+			field = field.Elem()
+		}
+		if err := bind(field.Addr().Interface(), param[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestBinding(t *testing.T) {
+	t.Parallel()
+	binders := map[string]func(field any, param string) error{
+		"param": bindParam,
+		"text":  bindText,
+	}
+	shipped := binding.OrderStatuses.Shipped
+	tests := []struct {
+		name    string
+		binder  string
+		query   string
+		want    listOrdersRequest
+		wantErr bool
+	}{
+		{name: "name", query: "status=Pending", want: listOrdersRequest{Status: binding.OrderStatuses.Pending}},
+		{name: "pointer", query: "status=Delivered&next=Shipped", want: listOrdersRequest{Status: binding.OrderStatuses.Delivered, Next: &shipped}},
+		{name: "missing", query: ""},
+		{name: "unknown name", query: "status=Lost", wantErr: true},
+		{name: "unknown pointer name", query: "next=Lost", wantErr: true},
+		{name: "empty", query: "status=", wantErr: true},
+		{name: "case mismatch", query: "status=shipped", wantErr: true},
+		{name: "value", binder: "param", query: "status=3", want: listOrdersRequest{Status: binding.OrderStatuses.Delivered}},
+		{name: "value", binder: "text", query: "status=3", wantErr: true},
+	}
+	for binder, bind := range binders {
+		for _, tt := range tests {
+			if tt.binder != "" && tt.binder != binder {
+				continue
+			}
+			t.Run(binder+"/"+tt.name, func(t *testing.T) {
+				t.Parallel()
+				values, err := url.ParseQuery(tt.query)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				var got listOrdersRequest
+				err = bindQuery(&got, values, bind)
+				if tt.wantErr {
+					if !errors.Is(err, binding.ErrInvalidOrderStatus) {
+						t.Fatalf("expected error wrapping %v, got %v", binding.ErrInvalidOrderStatus, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("expected %v, got %v", tt.want, got)
+				}
+			})
+		}
+	}
+}
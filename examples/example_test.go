@@ -7,9 +7,11 @@ package examples_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/donutnomad/goenums/examples/solarsystem"
+	"github.com/donutnomad/goenums/examples/validation"
 )
 
 // Example_basicEnum demonstrates the most basic usage of goenums.
@@ -233,3 +235,35 @@ func Example_binaryMarshaling() {
 	// Unmarshaled: Mercury
 	// Orbit days: 88
 }
+
+// Example_parseErrors demonstrates inspecting parse failures with errors.Is
+// and errors.As instead of matching on error strings.
+func Example_parseErrors() {
+	_, err := validation.ParseStringStatus("Pending")
+
+	fmt.Println("Is ErrInvalidStringStatus:", errors.Is(err, validation.ErrInvalidStringStatus))
+
+	var invalid *validation.InvalidStringStatusError
+	if errors.As(err, &invalid) {
+		fmt.Println("Offending input:", invalid.Input)
+	}
+
+	// Output: Is ErrInvalidStringStatus: true
+	// Offending input: Pending
+}
+
+// Example_parseSuggestions demonstrates the "did you mean" suggestion
+// generated for enums configured with the -suggest directive, which the
+// unmarshalers return as well.
+func Example_parseSuggestions() {
+	_, err := validation.ParseStringStatus("Actve")
+	fmt.Println(err)
+
+	var status validation.StringStatus
+	fmt.Println(json.Unmarshal([]byte(`"Inactve"`), &status))
+	fmt.Println(status.UnmarshalText([]byte("Actve")))
+
+	// Output: invalid StringStatus value Actve, did you mean "Active"?
+	// invalid StringStatus value Inactve, did you mean "Inactive"?
+	// invalid StringStatus value Actve, did you mean "Active"?
+}
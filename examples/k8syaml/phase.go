@@ -0,0 +1,23 @@
+// Package k8syaml shows enums serialized through sigs.k8s.io/yaml, which
+// converts YAML to JSON before decoding.
+package k8syaml
+
+//go:generate ../../goenums -yaml-library sigs.k8s.io phase.go
+
+// goenums: -yaml
+type phase int
+
+const (
+	pending   phase = iota // Pending
+	running                // Running
+	succeeded              // Succeeded
+)
+
+// goenums: -yaml -serde/value
+type priority int
+
+const (
+	low    priority = iota + 1 // Low
+	medium                     // Medium
+	high                       // High
+)
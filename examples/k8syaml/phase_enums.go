@@ -0,0 +1,517 @@
+// DO NOT EDIT.
+// code generated by goenums v0.4.0 at Oct 15 23:34:48.
+//
+// github.com/donutnomad/goenums
+//
+// using the command:
+// goenums -yaml-library sigs.k8s.io phase.go
+
+package k8syaml
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/donutnomad/goenums/enums"
+)
+
+// ==================================== Phase =====================================
+
+// Phase is a type that represents a single enum value.
+// It combines the core information about the enum constant and it's defined fields.
+type Phase struct {
+	phase
+}
+
+// Verify that Phase implements the Enum interface
+var _ enums.Enum[int, Phase] = Phase{}
+
+// phasesContainer is the container for all enum values.
+// It is private and should not be used directly use the public methods on the Phase type.
+type phasesContainer struct {
+	Pending   Phase
+	Running   Phase
+	Succeeded Phase
+}
+
+// PhaseRaw is a type alias for the underlying enum type phase.
+// It provides direct access to the raw enum values for cases where you need
+// to work with the underlying type directly.
+type PhaseRaw = phase
+
+// Phases is a main entry point using the Phase type.
+// It it a container for all enum values and provides a convenient way to access all enum values and perform
+// operations, with convenience methods for common use cases.
+var Phases = phasesContainer{
+	Pending: Phase{
+		phase: pending,
+	},
+	Running: Phase{
+		phase: running,
+	},
+	Succeeded: Phase{
+		phase: succeeded,
+	},
+}
+
+// invalidPhase is an invalid sentinel value for Phase
+var invalidPhase = Phase{}
+
+// allSlice returns a slice of all enum values.
+// This method is useful for iterating over all enum values in a loop.
+func (p phasesContainer) allSlice() []Phase {
+	return []Phase{
+		Phases.Pending,
+		Phases.Running,
+		Phases.Succeeded,
+	}
+}
+
+// validPhases is a map of enum values to their validity
+var validPhases = map[Phase]bool{
+	Phases.Pending:   true,
+	Phases.Running:   true,
+	Phases.Succeeded: true,
+}
+
+// IsValid checks whether the Phases value is valid.
+// A valid value is one that is defined in the original enum and not marked as invalid.
+func (p Phase) IsValid() bool {
+	return validPhases[p]
+}
+
+// phaseNames is a constant string slice containing all enum values cononical absolute names
+const phaseNames = "PendingRunningSucceeded"
+
+// phaseNamesMap is a map of enum values to their canonical absolute
+// name positions within the phaseNames string slice
+var phaseNamesMap = map[Phase]string{
+	Phases.Pending:   phaseNames[0:7],
+	Phases.Running:   phaseNames[7:14],
+	Phases.Succeeded: phaseNames[14:23],
+}
+
+// String implements the Stringer interface.
+// It returns the canonical absolute name of the enum value.
+func (p Phase) String() string {
+	if str, ok := phaseNamesMap[p]; ok {
+		return str
+	}
+	return fmt.Sprintf("phase(%v)", p.phase)
+}
+
+// ErrInvalidPhase is the sentinel wrapped by every error returned
+// when an input cannot be parsed into a Phase.
+var ErrInvalidPhase = errors.New("invalid Phase")
+
+// InvalidPhaseError is returned when an input cannot be parsed into
+// a Phase. It carries the offending input and, when one is known,
+// the closest valid name.
+type InvalidPhaseError struct {
+	Input      any
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *InvalidPhaseError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("invalid Phase value %v, did you mean %q?", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("invalid Phase value %v", e.Input)
+}
+
+// Unwrap returns ErrInvalidPhase so callers can use errors.Is.
+func (e *InvalidPhaseError) Unwrap() error {
+	return ErrInvalidPhase
+}
+
+// ParsePhase parses the input value into an enum value.
+// It returns the parsed enum value or an *InvalidPhaseError if the input is invalid.
+// It is a convenience function that can be used to parse enum values from
+// various input types, such as strings, byte slices, or underlying values.
+func ParsePhase(input any) (Phase, error) {
+	res, err := enums.Parse(Phase{}, input)
+	if err != nil {
+		return invalidPhase, &InvalidPhaseError{Input: input}
+	}
+	return res, nil
+}
+
+// MustParsePhase parses the input value into an enum value.
+// It panics if the input is invalid, which makes it suitable for
+// initialization code where the input is known to be valid.
+func MustParsePhase(input any) Phase {
+	res, err := ParsePhase(input)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ParsePhaseOr parses the input value into an enum value.
+// It returns def if the input is invalid.
+func ParsePhaseOr(input any, def Phase) Phase {
+	res, err := ParsePhase(input)
+	if err != nil {
+		return def
+	}
+	return res
+}
+
+// Val implements the Enum interface.
+// It returns the underlying enum value.
+func (p Phase) Val() int {
+	return int(p.phase)
+}
+
+// All implements the Enum interface.
+// It returns an iterator over all enum values.
+func (p Phase) All() iter.Seq[Phase] {
+	return func(yield func(Phase) bool) {
+		for _, v := range Phases.allSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FromName implements the Enum interface.
+// It finds an enum value by name and returns the enum instance and a boolean indicating if found.
+func (p Phase) FromName(name string) (Phase, bool) {
+	for enum, enumName := range phaseNamesMap {
+		if enumName == name {
+			return enum, true
+		}
+	}
+	var zero Phase
+	return zero, false
+}
+
+// FromValue implements the Enum interface.
+// It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
+func (p Phase) FromValue(value int) (Phase, bool) {
+	for _, v := range Phases.allSlice() {
+		if v.Val() == value {
+			return v, true
+		}
+	}
+	var zero Phase
+	return zero, false
+}
+
+// SerdeFormat implements the Enum interface.
+// It returns the format used for serialization.
+func (p Phase) SerdeFormat() enums.Format {
+	return enums.FormatName
+}
+
+// Name implements the Enum interface.
+// It returns the name of the current enum value.
+func (p Phase) Name() string {
+	if str, ok := phaseNamesMap[p]; ok {
+		return str
+	}
+	return fmt.Sprintf("phase(%v)", p.phase)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Phase
+// following the YAML marshaling rules, for sigs.k8s.io/yaml which converts
+// YAML to JSON.
+func (p Phase) MarshalJSON() ([]byte, error) {
+	return enums.MarshalYAMLJSON(p, p.phase)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Phase
+// following the YAML unmarshaling rules, for sigs.k8s.io/yaml which converts
+// YAML to JSON.
+func (p *Phase) UnmarshalJSON(data []byte) error {
+	result, err := enums.UnmarshalYAMLJSON(*p, data)
+	if err != nil {
+		return err
+	}
+	*p = *result
+	return nil
+}
+
+// All returns an iterator over all enum values.
+// This is a convenience method that delegates to the zero value enum instance.
+func (p phasesContainer) All() iter.Seq[Phase] {
+	return Phase{}.All()
+}
+
+// FromName finds an enum value by name and returns the enum instance and a boolean indicating if found.
+// This is a convenience method that delegates to the zero value enum instance.
+func (p phasesContainer) FromName(name string) (Phase, bool) {
+	return Phase{}.FromName(name)
+}
+
+// FromValue finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
+// This is a convenience method that delegates to the zero value enum instance.
+func (p phasesContainer) FromValue(value int) (Phase, bool) {
+	return Phase{}.FromValue(value)
+}
+
+// Compile-time check that all enum values are valid.
+// This function is used to ensure that all enum values are defined and valid.
+// It is called by the compiler to verify that the enum values are valid.
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [3]struct{}
+	_ = x[pending-0]
+	_ = x[running-0]
+	_ = x[succeeded-1]
+}
+
+// =================================== Priority ===================================
+
+// Priority is a type that represents a single enum value.
+// It combines the core information about the enum constant and it's defined fields.
+type Priority struct {
+	priority
+}
+
+// Verify that Priority implements the Enum interface
+var _ enums.Enum[int, Priority] = Priority{}
+
+// prioritiesContainer is the container for all enum values.
+// It is private and should not be used directly use the public methods on the Priority type.
+type prioritiesContainer struct {
+	Low    Priority
+	Medium Priority
+	High   Priority
+}
+
+// PriorityRaw is a type alias for the underlying enum type priority.
+// It provides direct access to the raw enum values for cases where you need
+// to work with the underlying type directly.
+type PriorityRaw = priority
+
+// Priorities is a main entry point using the Priority type.
+// It it a container for all enum values and provides a convenient way to access all enum values and perform
+// operations, with convenience methods for common use cases.
+var Priorities = prioritiesContainer{
+	Low: Priority{
+		priority: low,
+	},
+	Medium: Priority{
+		priority: medium,
+	},
+	High: Priority{
+		priority: high,
+	},
+}
+
+// invalidPriority is an invalid sentinel value for Priority
+var invalidPriority = Priority{}
+
+// allSlice returns a slice of all enum values.
+// This method is useful for iterating over all enum values in a loop.
+func (p prioritiesContainer) allSlice() []Priority {
+	return []Priority{
+		Priorities.Low,
+		Priorities.Medium,
+		Priorities.High,
+	}
+}
+
+// validPriorities is a map of enum values to their validity
+var validPriorities = map[Priority]bool{
+	Priorities.Low:    true,
+	Priorities.Medium: true,
+	Priorities.High:   true,
+}
+
+// IsValid checks whether the Priorities value is valid.
+// A valid value is one that is defined in the original enum and not marked as invalid.
+func (p Priority) IsValid() bool {
+	return validPriorities[p]
+}
+
+// priorityNames is a constant string slice containing all enum values cononical absolute names
+const priorityNames = "LowMediumHigh"
+
+// priorityNamesMap is a map of enum values to their canonical absolute
+// name positions within the priorityNames string slice
+var priorityNamesMap = map[Priority]string{
+	Priorities.Low:    priorityNames[0:3],
+	Priorities.Medium: priorityNames[3:9],
+	Priorities.High:   priorityNames[9:13],
+}
+
+// String implements the Stringer interface.
+// It returns the canonical absolute name of the enum value.
+func (p Priority) String() string {
+	if str, ok := priorityNamesMap[p]; ok {
+		return str
+	}
+	return fmt.Sprintf("priority(%v)", p.priority)
+}
+
+// ErrInvalidPriority is the sentinel wrapped by every error returned
+// when an input cannot be parsed into a Priority.
+var ErrInvalidPriority = errors.New("invalid Priority")
+
+// InvalidPriorityError is returned when an input cannot be parsed into
+// a Priority. It carries the offending input and, when one is known,
+// the closest valid name.
+type InvalidPriorityError struct {
+	Input      any
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *InvalidPriorityError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("invalid Priority value %v, did you mean %q?", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("invalid Priority value %v", e.Input)
+}
+
+// Unwrap returns ErrInvalidPriority so callers can use errors.Is.
+func (e *InvalidPriorityError) Unwrap() error {
+	return ErrInvalidPriority
+}
+
+// ParsePriority parses the input value into an enum value.
+// It returns the parsed enum value or an *InvalidPriorityError if the input is invalid.
+// It is a convenience function that can be used to parse enum values from
+// various input types, such as strings, byte slices, or underlying values.
+func ParsePriority(input any) (Priority, error) {
+	res, err := enums.Parse(Priority{}, input)
+	if err != nil {
+		return invalidPriority, &InvalidPriorityError{Input: input}
+	}
+	return res, nil
+}
+
+// MustParsePriority parses the input value into an enum value.
+// It panics if the input is invalid, which makes it suitable for
+// initialization code where the input is known to be valid.
+func MustParsePriority(input any) Priority {
+	res, err := ParsePriority(input)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ParsePriorityOr parses the input value into an enum value.
+// It returns def if the input is invalid.
+func ParsePriorityOr(input any, def Priority) Priority {
+	res, err := ParsePriority(input)
+	if err != nil {
+		return def
+	}
+	return res
+}
+
+// Val implements the Enum interface.
+// It returns the underlying enum value.
+func (p Priority) Val() int {
+	return int(p.priority)
+}
+
+// All implements the Enum interface.
+// It returns an iterator over all enum values.
+func (p Priority) All() iter.Seq[Priority] {
+	return func(yield func(Priority) bool) {
+		for _, v := range Priorities.allSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FromName implements the Enum interface.
+// It finds an enum value by name and returns the enum instance and a boolean indicating if found.
+func (p Priority) FromName(name string) (Priority, bool) {
+	for enum, enumName := range priorityNamesMap {
+		if enumName == name {
+			return enum, true
+		}
+	}
+	var zero Priority
+	return zero, false
+}
+
+// FromValue implements the Enum interface.
+// It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
+func (p Priority) FromValue(value int) (Priority, bool) {
+	for _, v := range Priorities.allSlice() {
+		if v.Val() == value {
+			return v, true
+		}
+	}
+	var zero Priority
+	return zero, false
+}
+
+// SerdeFormat implements the Enum interface.
+// It returns the format used for serialization.
+func (p Priority) SerdeFormat() enums.Format {
+	return enums.FormatValue
+}
+
+// Name implements the Enum interface.
+// It returns the name of the current enum value.
+func (p Priority) Name() string {
+	if str, ok := priorityNamesMap[p]; ok {
+		return str
+	}
+	return fmt.Sprintf("priority(%v)", p.priority)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Priority
+// following the YAML marshaling rules, for sigs.k8s.io/yaml which converts
+// YAML to JSON.
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return enums.MarshalYAMLJSON(p, p.priority)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Priority
+// following the YAML unmarshaling rules, for sigs.k8s.io/yaml which converts
+// YAML to JSON.
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	result, err := enums.UnmarshalYAMLJSON(*p, data)
+	if err != nil {
+		return err
+	}
+	*p = *result
+	return nil
+}
+
+// All returns an iterator over all enum values.
+// This is a convenience method that delegates to the zero value enum instance.
+func (p prioritiesContainer) All() iter.Seq[Priority] {
+	return Priority{}.All()
+}
+
+// FromName finds an enum value by name and returns the enum instance and a boolean indicating if found.
+// This is a convenience method that delegates to the zero value enum instance.
+func (p prioritiesContainer) FromName(name string) (Priority, bool) {
+	return Priority{}.FromName(name)
+}
+
+// FromValue finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
+// This is a convenience method that delegates to the zero value enum instance.
+func (p prioritiesContainer) FromValue(value int) (Priority, bool) {
+	return Priority{}.FromValue(value)
+}
+
+// Compile-time check that all enum values are valid.
+// This function is used to ensure that all enum values are defined and valid.
+// It is called by the compiler to verify that the enum values are valid.
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [3]struct{}
+	_ = x[low-0]
+	_ = x[medium-1]
+	_ = x[high-2]
+}
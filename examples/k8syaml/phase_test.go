@@ -0,0 +1,62 @@
+package k8syaml_test
+
+import (
+	"testing"
+
+	"github.com/donutnomad/goenums/examples/k8syaml"
+	"sigs.k8s.io/yaml"
+)
+
+type podStatus struct {
+	Phase    k8syaml.Phase    `json:"phase"`
+	Priority k8syaml.Priority `json:"priority"`
+}
+
+func TestSigsYAML_RoundTrip(t *testing.T) {
+	t.Parallel()
+	in := podStatus{Phase: k8syaml.Phases.Running, Priority: k8syaml.Priorities.High}
+	b, err := yaml.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "phase: Running\npriority: 3\n"; string(b) != want {
+		t.Errorf("expected %q, got %q", want, string(b))
+	}
+	var out podStatus
+	if err := yaml.Unmarshal(b, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != in {
+		t.Errorf("expected %v, got %v", in, out)
+	}
+}
+
+func TestSigsYAML_Unmarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		doc     string
+		want    podStatus
+		wantErr bool
+	}{
+		{"plain", "phase: Succeeded\npriority: 1\n", podStatus{k8syaml.Phases.Succeeded, k8syaml.Priorities.Low}, false},
+		{"quoted name", "phase: \"Pending\"\npriority: 2\n", podStatus{k8syaml.Phases.Pending, k8syaml.Priorities.Medium}, false},
+		{"quoted value", "priority: \"2\"\n", podStatus{}, true},
+		{"unknown name", "phase: Failed\n", podStatus{}, true},
+		{"unknown value", "priority: 9\n", podStatus{}, true},
+		{"value for name format", "phase: 1\n", podStatus{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got podStatus
+			err := yaml.Unmarshal([]byte(tt.doc), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -24,7 +24,7 @@ const (
 	Step4Success tokenRequestStatus = 4000 // ;4000
 )
 
-// goenums: -json -text -binary -yaml -serde/name
+// goenums: -json -text -binary -yaml -serde/name -suggest
 type stringStatus int
 
 const (
@@ -1,14 +1,16 @@
 // DO NOT EDIT.
-// code generated by goenums v0.4.0 at Jun 24 11:42:54.
+// code generated by goenums v0.4.0 at Oct 16 00:11:29.
 //
 // github.com/donutnomad/goenums
 //
 // using the command:
-// goenums -vv examples/validation/status.go
+// goenums examples/validation/status.go
 
 package validation
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
 
@@ -190,6 +192,64 @@ func (t TokenRequestStatus) String() string {
 	return fmt.Sprintf("tokenrequeststatus(%v)", t.tokenRequestStatus)
 }
 
+// ErrInvalidTokenRequestStatus is the sentinel wrapped by every error returned
+// when an input cannot be parsed into a TokenRequestStatus.
+var ErrInvalidTokenRequestStatus = errors.New("invalid TokenRequestStatus")
+
+// InvalidTokenRequestStatusError is returned when an input cannot be parsed into
+// a TokenRequestStatus. It carries the offending input and, when one is known,
+// the closest valid name.
+type InvalidTokenRequestStatusError struct {
+	Input      any
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *InvalidTokenRequestStatusError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("invalid TokenRequestStatus value %v, did you mean %q?", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("invalid TokenRequestStatus value %v", e.Input)
+}
+
+// Unwrap returns ErrInvalidTokenRequestStatus so callers can use errors.Is.
+func (e *InvalidTokenRequestStatusError) Unwrap() error {
+	return ErrInvalidTokenRequestStatus
+}
+
+// ParseTokenRequestStatus parses the input value into an enum value.
+// It returns the parsed enum value or an *InvalidTokenRequestStatusError if the input is invalid.
+// It is a convenience function that can be used to parse enum values from
+// various input types, such as strings, byte slices, or underlying values.
+func ParseTokenRequestStatus(input any) (TokenRequestStatus, error) {
+	res, err := enums.Parse(TokenRequestStatus{}, input)
+	if err != nil {
+		return invalidTokenRequestStatus, &InvalidTokenRequestStatusError{Input: input}
+	}
+	return res, nil
+}
+
+// MustParseTokenRequestStatus parses the input value into an enum value.
+// It panics if the input is invalid, which makes it suitable for
+// initialization code where the input is known to be valid.
+func MustParseTokenRequestStatus(input any) TokenRequestStatus {
+	res, err := ParseTokenRequestStatus(input)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ParseTokenRequestStatusOr parses the input value into an enum value.
+// It returns def if the input is invalid.
+func ParseTokenRequestStatusOr(input any, def TokenRequestStatus) TokenRequestStatus {
+	res, err := ParseTokenRequestStatus(input)
+	if err != nil {
+		return def
+	}
+	return res
+}
+
 // Val implements the Enum interface.
 // It returns the underlying enum value.
 func (t TokenRequestStatus) Val() int {
@@ -223,7 +283,7 @@ func (t TokenRequestStatus) FromName(name string) (TokenRequestStatus, bool) {
 // FromValue implements the Enum interface.
 // It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
 func (t TokenRequestStatus) FromValue(value int) (TokenRequestStatus, bool) {
-	for v := range t.All() {
+	for _, v := range TokenRequestStatuses.allSlice() {
 		if v.Val() == value {
 			return v, true
 		}
@@ -409,6 +469,85 @@ func (s StringStatus) String() string {
 	return fmt.Sprintf("stringstatus(%v)", s.stringStatus)
 }
 
+// stringstatusSuggestNames lists the valid names offered as suggestions
+// when parsing a near-miss name fails.
+var stringstatusSuggestNames = []string{
+	"Active",
+	"Inactive",
+}
+
+// ErrInvalidStringStatus is the sentinel wrapped by every error returned
+// when an input cannot be parsed into a StringStatus.
+var ErrInvalidStringStatus = errors.New("invalid StringStatus")
+
+// InvalidStringStatusError is returned when an input cannot be parsed into
+// a StringStatus. It carries the offending input and, when one is known,
+// the closest valid name.
+type InvalidStringStatusError struct {
+	Input      any
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *InvalidStringStatusError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("invalid StringStatus value %v, did you mean %q?", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("invalid StringStatus value %v", e.Input)
+}
+
+// Unwrap returns ErrInvalidStringStatus so callers can use errors.Is.
+func (e *InvalidStringStatusError) Unwrap() error {
+	return ErrInvalidStringStatus
+}
+
+// invalidStringStatusError returns the *InvalidStringStatusError of input,
+// suggesting the closest valid name to strings and byte slices. ParseStringStatus
+// and the unmarshalers of StringStatus return it for the names they reject.
+func invalidStringStatusError(input any) *InvalidStringStatusError {
+	invalid := &InvalidStringStatusError{Input: input}
+	switch v := input.(type) {
+	case string:
+		invalid.Suggestion = enums.Suggest(v, stringstatusSuggestNames)
+	case []byte:
+		invalid.Suggestion = enums.Suggest(string(v), stringstatusSuggestNames)
+	}
+	return invalid
+}
+
+// ParseStringStatus parses the input value into an enum value.
+// It returns the parsed enum value or an *InvalidStringStatusError if the input is invalid.
+// It is a convenience function that can be used to parse enum values from
+// various input types, such as strings, byte slices, or underlying values.
+func ParseStringStatus(input any) (StringStatus, error) {
+	res, err := enums.Parse(StringStatus{}, input)
+	if err != nil {
+		return invalidStringStatus, invalidStringStatusError(input)
+	}
+	return res, nil
+}
+
+// MustParseStringStatus parses the input value into an enum value.
+// It panics if the input is invalid, which makes it suitable for
+// initialization code where the input is known to be valid.
+func MustParseStringStatus(input any) StringStatus {
+	res, err := ParseStringStatus(input)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ParseStringStatusOr parses the input value into an enum value.
+// It returns def if the input is invalid.
+func ParseStringStatusOr(input any, def StringStatus) StringStatus {
+	res, err := ParseStringStatus(input)
+	if err != nil {
+		return def
+	}
+	return res
+}
+
 // Val implements the Enum interface.
 // It returns the underlying enum value.
 func (s StringStatus) Val() int {
@@ -442,7 +581,7 @@ func (s StringStatus) FromName(name string) (StringStatus, bool) {
 // FromValue implements the Enum interface.
 // It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
 func (s StringStatus) FromValue(value int) (StringStatus, bool) {
-	for v := range s.All() {
+	for _, v := range StringStatuses.allSlice() {
 		if v.Val() == value {
 			return v, true
 		}
@@ -478,6 +617,11 @@ func (s StringStatus) MarshalJSON() ([]byte, error) {
 func (s *StringStatus) UnmarshalJSON(data []byte) error {
 	result, err := enums.UnmarshalJSON(*s, data)
 	if err != nil {
+		// Reject names with the error of ParseStringStatus, which suggests the closest
+		var name string
+		if json.Unmarshal(data, &name) == nil {
+			return invalidStringStatusError(name)
+		}
 		return err
 	}
 	*s = *result
@@ -496,7 +640,7 @@ func (s StringStatus) MarshalText() ([]byte, error) {
 func (s *StringStatus) UnmarshalText(data []byte) error {
 	result, err := enums.UnmarshalText(*s, data)
 	if err != nil {
-		return err
+		return invalidStringStatusError(string(data))
 	}
 	*s = *result
 	return nil
@@ -647,6 +791,64 @@ func (b BytesStatus) String() string {
 	return fmt.Sprintf("bytesstatus(%v)", b.bytesStatus)
 }
 
+// ErrInvalidBytesStatus is the sentinel wrapped by every error returned
+// when an input cannot be parsed into a BytesStatus.
+var ErrInvalidBytesStatus = errors.New("invalid BytesStatus")
+
+// InvalidBytesStatusError is returned when an input cannot be parsed into
+// a BytesStatus. It carries the offending input and, when one is known,
+// the closest valid name.
+type InvalidBytesStatusError struct {
+	Input      any
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *InvalidBytesStatusError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("invalid BytesStatus value %v, did you mean %q?", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("invalid BytesStatus value %v", e.Input)
+}
+
+// Unwrap returns ErrInvalidBytesStatus so callers can use errors.Is.
+func (e *InvalidBytesStatusError) Unwrap() error {
+	return ErrInvalidBytesStatus
+}
+
+// ParseBytesStatus parses the input value into an enum value.
+// It returns the parsed enum value or an *InvalidBytesStatusError if the input is invalid.
+// It is a convenience function that can be used to parse enum values from
+// various input types, such as strings, byte slices, or underlying values.
+func ParseBytesStatus(input any) (BytesStatus, error) {
+	res, err := enums.Parse(BytesStatus{}, input)
+	if err != nil {
+		return invalidBytesStatus, &InvalidBytesStatusError{Input: input}
+	}
+	return res, nil
+}
+
+// MustParseBytesStatus parses the input value into an enum value.
+// It panics if the input is invalid, which makes it suitable for
+// initialization code where the input is known to be valid.
+func MustParseBytesStatus(input any) BytesStatus {
+	res, err := ParseBytesStatus(input)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ParseBytesStatusOr parses the input value into an enum value.
+// It returns def if the input is invalid.
+func ParseBytesStatusOr(input any, def BytesStatus) BytesStatus {
+	res, err := ParseBytesStatus(input)
+	if err != nil {
+		return def
+	}
+	return res
+}
+
 // Val implements the Enum interface.
 // It returns the underlying enum value.
 func (b BytesStatus) Val() int {
@@ -680,7 +882,7 @@ func (b BytesStatus) FromName(name string) (BytesStatus, bool) {
 // FromValue implements the Enum interface.
 // It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
 func (b BytesStatus) FromValue(value int) (BytesStatus, bool) {
-	for v := range b.All() {
+	for _, v := range BytesStatuses.allSlice() {
 		if v.Val() == value {
 			return v, true
 		}
@@ -866,6 +1068,64 @@ func (p PrimitiveStatus) String() string {
 	return fmt.Sprintf("primitivestatus(%v)", p.primitiveStatus)
 }
 
+// ErrInvalidPrimitiveStatus is the sentinel wrapped by every error returned
+// when an input cannot be parsed into a PrimitiveStatus.
+var ErrInvalidPrimitiveStatus = errors.New("invalid PrimitiveStatus")
+
+// InvalidPrimitiveStatusError is returned when an input cannot be parsed into
+// a PrimitiveStatus. It carries the offending input and, when one is known,
+// the closest valid name.
+type InvalidPrimitiveStatusError struct {
+	Input      any
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *InvalidPrimitiveStatusError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("invalid PrimitiveStatus value %v, did you mean %q?", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("invalid PrimitiveStatus value %v", e.Input)
+}
+
+// Unwrap returns ErrInvalidPrimitiveStatus so callers can use errors.Is.
+func (e *InvalidPrimitiveStatusError) Unwrap() error {
+	return ErrInvalidPrimitiveStatus
+}
+
+// ParsePrimitiveStatus parses the input value into an enum value.
+// It returns the parsed enum value or an *InvalidPrimitiveStatusError if the input is invalid.
+// It is a convenience function that can be used to parse enum values from
+// various input types, such as strings, byte slices, or underlying values.
+func ParsePrimitiveStatus(input any) (PrimitiveStatus, error) {
+	res, err := enums.Parse(PrimitiveStatus{}, input)
+	if err != nil {
+		return invalidPrimitiveStatus, &InvalidPrimitiveStatusError{Input: input}
+	}
+	return res, nil
+}
+
+// MustParsePrimitiveStatus parses the input value into an enum value.
+// It panics if the input is invalid, which makes it suitable for
+// initialization code where the input is known to be valid.
+func MustParsePrimitiveStatus(input any) PrimitiveStatus {
+	res, err := ParsePrimitiveStatus(input)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ParsePrimitiveStatusOr parses the input value into an enum value.
+// It returns def if the input is invalid.
+func ParsePrimitiveStatusOr(input any, def PrimitiveStatus) PrimitiveStatus {
+	res, err := ParsePrimitiveStatus(input)
+	if err != nil {
+		return def
+	}
+	return res
+}
+
 // Val implements the Enum interface.
 // It returns the underlying enum value.
 func (p PrimitiveStatus) Val() float32 {
@@ -899,7 +1159,7 @@ func (p PrimitiveStatus) FromName(name string) (PrimitiveStatus, bool) {
 // FromValue implements the Enum interface.
 // It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
 func (p PrimitiveStatus) FromValue(value float32) (PrimitiveStatus, bool) {
-	for v := range p.All() {
+	for _, v := range PrimitiveStatuses.allSlice() {
 		if v.Val() == value {
 			return v, true
 		}
@@ -1064,12 +1324,12 @@ func (o orderStatusesContainer) allSlice() []OrderStatus {
 
 // validOrderStatuses is a map of enum values to their validity
 var validOrderStatuses = map[OrderStatus]bool{
-	OrderStatuses.OrderPending:   false,
-	OrderStatuses.XProcessing:    false,
-	OrderStatuses.OrderShipped:   false,
-	OrderStatuses.OrderDelivered: false,
-	OrderStatuses.OrderCancelled: false,
-	OrderStatuses.OrderFailed:    false,
+	OrderStatuses.OrderPending:   true,
+	OrderStatuses.XProcessing:    true,
+	OrderStatuses.OrderShipped:   true,
+	OrderStatuses.OrderDelivered: true,
+	OrderStatuses.OrderCancelled: true,
+	OrderStatuses.OrderFailed:    true,
 }
 
 // IsValid checks whether the OrderStatuses value is valid.
@@ -1101,6 +1361,64 @@ func (o OrderStatus) String() string {
 	return fmt.Sprintf("orderstatus(%v)", o.orderStatus)
 }
 
+// ErrInvalidOrderStatus is the sentinel wrapped by every error returned
+// when an input cannot be parsed into a OrderStatus.
+var ErrInvalidOrderStatus = errors.New("invalid OrderStatus")
+
+// InvalidOrderStatusError is returned when an input cannot be parsed into
+// a OrderStatus. It carries the offending input and, when one is known,
+// the closest valid name.
+type InvalidOrderStatusError struct {
+	Input      any
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *InvalidOrderStatusError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("invalid OrderStatus value %v, did you mean %q?", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("invalid OrderStatus value %v", e.Input)
+}
+
+// Unwrap returns ErrInvalidOrderStatus so callers can use errors.Is.
+func (e *InvalidOrderStatusError) Unwrap() error {
+	return ErrInvalidOrderStatus
+}
+
+// ParseOrderStatus parses the input value into an enum value.
+// It returns the parsed enum value or an *InvalidOrderStatusError if the input is invalid.
+// It is a convenience function that can be used to parse enum values from
+// various input types, such as strings, byte slices, or underlying values.
+func ParseOrderStatus(input any) (OrderStatus, error) {
+	res, err := enums.Parse(OrderStatus{}, input)
+	if err != nil {
+		return invalidOrderStatus, &InvalidOrderStatusError{Input: input}
+	}
+	return res, nil
+}
+
+// MustParseOrderStatus parses the input value into an enum value.
+// It panics if the input is invalid, which makes it suitable for
+// initialization code where the input is known to be valid.
+func MustParseOrderStatus(input any) OrderStatus {
+	res, err := ParseOrderStatus(input)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ParseOrderStatusOr parses the input value into an enum value.
+// It returns def if the input is invalid.
+func ParseOrderStatusOr(input any, def OrderStatus) OrderStatus {
+	res, err := ParseOrderStatus(input)
+	if err != nil {
+		return def
+	}
+	return res
+}
+
 // Val implements the Enum interface.
 // It returns the underlying enum value.
 func (o OrderStatus) Val() int {
@@ -1134,7 +1452,7 @@ func (o OrderStatus) FromName(name string) (OrderStatus, bool) {
 // FromValue implements the Enum interface.
 // It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
 func (o OrderStatus) FromValue(value int) (OrderStatus, bool) {
-	for v := range o.All() {
+	for _, v := range OrderStatuses.allSlice() {
 		if v.Val() == value {
 			return v, true
 		}
@@ -1185,11 +1503,11 @@ func _() {
 	// Does not identify newly added constant values unless order changes
 	var x [6]struct{}
 	_ = x[orderPending-0]
-	_ = x[xProcessing-0]
-	_ = x[orderShipped-1]
-	_ = x[orderDelivered-2]
-	_ = x[orderCancelled-3]
-	_ = x[orderFailed-4]
+	_ = x[xProcessing-1]
+	_ = x[orderShipped-2]
+	_ = x[orderDelivered-3]
+	_ = x[orderCancelled-4]
+	_ = x[orderFailed-5]
 }
 
 // CanTransitionTo checks if the current state can transition to the target state.
@@ -22,6 +22,9 @@ func validatePath(name string) error {
 // compile-time check to ensure OSReadFileFS implements ReadFileFS
 var _ fs.ReadFileFS = (*OSReadWriteFileFS)(nil)
 
+// compile-time check to ensure OSReadWriteFileFS lists directories itself
+var _ fs.ReadDirFS = (*OSReadWriteFileFS)(nil)
+
 // OSReadWriteFileFS is a type that implements fs.ReadFileFS using os.ReadFile.
 type OSReadWriteFileFS struct {
 }
@@ -42,6 +45,15 @@ func (o *OSReadWriteFileFS) Open(name string) (fs.File, error) {
 	return os.Open(name) // #nosec G304 - path validated above
 }
 
+// ReadDir reads the named directory and returns its entries sorted by
+// name.
+func (o *OSReadWriteFileFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := validatePath(name); err != nil {
+		return nil, err
+	}
+	return os.ReadDir(name)
+}
+
 // Stat returns the FileInfo for the named file.
 func (o *OSReadWriteFileFS) Stat(name string) (fs.FileInfo, error) {
 	return os.Stat(name)
@@ -0,0 +1,116 @@
+package file
+
+import (
+	"io"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Compile time check to ensure OverlayFS implements ReadCreateWriteFileFS
+var _ ReadCreateWriteFileFS = (*OverlayFS)(nil)
+
+// OverlayFS reads from any fs.FS, such as an fstest.MapFS, an embed.FS or
+// an afero filesystem wrapped with afero.NewIOFS, and keeps the files
+// written in memory on top of it. Given to both the sources and the
+// writers, it runs the whole pipeline without touching the disk, for
+// hermetic tests and for embedding the generator where there is no
+// filesystem, such as in WASM.
+//
+// Names are cleaned and converted to slash-separated paths, so the relative
+// paths writers build with path/filepath address the files of the base.
+type OverlayFS struct {
+	base fs.FS
+	mem  *MemFS
+}
+
+// NewOverlayFS creates an OverlayFS reading from base. A nil base starts
+// empty.
+func NewOverlayFS(base fs.FS) *OverlayFS {
+	return &OverlayFS{base: base, mem: NewMemFS()}
+}
+
+// fsPath converts name to the unrooted, slash-separated path fs.FS
+// requires.
+func fsPath(op, name string) (string, error) {
+	p := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(name)), "./")
+	if !fs.ValidPath(p) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return p, nil
+}
+
+// ReadFile reads the named file, as last written or from the base.
+func (o *OverlayFS) ReadFile(name string) ([]byte, error) {
+	p, err := fsPath("read", name)
+	if err != nil {
+		return nil, err
+	}
+	if b, err := o.mem.ReadFile(p); err == nil {
+		return slices.Clone(b), nil
+	}
+	if o.base == nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return fs.ReadFile(o.base, p)
+}
+
+// Open opens the named file, as last written or from the base.
+func (o *OverlayFS) Open(name string) (fs.File, error) {
+	p, err := fsPath("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if f, err := o.mem.Open(p); err == nil {
+		return f, nil
+	}
+	if o.base == nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return o.base.Open(p)
+}
+
+// Stat returns the FileInfo for the named file, as last written or from
+// the base.
+func (o *OverlayFS) Stat(name string) (fs.FileInfo, error) {
+	p, err := fsPath("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := o.mem.Stat(p); err == nil {
+		return info, nil
+	}
+	if o.base == nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return fs.Stat(o.base, p)
+}
+
+// WriteFile writes data to the named file in memory; the base is never
+// modified.
+func (o *OverlayFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	p, err := fsPath("write", name)
+	if err != nil {
+		return err
+	}
+	return o.mem.WriteFile(p, slices.Clone(data), perm)
+}
+
+// Create creates or truncates the named file in memory.
+func (o *OverlayFS) Create(name string) (io.WriteCloser, error) {
+	p, err := fsPath("create", name)
+	if err != nil {
+		return nil, err
+	}
+	return o.mem.Create(p)
+}
+
+// Written returns the slash-separated names of the files written, in
+// lexical order.
+func (o *OverlayFS) Written() []string {
+	o.mem.mu.RLock()
+	defer o.mem.mu.RUnlock()
+	return slices.Sorted(maps.Keys(o.mem.files))
+}
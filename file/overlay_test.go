@@ -0,0 +1,62 @@
+package file_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/donutnomad/goenums/file"
+)
+
+func TestOverlayFS(t *testing.T) {
+	t.Parallel()
+	base := fstest.MapFS{
+		"orders/status.go": &fstest.MapFile{Data: []byte("package orders\n")},
+	}
+	o := file.NewOverlayFS(base)
+
+	b, err := o.ReadFile("./orders/status.go")
+	if err != nil || string(b) != "package orders\n" {
+		t.Fatalf("expected the base file, got %q, %v", b, err)
+	}
+	w, err := o.Create("orders/status_enums.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := io.WriteString(w, "package orders // generated\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.WriteFile("orders/status.go", []byte("package shadowed\n"), file.DefaultFilePerms); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err = o.ReadFile("orders/status.go")
+	if err != nil || string(b) != "package shadowed\n" {
+		t.Errorf("expected the written file to shadow the base, got %q, %v", b, err)
+	}
+	if string(base["orders/status.go"].Data) != "package orders\n" {
+		t.Errorf("expected the base to be left unchanged")
+	}
+	info, err := o.Stat("orders/status_enums.go")
+	if err != nil || info.Size() != int64(len("package orders // generated\n")) {
+		t.Errorf("unexpected stat %v, %v", info, err)
+	}
+	if want := []string{"orders/status.go", "orders/status_enums.go"}; !slices.Equal(o.Written(), want) {
+		t.Errorf("got written %q, want %q", o.Written(), want)
+	}
+
+	if _, err := o.ReadFile("orders/missing.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+	if _, err := o.Create("../outside.go"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("expected fs.ErrInvalid for a path outside the filesystem, got %v", err)
+	}
+	if _, err := file.NewOverlayFS(nil).Stat("status.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist without a base, got %v", err)
+	}
+}
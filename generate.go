@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/pkg/goenums"
+	"github.com/donutnomad/goenums/source"
+	"github.com/donutnomad/goenums/strings"
+)
+
+// ErrUnsupportedInput is returned for input files no parser can read.
+var ErrUnsupportedInput = goenums.ErrUnsupportedInput
+
+// ErrUnsupportedOutput is returned for unknown -output formats.
+var ErrUnsupportedOutput = goenums.ErrUnsupportedOutput
+
+// stdinFilename is the input naming standard input, which is read as Go
+// source.
+const stdinFilename = "-"
+
+// enumSourceMarker matches the comments marking a Go file as an enum source.
+var enumSourceMarker = regexp.MustCompile(`(?m)^\s*//\s*(goenums:|go:generate\s.*\bgoenums\b)`)
+
+// generateAll generates filenames with at most cfg.Jobs files in flight.
+// Every file is attempted and failures are returned together, each
+// prefixed with its filename; with -failfast the first failure cancels the
+// files not yet started instead.
+func generateAll(ctx context.Context, cfg config.Configuration, filenames []string) error {
+	jobs := cfg.Jobs
+	if jobs < 1 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// errs is indexed like filenames so failures are reported in input order
+	errs := make([]error, len(filenames))
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	for i, filename := range filenames {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			errs[i] = fmt.Errorf("%s: %w", filename, ctx.Err())
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := generateFile(ctx, cfg, filename); err != nil {
+				logGenerateError(filename, err)
+				errs[i] = fmt.Errorf("%s: %w", filename, err)
+				if cfg.Failfast {
+					cancel()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// generateFile parses filename and writes every configured output for it.
+func generateFile(ctx context.Context, cfg config.Configuration, filename string) error {
+	// Parsers record the configuration of the types they find, which must
+	// not leak into files generated concurrently
+	cfg.EnumTypeConfigs = maps.Clone(cfg.EnumTypeConfigs)
+	if cfg.EnumTypeConfigs == nil {
+		cfg.EnumTypeConfigs = make(map[string]config.EnumTypeConfig)
+	}
+
+	slog.Default().Info("processing file", slog.String("filename", filename))
+	start := time.Now()
+	parser, isSpec, err := newParser(ctx, cfg, filename)
+	if err != nil {
+		return err
+	}
+	var out io.Writer
+	if cfg.Stdout {
+		out = os.Stdout
+	}
+	writers, err := goenums.NewWriters(cfg, isSpec, nil, out)
+	if err != nil {
+		return err
+	}
+
+	slog.Default().Debug("starting parsing and generation", slog.String("filename", filename))
+	if err := generate(ctx, cfg, parser, writers); err != nil {
+		return err
+	}
+	slog.Default().Info("generated enums", slog.String("filename", filename))
+	slog.Default().Debug("file timing", slog.String("filename", filename),
+		slog.Int("outputs", len(writers)), slog.Duration("elapsed", time.Since(start)))
+	return nil
+}
+
+// newParser returns the parser for filename, and whether it is a spec,
+// OpenAPI document or proto file rather than Go source. A URL filename is
+// fetched with ctx.
+func newParser(ctx context.Context, cfg config.Configuration, filename string) (enum.Parser, bool, error) {
+	if source.IsURL(filename) {
+		src := source.FromURL(filename)
+		// Fetched now so the fetch stops with ctx
+		if _, err := src.Fetch(ctx); err != nil {
+			return nil, false, err
+		}
+		return newSourceParser(cfg, src)
+	}
+	if filename == stdinFilename {
+		slog.Default().Debug("initializing go parser for standard input")
+		return gofile.NewParser(
+			gofile.WithParserConfiguration(cfg),
+			gofile.WithSource(source.FromNamedReader(os.Stdin, stdinFilename))), false, nil
+	}
+	return newSourceParser(cfg, source.FromFile(filename))
+}
+
+// newSourceParser returns the parser for src, and whether it is a spec,
+// OpenAPI document or proto file rather than Go source.
+func newSourceParser(cfg config.Configuration, src enum.Source) (enum.Parser, bool, error) {
+	return goenums.NewParser(cfg, src)
+}
+
+// generate runs the parser once and gives its requests to every writer, so
+// each output is produced from the same parse.
+func generate(ctx context.Context, cfg config.Configuration, parser enum.Parser, writers []enum.Writer) error {
+	slog.Default().Debug("initializing generator")
+	gen := generator.New(
+		generator.WithConfig(cfg),
+		generator.WithParser(parser),
+		generator.WithWriters(writers...))
+	return gen.ParseAndWrite(ctx)
+}
+
+// logGenerateError logs why filename could not be generated, with hints
+// for the common causes.
+func logGenerateError(filename string, err error) {
+	if errors.Is(err, enum.ErrParseSource) {
+		slog.Default().Error("unable to parse file", slog.String("filename", filename))
+		slog.Default().Error("please ensure that the file is a valid input file")
+		slog.Default().Error("for the selected parser")
+	}
+	if errors.Is(err, enum.ErrNoEnumsFound) {
+		slog.Default().Error("no enums found in file", slog.String("filename", filename))
+		slog.Default().Error("please ensure that the file contains enum definitions")
+	}
+	if errors.Is(err, enum.ErrWriteOutput) {
+		slog.Default().Error("could not generate output")
+		slog.Default().Error("please ensure that the output destination is writable")
+		slog.Default().Error("and that input enums contain only valid characters")
+	}
+	slog.Default().Error("could not generate enums",
+		slog.String("filename", filename),
+		slog.String("error", err.Error()))
+}
+
+// expandInputs resolves the input paths to the files to generate. Files,
+// URLs and "-" for standard input, are kept as given. Directories contribute the enum sources they contain,
+// and their subdirectories too when followed by "/..." like in package
+// patterns; hidden directories, vendor and testdata are skipped.
+func expandInputs(paths []string) ([]string, error) {
+	var filenames []string
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		if path == stdinFilename || source.IsURL(path) {
+			filenames = append(filenames, path)
+			continue
+		}
+		recursive := strings.HasSuffix(path, "...")
+		root := strings.TrimSuffix(path, "...")
+		if root == "" {
+			root = "."
+		}
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			filenames = append(filenames, path)
+			continue
+		}
+		files, err := source.FromDir(root, recursive).Files()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range files {
+			ok, err := isEnumSource(name)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				filenames = append(filenames, name)
+			}
+		}
+	}
+	return filenames, nil
+}
+
+// isEnumSource reports whether a file found in a directory is an enum
+// source: a spec named "*.enums.yaml", "*.enums.yml" or "*.enums.json", or
+// a hand-written Go file with a goenums directive or go:generate line.
+func isEnumSource(name string) (bool, error) {
+	base := filepath.Base(name)
+	for _, ext := range []string{".enums.yaml", ".enums.yml", ".enums.json"} {
+		if strings.HasSuffix(base, ext) {
+			return true, nil
+		}
+	}
+	if filepath.Ext(base) != ".go" || strings.HasSuffix(base, "_test.go") {
+		return false, nil
+	}
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Contains(b, []byte("code generated by goenums")) || bytes.Contains(b, []byte("Code generated by goenums")) {
+		return false, nil
+	}
+	return enumSourceMarker.Match(b), nil
+}
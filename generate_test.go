@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+)
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const colorGo = `package colors
+
+// goenums: -json
+type color int
+
+const (
+	unknown color = iota // invalid
+	red                  // Red
+	green                // Green
+)
+`
+
+func TestExpandInputs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "color.go"), colorGo)
+	writeFile(t, filepath.Join(dir, "color_enums.go"), "// DO NOT EDIT.\n// code generated by goenums v0.4.0 at Jan  1.\n\npackage colors\n")
+	writeFile(t, filepath.Join(dir, "color_test.go"), "package colors\n\n// goenums: -json\n")
+	writeFile(t, filepath.Join(dir, "util.go"), "package colors\n\nimport _ \"github.com/donutnomad/goenums/enums\"\n")
+	writeFile(t, filepath.Join(dir, "shapes", "shape.go"), "package shapes\n\n//go:generate goenums shape.go\n")
+	writeFile(t, filepath.Join(dir, "shapes", "sizes.enums.yaml"), "package: shapes\n")
+	writeFile(t, filepath.Join(dir, "testdata", "old.go"), colorGo)
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			name:  "file",
+			paths: []string{filepath.Join(dir, "util.go")},
+			want:  []string{"util.go"},
+		},
+		{
+			name:  "directory",
+			paths: []string{dir},
+			want:  []string{"color.go"},
+		},
+		{
+			name:  "recursive",
+			paths: []string{dir + "/..."},
+			want:  []string{"color.go", "shapes/shape.go", "shapes/sizes.enums.yaml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			filenames, err := expandInputs(tt.paths)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, name := range filenames {
+				rel, err := filepath.Rel(dir, name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, filepath.ToSlash(rel))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := expandInputs([]string{filepath.Join(dir, "missing.go")}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing input to fail, got %v", err)
+	}
+}
+
+func TestGenerateAll(t *testing.T) {
+	t.Parallel()
+	// Writers only accept sources below the working directory
+	dir, err := os.MkdirTemp(".", "generate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	var filenames []string
+	for _, pkg := range []string{"a", "b", "c", "d"} {
+		name := filepath.Join(dir, pkg, "color.go")
+		writeFile(t, name, strings.Replace(colorGo, "package colors", "package "+pkg, 1))
+		filenames = append(filenames, name)
+	}
+	empty := filepath.Join(dir, "e", "empty.go")
+	writeFile(t, empty, "package e\n")
+	unsupported := filepath.Join(dir, "f", "color.txt")
+	writeFile(t, unsupported, colorGo)
+	filenames = append(filenames, empty, unsupported)
+
+	cfg := config.Configuration{Jobs: 2}
+	err = generateAll(t.Context(), cfg, filenames)
+	if !errors.Is(err, enum.ErrNoEnumsFound) || !errors.Is(err, ErrUnsupportedInput) {
+		t.Fatalf("expected both failures to be reported, got %v", err)
+	}
+	if msg := err.Error(); !strings.HasPrefix(msg, empty+": ") || !strings.Contains(msg, "\n"+unsupported+": ") {
+		t.Errorf("expected failures prefixed with their filenames in input order, got %q", msg)
+	}
+	for _, name := range filenames[:4] {
+		if _, err := os.Stat(strings.TrimSuffix(name, ".go") + "_enums.go"); err != nil {
+			t.Errorf("expected %s to be generated: %v", name, err)
+		}
+	}
+}
+
+func TestMain_FailedGenerationExitStatus(t *testing.T) {
+	if input := os.Getenv("GOENUMS_TEST_MAIN_INPUT"); input != "" {
+		os.Args = []string{"goenums", input}
+		main()
+		return
+	}
+	input := filepath.Join(t.TempDir(), "broken.go")
+	writeFile(t, input, "package broken\n\nthis is not Go\n")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestMain_FailedGenerationExitStatus$")
+	cmd.Env = append(os.Environ(), "GOENUMS_TEST_MAIN_INPUT="+input)
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected exit status 1 for a failed generation, got %v:\n%s", err, out)
+	}
+}
+
+func TestMain_FailedProfilingExitStatus(t *testing.T) {
+	if args := os.Getenv("GOENUMS_TEST_MAIN_ARGS"); args != "" {
+		os.Args = append([]string{"goenums"}, strings.Split(args, "\n")...)
+		main()
+		return
+	}
+	dir := t.TempDir()
+	input := filepath.Join(dir, "status.go")
+	writeFile(t, input, "package status\n\ntype status int\n\nconst (\n\tunknown status = iota // invalid\n\tactive\n)\n")
+	profile := filepath.Join(dir, "missing", "cpu.pprof")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestMain_FailedProfilingExitStatus$")
+	cmd.Env = append(os.Environ(), "GOENUMS_TEST_MAIN_ARGS=-cpuprofile\n"+profile+"\n"+input)
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected exit status 1 when profiling cannot start, got %v:\n%s", err, out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "status_enums.go")); err == nil {
+		t.Error("expected nothing to be generated when profiling cannot start")
+	}
+}
@@ -0,0 +1,137 @@
+// Package avro exports enum types as Avro enum schemas.
+//
+// The Writer emits one "<enum>.avsc" file per enum type, next to the source
+// it was parsed from, so the schemas registered for Kafka topics follow the
+// Go definitions. The symbols are the names the generated text methods
+// marshal to, in declaration order, which is the order Avro encodes them
+// by: new values must be appended to keep existing data readable.
+package avro
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/jsonschema"
+	"github.com/donutnomad/goenums/strings"
+)
+
+var _ enum.Writer = &Writer{}
+
+var (
+	// ErrWriteSchema is returned when a schema file cannot be written.
+	ErrWriteSchema = errors.New("error writing avro schema")
+	// ErrInvalidSymbol is returned for enum names Avro does not accept as
+	// symbols.
+	ErrInvalidSymbol = errors.New("invalid avro symbol")
+)
+
+// symbolPattern matches the names Avro accepts as enum symbols.
+var symbolPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Schema is the Avro schema of a single enum type.
+type Schema struct {
+	Type      string   `json:"type"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Doc       string   `json:"doc,omitempty"`
+	Symbols   []string `json:"symbols"`
+}
+
+// FromEnum returns the schema of enumIota, declared in namespace. Its
+// symbols are the names the values are serialized as; invalid values are
+// left out. Enums serialized by value have no Avro representation.
+func FromEnum(enumIota enum.EnumIota, namespace string, cfg config.EnumTypeConfig) (Schema, error) {
+	if cfg.SerializationType == config.SerdeValue {
+		return Schema{}, fmt.Errorf("%w: %s: Avro enums are serialized by name, not with -serde/value",
+			config.ErrUnsupportedCombination, enumIota.Type)
+	}
+	js := jsonschema.FromEnum(enumIota, cfg)
+	s := Schema{
+		Type:      "enum",
+		Name:      js.Title,
+		Namespace: namespace,
+		Doc:       js.Description,
+		Symbols:   make([]string, 0, len(js.Enum)),
+	}
+	for _, v := range js.Enum {
+		symbol := fmt.Sprint(v)
+		if !symbolPattern.MatchString(symbol) {
+			return Schema{}, fmt.Errorf("%w: %s: %q", ErrInvalidSymbol, enumIota.Type, symbol)
+		}
+		s.Symbols = append(s.Symbols, symbol)
+	}
+	return s, nil
+}
+
+// Writer implements enum.Writer and writes an Avro schema for every enum
+// type in a request.
+type Writer struct {
+	Configuration config.Configuration
+	fs            file.ReadCreateWriteFileFS
+}
+
+// WriterOption is a function that configures a Writer.
+type WriterOption func(*Writer)
+
+// WithFileSystem sets the filesystem to use for writing files.
+func WithFileSystem(fs file.ReadCreateWriteFileFS) WriterOption {
+	return func(w *Writer) {
+		w.fs = fs
+	}
+}
+
+// WithWriterConfiguration sets the configuration for the writer.
+func WithWriterConfiguration(configuration config.Configuration) WriterOption {
+	return func(w *Writer) {
+		w.Configuration = configuration
+	}
+}
+
+// NewWriter creates a new Avro schema writer, writing to the operating
+// system filesystem by default.
+func NewWriter(opts ...WriterOption) *Writer {
+	w := Writer{
+		Configuration: config.Configuration{},
+		fs:            &file.OSReadWriteFileFS{},
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return &w
+}
+
+// Write emits "<enum>.avsc" for each enum type of the requests, in the
+// directory of the source it was parsed from. The Go package name is used
+// as the namespace.
+func (w *Writer) Write(ctx context.Context, reqs []enum.GenerationRequest) error {
+	for _, req := range reqs {
+		if !req.IsValid() {
+			return fmt.Errorf("invalid enum: %s", req.SourceFilename)
+		}
+		for _, enumIota := range req.GetEnumIotas() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s, err := FromEnum(enumIota, req.Package, req.Configuration.GetEnumTypeConfig(enumIota.Type))
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrWriteSchema, err)
+			}
+			b, err := json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrWriteSchema, enumIota.Type, err)
+			}
+			path := filepath.Join(filepath.Dir(req.SourceFilename), strings.Snake(s.Name)+".avsc")
+			if err := w.fs.WriteFile(path, append(b, '\n'), file.DefaultFilePerms); err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrWriteSchema, path, err)
+			}
+		}
+	}
+	return nil
+}
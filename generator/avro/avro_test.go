@@ -0,0 +1,95 @@
+package avro_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/avro"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/source"
+)
+
+const statusGo = `package orders
+
+// status is the lifecycle of an order.
+type status int
+
+const (
+	unknown status = iota // invalid
+	pending               // PENDING
+	shipped               // SHIPPED
+)
+`
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	if err := memfs.WriteFile("orders/status.go", []byte(statusGo), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reqs, err := gofile.NewParser(gofile.WithSource(source.FromFileSystem(memfs, "orders/status.go"))).Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := avro.NewWriter(avro.WithFileSystem(memfs)).Write(t.Context(), reqs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("orders/status.avsc")
+	if err != nil {
+		t.Fatalf("expected status.avsc to be written: %v", err)
+	}
+	var got avro.Schema
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+	want := avro.Schema{
+		Type:      "enum",
+		Name:      "Status",
+		Namespace: "orders",
+		Doc:       "status is the lifecycle of an order.",
+		Symbols:   []string{"PENDING", "SHIPPED"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected schema\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestFromEnum(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		enums   []enum.Enum
+		cfg     config.EnumTypeConfig
+		wantErr error
+	}{
+		{
+			name:  "valid symbols",
+			enums: []enum.Enum{{Name: "low", Valid: true}, {Name: "high", Valid: true, SerdeName: "HIGH_1"}},
+		},
+		{
+			name:    "invalid symbol",
+			enums:   []enum.Enum{{Name: "low", Valid: true, Aliases: []string{"Very Low"}}},
+			wantErr: avro.ErrInvalidSymbol,
+		},
+		{
+			name:    "by value",
+			enums:   []enum.Enum{{Name: "low", Valid: true}},
+			cfg:     config.EnumTypeConfig{SerializationType: config.SerdeValue},
+			wantErr: config.ErrUnsupportedCombination,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := avro.FromEnum(enum.EnumIota{Type: "level", UnderlyingType: "int", Enums: tt.enums}, "levels", tt.cfg)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
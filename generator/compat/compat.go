@@ -0,0 +1,94 @@
+// Package compat generates the method sets of the stringer and enumer tools,
+// so packages can move to goenums without rewriting their go:generate
+// directives all at once.
+//
+// Unlike the goenums writers, which wrap each constant in a generated struct,
+// compat adds its methods to the integer type the constants are declared
+// with, exactly like the tools it replaces:
+//
+//	//go:generate goenums -compat=stringer -type=Pill
+//	//go:generate goenums -compat=enumer -type=Pill -json -trimprefix=Pill
+//
+// The Parser type-checks the package the way stringer does and the Writer
+// emits a single "<type>_string.go" or "<type>_enumer.go" file, or the file
+// named with -output.
+package compat
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"unicode"
+
+	"github.com/donutnomad/goenums/strings"
+)
+
+// The modes compat generates the method set of.
+const (
+	// ModeStringer generates the String method of golang.org/x/tools/cmd/stringer.
+	ModeStringer = "stringer"
+	// ModeEnumer generates the methods of github.com/dmarkham/enumer.
+	ModeEnumer = "enumer"
+)
+
+var (
+	// ErrUnknownMode is returned for modes other than ModeStringer and ModeEnumer.
+	ErrUnknownMode = errors.New("unknown compat mode")
+	// ErrUnknownTransform is returned for -transform values enumer does not define.
+	ErrUnknownTransform = errors.New("unknown transform")
+	// ErrTypeNotFound is returned when a requested type declares no constants.
+	ErrTypeNotFound = errors.New("no constants found for type")
+	// ErrUnsupportedType is returned for types that are not integers.
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// Modes lists the supported modes.
+func Modes() []string {
+	return []string{ModeStringer, ModeEnumer}
+}
+
+// ValidateMode returns ErrUnknownMode unless mode is one of Modes.
+func ValidateMode(mode string) error {
+	if !slices.Contains(Modes(), mode) {
+		return fmt.Errorf("%w: %q, must be one of %s", ErrUnknownMode, mode, strings.Join(Modes(), ", "))
+	}
+	return nil
+}
+
+// transforms are the -transform values of enumer, applied to the names
+// after the prefix is trimmed.
+var transforms = map[string]func(string) string{
+	"noop":        func(s string) string { return s },
+	"snake":       strings.Snake,
+	"snake-upper": func(s string) string { return strings.ToUpper(strings.Snake(s)) },
+	"kebab":       func(s string) string { return strings.ReplaceAll(strings.Snake(s), "_", "-") },
+	"kebab-upper": func(s string) string { return strings.ToUpper(strings.ReplaceAll(strings.Snake(s), "_", "-")) },
+	"lower":       strings.ToLower,
+	"upper":       strings.ToUpper,
+	"title":       strings.Camel,
+	"title-lower": strings.Lower1stCharacter,
+	"first":       func(s string) string { return firstRune(s, func(r rune) rune { return r }) },
+	"first-upper": func(s string) string { return firstRune(s, unicode.ToUpper) },
+	"first-lower": func(s string) string { return firstRune(s, unicode.ToLower) },
+	"whitespace":  func(s string) string { return strings.ReplaceAll(strings.Snake(s), "_", " ") },
+}
+
+// Transform returns the enumer -transform named name. An empty name
+// leaves the names unchanged.
+func Transform(name string) (func(string) string, error) {
+	if name == "" {
+		return transforms["noop"], nil
+	}
+	t, ok := transforms[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTransform, name)
+	}
+	return t, nil
+}
+
+func firstRune(s string, f func(rune) rune) string {
+	for _, r := range s {
+		return string(f(r))
+	}
+	return ""
+}
@@ -0,0 +1,189 @@
+package compat_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/compat"
+	"github.com/donutnomad/goenums/generator/config"
+)
+
+const pillGo = `package painkiller
+
+type Pill int
+
+const (
+	Placebo Pill = iota
+	Aspirin // aspirin
+	Ibuprofen
+	Acetaminophen
+	Paracetamol = Acetaminophen
+)
+
+type Level uint8
+
+const (
+	LevelHTTPError Level = iota + 1
+	LevelDebug
+)
+
+type Ratio float64
+
+const Half Ratio = 0.5
+
+func local() {
+	const Ignored Pill = 42
+}
+`
+
+func parse(t *testing.T, opts ...compat.ParserOption) ([]enum.GenerationRequest, error) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pill.go"), []byte(pillGo), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return compat.NewParser(append([]compat.ParserOption{compat.WithInputs(dir)}, opts...)...).Parse(t.Context())
+}
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		opts    []compat.ParserOption
+		want    string
+		wantErr error
+	}{
+		{
+			name: "constant names in value order",
+			opts: []compat.ParserOption{compat.WithTypes("Pill")},
+			want: "Placebo=0:Placebo Aspirin=1:Aspirin Ibuprofen=2:Ibuprofen Acetaminophen=3:Acetaminophen Paracetamol=3:Paracetamol",
+		},
+		{
+			name: "line comments",
+			opts: []compat.ParserOption{compat.WithTypes("Pill"), compat.WithLineComment(true)},
+			want: "Placebo=0:Placebo Aspirin=1:aspirin Ibuprofen=2:Ibuprofen Acetaminophen=3:Acetaminophen Paracetamol=3:Paracetamol",
+		},
+		{
+			name: "trimmed and transformed",
+			opts: []compat.ParserOption{compat.WithTypes("Level"), compat.WithTrimPrefix("Level"), compat.WithTransform("kebab")},
+			want: "LevelHTTPError=1:http-error LevelDebug=2:debug",
+		},
+		{
+			name:    "unknown type",
+			opts:    []compat.ParserOption{compat.WithTypes("Dose")},
+			wantErr: compat.ErrTypeNotFound,
+		},
+		{
+			name:    "not an integer",
+			opts:    []compat.ParserOption{compat.WithTypes("Ratio")},
+			wantErr: compat.ErrUnsupportedType,
+		},
+		{
+			name:    "unknown transform",
+			opts:    []compat.ParserOption{compat.WithTypes("Pill"), compat.WithTransform("shout")},
+			wantErr: compat.ErrUnknownTransform,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reqs, err := parse(t, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			var got []string
+			for _, e := range reqs[0].EnumIotas[0].Enums {
+				got = append(got, e.Name+"="+strconv.Itoa(e.Index)+":"+e.SerdeName)
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("unexpected values\n got: %s\nwant: %s", strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+	reqs, err := parse(t, compat.WithTypes("Pill", "Level"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range reqs {
+		reqs[i].SourceFilename = "painkiller/pill.go"
+		reqs[i].Configuration.Defaults.Handlers = config.Handlers{JSON: true}
+	}
+	tests := []struct {
+		name    string
+		mode    string
+		output  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:   "stringer",
+			mode:   compat.ModeStringer,
+			output: "painkiller/pill_string.go",
+			want: []string{
+				"_ = x[Paracetamol-3]",
+				"case Acetaminophen:\n\t\treturn \"Acetaminophen\"\n\tdefault:",
+				`return "Pill(" + strconv.FormatInt(int64(i), 10) + ")"`,
+				`return "Level(" + strconv.FormatUint(uint64(i), 10) + ")"`,
+			},
+			notWant: []string{"case Paracetamol:", "func PillString(", "MarshalJSON", `"encoding/json"`},
+		},
+		{
+			name:   "enumer",
+			mode:   compat.ModeEnumer,
+			output: "painkiller/pill_enumer.go",
+			want: []string{
+				"func PillString(s string) (Pill, error) {",
+				"func PillValues() []Pill {",
+				"func LevelStrings() []string {",
+				"func (i Level) IsALevel() bool {",
+				"func (i *Pill) UnmarshalJSON(data []byte) error {",
+				`"encoding/json"`,
+			},
+			notWant: []string{"case Paracetamol:", "MarshalText", "driver.Value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			if err := compat.NewWriter(compat.WithFileSystem(memfs), compat.WithMode(tt.mode)).Write(t.Context(), reqs); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := memfs.ReadFile(tt.output)
+			if err != nil {
+				t.Fatalf("expected %s to be written: %v", tt.output, err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(b), want) {
+					t.Errorf("expected output to contain %q\n%s", want, b)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(b), notWant) {
+					t.Errorf("expected output not to contain %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_UnknownMode(t *testing.T) {
+	t.Parallel()
+	err := compat.NewWriter(compat.WithFileSystem(file.NewMemFS()), compat.WithMode("gostringer")).Write(t.Context(), nil)
+	if !errors.Is(err, compat.ErrUnknownMode) {
+		t.Errorf("expected ErrUnknownMode, got %v", err)
+	}
+}
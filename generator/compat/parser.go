@@ -0,0 +1,252 @@
+package compat
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/internal/version"
+	"github.com/donutnomad/goenums/strings"
+)
+
+var _ enum.Parser = &Parser{}
+
+// Parser finds the constants of the requested types in a Go package. The
+// names they are printed as are resolved while parsing: the line comment
+// with WithLineComment, otherwise the constant name without the trimmed
+// prefix, passed through the transform.
+type Parser struct {
+	Configuration config.Configuration
+	inputs        []string
+	types         []string
+	trimPrefix    string
+	transform     string
+	lineComment   bool
+}
+
+// ParserOption is a function that configures a Parser.
+type ParserOption func(*Parser)
+
+// WithParserConfiguration sets the configuration for the parser. Its
+// build tags select the files of the package.
+func WithParserConfiguration(configuration config.Configuration) ParserOption {
+	return func(p *Parser) {
+		p.Configuration = configuration
+	}
+}
+
+// WithInputs sets the package to parse, either a single directory or a list
+// of files of one package. It defaults to the current directory.
+func WithInputs(inputs ...string) ParserOption {
+	return func(p *Parser) {
+		p.inputs = inputs
+	}
+}
+
+// WithTypes sets the names of the types whose constants are parsed.
+func WithTypes(types ...string) ParserOption {
+	return func(p *Parser) {
+		p.types = types
+	}
+}
+
+// WithTrimPrefix removes prefix from the constant names.
+func WithTrimPrefix(prefix string) ParserOption {
+	return func(p *Parser) {
+		p.trimPrefix = prefix
+	}
+}
+
+// WithTransform sets the enumer transform applied to the constant names.
+func WithTransform(transform string) ParserOption {
+	return func(p *Parser) {
+		p.transform = transform
+	}
+}
+
+// WithLineComment uses the line comment of a constant as its name.
+func WithLineComment(lineComment bool) ParserOption {
+	return func(p *Parser) {
+		p.lineComment = lineComment
+	}
+}
+
+// NewParser creates a new compat parser for the package in the current
+// directory.
+func NewParser(opts ...ParserOption) *Parser {
+	p := Parser{
+		Configuration: config.Configuration{},
+		inputs:        []string{"."},
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return &p
+}
+
+// Parse returns a single request holding one enum per requested type, with
+// the values sorted by value. Constants sharing a value are all kept; the
+// Writer names a value after the first of them.
+func (p *Parser) Parse(ctx context.Context) ([]enum.GenerationRequest, error) {
+	if len(p.types) == 0 {
+		return nil, fmt.Errorf("%w: no types given", ErrTypeNotFound)
+	}
+	transform, err := Transform(p.transform)
+	if err != nil {
+		return nil, err
+	}
+	filenames, err := p.files()
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(filenames))
+	for _, name := range filenames {
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", enum.ErrParseSource, err)
+		}
+		files = append(files, f)
+	}
+	info := &types.Info{Defs: map[*ast.Ident]types.Object{}}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// Constants are evaluated even when unrelated code does not type-check
+		Error: func(error) {},
+	}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, info)
+
+	req := enum.GenerationRequest{
+		Package:        pkg.Name(),
+		Version:        version.CURRENT,
+		SourceFilename: filenames[0],
+		Configuration:  p.Configuration,
+	}
+	for _, typeName := range p.types {
+		e, err := p.enumIota(pkg, info, files, typeName, transform)
+		if err != nil {
+			return nil, err
+		}
+		req.EnumIotas = append(req.EnumIotas, e)
+	}
+	return []enum.GenerationRequest{req}, nil
+}
+
+// files lists the Go files of the inputs, excluding tests and the files the
+// build tags leave out.
+func (p *Parser) files() ([]string, error) {
+	if len(p.inputs) == 1 && !strings.HasSuffix(p.inputs[0], ".go") {
+		bctx := build.Default
+		bctx.BuildTags = p.Configuration.BuildTags
+		bp, err := bctx.ImportDir(p.inputs[0], 0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", enum.ErrParseSource, err)
+		}
+		files := make([]string, 0, len(bp.GoFiles))
+		for _, f := range bp.GoFiles {
+			files = append(files, filepath.Join(p.inputs[0], f))
+		}
+		return files, nil
+	}
+	for _, f := range p.inputs {
+		if !strings.HasSuffix(f, ".go") {
+			return nil, fmt.Errorf("%w: %s: a list of files must only hold Go files", enum.ErrParseSource, f)
+		}
+	}
+	return p.inputs, nil
+}
+
+func (p *Parser) enumIota(pkg *types.Package, info *types.Info, files []*ast.File, typeName string,
+	transform func(string) string) (enum.EnumIota, error) {
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return enum.EnumIota{}, fmt.Errorf("%w: %s", ErrTypeNotFound, typeName)
+	}
+	basic, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return enum.EnumIota{}, fmt.Errorf("%w: %s: must be an integer type", ErrUnsupportedType, typeName)
+	}
+	comments := p.lineComments(files)
+	e := enum.EnumIota{Type: typeName, UnderlyingType: basic.Name()}
+	for id, def := range info.Defs {
+		c, ok := def.(*types.Const)
+		if !ok || c.Type() != obj.Type() || c.Parent() != pkg.Scope() || id.Name == "_" {
+			continue
+		}
+		v, exact := constant.Int64Val(c.Val())
+		if !exact {
+			return enum.EnumIota{}, fmt.Errorf("%w: %s: %s does not fit in an int64", ErrUnsupportedType, typeName, id.Name)
+		}
+		name := transform(strings.TrimPrefix(id.Name, p.trimPrefix))
+		if comment, ok := comments[id]; ok && p.lineComment {
+			name = comment
+		}
+		e.Enums = append(e.Enums, enum.Enum{
+			Name:      id.Name,
+			Index:     int(v),
+			Valid:     true,
+			SerdeName: name,
+		})
+	}
+	if len(e.Enums) == 0 {
+		return enum.EnumIota{}, fmt.Errorf("%w: %s", ErrTypeNotFound, typeName)
+	}
+	// Defs is a map; declaration order breaks ties between equal values
+	positions := constPositions(files)
+	slices.SortFunc(e.Enums, func(a, b enum.Enum) int {
+		return cmp.Or(cmp.Compare(a.Index, b.Index), cmp.Compare(positions[a.Name], positions[b.Name]))
+	})
+	return e, nil
+}
+
+// lineComments maps constant identifiers to their trimmed line comment.
+func (p *Parser) lineComments(files []*ast.File) map[*ast.Ident]string {
+	comments := map[*ast.Ident]string{}
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			vs, ok := n.(*ast.ValueSpec)
+			if !ok || vs.Comment == nil {
+				return true
+			}
+			text := strings.TrimSpace(vs.Comment.Text())
+			for _, id := range vs.Names {
+				comments[id] = text
+			}
+			return false
+		})
+	}
+	return comments
+}
+
+// constPositions numbers the package level constants in declaration order.
+func constPositions(files []*ast.File) map[string]int {
+	positions := map[string]int{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				for _, id := range spec.(*ast.ValueSpec).Names {
+					positions[id.Name] = len(positions)
+				}
+			}
+		}
+	}
+	return positions
+}
@@ -0,0 +1,364 @@
+package compat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"text/template"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/strings"
+)
+
+var _ enum.Writer = &Writer{}
+
+// ErrWriteCompat is returned when the generated methods cannot be written.
+var ErrWriteCompat = errors.New("error writing compat methods")
+
+// Writer implements enum.Writer and writes the methods of its mode for
+// every enum type in a request to a single file, next to the package the
+// types were parsed from.
+type Writer struct {
+	Configuration config.Configuration
+	fs            file.ReadCreateWriteFileFS
+	mode          string
+	output        string
+}
+
+// WriterOption is a function that configures a Writer.
+type WriterOption func(*Writer)
+
+// WithFileSystem sets the filesystem to use for writing files.
+func WithFileSystem(fs file.ReadCreateWriteFileFS) WriterOption {
+	return func(w *Writer) {
+		w.fs = fs
+	}
+}
+
+// WithWriterConfiguration sets the configuration for the writer.
+func WithWriterConfiguration(configuration config.Configuration) WriterOption {
+	return func(w *Writer) {
+		w.Configuration = configuration
+	}
+}
+
+// WithMode sets the tool whose methods are generated, ModeStringer or
+// ModeEnumer.
+func WithMode(mode string) WriterOption {
+	return func(w *Writer) {
+		w.mode = mode
+	}
+}
+
+// WithOutput sets the path of the generated file. By default it is
+// "<type>_string.go" in stringer mode and "<type>_enumer.go" in enumer
+// mode, named after the first type and written next to the package.
+func WithOutput(output string) WriterOption {
+	return func(w *Writer) {
+		w.output = output
+	}
+}
+
+// NewWriter creates a new stringer compatible writer, writing to the
+// operating system filesystem by default.
+func NewWriter(opts ...WriterOption) *Writer {
+	w := Writer{
+		Configuration: config.Configuration{},
+		fs:            &file.OSReadWriteFileFS{},
+		mode:          ModeStringer,
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return &w
+}
+
+var (
+	compatStr = `// Code generated by goenums -compat={{ .Mode }}. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- if .Imports.SQL }}
+	"database/sql/driver"
+{{- end }}
+{{- if .Imports.JSON }}
+	"encoding/json"
+{{- end }}
+{{- if .Enumer }}
+	"fmt"
+{{- end }}
+	"strconv"
+{{- if .Enumer }}
+	"strings"
+{{- end }}
+)
+{{ range .Enums }}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run goenums to generate them again.
+	var x [1]struct{}
+{{- range .All }}
+	_ = x[{{ .Name }}{{ offset .Index }}]
+{{- end }}
+}
+
+func (i {{ .Type }}) String() string {
+	switch i {
+{{- range .Values }}
+	case {{ .Name }}:
+		return {{ quote .SerdeName }}
+{{- end }}
+	default:
+{{- if .Unsigned }}
+		return "{{ .Type }}(" + strconv.FormatUint(uint64(i), 10) + ")"
+{{- else }}
+		return "{{ .Type }}(" + strconv.FormatInt(int64(i), 10) + ")"
+{{- end }}
+	}
+}
+{{- if $.Enumer }}
+
+// {{ .Type }}String retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func {{ .Type }}String(s string) ({{ .Type }}, error) {
+	switch s {
+{{- range .Names }}
+	case {{ quote .SerdeName }}:
+		return {{ .Name }}, nil
+{{- end }}
+	}
+	for _, v := range {{ .Type }}Values() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("%s does not belong to {{ .Type }} values", s)
+}
+
+// {{ .Type }}Values returns all values of the enum
+func {{ .Type }}Values() []{{ .Type }} {
+	return []{{ .Type }}{
+{{- range .Values }}
+		{{ .Name }},
+{{- end }}
+	}
+}
+
+// {{ .Type }}Strings returns a slice of all String values of the enum
+func {{ .Type }}Strings() []string {
+	return []string{
+{{- range .Values }}
+		{{ quote .SerdeName }},
+{{- end }}
+	}
+}
+
+// IsA{{ .Type }} returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i {{ .Type }}) IsA{{ .Type }}() bool {
+	switch i {
+	case {{ range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ $v.Name }}{{ end }}:
+		return true
+	}
+	return false
+}
+{{- if .Handlers.JSON }}
+
+// MarshalJSON implements the json.Marshaler interface for {{ .Type }}
+func (i {{ .Type }}) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for {{ .Type }}
+func (i *{{ .Type }}) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("{{ .Type }} should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = {{ .Type }}String(s)
+	return err
+}
+{{- end }}
+{{- if .Handlers.Text }}
+
+// MarshalText implements the encoding.TextMarshaler interface for {{ .Type }}
+func (i {{ .Type }}) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for {{ .Type }}
+func (i *{{ .Type }}) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = {{ .Type }}String(string(text))
+	return err
+}
+{{- end }}
+{{- if .Handlers.YAML }}
+
+// MarshalYAML implements a YAML Marshaler for {{ .Type }}
+func (i {{ .Type }}) MarshalYAML() (any, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for {{ .Type }}
+func (i *{{ .Type }}) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = {{ .Type }}String(s)
+	return err
+}
+{{- end }}
+{{- if .Handlers.SQL }}
+
+// Value implements the driver.Valuer interface for {{ .Type }}
+func (i {{ .Type }}) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for {{ .Type }}
+func (i *{{ .Type }}) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of {{ .Type }}: %[1]T(%[1]v)", value)
+	}
+
+	val, err := {{ .Type }}String(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
+{{- end }}
+{{- end }}
+{{ end }}`
+	compatTemplate = template.Must(template.New("compat").Funcs(template.FuncMap{
+		"quote":  strconv.Quote,
+		"offset": offset,
+	}).Parse(compatStr))
+)
+
+// offset writes the index expression subtracting value from a constant.
+func offset(value int) string {
+	if value < 0 {
+		return "+" + strconv.Itoa(-value)
+	}
+	return "-" + strconv.Itoa(value)
+}
+
+type compatData struct {
+	Mode    string
+	Package string
+	Enumer  bool
+	// Imports are the handlers of any of the types
+	Imports config.Handlers
+	Enums   []compatEnum
+}
+
+type compatEnum struct {
+	Type     string
+	Unsigned bool
+	Handlers config.Handlers
+	// All are the constants of the type, checked against their values
+	All []enum.Enum
+	// Values holds the first constant of every value, in value order
+	Values []enum.Enum
+	// Names holds the first constant of every name, which FromString returns
+	Names []enum.Enum
+}
+
+func newCompatEnum(e enum.EnumIota, handlers config.Handlers) compatEnum {
+	ce := compatEnum{
+		Type:     e.Type,
+		Unsigned: strings.HasPrefix(e.UnderlyingType, "uint") || e.UnderlyingType == "byte",
+		Handlers: handlers,
+		All:      e.Enums,
+	}
+	values := map[int]bool{}
+	names := map[string]bool{}
+	for _, v := range e.Enums {
+		if !values[v.Index] {
+			values[v.Index] = true
+			ce.Values = append(ce.Values, v)
+			if !names[v.SerdeName] {
+				names[v.SerdeName] = true
+				ce.Names = append(ce.Names, v)
+			}
+		}
+	}
+	return ce
+}
+
+// Write emits the methods of every request's enum types.
+func (w *Writer) Write(ctx context.Context, reqs []enum.GenerationRequest) error {
+	if err := ValidateMode(w.mode); err != nil {
+		return fmt.Errorf("%w: %w", ErrWriteCompat, err)
+	}
+	for _, req := range reqs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !req.IsValid() {
+			return fmt.Errorf("invalid enum: %s", req.SourceFilename)
+		}
+		data := compatData{
+			Mode:    w.mode,
+			Package: req.Package,
+			Enumer:  w.mode == ModeEnumer,
+		}
+		for _, e := range req.GetEnumIotas() {
+			h := req.Configuration.GetEnumTypeConfig(e.Type).Handlers
+			data.Imports.JSON = data.Imports.JSON || h.JSON
+			data.Imports.SQL = data.Imports.SQL || h.SQL
+			data.Enums = append(data.Enums, newCompatEnum(e, h))
+		}
+		if !data.Enumer {
+			data.Imports = config.Handlers{}
+		}
+		fullPath := filepath.Clean(w.outputPath(req))
+		err := file.WriteToFileAndFormatFS(ctx, w.fs, fullPath, true,
+			func(out io.Writer) error {
+				return compatTemplate.Execute(out, data)
+			})
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrWriteCompat, fullPath, err)
+		}
+	}
+	return nil
+}
+
+// outputPath is the file the methods of req are written to.
+func (w *Writer) outputPath(req enum.GenerationRequest) string {
+	if w.output != "" {
+		return w.output
+	}
+	suffix := "_string.go"
+	if w.mode == ModeEnumer {
+		suffix = "_enumer.go"
+	}
+	name := strings.ToLower(req.GetEnumIotas()[0].Type) + suffix
+	return filepath.Join(filepath.Dir(req.SourceFilename), name)
+}
@@ -12,6 +12,25 @@
 // system, ensuring all components respect the same settings.
 package config
 
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrUnsupportedCombination is returned when the directives of an enum type
+	// combine handlers and serialization types the generated code cannot support.
+	ErrUnsupportedCombination = errors.New("unsupported directive combination")
+	// ErrUnknownDirective is returned for directives that are not recognised.
+	ErrUnknownDirective = errors.New("unknown enum args")
+)
+
 // SerializationType defines the type of serialization/deserialization to use
 type SerializationType int
 
@@ -20,6 +39,9 @@ const (
 	SerdeName SerializationType = iota
 	// SerdeValue uses the underlying value type (int, float, etc.)
 	SerdeValue
+	// SerdeObject writes JSON objects holding the name, value, validity and
+	// fields of values, and uses names for the other formats
+	SerdeObject
 )
 
 // EnumTypeConfig holds configuration for a specific enum type
@@ -46,6 +68,691 @@ type EnumTypeConfig struct {
 	// StateMachine enables state machine functionality for this enum type
 	// When true, generates state transition validation methods
 	StateMachine bool
+
+	// StateHistory generates a <Type>History type recording the transitions
+	// of the state machine for auditing. It requires StateMachine.
+	StateHistory bool
+
+	// Suggest enables "did you mean" suggestions on parse failures.
+	// When true, a table of valid names is generated and the closest one
+	// is reported in the parse error for near-miss string inputs.
+	Suggest bool
+
+	// Stringer selects how String looks names up, one of the Stringer
+	// constants. It defaults to StringerMap.
+	Stringer string
+
+	// SQLType forces the driver value Value emits, and Scan reads, to one of
+	// the SQLType constants whatever the serialization type, to match an
+	// existing column. It defaults to following SerializationType.
+	SQLType string
+
+	// BinaryEncoding selects the encoding of -binary as a comma-separated
+	// list of the Binary constants, such as "varint,length-prefixed". It
+	// defaults to big-endian fixed-width values and unprefixed names.
+	BinaryEncoding string
+
+	// Match generates a Match function taking one case per value, as an
+	// exhaustive alternative to switch statements.
+	Match bool
+
+	// Registry registers the enum type in the EnumRegistry of its package,
+	// for tooling that lists the enums of a program at runtime.
+	Registry bool
+
+	// SchemaHash generates a constant fingerprinting the names and values
+	// of the enum, for binaries to check they agree on them.
+	SchemaHash bool
+
+	// FieldAccessors generates getters for the fields of the enum, lookups
+	// of values by field and iterators over the field values.
+	FieldAccessors bool
+
+	// JSONNull marshals the values IsZero reports, the invalid ones, as JSON
+	// null with -json, and leaves values unchanged when unmarshaling null.
+	JSONNull bool
+
+	// DefaultOnError makes the generated unmarshalers store the value named
+	// by the "default:" line of the type documentation instead of returning
+	// an error for invalid input.
+	DefaultOnError bool
+
+	// Atomic generates an Atomic<Type> holder, for values loaded and stored
+	// concurrently without locks.
+	Atomic bool
+
+	// Prometheus generates the label values of the enum and a function
+	// creating the series of every value in a metric vector.
+	Prometheus bool
+
+	// MigrationTable and MigrationColumn identify the column constrained by
+	// generated migrations. They default to the pluralised and singular
+	// snake_case forms of the type name when empty.
+	MigrationTable  string
+	MigrationColumn string
+
+	// MigrationStyle selects how generated migrations constrain the column.
+	MigrationStyle MigrationStyle
+
+	// ZarldevCompat generates deprecated aliases for the API generated by
+	// upstream github.com/zarldev/goenums: uppercase container fields and
+	// the Exhaustive function, so call sites compile while they migrate.
+	ZarldevCompat bool
+
+	// Proto names the generated protobuf enum, as "pkg.EnumName", that the
+	// enum is converted to and from. ProtoPrefix overrides the prefix of
+	// its value constants, "EnumName_ENUM_NAME_" by default.
+	Proto       string
+	ProtoPrefix string
+
+	// FloatEpsilon is the tolerance FromValue compares the values of float
+	// enums with, so that inputs such as 2.5000001 parse as 2.5. Values are
+	// compared exactly when it is zero.
+	FloatEpsilon float64
+
+	// FloatFormat is the fmt verb, such as "%.2f", the ValString method of
+	// float enums formats their value with, which MarshalJSON and MarshalText
+	// write when serializing by value. It defaults to the shortest
+	// representation.
+	FloatFormat string
+
+	// InvalidName exposes the invalid sentinel as an exported member of the
+	// container with this name, which String returns for it. The sentinel
+	// is then distinct from every constant, including one with the zero
+	// value, which otherwise compares equal to it.
+	InvalidName string
+
+	// InvalidInAll makes All yield the named invalid sentinel before the
+	// enum values.
+	InvalidInAll bool
+
+	// WrapperName overrides the name of the generated wrapper type, and
+	// with it the names derived from it such as Parse<Type>. Receiver
+	// overrides the receiver of the generated methods.
+	WrapperName string
+	Receiver    string
+
+	// Plural overrides the name of the generated container, which
+	// otherwise is the plural of the type name.
+	Plural string
+
+	// Order is the order of the values in All, the container literal and
+	// the names maps, one of the Order constants. It defaults to
+	// OrderSource.
+	Order string
+}
+
+// ApplyDirectives returns c with the "// goenums:" directives applied, such
+// as "-json" or "-serde/value".
+func (c EnumTypeConfig) ApplyDirectives(directives []string) (EnumTypeConfig, error) {
+	for _, directive := range directives {
+		if name, value, ok := strings.Cut(directive, "="); ok {
+			// -binary also accepts an encoding, which enables it
+			if _, err := strconv.ParseBool(value); err != nil && name == "-binary" {
+				if err := validateBinaryEncoding(value); err != nil {
+					return c, err
+				}
+				c.Handlers.Binary = true
+				c.BinaryEncoding = value
+				continue
+			}
+			// Boolean directives accept an explicit value like their flags,
+			// so that a default can be switched off for a single type
+			if flag, isBool := c.boolDirectives()[name]; isBool {
+				enabled, err := strconv.ParseBool(value)
+				if err != nil {
+					return c, fmt.Errorf("%w: invalid value for %s: %s", ErrUnknownDirective, name, value)
+				}
+				*flag = enabled
+				continue
+			}
+		}
+		switch directive {
+		case "-json":
+			c.Handlers.JSON = true
+		case "-json/null":
+			c.JSONNull = true
+		case "-yaml":
+			c.Handlers.YAML = true
+		case "-text":
+			c.Handlers.Text = true
+		case "-binary":
+			c.Handlers.Binary = true
+		case "-sql":
+			c.Handlers.SQL = true
+		case "-sql/array":
+			c.Handlers.SQLArray = true
+		case "-sql/column":
+			c.Handlers.SQLColumn = true
+		case "-sqlboiler":
+			c.Handlers.SQLBoiler = true
+		case "-bun":
+			c.Handlers.Bun = true
+		case "-avro":
+			c.Handlers.Avro = true
+		case "-mapstructure":
+			c.Handlers.Mapstructure = true
+		case "-http":
+			c.Handlers.HTTP = true
+		case "-redis":
+			c.Handlers.Redis = true
+		case "-kafka":
+			c.Handlers.Kafka = true
+		case "-uppercaseFields":
+			c.UppercaseFields = true
+		case "-genName":
+			c.GenerateNameConstants = true
+		case "-serde/name":
+			c.SerializationType = SerdeName
+		case "-serde/value":
+			c.SerializationType = SerdeValue
+		case "-serde/object":
+			c.SerializationType = SerdeObject
+		case "-statemachine":
+			c.StateMachine = true
+		case "-statemachine/history":
+			c.StateHistory = true
+		case "-suggest":
+			c.Suggest = true
+		case "-match":
+			c.Match = true
+		case "-registry":
+			c.Registry = true
+		case "-schemahash":
+			c.SchemaHash = true
+		case "-fields":
+			c.FieldAccessors = true
+		case "-default-on-error":
+			c.DefaultOnError = true
+		case "-atomic":
+			c.Atomic = true
+		case "-prometheus":
+			c.Prometheus = true
+		case "-migrate/check":
+			c.MigrationStyle = MigrationCheck
+		case "-migrate/enum":
+			c.MigrationStyle = MigrationNativeEnum
+		case "-compat/zarldev":
+			c.ZarldevCompat = true
+		case "-invalid/all":
+			c.InvalidInAll = true
+		default:
+			if value, ok := strings.CutPrefix(directive, "-migrate/table="); ok {
+				c.MigrationTable = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-migrate/column="); ok {
+				c.MigrationColumn = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-proto="); ok {
+				if pkg, name, ok := strings.Cut(value, "."); !ok || pkg == "" || name == "" || strings.Contains(name, ".") {
+					return c, fmt.Errorf("%w: invalid value for -proto, want pkg.EnumName: %s", ErrUnknownDirective, value)
+				}
+				c.Proto = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-stringer="); ok {
+				if value != StringerMap && value != StringerSwitch {
+					return c, fmt.Errorf("%w: invalid value for -stringer, want %s or %s: %s",
+						ErrUnknownDirective, StringerMap, StringerSwitch, value)
+				}
+				c.Stringer = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-order="); ok {
+				if value != OrderSource && value != OrderValue && value != OrderName {
+					return c, fmt.Errorf("%w: invalid value for -order, want %s, %s or %s: %s",
+						ErrUnknownDirective, OrderSource, OrderValue, OrderName, value)
+				}
+				c.Order = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-sqltype="); ok {
+				if value != SQLTypeString && value != SQLTypeInt && value != SQLTypeBool {
+					return c, fmt.Errorf("%w: invalid value for -sqltype, want %s, %s or %s: %s",
+						ErrUnknownDirective, SQLTypeString, SQLTypeInt, SQLTypeBool, value)
+				}
+				c.SQLType = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-proto/prefix="); ok {
+				c.ProtoPrefix = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-float/epsilon="); ok {
+				epsilon, err := strconv.ParseFloat(value, 64)
+				if err != nil || epsilon <= 0 {
+					return c, fmt.Errorf("%w: invalid value for -float/epsilon, want a positive number: %s",
+						ErrUnknownDirective, value)
+				}
+				c.FloatEpsilon = epsilon
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-float/format="); ok {
+				if !floatFormat.MatchString(value) {
+					return c, fmt.Errorf("%w: invalid value for -float/format, want a verb such as %%.2f, %%e or %%g: %s",
+						ErrUnknownDirective, value)
+				}
+				c.FloatFormat = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-wrapper="); ok {
+				if !token.IsIdentifier(value) || !token.IsExported(value) {
+					return c, fmt.Errorf("%w: invalid value for -wrapper, want an exported identifier: %s",
+						ErrUnknownDirective, value)
+				}
+				c.WrapperName = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-plural="); ok {
+				if !token.IsIdentifier(value) || !token.IsExported(value) {
+					return c, fmt.Errorf("%w: invalid value for -plural, want an exported identifier: %s",
+						ErrUnknownDirective, value)
+				}
+				c.Plural = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-receiver="); ok {
+				if !token.IsIdentifier(value) || value == "_" {
+					return c, fmt.Errorf("%w: invalid value for -receiver, want an identifier: %s",
+						ErrUnknownDirective, value)
+				}
+				c.Receiver = value
+				continue
+			}
+			if value, ok := strings.CutPrefix(directive, "-invalid="); ok {
+				if !token.IsIdentifier(value) || !token.IsExported(value) {
+					return c, fmt.Errorf("%w: invalid value for -invalid, want an exported identifier: %s",
+						ErrUnknownDirective, value)
+				}
+				c.InvalidName = value
+				continue
+			}
+			return c, fmt.Errorf("%w: %s", ErrUnknownDirective, directive)
+		}
+	}
+	return c, nil
+}
+
+// floatFormat matches the fmt verbs of -float/format, which all write valid
+// JSON numbers.
+var floatFormat = regexp.MustCompile(`^%[0-9]*(\.[0-9]+)?[eEfFgG]$`)
+
+// validateBinaryEncoding reports an error unless value is a comma-separated
+// list of the Binary encodings.
+func validateBinaryEncoding(value string) error {
+	for encoding := range strings.SplitSeq(value, ",") {
+		switch encoding {
+		case BinaryVarint, BinaryLittleEndian, BinaryLengthPrefixed:
+		default:
+			return fmt.Errorf("%w: invalid value for -binary, want true, false or a comma-separated list of %s, %s and %s: %s",
+				ErrUnknownDirective, BinaryVarint, BinaryLittleEndian, BinaryLengthPrefixed, value)
+		}
+	}
+	return nil
+}
+
+// boolDirectives maps the boolean directives to the fields of c they set.
+func (c *EnumTypeConfig) boolDirectives() map[string]*bool {
+	return map[string]*bool{
+		"-json":                 &c.Handlers.JSON,
+		"-json/null":            &c.JSONNull,
+		"-yaml":                 &c.Handlers.YAML,
+		"-text":                 &c.Handlers.Text,
+		"-binary":               &c.Handlers.Binary,
+		"-sql":                  &c.Handlers.SQL,
+		"-sql/array":            &c.Handlers.SQLArray,
+		"-sql/column":           &c.Handlers.SQLColumn,
+		"-sqlboiler":            &c.Handlers.SQLBoiler,
+		"-bun":                  &c.Handlers.Bun,
+		"-avro":                 &c.Handlers.Avro,
+		"-mapstructure":         &c.Handlers.Mapstructure,
+		"-http":                 &c.Handlers.HTTP,
+		"-redis":                &c.Handlers.Redis,
+		"-kafka":                &c.Handlers.Kafka,
+		"-uppercaseFields":      &c.UppercaseFields,
+		"-genName":              &c.GenerateNameConstants,
+		"-statemachine":         &c.StateMachine,
+		"-statemachine/history": &c.StateHistory,
+		"-suggest":              &c.Suggest,
+		"-match":                &c.Match,
+		"-registry":             &c.Registry,
+		"-schemahash":           &c.SchemaHash,
+		"-fields":               &c.FieldAccessors,
+		"-default-on-error":     &c.DefaultOnError,
+		"-atomic":               &c.Atomic,
+		"-prometheus":           &c.Prometheus,
+		"-compat/zarldev":       &c.ZarldevCompat,
+		"-invalid/all":          &c.InvalidInAll,
+	}
+}
+
+// Directives returns the "// goenums:" directives that reproduce c, in the
+// order they are documented. Type-specific settings such as the migration
+// table and column, or the wrapper and receiver names, are not included.
+func (c EnumTypeConfig) Directives() []string {
+	var args []string
+	for _, d := range []struct {
+		name string
+		set  bool
+	}{
+		{"-json", c.Handlers.JSON},
+		{"-json/null", c.JSONNull},
+		{"-yaml", c.Handlers.YAML},
+		{"-text", c.Handlers.Text},
+		{"-binary", c.Handlers.Binary},
+		{"-sql", c.Handlers.SQL},
+		{"-sql/array", c.Handlers.SQLArray},
+		{"-sql/column", c.Handlers.SQLColumn},
+		{"-sqlboiler", c.Handlers.SQLBoiler},
+		{"-bun", c.Handlers.Bun},
+		{"-avro", c.Handlers.Avro},
+		{"-mapstructure", c.Handlers.Mapstructure},
+		{"-http", c.Handlers.HTTP},
+		{"-redis", c.Handlers.Redis},
+		{"-kafka", c.Handlers.Kafka},
+		{"-serde/value", c.SerializationType == SerdeValue},
+		{"-serde/object", c.SerializationType == SerdeObject},
+		{"-genName", c.GenerateNameConstants},
+		{"-uppercaseFields", c.UppercaseFields},
+		{"-statemachine", c.StateMachine},
+		{"-statemachine/history", c.StateHistory},
+		{"-suggest", c.Suggest},
+		{"-match", c.Match},
+		{"-registry", c.Registry},
+		{"-schemahash", c.SchemaHash},
+		{"-fields", c.FieldAccessors},
+		{"-default-on-error", c.DefaultOnError},
+		{"-atomic", c.Atomic},
+		{"-prometheus", c.Prometheus},
+		{"-binary=" + c.BinaryEncoding, c.BinaryEncoding != ""},
+		{"-stringer=switch", c.Stringer == StringerSwitch},
+		{"-order=" + c.Order, c.Order != "" && c.Order != OrderSource},
+		{"-sqltype=" + c.SQLType, c.SQLType != ""},
+		{"-float/epsilon=" + strconv.FormatFloat(c.FloatEpsilon, 'g', -1, 64), c.FloatEpsilon != 0},
+		{"-float/format=" + c.FloatFormat, c.FloatFormat != ""},
+		{"-migrate/enum", c.MigrationStyle == MigrationNativeEnum},
+		{"-compat/zarldev", c.ZarldevCompat},
+		{"-invalid=" + c.InvalidName, c.InvalidName != ""},
+		{"-invalid/all", c.InvalidInAll},
+	} {
+		if d.set {
+			args = append(args, d.name)
+		}
+	}
+	return args
+}
+
+// Validate reports an error when c combines handlers and a serialization
+// type that cannot work for an enum with the given underlying type, rather
+// than letting the generated code fail at runtime.
+func (c EnumTypeConfig) Validate(underlyingType string) error {
+	// Avro enums only have symbols
+	if c.SerializationType == SerdeValue && c.Handlers.Avro {
+		return fmt.Errorf("%w: %s: -serde/value cannot be combined with -avro, which serializes by name",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	if c.SerializationType == SerdeValue && c.Handlers.Kafka {
+		return fmt.Errorf("%w: %s: -serde/value cannot be combined with -kafka, whose schemas list names",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	if c.SerializationType == SerdeObject && !c.Handlers.JSON {
+		return fmt.Errorf("%w: %s: -serde/object requires -json",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	// The column definition is built from the values Value returns
+	if c.Handlers.SQLColumn && !c.Handlers.SQL {
+		return fmt.Errorf("%w: %s: -sql/column requires -sql",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	// The ORMs store values with Scan and Value
+	if c.Handlers.SQLBoiler && !c.Handlers.SQL {
+		return fmt.Errorf("%w: %s: -sqlboiler requires -sql",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	if c.Handlers.Bun && !c.Handlers.SQL {
+		return fmt.Errorf("%w: %s: -bun requires -sql",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	if c.StateHistory && !c.StateMachine {
+		return fmt.Errorf("%w: %s: -statemachine/history requires -statemachine",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	if c.JSONNull && !c.Handlers.JSON {
+		return fmt.Errorf("%w: %s: -json/null requires -json",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	if c.InvalidInAll && c.InvalidName == "" {
+		return fmt.Errorf("%w: %s: -invalid/all requires -invalid",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	if err := c.validateSQLType(underlyingType); err != nil {
+		return err
+	}
+	if underlyingType != "float32" && underlyingType != "float64" {
+		if c.FloatEpsilon != 0 {
+			return fmt.Errorf("%w: %s: -float/epsilon requires a float underlying type, got %s",
+				ErrUnsupportedCombination, c.TypeName, underlyingType)
+		}
+		if c.FloatFormat != "" {
+			return fmt.Errorf("%w: %s: -float/format requires a float underlying type, got %s",
+				ErrUnsupportedCombination, c.TypeName, underlyingType)
+		}
+	}
+	if c.SerializationType != SerdeValue || underlyingType != "string" {
+		return nil
+	}
+	// String values would be written to JSON unquoted
+	if c.Handlers.JSON {
+		return fmt.Errorf("%w: %s: -serde/value with -json requires a numeric underlying type, got string",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	// The binary form of a string value is the same as its name
+	if c.Handlers.Binary {
+		return fmt.Errorf("%w: %s: -serde/value with -binary requires a numeric underlying type, got string",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	return nil
+}
+
+// validateSQLType reports an error unless the SQLType of c, if any, can
+// hold the values of an enum with the given underlying type.
+func (c EnumTypeConfig) validateSQLType(underlyingType string) error {
+	switch c.SQLType {
+	case "":
+		return nil
+	case SQLTypeString:
+	case SQLTypeInt:
+		switch underlyingType {
+		case "int", "int8", "int16", "int32", "int64", "rune",
+			"uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		default:
+			return fmt.Errorf("%w: %s: -sqltype=int requires an integer underlying type, got %s",
+				ErrUnsupportedCombination, c.TypeName, underlyingType)
+		}
+	case SQLTypeBool:
+		if underlyingType != "bool" {
+			return fmt.Errorf("%w: %s: -sqltype=bool requires a bool underlying type, got %s",
+				ErrUnsupportedCombination, c.TypeName, underlyingType)
+		}
+	default:
+		return fmt.Errorf("%w: %s: invalid -sqltype, want %s, %s or %s: %s",
+			ErrUnsupportedCombination, c.TypeName, SQLTypeString, SQLTypeInt, SQLTypeBool, c.SQLType)
+	}
+	if !c.Handlers.SQL {
+		return fmt.Errorf("%w: %s: -sqltype requires -sql",
+			ErrUnsupportedCombination, c.TypeName)
+	}
+	return nil
+}
+
+// MigrationStyle defines how enum values are enforced in the database
+type MigrationStyle int
+
+const (
+	// MigrationCheck enforces values with a CHECK constraint (default)
+	MigrationCheck MigrationStyle = iota
+	// MigrationNativeEnum enforces values with a PostgreSQL native enum type
+	MigrationNativeEnum
+)
+
+const (
+	// MigrationFormatGolangMigrate writes paired up/down SQL files for golang-migrate
+	MigrationFormatGolangMigrate = "golang-migrate"
+	// MigrationFormatLiquibase writes XML changelogs for Liquibase
+	MigrationFormatLiquibase = "liquibase"
+)
+
+const (
+	// DiagramMermaid writes Mermaid state diagrams
+	DiagramMermaid = "mermaid"
+	// DiagramDot writes Graphviz digraphs
+	DiagramDot = "dot"
+)
+
+const (
+	// YAMLLibraryV3 implements the gopkg.in/yaml.v3 Marshaler and Unmarshaler interfaces (default)
+	YAMLLibraryV3 = "yaml.v3"
+	// YAMLLibraryGoccy implements the function-based interfaces of github.com/goccy/go-yaml,
+	// which gopkg.in/yaml.v2 shares; the generated code imports no YAML package
+	YAMLLibraryGoccy = "goccy"
+	// YAMLLibrarySigs implements the JSON methods following the YAML rules,
+	// as sigs.k8s.io/yaml converts YAML to JSON
+	YAMLLibrarySigs = "sigs.k8s.io"
+	// YAMLLibraryText relies on encoding.TextMarshaler, which most YAML libraries honor for scalars
+	YAMLLibraryText = "text"
+)
+
+// Ways the generated String method looks names up.
+const (
+	// StringerMap looks names up in a map of slices of a names constant
+	StringerMap = "map"
+	// StringerSwitch returns names from a switch statement like the stringer
+	// tool, without a map
+	StringerSwitch = "switch"
+)
+
+// Orders of the values of -order.
+const (
+	// OrderSource keeps the values in the order they are declared (default)
+	OrderSource = "source"
+	// OrderValue sorts the values by their underlying value
+	OrderValue = "value"
+	// OrderName sorts the values by the name String returns
+	OrderName = "name"
+)
+
+// Driver values of -sqltype.
+const (
+	// SQLTypeString stores values by name
+	SQLTypeString = "string"
+	// SQLTypeInt stores integer values as int64
+	SQLTypeInt = "int"
+	// SQLTypeBool stores bool values as bool
+	SQLTypeBool = "bool"
+)
+
+// Encodings of -binary, combined in BinaryEncoding.
+const (
+	// BinaryVarint encodes integer values as varints
+	BinaryVarint = "varint"
+	// BinaryLittleEndian encodes fixed-width values in little-endian order
+	BinaryLittleEndian = "little-endian"
+	// BinaryLengthPrefixed prefixes names and string values with their length
+	BinaryLengthPrefixed = "length-prefixed"
+)
+
+// Sections of the code generated for each enum type. Their names are used
+// to configure the order in which they are written and which are skipped.
+const (
+	// SectionWrapper is the wrapper type and its Enum interface assertion
+	SectionWrapper = "wrapper"
+	// SectionRawType is the Raw type alias of the underlying enum type
+	SectionRawType = "raw"
+	// SectionContainer is the container type and the variable holding all values
+	SectionContainer = "container"
+	// SectionInvalid is the invalid sentinel value
+	SectionInvalid = "invalid"
+	// SectionAll is the allSlice method of the container
+	SectionAll = "all"
+	// SectionValidation is the validity map and the IsValid method
+	SectionValidation = "validation"
+	// SectionString is the names map and the String method
+	SectionString = "string"
+	// SectionParse is the parse errors and the Parse functions
+	SectionParse = "parse"
+	// SectionEnum is the remaining methods of the enums.Enum interface
+	SectionEnum = "enum"
+	// SectionSerde is the serialization methods
+	SectionSerde = "serde"
+	// SectionConvenience is the convenience methods of the container
+	SectionConvenience = "convenience"
+	// SectionCompileCheck is the compile-time check of the constant values
+	SectionCompileCheck = "compilecheck"
+	// SectionStateMachine is the state machine methods, written with -statemachine
+	SectionStateMachine = "statemachine"
+	// SectionCompat is the upstream zarldev/goenums API, written with -compat/zarldev
+	SectionCompat = "compat"
+	// SectionMatch is the cases type and the Match function, written with -match
+	SectionMatch = "match"
+	// SectionRegistry is the registration in the EnumRegistry, written with -registry
+	SectionRegistry = "registry"
+	// SectionSchemaHash is the schema fingerprint constant, written with -schemahash
+	SectionSchemaHash = "schemahash"
+	// SectionFields is the field getters, lookups and iterators, written with -fields
+	SectionFields = "fields"
+	// SectionGroups is the group lists and predicates, written for values
+	// annotated with "group:"
+	SectionGroups = "groups"
+	// SectionTags is the tags map, HasTag and FilterByTag, written for values
+	// annotated with "tags:"
+	SectionTags = "tags"
+	// SectionAtomic is the Atomic<Type> holder, written with -atomic
+	SectionAtomic = "atomic"
+	// SectionPrometheus is the metric label values and initialisation,
+	// written with -prometheus
+	SectionPrometheus = "prometheus"
+)
+
+// DefaultSectionOrder is the order in which the sections of each enum type
+// are written unless configured otherwise. It is part of the generated
+// file's contract: sections added in later versions are appended to it.
+var DefaultSectionOrder = []string{
+	SectionWrapper,
+	SectionRawType,
+	SectionContainer,
+	SectionInvalid,
+	SectionAll,
+	SectionValidation,
+	SectionString,
+	SectionParse,
+	SectionEnum,
+	SectionSerde,
+	SectionConvenience,
+	SectionCompileCheck,
+	SectionStateMachine,
+	SectionCompat,
+	SectionMatch,
+	SectionRegistry,
+	SectionSchemaHash,
+	SectionFields,
+	SectionGroups,
+	SectionTags,
+	SectionAtomic,
+	SectionPrometheus,
+}
+
+// RequiredSections are the sections every other depends on, which are
+// written whatever sections are skipped.
+var RequiredSections = []string{
+	SectionWrapper,
+	SectionContainer,
+	SectionInvalid,
+	SectionAll,
+	SectionValidation,
+	SectionString,
+	SectionEnum,
 }
 
 // Configuration holds all the settings that control enum generation behavior.
@@ -71,15 +778,86 @@ type Configuration struct {
 	// be logged, which is useful for debugging.
 	Verbose bool
 
+	// LogLevel is the minimum level of the logs written. The zero value is
+	// info; -verbose lowers it to debug and -quiet raises it to error.
+	LogLevel slog.Level
+
+	// LogFormat is the format logs are written in: "text", the default, or
+	// "json".
+	LogFormat string
+
 	// OutputFormat is the format of the output file.
 	OutputFormat string
 
 	// Filenames is the list of paths provided to the reader
 	Filenames []string
 
+	// Stdout writes the generated Go code to standard output instead of a
+	// file next to the source.
+	Stdout bool
+
+	// Jobs is the maximum number of files generated concurrently.
+	// When zero, it defaults to GOMAXPROCS.
+	Jobs int
+
+	// CPUProfile, MemProfile and Trace are the files a CPU profile, a memory
+	// profile and an execution trace of the run are written to. When empty,
+	// they are not written.
+	CPUProfile string
+	MemProfile string
+	Trace      string
+
 	// Constraints is the flag to generate the constraints or not
 	Constraints bool
 
+	// MigrationsDir is the directory incremental enum migrations are written to.
+	// When empty, no migrations are generated.
+	MigrationsDir string
+
+	// MigrationFormat is the layout of generated migrations, either
+	// MigrationFormatGolangMigrate (default) or MigrationFormatLiquibase.
+	MigrationFormat string
+
+	// Diagrams are the formats, DiagramMermaid or DiagramDot, the state
+	// machines of enum types generated with -statemachine are drawn in.
+	// When empty, no diagrams are written.
+	Diagrams []string
+
+	// Plugins are the commands of the exec plugins appending their code to
+	// every generated Go file, each a program followed by its arguments.
+	Plugins []string
+
+	// YAMLLibrary selects the YAML library the generated YAML methods target,
+	// one of the YAMLLibrary constants. It defaults to YAMLLibraryV3.
+	YAMLLibrary string
+
+	// SectionOrder lists the sections of each enum type in the order they are
+	// written. Sections it leaves out follow in DefaultSectionOrder.
+	SectionOrder []string
+
+	// SkipSections lists sections of each enum type that are not written.
+	// When OnlySections is set, the optional sections it leaves out are not
+	// written either. RequiredSections are always written.
+	SkipSections []string
+	OnlySections []string
+
+	// Plurals maps lowercase words to their plurals. When the last word of
+	// an enum type name is one of them, it decides the plural naming the
+	// container and the singular naming the wrapper, instead of the rules
+	// of strings.Pluralise and strings.Singularise.
+	Plurals map[string]string
+
+	// BuildTags are the build tags generation targets. They select the files
+	// loaded when evaluating constants, and the generated file is guarded by
+	// them so it only compiles in the matching builds.
+	BuildTags []string
+
+	// Defaults is the configuration every enum type starts from. Directives
+	// in a "// goenums:" comment are applied on top of it, and types without
+	// a directive use it as is. It is populated from the command line flags
+	// that mirror the directives.
+	Defaults EnumTypeConfig
+
 	// Handlers defines the behavior of the enum generation process.
 	// DEPRECATED: Use EnumTypeConfigs instead for per-type configuration
 	Handlers Handlers
@@ -90,18 +868,53 @@ type Configuration struct {
 }
 
 // GetEnumTypeConfig returns the configuration for a specific enum type
-// Falls back to global configuration if no specific config is found
+// Falls back to the defaults if no specific config is found
 func (c *Configuration) GetEnumTypeConfig(typeName string) EnumTypeConfig {
 	if config, exists := c.EnumTypeConfigs[typeName]; exists {
 		return config
 	}
 
-	// Fallback to global configuration for backward compatibility
-	return EnumTypeConfig{
-		TypeName:          typeName,
-		SerializationType: SerdeName,  // Default to name serialization
-		Handlers:          c.Handlers, // Use global handlers configuration
+	config := c.Defaults
+	config.TypeName = typeName
+	if config.Handlers == (Handlers{}) {
+		// Fallback to global handlers for backward compatibility
+		config.Handlers = c.Handlers
+	}
+	return config
+}
+
+// SplitList splits a comma-separated list of flag or directive values,
+// such as "json,go", trimming the items and leaving out empty ones.
+func SplitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
+}
+
+// ParsePlurals parses the -plurals flag, a comma-separated list of
+// singular:plural words such as "schema:schemata,status:statii", into the
+// Plurals of a Configuration.
+func ParsePlurals(list string) (map[string]string, error) {
+	plurals := make(map[string]string)
+	for _, pair := range SplitList(list) {
+		singular, plural, ok := strings.Cut(pair, ":")
+		singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+		if !ok || !isWord(singular) || !isWord(plural) {
+			return nil, fmt.Errorf("%w: invalid value for -plurals, want singular:plural words: %s",
+				ErrUnknownDirective, pair)
+		}
+		plurals[singular] = plural
+	}
+	return plurals, nil
+}
+
+// isWord reports whether s is a non-empty run of letters.
+func isWord(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }) < 0
 }
 
 type Handlers struct {
@@ -110,4 +923,30 @@ type Handlers struct {
 	YAML   bool
 	SQL    bool
 	Binary bool
+	// SQLArray generates a slice type stored in Postgres array columns
+	SQLArray bool
+	// SQLColumn generates the definition of the column storing the enum
+	// and a check of the values the database accepts in it
+	SQLColumn bool
+	// SQLBoiler generates the nullable type and the Randomize methods
+	// sqlboiler models and tests use
+	SQLBoiler bool
+	// Bun generates the SQL literal of values, for bun.Safe
+	Bun bool
+	// Avro generates the Avro schema of the enum and the text methods
+	// Avro libraries encode enums with
+	Avro bool
+	// Mapstructure generates a mapstructure decode hook, for loading
+	// configuration files with viper
+	Mapstructure bool
+	// HTTP generates a helper parsing the enum from query strings and
+	// forms, and the methods Gin and Echo bind parameters with
+	HTTP bool
+	// Redis generates the redigo argument and scanner methods, and the
+	// binary methods go-redis stores values with, writing the text form
+	Redis bool
+	// Kafka generates the Avro and JSON schemas registered for the enum in
+	// a Confluent Schema Registry, and a serializer of the values in the
+	// Confluent wire format for confluent-kafka-go
+	Kafka bool
 }
@@ -0,0 +1,217 @@
+// Package diagram draws the state machines of enum types.
+//
+// The Writer emits "<enum>.mmd" Mermaid state diagrams and "<enum>.dot"
+// Graphviz digraphs for the enum types generated with -statemachine, next
+// to the source they were parsed from, so architecture docs show the
+// transitions the generated code enforces rather than a hand-drawn copy.
+package diagram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/internal/naming"
+	gostrings "github.com/donutnomad/goenums/strings"
+)
+
+var _ enum.Writer = &Writer{}
+
+var (
+	// ErrWriteDiagram is returned when a diagram cannot be written.
+	ErrWriteDiagram = errors.New("error writing diagram")
+	// ErrUnsupportedFormat is returned for unknown diagram formats.
+	ErrUnsupportedFormat = errors.New("unsupported diagram format")
+)
+
+// extensions are the file extensions of the diagram formats.
+var extensions = map[string]string{
+	config.DiagramMermaid: ".mmd",
+	config.DiagramDot:     ".dot",
+}
+
+// edge is a transition of a state machine.
+type edge struct {
+	from, to enum.Enum
+	event    string
+}
+
+// edges returns the transitions of enumIota in declaration order.
+func edges(enumIota enum.EnumIota) ([]edge, error) {
+	var es []edge
+	for _, e := range enumIota.Enums {
+		for i, t := range e.StateTransitions {
+			to, ok := enumIota.State(t)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s -> %s", enum.ErrUnknownState, e.Name, t)
+			}
+			ed := edge{from: e, to: to}
+			if i < len(e.StateEvents) {
+				ed.event = e.StateEvents[i]
+			}
+			es = append(es, ed)
+		}
+	}
+	return es, nil
+}
+
+// Mermaid returns the Mermaid state diagram of enumIota. It starts at the
+// first valid value and ends at the final states; states are labelled as
+// String returns them and transitions with their event.
+func Mermaid(enumIota enum.EnumIota) (string, error) {
+	es, err := edges(enumIota)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	initial, invalid := true, false
+	for _, e := range enumIota.Enums {
+		fmt.Fprintf(&b, "    state %q as %s\n", naming.DisplayName(e), e.Name)
+		if !e.Valid {
+			fmt.Fprintf(&b, "    class %s invalid\n", e.Name)
+			invalid = true
+			continue
+		}
+		if initial {
+			fmt.Fprintf(&b, "    [*] --> %s\n", e.Name)
+			initial = false
+		}
+	}
+	for _, ed := range es {
+		fmt.Fprintf(&b, "    %s --> %s", ed.from.Name, ed.to.Name)
+		if ed.event != "" {
+			fmt.Fprintf(&b, ": %s", ed.event)
+		}
+		b.WriteString("\n")
+	}
+	for _, e := range enumIota.Enums {
+		if e.IsFinalState {
+			fmt.Fprintf(&b, "    %s --> [*]\n", e.Name)
+		}
+	}
+	if invalid {
+		b.WriteString("    classDef invalid stroke-dasharray: 5 5\n")
+	}
+	return b.String(), nil
+}
+
+// Dot returns the Graphviz digraph of enumIota. A point leads to the first
+// valid value, final states are double circles and invalid values are
+// dashed; states are labelled as String returns them and transitions with
+// their event.
+func Dot(enumIota enum.EnumIota) (string, error) {
+	es, err := edges(enumIota)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", naming.TypeName(enumIota.Type))
+	b.WriteString("\tnode [shape=circle];\n")
+	initial := true
+	for _, e := range enumIota.Enums {
+		attrs := "label=" + strconv.Quote(naming.DisplayName(e))
+		switch {
+		case !e.Valid:
+			attrs += ", style=dashed"
+		case e.IsFinalState:
+			attrs += ", shape=doublecircle"
+		}
+		fmt.Fprintf(&b, "\t%s [%s];\n", e.Name, attrs)
+		if e.Valid && initial {
+			fmt.Fprintf(&b, "\t__start [shape=point];\n\t__start -> %s;\n", e.Name)
+			initial = false
+		}
+	}
+	for _, ed := range es {
+		fmt.Fprintf(&b, "\t%s -> %s", ed.from.Name, ed.to.Name)
+		if ed.event != "" {
+			fmt.Fprintf(&b, " [label=%q]", ed.event)
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// Writer implements enum.Writer and draws the state machines of a request
+// in every format of Configuration.Diagrams.
+type Writer struct {
+	Configuration config.Configuration
+	fs            file.ReadCreateWriteFileFS
+}
+
+// WriterOption is a function that configures a Writer.
+type WriterOption func(*Writer)
+
+// WithFileSystem sets the filesystem to use for writing files.
+func WithFileSystem(fs file.ReadCreateWriteFileFS) WriterOption {
+	return func(w *Writer) {
+		w.fs = fs
+	}
+}
+
+// WithWriterConfiguration sets the configuration for the writer.
+func WithWriterConfiguration(configuration config.Configuration) WriterOption {
+	return func(w *Writer) {
+		w.Configuration = configuration
+	}
+}
+
+// NewWriter creates a new diagram writer, writing to the operating system
+// filesystem by default.
+func NewWriter(opts ...WriterOption) *Writer {
+	w := Writer{
+		Configuration: config.Configuration{},
+		fs:            &file.OSReadWriteFileFS{},
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return &w
+}
+
+// Write emits "<enum>.mmd" and "<enum>.dot" for each enum type of the
+// requests generated with -statemachine, in the directory of the source it
+// was parsed from, as Configuration.Diagrams selects.
+func (w *Writer) Write(ctx context.Context, reqs []enum.GenerationRequest) error {
+	for _, format := range w.Configuration.Diagrams {
+		if _, ok := extensions[format]; !ok {
+			return fmt.Errorf("%w: %s: only mermaid and dot are supported", ErrUnsupportedFormat, format)
+		}
+	}
+	for _, req := range reqs {
+		if !req.IsValid() {
+			return fmt.Errorf("invalid enum: %s", req.SourceFilename)
+		}
+		for _, enumIota := range req.GetEnumIotas() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !req.Configuration.GetEnumTypeConfig(enumIota.Type).StateMachine {
+				continue
+			}
+			for _, format := range w.Configuration.Diagrams {
+				draw := Mermaid
+				if format == config.DiagramDot {
+					draw = Dot
+				}
+				d, err := draw(enumIota)
+				if err != nil {
+					return fmt.Errorf("%w: %s: %w", ErrWriteDiagram, enumIota.Type, err)
+				}
+				path := filepath.Join(filepath.Dir(req.SourceFilename), gostrings.Snake(naming.TypeName(enumIota.Type))+extensions[format])
+				if err := w.fs.WriteFile(path, []byte(d), file.DefaultFilePerms); err != nil {
+					return fmt.Errorf("%w: %s: %w", ErrWriteDiagram, path, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package diagram_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/diagram"
+	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/source"
+)
+
+const statusGo = `package orders
+
+// goenums: -statemachine
+type status int
+
+const (
+	unknown  status = iota // invalid
+	pending                // Pending state: OnPay -> paid, -> canceled
+	paid                   // Paid state: -> shipped
+	shipped                // Shipped state: [final]
+	canceled               // Canceled state: [final]
+)
+`
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	if err := memfs.WriteFile("orders/status.go", []byte(statusGo), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reqs, err := gofile.NewParser(gofile.WithSource(source.FromFileSystem(memfs, "orders/status.go"))).Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w := diagram.NewWriter(
+		diagram.WithFileSystem(memfs),
+		diagram.WithWriterConfiguration(config.Configuration{Diagrams: []string{config.DiagramMermaid, config.DiagramDot}}),
+	)
+	if err := w.Write(t.Context(), reqs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "orders/status.dot",
+			want: `digraph Status {
+	node [shape=circle];
+	unknown [label="unknown", style=dashed];
+	pending [label="Pending"];
+	__start [shape=point];
+	__start -> pending;
+	paid [label="Paid"];
+	shipped [label="Shipped", shape=doublecircle];
+	canceled [label="Canceled", shape=doublecircle];
+	pending -> paid [label="OnPay"];
+	pending -> canceled;
+	paid -> shipped;
+}
+`,
+		},
+		{
+			path: "orders/status.mmd",
+			want: `stateDiagram-v2
+    state "unknown" as unknown
+    class unknown invalid
+    state "Pending" as pending
+    [*] --> pending
+    state "Paid" as paid
+    state "Shipped" as shipped
+    state "Canceled" as canceled
+    pending --> paid: OnPay
+    pending --> canceled
+    paid --> shipped
+    shipped --> [*]
+    canceled --> [*]
+    classDef invalid stroke-dasharray: 5 5
+`,
+		},
+	}
+	for _, tt := range tests {
+		b, err := memfs.ReadFile(tt.path)
+		if err != nil {
+			t.Fatalf("expected %s to be written: %v", tt.path, err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("unexpected %s\n got: %s\nwant: %s", tt.path, b, tt.want)
+		}
+	}
+}
+
+func TestWriter_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+	w := diagram.NewWriter(
+		diagram.WithFileSystem(file.NewMemFS()),
+		diagram.WithWriterConfiguration(config.Configuration{Diagrams: []string{"plantuml"}}),
+	)
+	if err := w.Write(t.Context(), nil); !errors.Is(err, diagram.ErrUnsupportedFormat) {
+		t.Errorf("expected error %v, got %v", diagram.ErrUnsupportedFormat, err)
+	}
+}
+
+func TestDot_UnknownState(t *testing.T) {
+	t.Parallel()
+	_, err := diagram.Dot(enum.EnumIota{Type: "status", Enums: []enum.Enum{
+		{Name: "pending", Valid: true, StateTransitions: []string{"payed"}},
+	}})
+	if !errors.Is(err, enum.ErrUnknownState) {
+		t.Errorf("expected error %v, got %v", enum.ErrUnknownState, err)
+	}
+}
@@ -20,6 +20,8 @@ package generator
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/donutnomad/goenums/enum"
 	"github.com/donutnomad/goenums/generator/config"
@@ -28,11 +30,11 @@ import (
 
 // Generator is the main orchestrator for the enum generation workflow.
 // Generator orchestrates the enum generation workflow by connecting
-// a parser and writer with configuration settings.
+// a parser and writers with configuration settings.
 type Generator struct {
 	Configuration config.Configuration
 	parser        enum.Parser
-	writer        enum.Writer
+	writers       []enum.Writer
 }
 
 type GeneratorOption func(*Generator)
@@ -50,18 +52,26 @@ func WithParser(parser enum.Parser) func(*Generator) {
 }
 func WithWriter(writer enum.Writer) func(*Generator) {
 	return func(g *Generator) {
-		g.writer = writer
+		g.writers = []enum.Writer{writer}
+	}
+}
+
+// WithWriters sets the writers every output is generated with, in order.
+// They are all given the requests of a single parse.
+func WithWriters(writers ...enum.Writer) func(*Generator) {
+	return func(g *Generator) {
+		g.writers = writers
 	}
 }
 
 // New creates a Generator with the specified configuration and components.
 // The generator will use the given parser to extract enum definitions and the
-// writer to generate output artifacts.
+// writers to generate output artifacts.
 func New(opts ...GeneratorOption) *Generator {
 	g := Generator{
 		Configuration: config.Configuration{},
 		parser:        gofile.NewParser(),
-		writer:        gofile.NewWriter(),
+		writers:       []enum.Writer{gofile.NewWriter()},
 	}
 	for _, opt := range opts {
 		opt(&g)
@@ -71,8 +81,9 @@ func New(opts ...GeneratorOption) *Generator {
 
 // ParseAndWrite executes the complete enum generation workflow:
 // 1. Parse input to extract enum representations
-// 2. Generate output from those representations
-// It returns an error if either step fails.
+// 2. Generate the output of every writer from those representations
+// The input is parsed once, however many writers there are. It returns an
+// error if parsing or any writer fails, skipping the writers after it.
 func (g *Generator) ParseAndWrite(ctx context.Context) error {
 	if ctx.Err() != nil {
 		return fmt.Errorf("%w: %w", enum.ErrParseSource, ctx.Err())
@@ -84,8 +95,16 @@ func (g *Generator) ParseAndWrite(ctx context.Context) error {
 	if ctx.Err() != nil {
 		return fmt.Errorf("%w: %w", enum.ErrParseSource, ctx.Err())
 	}
-	if err = g.writer.Write(ctx, genr); err != nil {
-		return fmt.Errorf("%w: %w", enum.ErrWriteOutput, err)
+	for _, writer := range g.writers {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: %w", enum.ErrWriteOutput, ctx.Err())
+		}
+		start := time.Now()
+		if err = writer.Write(ctx, genr); err != nil {
+			return fmt.Errorf("%w: %w", enum.ErrWriteOutput, err)
+		}
+		slog.Default().Debug("output timing", slog.String("writer", fmt.Sprintf("%T", writer)),
+			slog.Duration("elapsed", time.Since(start)))
 	}
 	return nil
 }
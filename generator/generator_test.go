@@ -1,11 +1,16 @@
 package generator_test
 
 import (
+	"context"
 	"errors"
+	"reflect"
 	"slices"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
 	"github.com/donutnomad/goenums/generator"
 	"github.com/donutnomad/goenums/generator/gofile"
 	"github.com/donutnomad/goenums/internal/testdata"
@@ -59,3 +64,108 @@ func TestGenerator_ParseAndWrite(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerator_ParseAndWrite_OverlayFS(t *testing.T) {
+	t.Parallel()
+	// A file with the same name on disk must not be loaded in its place
+	fsys := file.NewOverlayFS(fstest.MapFS{
+		"orders/status.go": &fstest.MapFile{Data: []byte(`package orders
+
+const base = 10
+
+type status int
+
+const (
+	unknown status = base + iota // invalid
+	pending                      // Pending
+	shipped                      // Shipped
+)
+`)},
+	})
+	parser := gofile.NewParser(
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+		gofile.WithSource(source.FromFileSystem(fsys, "orders/status.go")))
+	wri := gofile.NewWriter(
+		gofile.WithWriterConfiguration(testdata.DefaultConfig),
+		gofile.WithFileSystem(fsys))
+	p := generator.New(
+		generator.WithConfig(testdata.DefaultConfig),
+		generator.WithParser(parser),
+		generator.WithWriter(wri))
+	if err := p.ParseAndWrite(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"orders/status_enums.go"}; !slices.Equal(fsys.Written(), want) {
+		t.Fatalf("got written %q, want %q", fsys.Written(), want)
+	}
+	b, err := fsys.ReadFile("orders/status_enums.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"package orders", "Pending", "pending == 11"} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected the generated file to contain %q", want)
+		}
+	}
+}
+
+// countingParser counts the parses of the parser it wraps.
+type countingParser struct {
+	enum.Parser
+	parses int
+}
+
+func (p *countingParser) Parse(ctx context.Context) ([]enum.GenerationRequest, error) {
+	p.parses++
+	return p.Parser.Parse(ctx)
+}
+
+// recordingWriter records the requests it is given.
+type recordingWriter struct {
+	reqs []enum.GenerationRequest
+	err  error
+}
+
+func (w *recordingWriter) Write(_ context.Context, reqs []enum.GenerationRequest) error {
+	w.reqs = reqs
+	return w.err
+}
+
+func TestGenerator_ParseAndWrite_Writers(t *testing.T) {
+	t.Parallel()
+	newParser := func() *countingParser {
+		return &countingParser{Parser: gofile.NewParser(
+			gofile.WithParserConfiguration(testdata.DefaultConfig),
+			gofile.WithSource(source.FromFileSystem(testdata.FS, "invalid/status.go")))}
+	}
+
+	parser := newParser()
+	first, second := &recordingWriter{}, &recordingWriter{}
+	p := generator.New(
+		generator.WithConfig(testdata.DefaultConfig),
+		generator.WithParser(parser),
+		generator.WithWriters(first, second))
+	if err := p.ParseAndWrite(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parser.parses != 1 {
+		t.Errorf("got %d parses, want 1", parser.parses)
+	}
+	if len(first.reqs) == 0 || !reflect.DeepEqual(first.reqs, second.reqs) {
+		t.Errorf("expected both writers to be given the same requests, got %d and %d",
+			len(first.reqs), len(second.reqs))
+	}
+
+	errWrite := errors.New("write failed")
+	failing, skipped := &recordingWriter{err: errWrite}, &recordingWriter{}
+	p = generator.New(
+		generator.WithConfig(testdata.DefaultConfig),
+		generator.WithParser(newParser()),
+		generator.WithWriters(failing, skipped))
+	if err := p.ParseAndWrite(t.Context()); !errors.Is(err, errWrite) || !errors.Is(err, enum.ErrWriteOutput) {
+		t.Errorf("got error %v, want %v", err, errWrite)
+	}
+	if skipped.reqs != nil {
+		t.Error("expected the writers after a failing one to be skipped")
+	}
+}
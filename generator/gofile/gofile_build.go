@@ -0,0 +1,80 @@
+package gofile
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Operating systems and architectures recognised as implicit build
+// constraints in file names, as listed by go/build.
+var (
+	knownOS = []string{
+		"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js",
+		"linux", "nacl", "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows", "zos",
+	}
+	knownArch = []string{
+		"386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be", "loong64", "mips",
+		"mipsle", "mips64", "mips64le", "mips64p32", "mips64p32le", "ppc", "ppc64", "ppc64le",
+		"riscv", "riscv64", "s390", "s390x", "sparc", "sparc64", "wasm",
+	}
+)
+
+// buildConstraint returns the build constraint the generated file must carry
+// to compile in exactly the builds the source file does: the source's
+// //go:build line, the GOOS and GOARCH implied by its file name, which the
+// "_enums.go" suffix of the output would otherwise drop, and the tags given
+// on the command line. It returns an empty string when there is none.
+func buildConstraint(node *ast.File, filename string, tags []string) string {
+	var exprs []constraint.Expr
+	for _, group := range node.Comments {
+		if group.Pos() > node.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if !constraint.IsGoBuild(comment.Text) {
+				continue
+			}
+			if expr, err := constraint.Parse(comment.Text); err == nil {
+				exprs = append(exprs, expr)
+			}
+		}
+	}
+	for _, tag := range fileNameTags(filename) {
+		exprs = append(exprs, &constraint.TagExpr{Tag: tag})
+	}
+	for _, tag := range tags {
+		exprs = append(exprs, &constraint.TagExpr{Tag: tag})
+	}
+	if len(exprs) == 0 {
+		return ""
+	}
+	expr := exprs[0]
+	for _, next := range exprs[1:] {
+		expr = &constraint.AndExpr{X: expr, Y: next}
+	}
+	return expr.String()
+}
+
+// fileNameTags returns the GOOS and GOARCH tags implied by the
+// *_GOOS, *_GOARCH and *_GOOS_GOARCH file name patterns.
+func fileNameTags(filename string) []string {
+	name := strings.TrimSuffix(filepath.Base(filename), ".go")
+	name = strings.TrimSuffix(name, "_test")
+	// The part before the first underscore never constrains the file
+	i := strings.Index(name, "_")
+	if i < 0 {
+		return nil
+	}
+	l := strings.Split(name[i:], "_")
+	n := len(l)
+	if n >= 2 && slices.Contains(knownOS, l[n-2]) && slices.Contains(knownArch, l[n-1]) {
+		return l[n-2:]
+	}
+	if slices.Contains(knownOS, l[n-1]) || slices.Contains(knownArch, l[n-1]) {
+		return l[n-1:]
+	}
+	return nil
+}
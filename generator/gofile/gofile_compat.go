@@ -0,0 +1,37 @@
+package gofile
+
+import (
+	"text/template"
+
+	"github.com/donutnomad/goenums/enum"
+)
+
+var (
+	zarldevCompatStr = `
+// Exhaustive{{ .ContainerName }} calls f with each enum value.
+//
+// Deprecated: Exhaustive{{ .ContainerName }} is generated for compatibility with
+// zarldev/goenums; range over {{ .ContainerName }}.All() instead.
+func Exhaustive{{ .ContainerName }}(f func({{ .WrapperName }})) {
+	for _, v := range {{ .ContainerName }}.allSlice() {
+		f(v)
+	}
+}
+`
+	zarldevCompatTemplate = template.Must(template.New("zarldevCompat").Parse(zarldevCompatStr))
+)
+
+type zarldevCompatData struct {
+	ContainerName string
+	WrapperName   string
+}
+
+// writeZarldevCompat writes the functions generated by zarldev/goenums that
+// have no counterpart here. Its uppercase container fields are written with
+// the container.
+func (g *Writer) writeZarldevCompat(rep enum.GenerationRequest) {
+	g.writeTemplate(zarldevCompatTemplate, zarldevCompatData{
+		ContainerName: enumType(rep),
+		WrapperName:   wrapperName(rep),
+	})
+}
@@ -0,0 +1,183 @@
+package gofile
+
+import (
+	"context"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/source"
+	"golang.org/x/tools/go/packages"
+)
+
+// constantValues maps the names of package-level constants to their values
+// as evaluated by the Go type checker.
+type constantValues map[string]constantValue
+
+type constantValue struct {
+	value constant.Value
+	// typeName is the name of the constant's type, empty when untyped
+	typeName string
+	// pkgName and pkgPath are the package declaring the type, and
+	// underlying the name of its underlying basic type
+	pkgName, pkgPath, underlying string
+}
+
+// index returns the value of the named constant as an enum index. It reports
+// false when the constant was not evaluated or is not a whole number that
+// fits an int, in which case the caller falls back to syntactic numbering.
+func (c constantValues) index(name string) (int, bool) {
+	cv, ok := c[name]
+	if !ok {
+		return 0, false
+	}
+	v := constant.ToInt(cv.value)
+	if v.Kind() != constant.Int {
+		return 0, false
+	}
+	i, exact := constant.Int64Val(v)
+	if !exact || int64(int(i)) != i {
+		return 0, false
+	}
+	return int(i), true
+}
+
+// literal returns the value of the named constant as a Go literal that
+// compares equal to it, or "" when the constant was not evaluated.
+func (c constantValues) literal(name string) string {
+	cv, ok := c[name]
+	if !ok {
+		return ""
+	}
+	if cv.value.Kind() == constant.Float {
+		// ExactString writes rationals as fractions such as 1/10, which
+		// are integer divisions in Go source
+		f, _ := constant.Float64Val(cv.value)
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return cv.value.ExactString()
+}
+
+// evaluateConstants type-checks the source and returns the values of its
+// package-level constants, so that expressions such as "Base + iota*10",
+// references to other constants and hex or shifted literals are numbered
+// exactly as the compiler numbers them.
+//
+// Sources carrying their type-checked package, such as those of
+// source.FromPackages, use its constants. When the source file exists on
+// disk its whole package is loaded, with the content being parsed overlaid,
+// so constants declared in sibling files and imported packages resolve.
+// Otherwise, or when loading fails, the file is checked on its own. Errors
+// are not reported: constants the checker cannot evaluate are simply left
+// out.
+func (p *Parser) evaluateConstants(ctx context.Context, fset *token.FileSet, filename string, content []byte, node *ast.File) constantValues {
+	if src, ok := p.source.(typedSource); ok && src.Types() != nil {
+		return scopeConstants(src.Types().Scope())
+	}
+	if p.onDisk() {
+		if values := loadPackageConstants(ctx, filename, content, p.Configuration.BuildTags); values != nil {
+			return values
+		}
+	}
+	conf := types.Config{
+		Importer: importer.Default(),
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(node.Name.Name, fset, []*ast.File{node}, nil)
+	if pkg == nil {
+		return nil
+	}
+	return scopeConstants(pkg.Scope())
+}
+
+// onDisk reports whether the source may be a file on disk, whose package
+// can be loaded. Files of other filesystems, such as a file.OverlayFS, are
+// checked on their own even when a file of the same name exists on disk.
+func (p *Parser) onDisk() bool {
+	src, ok := p.source.(*source.FileSource)
+	if !ok {
+		return true
+	}
+	_, ok = src.FS.(*file.OSReadWriteFileFS)
+	return ok
+}
+
+// typedSource is a source whose package was loaded and type-checked.
+type typedSource interface {
+	Types() *types.Package
+}
+
+// loadPackageConstants loads the package of the file at filename with
+// golang.org/x/tools/go/packages, selecting files with the given build tags,
+// and returns its constants, or nil when the file is not on disk or the
+// package cannot be loaded.
+func loadPackageConstants(ctx context.Context, filename string, content []byte, tags []string) constantValues {
+	if filename == "" {
+		return nil
+	}
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return nil
+	}
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    packages.NeedName | packages.NeedTypes | packages.NeedSyntax,
+		Dir:     filepath.Dir(abs),
+		Overlay: map[string][]byte{abs: content},
+	}
+	if len(tags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(tags, ",")}
+	}
+	pkgs, err := packages.Load(cfg, "file="+abs)
+	if err != nil || len(pkgs) != 1 || pkgs[0].Types == nil {
+		slog.Default().DebugContext(ctx, "failed to load package, checking file on its own",
+			"filename", filename, "error", err)
+		return nil
+	}
+	return scopeConstants(pkgs[0].Types.Scope())
+}
+
+func scopeConstants(scope *types.Scope) constantValues {
+	values := make(constantValues)
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || c.Val().Kind() == constant.Unknown {
+			continue
+		}
+		value := constantValue{value: c.Val()}
+		if named, ok := c.Type().(*types.Named); ok {
+			value.typeName = named.Obj().Name()
+			if pkg := named.Obj().Pkg(); pkg != nil {
+				value.pkgName, value.pkgPath = pkg.Name(), pkg.Path()
+			}
+			if basic, ok := named.Underlying().(*types.Basic); ok {
+				value.underlying = basic.Name()
+			}
+		}
+		values[name] = value
+	}
+	return values
+}
+
+// usesIota reports whether expr refers to iota.
+func usesIota(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == iotaIdentifier {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,63 @@
+package gofile_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/internal/testdata"
+	"github.com/donutnomad/goenums/source"
+)
+
+// parseFuzzed parses src and fails when the parser panics.
+func parseFuzzed(t *testing.T, src string) error {
+	t.Helper()
+	parser := gofile.NewParser(
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+		gofile.WithSource(source.FromReader(strings.NewReader(src))))
+	reqs, err := parser.Parse(t.Context())
+	if errors.Is(err, gofile.ErrParserPanic) {
+		t.Fatalf("parse(%q) panicked: %v", src, err)
+	}
+	if err == nil && len(reqs) == 0 {
+		t.Errorf("parse(%q) returned neither requests nor an error", src)
+	}
+	return err
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add("package colors\n\ntype color int\n\nconst (\n\tred color = iota\n\tgreen\n)\n")
+	f.Add("package colors\n\ntype color string\n\nconst (\n\tred color = \"r\" // Red\n\tgreen color = \"g\"\n)\n")
+	f.Add("package planets\n\ntype planet int // Gravity[float64]\n\nconst (\n\tunknown planet = iota // invalid\n\tmercury // Mercury 0.378\n)\n")
+	f.Add("package orders\n\ntype status int\n\nconst (\n\tunknown status = iota - 1 // invalid\n\tpending // pending,waiting\n)\n")
+	f.Add("package x\n\nconst (\n\ta = iota\n)\n")
+	f.Add("package x\n")
+	f.Add("")
+	f.Add("not go code")
+
+	f.Fuzz(func(t *testing.T, src string) {
+		_ = parseFuzzed(t, src)
+	})
+}
+
+func FuzzParseDirective(f *testing.F) {
+	f.Add("-json")
+	f.Add("-json -sql -text -yaml -binary")
+	f.Add("-serde/value -sql")
+	f.Add("-binary=varint,length-prefixed")
+	f.Add("-statemachine -statemachine/history")
+	f.Add("-invalid=Unset -order=name -stringer=switch")
+	f.Add("-bogus")
+	f.Add("-json=false")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, directive string) {
+		if strings.ContainsAny(directive, "\r\n") {
+			t.Skip("directives are single line comments")
+		}
+		src := "package colors\n\n// goenums: " + directive +
+			"\ntype color int\n\nconst (\n\tred color = iota\n\tgreen\n)\n"
+		_ = parseFuzzed(t, src)
+	})
+}
@@ -0,0 +1,188 @@
+package gofile
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+	gostrings "github.com/donutnomad/goenums/strings"
+)
+
+// importedType is the type of a const block that is declared in another
+// package, such as a package shared by the services of a monorepo.
+type importedType struct {
+	// qualifier is the package name qualifying the type in the source,
+	// empty when its package is dot-imported
+	qualifier string
+	name      string
+}
+
+// alias returns the name of the alias of t the generated code declares,
+// which the generated code refers to t by.
+func (t importedType) alias() string {
+	return gostrings.Lower1stCharacter(t.name)
+}
+
+// String returns t as written in the source.
+func (t importedType) String() string {
+	if t.qualifier == "" {
+		return t.name
+	}
+	return t.qualifier + "." + t.name
+}
+
+// constBlockImportedType returns the type of the constants of decl when it
+// is declared in another package: qualified, or exported by a dot-imported
+// package rather than declared in the file.
+func constBlockImportedType(node *ast.File, decl *ast.GenDecl) (importedType, bool) {
+	if decl.Tok != token.CONST {
+		return importedType{}, false
+	}
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok || vs.Type == nil {
+			continue
+		}
+		switch t := vs.Type.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := t.X.(*ast.Ident); ok {
+				return importedType{qualifier: x.Name, name: t.Sel.Name}, true
+			}
+		case *ast.Ident:
+			if hasDotImport(node) && token.IsExported(t.Name) &&
+				types.Universe.Lookup(t.Name) == nil && !declaresType(node, t.Name) {
+				return importedType{name: t.Name}, true
+			}
+		}
+		return importedType{}, false
+	}
+	return importedType{}, false
+}
+
+// hasDotImport reports whether the file imports a package with ".".
+func hasDotImport(node *ast.File) bool {
+	return slices.ContainsFunc(node.Imports, func(spec *ast.ImportSpec) bool {
+		return spec.Name != nil && spec.Name.Name == "."
+	})
+}
+
+// declaresType reports whether the file declares the type name.
+func declaresType(node *ast.File, name string) bool {
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasDirective reports whether the comment group holds a goenums directive.
+func hasDirective(doc *ast.CommentGroup) bool {
+	return doc != nil && slices.ContainsFunc(doc.List, func(c *ast.Comment) bool {
+		return gostrings.HasPrefix(c.Text, "// goenums:")
+	})
+}
+
+// getImportedEnums returns the enum types of the const blocks of the file
+// whose type is declared in another package, and the imports of those
+// packages. Only blocks documented with a goenums directive are enums, so
+// that constants of types such as time.Duration are left alone. The types
+// are resolved from the constants evaluated with their package loaded.
+func getImportedEnums(node *ast.File, consts constantValues, configs map[string]config.EnumTypeConfig) ([]enum.EnumIota, []enum.Import, error) {
+	var (
+		enumIotas []enum.EnumIota
+		imports   []enum.Import
+	)
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || !hasDirective(genDecl.Doc) {
+			continue
+		}
+		t, ok := constBlockImportedType(node, genDecl)
+		if !ok || slices.ContainsFunc(enumIotas, func(e enum.EnumIota) bool { return e.Type == t.alias() }) {
+			continue
+		}
+		if declaresType(node, t.alias()) {
+			return nil, nil, fmt.Errorf("%w: %s: the file declares %s, the name of its alias",
+				ErrParseGoSource, t, t.alias())
+		}
+		// The wrapper would be declared in the package block, where the
+		// dot-imported type already is
+		if wrapper := configs[t.alias()].WrapperName; t.qualifier == "" && (wrapper == "" || wrapper == t.name) {
+			return nil, nil, fmt.Errorf("%w: %s: a dot-imported type needs a -wrapper name other than its own",
+				config.ErrUnsupportedCombination, t)
+		}
+		cv, ok := blockConstant(genDecl, consts, t.name)
+		if !ok || cv.underlying == "" || (t.qualifier == "" && cv.pkgName == node.Name.Name) {
+			return nil, nil, fmt.Errorf("%w: %s: the type could not be loaded from its package",
+				ErrParseGoSource, t)
+		}
+		imp := enum.Import{Name: cv.pkgName, Path: cv.pkgPath}
+		if t.qualifier != "" {
+			imp.Name = t.qualifier
+		}
+		lines := commentLines(genDecl.Doc)
+		enumIotas = append(enumIotas, enum.EnumIota{
+			Type:           t.alias(),
+			ImportedType:   imp.Name + "." + t.name,
+			UnderlyingType: cv.underlying,
+			Doc:            typeDoc(lines),
+			Default:        typeDefault(lines),
+		})
+		if !slices.Contains(imports, imp) {
+			imports = append(imports, imp)
+		}
+	}
+	return enumIotas, imports, nil
+}
+
+// blockConstant returns the evaluated value of the first constant of decl
+// of the type name.
+func blockConstant(decl *ast.GenDecl, consts constantValues, name string) (constantValue, bool) {
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for _, ident := range vs.Names {
+			if cv, ok := consts[ident.Name]; ok && cv.typeName == name {
+				return cv, true
+			}
+		}
+	}
+	return constantValue{}, false
+}
+
+// specHasType reports whether the constants of vs are declared with the
+// type of enumIota, which for an imported type is written qualified, or
+// unqualified when dot-imported.
+func specHasType(vs *ast.ValueSpec, enumIota *enum.EnumIota) bool {
+	switch t := vs.Type.(type) {
+	case *ast.Ident:
+		return t.Name == sourceTypeName(enumIota)
+	case *ast.SelectorExpr:
+		x, ok := t.X.(*ast.Ident)
+		return ok && enumIota.ImportedType != "" && x.Name+"."+t.Sel.Name == enumIota.ImportedType
+	}
+	return false
+}
+
+// sourceTypeName returns the name of the type of enumIota in the package
+// declaring it.
+func sourceTypeName(enumIota *enum.EnumIota) string {
+	if _, name, ok := strings.Cut(enumIota.ImportedType, "."); ok {
+		return name
+	}
+	return enumIota.Type
+}
@@ -8,10 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/parser"
 	"go/token"
 	"log/slog"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -30,6 +33,15 @@ var (
 	ErrParseGoSource = errors.New("failed to parse Go source")
 	// ErrReadSource indicates an error occurred while reading the source file.
 	ErrReadGoSource = errors.New("failed to read Go source")
+	// ErrInvalidDefault indicates the default declared for a type is not one
+	// of its valid values.
+	ErrInvalidDefault = errors.New("default is not a valid value")
+	// ErrInvalidSerdeFormat indicates a "serde:" annotation names a format
+	// other than name and value.
+	ErrInvalidSerdeFormat = errors.New("invalid serialization format")
+	// ErrParserPanic indicates the parser panicked on the source, which is
+	// a bug in goenums rather than in the source.
+	ErrParserPanic = errors.New("unexpected panic in parser")
 )
 
 // Parser implements the enum.Parser interface for Go source files.
@@ -38,6 +50,10 @@ var (
 type Parser struct {
 	Configuration config.Configuration
 	source        enum.Source
+	// fset positions the nodes of the source being parsed
+	fset *token.FileSet
+	// commentErrs are the malformed comments found under Failfast
+	commentErrs []error
 }
 
 // ParserOption is a function that configures a Parser.
@@ -73,16 +89,13 @@ func NewParser(opts ...ParserOption) *Parser {
 // Parse analyzes Go source code to identify and extract enum-like constant declarations.
 // It returns a slice of enum representations or an error if parsing fails.
 // The implementation uses Go's standard AST parsing to analyze the source code structure.
-func (p *Parser) Parse(ctx context.Context) ([]enum.GenerationRequest, error) {
+// A panic while parsing is returned as an ErrParserPanic error.
+func (p *Parser) Parse(ctx context.Context) (reqs []enum.GenerationRequest, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			slog.Default().Error("unexpected panic in parser",
-				"version", version.CURRENT,
-				"build", version.BUILD,
-				"commit", version.COMMIT,
-				"recovered", true,
-				"error", fmt.Sprintf("%v", r),
-				"file", p.source.Filename())
+			reqs = nil
+			err = fmt.Errorf("%w: %s: %v (goenums %s, commit %s)",
+				ErrParserPanic, p.source.Filename(), r, version.CURRENT, version.COMMIT)
 		}
 	}()
 	return p.doParse(ctx)
@@ -98,19 +111,24 @@ func (p *Parser) doParse(ctx context.Context) ([]enum.GenerationRequest, error)
 		return nil, ctx.Err()
 	default:
 	}
-	filename, node, err := p.parseSourceContent(ctx)
+	filename, node, consts, err := p.parseSourceContent(ctx)
 	if err != nil {
 		return nil, err
 	}
-	packageName, enInfo, enumTypeConfigs, err := extractEnumInfo(ctx, p, node)
+	p.commentErrs = nil
+	packageName, enInfo, enumTypeConfigs, err := extractEnumInfo(ctx, p, node, consts)
+	if err := errors.Join(p.commentErrs...); err != nil {
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
 	slog.Default().DebugContext(ctx, "collected all enum representations from source", "filename", filename)
-	return p.buildGenerationRequests(enInfo, packageName, filename, enumTypeConfigs)
+	constraint := buildConstraint(node, filename, p.Configuration.BuildTags)
+	return p.buildGenerationRequests(enInfo, packageName, filename, constraint, enumTypeConfigs)
 }
 
-func (p *Parser) buildGenerationRequests(enInfo enumInfo, packageName string, filename string, enumTypeConfigs map[string]config.EnumTypeConfig) ([]enum.GenerationRequest, error) {
+func (p *Parser) buildGenerationRequests(enInfo enumInfo, packageName, filename, constraint string, enumTypeConfigs map[string]config.EnumTypeConfig) ([]enum.GenerationRequest, error) {
 	// Initialize EnumTypeConfigs if not already done
 	if p.Configuration.EnumTypeConfigs == nil {
 		p.Configuration.EnumTypeConfigs = make(map[string]config.EnumTypeConfig)
@@ -127,19 +145,46 @@ func (p *Parser) buildGenerationRequests(enInfo enumInfo, packageName string, fi
 		return nil, fmt.Errorf("no enums found in file")
 	}
 
+	for _, enumIota := range enInfo.Enums {
+		cfg := p.Configuration.GetEnumTypeConfig(enumIota.Type)
+		if err := cfg.Validate(enumIota.UnderlyingType); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParseGoSource, err)
+		}
+		if err := validateDefault(enumIota, cfg); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParseGoSource, err)
+		}
+		if err := validateSerdeFormats(enumIota, cfg); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParseGoSource, err)
+		}
+		if err := validateInvalidName(enumIota, cfg); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParseGoSource, err)
+		}
+		if cfg.StateMachine {
+			warnings, err := enum.CheckStateMachine(enumIota)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %w", ErrParseGoSource, enumIota.Type, err)
+			}
+			for _, w := range warnings {
+				slog.Default().Warn(w, "type", enumIota.Type)
+			}
+		}
+	}
+
 	// Extract the base filename without extension for output filename
 	baseFilename := filepath.Base(filename)
 	baseFilename = strings.TrimSuffix(baseFilename, filepath.Ext(baseFilename))
 
 	// Create a single GenerationRequest containing all enums from this file
 	request := enum.GenerationRequest{
-		Package:        packageName,
-		EnumIotas:      enInfo.Enums, // Pass all enums for multi-enum support
-		Version:        version.CURRENT,
-		SourceFilename: filename,
-		OutputFilename: gostrings.ToLower(baseFilename),
-		Configuration:  p.Configuration,
-		Imports:        enInfo.Imports,
+		Package:         packageName,
+		BuildConstraint: constraint,
+		EnumIotas:       enInfo.Enums, // Pass all enums for multi-enum support
+		Version:         version.CURRENT,
+		SourceFilename:  filename,
+		OutputFilename:  gostrings.ToLower(baseFilename),
+		Configuration:   p.Configuration,
+		Imports:         enInfo.Imports,
+		FieldImports:    enInfo.FieldImports,
 	}
 
 	// For backward compatibility: if there's only one enum, also set EnumIota
@@ -152,11 +197,74 @@ func (p *Parser) buildGenerationRequests(enInfo enumInfo, packageName string, fi
 	return genr, nil
 }
 
-func extractEnumInfo(ctx context.Context, p *Parser, node *ast.File) (string, enumInfo, map[string]config.EnumTypeConfig, error) {
+// validateDefault checks that a type generated with -default-on-error
+// declares a default naming one of its valid values.
+func validateDefault(enumIota enum.EnumIota, cfg config.EnumTypeConfig) error {
+	if !cfg.DefaultOnError {
+		return nil
+	}
+	if enumIota.Default == "" {
+		return fmt.Errorf("%w: %s: -default-on-error requires a \"default:\" line in the type documentation",
+			config.ErrUnsupportedCombination, enumIota.Type)
+	}
+	for _, e := range enumIota.Enums {
+		if e.Name == enumIota.Default && e.Valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s: %s", ErrInvalidDefault, enumIota.Type, enumIota.Default)
+}
+
+// validateSerdeFormats reports an error for "serde:" annotations naming an
+// unknown format, or the value format where the type could not serialize by
+// value, as Validate does for -serde/value.
+func validateSerdeFormats(enumIota enum.EnumIota, cfg config.EnumTypeConfig) error {
+	for _, e := range enumIota.Enums {
+		switch e.SerdeFormat {
+		case "", enum.SerdeFormatName:
+		case enum.SerdeFormatValue:
+			valueCfg := cfg
+			valueCfg.SerializationType = config.SerdeValue
+			if err := valueCfg.Validate(enumIota.UnderlyingType); err != nil {
+				return fmt.Errorf("%s: serde:%s: %w", e.Name, e.SerdeFormat, err)
+			}
+		default:
+			return fmt.Errorf("%w: %s: %s: serde:%s, want serde:%s or serde:%s", ErrInvalidSerdeFormat,
+				enumIota.Type, e.Name, e.SerdeFormat, enum.SerdeFormatName, enum.SerdeFormatValue)
+		}
+	}
+	return nil
+}
+
+// validateInvalidName reports an error when the -invalid name of the type
+// is also the container field of one of its constants.
+func validateInvalidName(enumIota enum.EnumIota, cfg config.EnumTypeConfig) error {
+	if cfg.InvalidName == "" {
+		return nil
+	}
+	for _, e := range enumIota.Enums {
+		if cfg.InvalidName == gostrings.Camel(e.Name) || cfg.InvalidName == strings.ToUpper(e.Name) {
+			return fmt.Errorf("%w: %s: -invalid=%s collides with the constant %s",
+				config.ErrUnsupportedCombination, enumIota.Type, cfg.InvalidName, e.Name)
+		}
+	}
+	return nil
+}
+
+func extractEnumInfo(ctx context.Context, p *Parser, node *ast.File, consts constantValues) (string, enumInfo, map[string]config.EnumTypeConfig, error) {
 	slog.Default().DebugContext(ctx, "collecting all enum representations")
 	packageName := p.getPackageName(node)
 	enInfo := p.getEnumInfo(node)
-	enumTypeConfigs := p.findGoEnumsComments(node)
+	enumTypeConfigs, err := p.findGoEnumsComments(node)
+	if err != nil {
+		return "", enumInfo{}, nil, err
+	}
+	importedEnums, imports, err := getImportedEnums(node, consts, enumTypeConfigs)
+	if err != nil {
+		return "", enumInfo{}, nil, err
+	}
+	enInfo.Enums = append(enInfo.Enums, importedEnums...)
+	enInfo.FieldImports = append(enInfo.FieldImports, imports...)
 
 	// Filter enums to only include those that have:
 	// 1. Explicit goenums comments, OR
@@ -166,7 +274,7 @@ func extractEnumInfo(ctx context.Context, p *Parser, node *ast.File) (string, en
 	slog.Default().DebugContext(ctx, "enum iota", "count", len(enInfo.Enums), "enumIota", enInfo.Enums)
 	for _, enumIota := range enInfo.Enums {
 		slog.Default().DebugContext(ctx, "enum iota", "enumIota", enumIota)
-		enums := p.getEnums(node, &enumIota)
+		enums := p.getEnums(node, &enumIota, consts)
 
 		// Check if this type has a goenums comment OR has valid enum constants
 		_, hasGoenumsComment := enumTypeConfigs[enumIota.Type]
@@ -195,26 +303,33 @@ func extractEnumInfo(ctx context.Context, p *Parser, node *ast.File) (string, en
 			ErrParseGoSource,
 			enum.ErrNoEnumsFound)
 	}
+	fieldImports, err := addProtoImports(node, enInfo.FieldImports, enumTypeConfigs)
+	if err != nil {
+		return "", enumInfo{}, nil, err
+	}
+	enInfo.FieldImports = fieldImports
 	return packageName, enInfo, enumTypeConfigs, nil
 }
 
-func (p *Parser) parseSourceContent(ctx context.Context) (string, *ast.File, error) {
+// parseSourceContent parses the source and evaluates the constants it declares.
+func (p *Parser) parseSourceContent(ctx context.Context) (string, *ast.File, constantValues, error) {
 	content, err := p.source.Content()
 	if err != nil {
-		return "", nil, fmt.Errorf("%w: %w", ErrReadGoSource, err)
+		return "", nil, nil, fmt.Errorf("%w: %w", ErrReadGoSource, err)
 	}
 	slog.Default().DebugContext(ctx, "parsing source content")
 	filename := p.source.Filename()
 	fset := token.NewFileSet()
+	p.fset = fset
 	if err := ctx.Err(); err != nil {
-		return "", nil, err
+		return "", nil, nil, err
 	}
 	slog.Default().DebugContext(ctx, "parsing file", "filename", filename)
 	node, err := parser.ParseFile(fset, filename, content, parser.ParseComments)
 	if err != nil {
-		return "", nil, fmt.Errorf("%w: %w", ErrParseGoSource, err)
+		return "", nil, nil, fmt.Errorf("%w: %w", ErrParseGoSource, err)
 	}
-	return filename, node, nil
+	return filename, node, p.evaluateConstants(ctx, fset, filename, content, node), nil
 }
 
 func (p *Parser) getPackageName(node *ast.File) string {
@@ -225,11 +340,13 @@ func (p *Parser) getPackageName(node *ast.File) string {
 	return packageName
 }
 
-func (p *Parser) getEnums(node *ast.File, enumIota *enum.EnumIota) []enum.Enum {
+func (p *Parser) getEnums(node *ast.File, enumIota *enum.EnumIota, consts constantValues) []enum.Enum {
 	var enums []enum.Enum
 	iotaFound := false
 	typeFound := false // Track if we found constants with the same type
 
+	// An enum type may be declared across several const blocks, each
+	// restarting iota; every block is numbered on its own
 	for _, decl := range node.Decls {
 		t, ok := decl.(*ast.GenDecl)
 		if !ok {
@@ -242,28 +359,31 @@ func (p *Parser) getEnums(node *ast.File, enumIota *enum.EnumIota) []enum.Enum {
 			continue
 		}
 
-		idx := 0
-		blockIotaFound := false
-		blockTypeFound := false
-
-		for _, spec := range t.Specs {
+		var block constBlock
+		for i, spec := range t.Specs {
 			vs, ok := spec.(*ast.ValueSpec)
 			if !ok {
 				continue
 			}
-			e := p.getEnum(vs, &idx, enumIota, &blockIotaFound, &blockTypeFound)
+			block.iota = i
+			e := p.getEnum(vs, consts, &block, enumIota)
 			if e == nil {
 				continue
 			}
+			// The first value starts the enum, which may be zero, negative
+			// or an explicit value rather than an iota offset
+			if len(enums) == 0 {
+				enumIota.StartIndex = e.Index
+			}
 			enums = append(enums, *e)
 			slog.Default().Debug("enum", "enum", e)
 		}
 
 		// Update global flags
-		if blockIotaFound {
+		if block.iotaFound {
 			iotaFound = true
 		}
-		if blockTypeFound {
+		if block.typeFound {
 			typeFound = true
 		}
 	}
@@ -274,7 +394,89 @@ func (p *Parser) getEnums(node *ast.File, enumIota *enum.EnumIota) []enum.Enum {
 	return enums
 }
 
-func (p *Parser) getEnum(vs *ast.ValueSpec, idx *int, enumIota *enum.EnumIota, iotaFound *bool, typeFound *bool) *enum.Enum {
+// constBlock tracks the state of the const block being read.
+type constBlock struct {
+	// iota is the position of the current spec within the block
+	iota int
+	// last is the last explicit expression, repeated by specs without values;
+	// unknown is set when it could not be parsed
+	last                 iotaExpr
+	unknown              bool
+	iotaFound, typeFound bool
+}
+
+// iotaExpr is a constant expression of the form "iota + offset", or just
+// "offset" when withIota is false.
+type iotaExpr struct {
+	offset   int
+	withIota bool
+	// value is the exact value of expressions without iota, which holds
+	// the values offset cannot, such as uint64 values from 1<<63
+	value constant.Value
+}
+
+// at returns the value of the expression for the given iota.
+func (e iotaExpr) at(iota int) int {
+	if e.withIota {
+		return e.offset + iota
+	}
+	return e.offset
+}
+
+// parseIotaExpr recognises the expressions that can be numbered without the
+// type checker: iota, integer literals, and iota plus or minus a literal.
+func parseIotaExpr(expr ast.Expr) (iotaExpr, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return iotaExpr{withIota: true}, e.Name == iotaIdentifier
+	case *ast.BasicLit:
+		if e.Kind != token.INT {
+			return iotaExpr{}, false
+		}
+		return literalExpr(constant.MakeFromLiteral(e.Value, e.Kind, 0))
+	case *ast.ParenExpr:
+		return parseIotaExpr(e.X)
+	case *ast.UnaryExpr:
+		x, ok := parseIotaExpr(e.X)
+		if !ok || x.withIota {
+			return iotaExpr{}, false
+		}
+		switch e.Op {
+		case token.SUB:
+			return literalExpr(constant.UnaryOp(token.SUB, x.value, 0))
+		case token.ADD:
+			return x, true
+		}
+	case *ast.BinaryExpr:
+		x, okX := parseIotaExpr(e.X)
+		y, okY := parseIotaExpr(e.Y)
+		if !okX || !okY || x.withIota == y.withIota {
+			return iotaExpr{}, false
+		}
+		switch {
+		case e.Op == token.ADD:
+			return iotaExpr{offset: x.offset + y.offset, withIota: true}, true
+		case e.Op == token.SUB && x.withIota:
+			return iotaExpr{offset: -y.offset, withIota: true}, true
+		}
+	}
+	return iotaExpr{}, false
+}
+
+// literalExpr returns the expression of the integer constant v, whose
+// offset is left zero when v does not fit an int.
+func literalExpr(v constant.Value) (iotaExpr, bool) {
+	if v.Kind() != constant.Int {
+		return iotaExpr{}, false
+	}
+	expr := iotaExpr{value: v}
+	if i, exact := constant.Int64Val(v); exact && int64(int(i)) == i {
+		expr.offset = int(i)
+	}
+	return expr, true
+}
+
+func (p *Parser) getEnum(vs *ast.ValueSpec, consts constantValues, block *constBlock, enumIota *enum.EnumIota) *enum.Enum {
 	if len(vs.Names) == 0 {
 		slog.Default().Debug("valuespec has no names")
 		return nil
@@ -282,92 +484,50 @@ func (p *Parser) getEnum(vs *ast.ValueSpec, idx *int, enumIota *enum.EnumIota, i
 
 	// Check if this constant has an explicit type
 	if vs.Type != nil {
-		t, ok := vs.Type.(*ast.Ident)
-		if !ok {
-			return nil
-		}
-		if t.Name != enumIota.Type {
+		if !specHasType(vs, enumIota) {
 			return nil
 		}
-		*typeFound = true
+		block.typeFound = true
 	}
 
 	// Check for iota usage
-	if vs.Values != nil {
-		for _, v := range vs.Values {
-			if t, ok := v.(*ast.Ident); ok && t.Name == "iota" {
-				*iotaFound = true
-				break
-			}
-			// Check for iota + offset (like iota + 1)
-			if binExpr, ok := v.(*ast.BinaryExpr); ok {
-				if x, ok := binExpr.X.(*ast.Ident); ok && x.Name == "iota" {
-					*iotaFound = true
-					break
-				}
-			}
-		}
+	if slices.ContainsFunc(vs.Values, usesIota) {
+		block.iotaFound = true
+	}
+
+	// The value evaluated by the type checker takes precedence over the
+	// syntactic numbering, which only understands "iota + n"
+	evaluated, isEvaluated := consts[vs.Names[0].Name]
+	if len(vs.Values) > 0 {
+		expr, ok := parseIotaExpr(vs.Values[0])
+		block.last, block.unknown = expr, !ok
+	}
+	if block.unknown && !isEvaluated {
+		return nil
 	}
+
 	name := vs.Names[0].Name
 	if name == "_" {
-		*idx++
+		return nil
+	}
+	// Untyped constants and constants of other types may share the block
+	if isEvaluated && evaluated.typeName != sourceTypeName(enumIota) {
 		return nil
 	}
 	en := enum.Enum{
 		Name:  vs.Names[0].Name,
+		Index: block.last.at(block.iota),
 		Valid: true, // Default to valid unless marked as invalid in comment
 	}
-
-	// Handle direct numeric assignment
-	hasDirectValue := false
-	if len(vs.Values) > 0 {
-		// Check if it's a direct numeric assignment
-		if basicLit, ok := vs.Values[0].(*ast.BasicLit); ok && basicLit.Kind == token.INT {
-			val, err := strconv.Atoi(basicLit.Value)
-			if err == nil {
-				en.Index = val
-				hasDirectValue = true
-				// Don't return here, continue processing comments
-			}
-		}
+	if index, ok := consts.index(name); ok {
+		en.Index = index
 	}
-
-	// Original iota processing logic
-	if !hasDirectValue {
-		for _, v := range vs.Values {
-			t, ok := v.(*ast.BinaryExpr)
-			if !ok {
-				continue
-			}
-			x, ok := t.X.(*ast.Ident)
-			if !ok {
-				return nil
-			}
-			if x.Name != iotaIdentifier {
-				return nil
-			} else {
-				*iotaFound = true
-			}
-			y, ok := t.Y.(*ast.BasicLit)
-			if !ok {
-				return nil
-			}
-			if y.Kind != token.INT {
-				return nil
-			}
-			val, err := strconv.Atoi(y.Value)
-			if err != nil {
-				return nil
-			}
-			*idx = val
-			enumIota.StartIndex = *idx
-		}
-
-		// If no direct assignment found, use index
-		if len(vs.Values) == 0 {
-			en.Index = *idx
-			*idx++
-		}
+	en.Value = consts.literal(name)
+	if !isEvaluated && !block.last.withIota && block.last.value != nil {
+		en.Value = block.last.value.ExactString()
+	}
+	if p.fset != nil {
+		en.Position = p.fset.Position(vs.Names[0].Pos())
 	}
 
 	// Process custom comments from doc comments (above the constant)
@@ -381,9 +541,19 @@ func (p *Parser) getEnum(vs *ast.ValueSpec, idx *int, enumIota *enum.EnumIota, i
 		// Extract all doc comments for the generated struct field
 		en.CustomComment = p.parseAllDocComments(vs.Doc.List)
 
+		if serdeName := p.parseDocWord(vs.Doc.List, serdeNamePrefix); serdeName != "" {
+			en.SerdeName = serdeName
+		}
+		en.SerdeFormat = p.parseDocWord(vs.Doc.List, serdeFormatPrefix)
+		en.Deprecated = p.isDeprecated(vs.Doc.List)
+		en.LegacyAliases = p.parseDocList(vs.Doc.List, legacyAliasesPrefix)
+		en.Groups = p.parseDocList(vs.Doc.List, groupsPrefix)
+		en.Tags = p.parseDocList(vs.Doc.List, tagsPrefix)
+
 		// Also check for state machine annotations in doc comments
-		if docStateTransitions, docIsFinal := p.parseDocStateAnnotations(vs.Doc.List); len(docStateTransitions) > 0 || docIsFinal {
+		if docStateTransitions, docStateEvents, docIsFinal := p.parseDocStateAnnotations(vs.Doc.List); len(docStateTransitions) > 0 || docIsFinal {
 			en.StateTransitions = docStateTransitions
+			en.StateEvents = docStateEvents
 			en.IsFinalState = docIsFinal
 		}
 	}
@@ -408,26 +578,47 @@ func (p *Parser) getEnum(vs *ast.ValueSpec, idx *int, enumIota *enum.EnumIota, i
 
 		// Parse state machine annotations
 		if gostrings.Contains(comment, "state:") {
-			cleanedComment, stateTransitions, isFinal := p.parseStateAnnotation(comment)
+			p.checkTransitions(vs.Comment.List[0], comment)
+			cleanedComment, stateTransitions, stateEvents, isFinal := p.parseStateAnnotation(comment)
 			comment = cleanedComment
 			en.StateTransitions = stateTransitions
+			en.StateEvents = stateEvents
 			en.IsFinalState = isFinal
 		}
 
+		// Parse serialization name and format overrides
+		if cleanedComment, serdeName := p.parseWordAnnotation(comment, serdeNamePrefix); serdeName != "" {
+			comment = cleanedComment
+			en.SerdeName = serdeName
+		}
+		if cleanedComment, serdeFormat := p.parseWordAnnotation(comment, serdeFormatPrefix); serdeFormat != "" {
+			comment = cleanedComment
+			en.SerdeFormat = serdeFormat
+		}
+
+		// Parse legacy aliases, groups and tags, which extend to the end of the comment
+		comment = p.parseListAnnotations(comment, map[string]*[]string{
+			legacyAliasesPrefix: &en.LegacyAliases,
+			groupsPrefix:        &en.Groups,
+			tagsPrefix:          &en.Tags,
+		})
+
 		valid := !gostrings.Contains(comment, "invalid")
 		if !valid {
 			comment = gostrings.ReplaceAll(comment, "invalid", "")
 		}
 		en.Valid = valid
-		s1, s2 := gostrings.SplitBySpace(gostrings.TrimLeft(comment, " "))
+		s1, s2, _ := enum.CutComment(gostrings.TrimLeft(comment, " "))
 		expectedFields := len(enumIota.Fields)
 		if s1 == "" && s2 == "" {
 			return &en
 		}
 		if s1 != "" && s2 == "" {
 			if expectedFields > 0 {
+				p.checkList(vs.Comment.List[0], s1, expectedFields)
 				f, err := enum.ParseEnumFields(s1, *enumIota)
 				if err != nil {
+					p.commentError(vs.Comment.List[0], s1, 0, err)
 					slog.Default().Warn("failed to parse enum fields",
 						"enum", vs.Names[0].Name,
 						"error", err)
@@ -436,13 +627,17 @@ func (p *Parser) getEnum(vs *ast.ValueSpec, idx *int, enumIota *enum.EnumIota, i
 				en.Fields = f
 				return &en
 			}
+			p.checkList(vs.Comment.List[0], s1, 0)
 			en.Aliases = enum.ParseEnumAliases(s1)
 			return &en
 		}
 		if s1 != "" && s2 != "" {
+			p.checkList(vs.Comment.List[0], s1, 0)
 			en.Aliases = enum.ParseEnumAliases(s1)
+			p.checkList(vs.Comment.List[0], s2, expectedFields)
 			f, err := enum.ParseEnumFields(s2, *enumIota)
 			if err != nil {
+				p.commentError(vs.Comment.List[0], s2, 0, err)
 				return nil
 			}
 			en.Fields = f
@@ -453,8 +648,9 @@ func (p *Parser) getEnum(vs *ast.ValueSpec, idx *int, enumIota *enum.EnumIota, i
 }
 
 type enumInfo struct {
-	Imports []string
-	Enums   []enum.EnumIota
+	Imports      []string
+	FieldImports []enum.Import
+	Enums        []enum.EnumIota
 }
 
 // parseCustomComment extracts custom comments from doc comment list
@@ -490,8 +686,13 @@ func (p *Parser) parseDocFirstLineAsAlias(comments []*ast.Comment) string {
 
 	content := gostrings.TrimSpace(firstComment[len(commentPrefix):])
 
-	// Skip if this line contains state machine annotations
-	if gostrings.Contains(content, "state:") {
+	// Skip if this line contains state machine, serialization or deprecation annotations
+	if gostrings.Contains(content, "state:") ||
+		gostrings.HasPrefix(content, serdeNamePrefix) ||
+		gostrings.HasPrefix(content, legacyAliasesPrefix) ||
+		gostrings.HasPrefix(content, groupsPrefix) ||
+		gostrings.HasPrefix(content, tagsPrefix) ||
+		gostrings.HasPrefix(content, deprecatedPrefix) {
 		return ""
 	}
 
@@ -520,7 +721,13 @@ func (p *Parser) parseAllDocComments(comments []*ast.Comment) string {
 		}
 
 		content := gostrings.TrimSpace(comment.Text[len(commentPrefix):])
-		if content == "" {
+		if content == "" ||
+			gostrings.HasPrefix(content, serdeNamePrefix) ||
+			gostrings.HasPrefix(content, serdeFormatPrefix) ||
+			gostrings.HasPrefix(content, legacyAliasesPrefix) ||
+			gostrings.HasPrefix(content, groupsPrefix) ||
+			gostrings.HasPrefix(content, tagsPrefix) ||
+			gostrings.HasPrefix(content, "state:") {
 			continue
 		}
 
@@ -569,16 +776,17 @@ func (p *Parser) isSimpleNameDefinition(content string) bool {
 // parseStateAnnotation parses state machine annotations from comments
 // Supports formats like:
 // - "state: -> Next1, Next2" for transitions
+// - "state: OnPay -> Next1, OnCancel -> Next2" for transitions fired by events
 // - "state: [final]" for final states
-// Returns the cleaned comment, transitions slice, and final state flag
-func (p *Parser) parseStateAnnotation(comment string) (string, []string, bool) {
-	var transitions []string
+// Returns the cleaned comment, transitions slice, their events, and final state flag
+func (p *Parser) parseStateAnnotation(comment string) (string, []string, []string, bool) {
+	var transitions, events []string
 	isFinal := false
 
 	// Find state: annotation
 	stateIndex := gostrings.Index(comment, "state:")
 	if stateIndex == -1 {
-		return comment, transitions, isFinal
+		return comment, transitions, events, isFinal
 	}
 
 	// Extract the state annotation part
@@ -600,32 +808,205 @@ func (p *Parser) parseStateAnnotation(comment string) (string, []string, bool) {
 
 		// Check for transitions (-> syntax)
 		if gostrings.Contains(afterState, "->") {
-			arrowIndex := gostrings.Index(afterState, "->")
-			transitionsPart := afterState[arrowIndex+2:]
-
-			// Split transitions by comma
-			if gostrings.TrimSpace(transitionsPart) != "" {
-				transitionsList := gostrings.Split(transitionsPart, ",")
-				for _, t := range transitionsList {
-					trimmed := gostrings.TrimSpace(t)
-					if trimmed != "" {
-						transitions = append(transitions, trimmed)
-					}
-				}
-			}
+			transitions, events = parseTransitionList(afterState)
 		}
 	}
 
 	// Clean up the comment by removing the state annotation
 	cleanedComment := gostrings.TrimSpace(beforeState + " " + remaining)
 
-	return cleanedComment, transitions, isFinal
+	return cleanedComment, transitions, events, isFinal
+}
+
+// commentError records err, found at offset in segment, a part of the text
+// of comment. Under Failfast it fails the parse with the position of the
+// problem; otherwise the comment is read as well as it can be.
+func (p *Parser) commentError(comment *ast.Comment, segment string, offset int, err error) {
+	pos := comment.Pos()
+	if i := gostrings.Index(comment.Text, segment); i >= 0 {
+		pos += token.Pos(i + offset)
+	}
+	err = fmt.Errorf("%w: %s: %w", ErrParseGoSource, p.position(pos), err)
+	if !p.Configuration.Failfast {
+		slog.Default().Debug("malformed comment", "error", err)
+		return
+	}
+	p.commentErrs = append(p.commentErrs, err)
+}
+
+// checkList reports the syntax errors of list, a comma-separated part of
+// comment, and a number of items other than want when want is not zero.
+func (p *Parser) checkList(comment *ast.Comment, list string, want int) {
+	tokens, err := enum.SplitComment(list, ',')
+	var syntaxErr *enum.CommentSyntaxError
+	if errors.As(err, &syntaxErr) {
+		p.commentError(comment, list, syntaxErr.Offset, err)
+		return
+	}
+	if want != 0 && len(tokens) != want {
+		p.commentError(comment, list, 0, &enum.CommentSyntaxError{
+			Msg: fmt.Sprintf("%d field values in %q, want %d", len(tokens), list, want),
+		})
+	}
+}
+
+// checkTransitions reports the syntax errors of the transitions of the
+// state annotation in text, a part of comment, and transitions without a
+// target state.
+func (p *Parser) checkTransitions(comment *ast.Comment, text string) {
+	_, list, _ := gostrings.Cut(text, "state:")
+	list = gostrings.ReplaceAll(list, "[final]", "")
+	if !gostrings.Contains(list, "->") {
+		return
+	}
+	tokens, err := enum.SplitComment(list, ',')
+	var syntaxErr *enum.CommentSyntaxError
+	if errors.As(err, &syntaxErr) {
+		p.commentError(comment, list, syntaxErr.Offset, err)
+		return
+	}
+	for _, t := range tokens {
+		if _, target, _ := gostrings.Cut(t.Raw, "->"); gostrings.Contains(t.Raw, "->") && gostrings.TrimSpace(target) == "" {
+			p.commentError(comment, list, t.Offset, &enum.CommentSyntaxError{
+				Offset: t.Offset,
+				Msg:    fmt.Sprintf("transition %q has no target state", t.Raw),
+			})
+		}
+	}
+}
+
+// parseTransitionList parses the comma separated transitions of a state
+// annotation, each either a target state or "Event -> Target". A leading
+// "->" without an event, as in "-> Next1, Next2", applies to no transition.
+// The events are returned at the index of their transition, and are nil
+// when no transition names one.
+func parseTransitionList(list string) (transitions, events []string) {
+	named := false
+	for _, t := range gostrings.Split(list, ",") {
+		event, target, found := gostrings.Cut(t, "->")
+		if !found {
+			event, target = "", t
+		}
+		event, target = gostrings.TrimSpace(event), gostrings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+		transitions = append(transitions, target)
+		events = append(events, event)
+		named = named || event != ""
+	}
+	if !named {
+		return transitions, nil
+	}
+	return transitions, events
+}
+
+// serdeNamePrefix introduces a per-constant serialization name override,
+// e.g. "json:archived_state".
+const serdeNamePrefix = "json:"
+
+// serdeFormatPrefix introduces a per-constant override of the serialization
+// format of the type, "serde:name" or "serde:value".
+const serdeFormatPrefix = "serde:"
+
+// parseWordAnnotation extracts a single-word "<prefix><value>" annotation,
+// such as "json:archived_state", from a trailing comment.
+// Returns the comment without the annotation and its value.
+func (p *Parser) parseWordAnnotation(comment, prefix string) (string, string) {
+	words := gostrings.Fields(comment)
+	for i, word := range words {
+		if value, ok := gostrings.CutPrefix(word, prefix); ok && value != "" {
+			words = slices.Delete(words, i, i+1)
+			return gostrings.Join(words, " "), value
+		}
+	}
+	return comment, ""
+}
+
+// parseDocWord looks for a standalone "<prefix><value>" line, such as
+// "json:archived_state", in doc comments and returns its value.
+func (p *Parser) parseDocWord(comments []*ast.Comment, prefix string) string {
+	for _, comment := range comments {
+		if !gostrings.HasPrefix(comment.Text, "//") {
+			continue
+		}
+		content := gostrings.TrimSpace(comment.Text[2:])
+		if value, ok := gostrings.CutPrefix(content, prefix); ok {
+			return gostrings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// legacyAliasesPrefix introduces the historic names of a constant,
+// e.g. "aliases: OldName,LegacyName".
+const legacyAliasesPrefix = "aliases:"
+
+// groupsPrefix introduces the groups of a constant, e.g. "group: terminal".
+const groupsPrefix = "group:"
+
+// tagsPrefix introduces the tags of a constant, e.g. "tags: billing,external".
+const tagsPrefix = "tags:"
+
+// parseListAnnotations extracts comma-separated list annotations, such as
+// "aliases: A,B", from a trailing comment. Each annotation extends to the end
+// of the comment or to the next annotation, so they are cut starting with the
+// one written last. The items of each annotation are appended to the list of
+// its prefix. Returns the comment without the annotations.
+func (p *Parser) parseListAnnotations(comment string, lists map[string]*[]string) string {
+	for {
+		last, idx := "", -1
+		for prefix := range lists {
+			if i := gostrings.Index(comment, prefix); i > idx {
+				last, idx = prefix, i
+			}
+		}
+		if idx == -1 {
+			return comment
+		}
+		items := config.SplitList(comment[idx+len(last):])
+		*lists[last] = append(items, *lists[last]...)
+		comment = gostrings.TrimSpace(comment[:idx])
+	}
+}
+
+// parseDocList looks for standalone lines starting with prefix, such as
+// "aliases:", in doc comments and returns the items they list.
+func (p *Parser) parseDocList(comments []*ast.Comment, prefix string) []string {
+	var items []string
+	for _, comment := range comments {
+		if !gostrings.HasPrefix(comment.Text, "//") {
+			continue
+		}
+		content := gostrings.TrimSpace(comment.Text[2:])
+		if list, ok := gostrings.CutPrefix(content, prefix); ok {
+			items = append(items, config.SplitList(list)...)
+		}
+	}
+	return items
+}
+
+// deprecatedPrefix starts the paragraph that marks an identifier as deprecated,
+// following the Go doc comment convention.
+const deprecatedPrefix = "Deprecated:"
+
+// isDeprecated reports whether doc comments contain a "Deprecated:" notice.
+func (p *Parser) isDeprecated(comments []*ast.Comment) bool {
+	for _, comment := range comments {
+		if !gostrings.HasPrefix(comment.Text, "//") {
+			continue
+		}
+		if gostrings.HasPrefix(gostrings.TrimSpace(comment.Text[2:]), deprecatedPrefix) {
+			return true
+		}
+	}
+	return false
 }
 
 // parseDocStateAnnotations parses state machine annotations from doc comments
 // Looks for standalone "state:" lines in doc comments
-func (p *Parser) parseDocStateAnnotations(comments []*ast.Comment) ([]string, bool) {
-	var transitions []string
+func (p *Parser) parseDocStateAnnotations(comments []*ast.Comment) ([]string, []string, bool) {
+	var transitions, events []string
 	isFinal := false
 
 	for _, comment := range comments {
@@ -638,6 +1019,7 @@ func (p *Parser) parseDocStateAnnotations(comments []*ast.Comment) ([]string, bo
 
 		// Check if this is a state annotation line
 		if gostrings.HasPrefix(content, "state:") {
+			p.checkTransitions(comment, content)
 			stateContent := gostrings.TrimSpace(content[6:]) // Remove "state:"
 
 			// Check for final state
@@ -648,22 +1030,13 @@ func (p *Parser) parseDocStateAnnotations(comments []*ast.Comment) ([]string, bo
 			}
 
 			// Check for transitions
-			if gostrings.HasPrefix(stateContent, "->") {
-				transitionsPart := gostrings.TrimSpace(stateContent[2:])
-				if transitionsPart != "" {
-					transitionsList := gostrings.Split(transitionsPart, ",")
-					for _, t := range transitionsList {
-						trimmed := gostrings.TrimSpace(t)
-						if trimmed != "" {
-							transitions = append(transitions, trimmed)
-						}
-					}
-				}
+			if gostrings.Contains(stateContent, "->") {
+				transitions, events = parseTransitionList(stateContent)
 			}
 		}
 	}
 
-	return transitions, isFinal
+	return transitions, events, isFinal
 }
 
 // constBlockBelongsToEnum determines if a const block belongs to the target enum type
@@ -684,27 +1057,13 @@ func (p *Parser) constBlockBelongsToEnum(genDecl *ast.GenDecl, enumIota *enum.En
 		}
 
 		// Check if this constant has the target type
-		if vs.Type != nil {
-			if ident, ok := vs.Type.(*ast.Ident); ok && ident.Name == enumIota.Type {
-				hasTargetType = true
-			}
+		if vs.Type != nil && specHasType(vs, enumIota) {
+			hasTargetType = true
 		}
 
 		// Check if this constant uses iota
-		if vs.Values != nil {
-			for _, v := range vs.Values {
-				if ident, ok := v.(*ast.Ident); ok && ident.Name == "iota" {
-					hasIota = true
-					break
-				}
-				// Check for iota + offset (like iota + 1)
-				if binExpr, ok := v.(*ast.BinaryExpr); ok {
-					if x, ok := binExpr.X.(*ast.Ident); ok && x.Name == "iota" {
-						hasIota = true
-						break
-					}
-				}
-			}
+		if slices.ContainsFunc(vs.Values, usesIota) {
+			hasIota = true
 		}
 
 		if hasTargetType && hasIota {
@@ -739,7 +1098,9 @@ func (p *Parser) getEnumInfo(node *ast.File) enumInfo {
 				typeName := ts.Name.Name
 
 				enumIota := enum.EnumIota{
-					Type: typeName,
+					Type:    typeName,
+					Doc:     typeDoc(typeDocLines(t, ts)),
+					Default: typeDefault(typeDocLines(t, ts)),
 				}
 
 				// Extract underlying type
@@ -753,6 +1114,7 @@ func (p *Parser) getEnumInfo(node *ast.File) enumInfo {
 					if gostrings.HasPrefix(comment, "//") {
 						comment = comment[2:]
 					}
+					p.checkList(ts.Comment.List[0], comment, 0)
 					opener, closer, fields := enum.ExtractFields(comment)
 
 					enumIota.Comment = comment
@@ -766,98 +1128,249 @@ func (p *Parser) getEnumInfo(node *ast.File) enumInfo {
 	}
 	imports := enum.ExtractImports(enumIotas)
 	return enumInfo{
-		Imports: imports,
-		Enums:   enumIotas,
+		Imports:      imports,
+		FieldImports: p.getFieldImports(node, enumIotas),
+		Enums:        enumIotas,
+	}
+}
+
+// defaultPrefix introduces the default value of a type in its documentation,
+// e.g. "default: Step1Initialized".
+const defaultPrefix = "default:"
+
+// typeDoc returns the documentation of a type declaration from its lines,
+// dropping the goenums directive and default annotation it may contain.
+func typeDoc(docLines []string) string {
+	var lines []string
+	for _, line := range docLines {
+		if !gostrings.HasPrefix(line, "goenums:") && !gostrings.HasPrefix(line, defaultPrefix) {
+			lines = append(lines, line)
+		}
+	}
+	return gostrings.TrimSpace(gostrings.Join(lines, "\n"))
+}
+
+// typeDefault returns the value named by the "default:" line of the
+// documentation of a type declaration, if any.
+func typeDefault(docLines []string) string {
+	for _, line := range docLines {
+		if rest, ok := gostrings.CutPrefix(line, defaultPrefix); ok {
+			return gostrings.TrimSpace(rest)
+		}
+	}
+	return ""
+}
+
+// typeDocLines returns the lines of the doc comment of a type declaration.
+// The doc comment of an ungrouped declaration belongs to the GenDecl rather
+// than the TypeSpec.
+func typeDocLines(decl *ast.GenDecl, ts *ast.TypeSpec) []string {
+	doc := ts.Doc
+	if doc == nil && len(decl.Specs) == 1 {
+		doc = decl.Doc
+	}
+	return commentLines(doc)
+}
+
+// commentLines returns the lines of a comment group, which may be nil.
+func commentLines(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
 	}
+	return gostrings.Split(gostrings.TrimSpace(doc.Text()), "\n")
+}
+
+// getFieldImports resolves the package qualifiers of field types declared in
+// type comments against the imports of the source file.
+func (p *Parser) getFieldImports(node *ast.File, enumIotas []enum.EnumIota) []enum.Import {
+	var imports []enum.Import
+	for _, enumIota := range enumIotas {
+		for _, field := range enumIota.Fields {
+			expr, ok := field.Value.(enum.Expr)
+			if !ok {
+				continue
+			}
+			qualifier := expr.Qualifier()
+			if slices.ContainsFunc(imports, func(imp enum.Import) bool { return imp.Name == qualifier }) {
+				continue
+			}
+			if imp, ok := resolveImport(node, qualifier); ok {
+				imports = append(imports, imp)
+			}
+		}
+	}
+	return imports
+}
+
+// resolveImport returns the import of the source file named qualifier.
+func resolveImport(node *ast.File, qualifier string) (enum.Import, bool) {
+	for _, spec := range node.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := enum.DefaultImportName(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == qualifier {
+			return enum.Import{Name: name, Path: path}, true
+		}
+	}
+	return enum.Import{}, false
+}
+
+// addProtoImports adds the packages of the protobuf enums named by -proto
+// directives to the imports of the generated code.
+func addProtoImports(node *ast.File, imports []enum.Import, configs map[string]config.EnumTypeConfig) ([]enum.Import, error) {
+	for _, typeName := range slices.Sorted(maps.Keys(configs)) {
+		qualifier, _, ok := gostrings.Cut(configs[typeName].Proto, ".")
+		if !ok || slices.ContainsFunc(imports, func(imp enum.Import) bool { return imp.Name == qualifier }) {
+			continue
+		}
+		imp, ok := resolveImport(node, qualifier)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s: -proto package %s is not imported by the source file",
+				ErrParseGoSource, typeName, qualifier)
+		}
+		imports = append(imports, imp)
+	}
+	return imports, nil
+}
+
+// position returns the position of pos in the source, or the filename of
+// the source when its nodes are not positioned.
+func (p *Parser) position(pos token.Pos) string {
+	if p.fset == nil {
+		return p.source.Filename()
+	}
+	return p.fset.Position(pos).String()
 }
 
 // parseGoEnumsComment parses a "// goenums: arg arg ..." comment and returns the configuration
-func (p *Parser) parseGoEnumsComment(comment string) config.EnumTypeConfig {
+func (p *Parser) parseGoEnumsComment(comment *ast.Comment) (config.EnumTypeConfig, error) {
 	// Remove "// goenums:" prefix
-	if !gostrings.HasPrefix(comment, "// goenums:") {
-		return config.EnumTypeConfig{}
-	}
-
-	args := gostrings.TrimSpace(comment[len("// goenums:"):])
-	if args == "" {
-		return config.EnumTypeConfig{}
-	}
-
-	// Parse arguments
-	parts := gostrings.Fields(args)
-	cfg := config.EnumTypeConfig{
-		SerializationType: config.SerdeName, // Default
-		Handlers: config.Handlers{
-			SQL: false, // Default SQL support
-		},
-	}
-
-	for _, part := range parts {
-		switch part {
-		case "-json":
-			cfg.Handlers.JSON = true
-		case "-yaml":
-			cfg.Handlers.YAML = true
-		case "-text":
-			cfg.Handlers.Text = true
-		case "-binary":
-			cfg.Handlers.Binary = true
-		case "-sql":
-			cfg.Handlers.SQL = true
-		case "-uppercaseFields":
-			cfg.UppercaseFields = true
-		case "-genName":
-			cfg.GenerateNameConstants = true
-		case "-serde/name":
-			cfg.SerializationType = config.SerdeName
-		case "-serde/value":
-			cfg.SerializationType = config.SerdeValue
-		case "-statemachine":
-			cfg.StateMachine = true
-		default:
-			panic("unknown enum args: " + part)
-		}
+	if !gostrings.HasPrefix(comment.Text, "// goenums:") {
+		return config.EnumTypeConfig{}, nil
 	}
 
-	return cfg
+	// Parse arguments on top of the defaults given on the command line
+	args := gostrings.Fields(comment.Text[len("// goenums:"):])
+	cfg, err := p.Configuration.Defaults.ApplyDirectives(args)
+	if err != nil {
+		return config.EnumTypeConfig{}, fmt.Errorf("%w: %s: %w", ErrParseGoSource, p.position(comment.Pos()), err)
+	}
+	return cfg, nil
 }
 
 // findGoEnumsComment searches for "// goenums:" comment in the source file
-// and returns a map of type names to their configurations
-func (p *Parser) findGoEnumsComments(node *ast.File) map[string]config.EnumTypeConfig {
+// and returns a map of type names to their configurations.
+// A directive in a type's doc comment is bound to that type and takes
+// precedence over standalone directives, which are bound to the next type
+// declared after them.
+func (p *Parser) findGoEnumsComments(node *ast.File) (map[string]config.EnumTypeConfig, error) {
 	configs := make(map[string]config.EnumTypeConfig)
+	attached := make(map[*ast.CommentGroup]bool)
+	documented := make(map[string]bool)
 
-	// Look for comments in the file
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		// A directive documenting the constants of a type declared in another
+		// package is bound to the alias the generated code declares for it
+		if t, ok := constBlockImportedType(node, genDecl); ok && hasDirective(genDecl.Doc) {
+			for _, comment := range genDecl.Doc.List {
+				if gostrings.HasPrefix(comment.Text, "// goenums:") {
+					cfg, err := p.parseGoEnumsComment(comment)
+					if err != nil {
+						return nil, err
+					}
+					cfg.TypeName = t.alias()
+					configs[cfg.TypeName] = cfg
+					attached[genDecl.Doc] = true
+					documented[cfg.TypeName] = true
+				}
+			}
+			continue
+		}
+		if genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			doc := typeSpec.Doc
+			if doc == nil && !genDecl.Lparen.IsValid() {
+				doc = genDecl.Doc
+			}
+			if doc == nil {
+				continue
+			}
+			for _, comment := range doc.List {
+				if gostrings.HasPrefix(comment.Text, "// goenums:") {
+					cfg, err := p.parseGoEnumsComment(comment)
+					if err != nil {
+						return nil, err
+					}
+					cfg.TypeName = typeSpec.Name.Name
+					configs[cfg.TypeName] = cfg
+					attached[doc] = true
+					documented[cfg.TypeName] = true
+				}
+			}
+		}
+	}
+
+	// Look for standalone comments in the file
 	for _, commentGroup := range node.Comments {
+		if attached[commentGroup] {
+			continue
+		}
 		for _, comment := range commentGroup.List {
 			if gostrings.HasPrefix(comment.Text, "// goenums:") {
-				cfg := p.parseGoEnumsComment(comment.Text)
+				cfg, err := p.parseGoEnumsComment(comment)
+				if err != nil {
+					return nil, err
+				}
 
 				// Find the next type declaration after this comment
 				typeName := p.findNextTypeDeclaration(node, comment.Pos())
-				if typeName != "" {
-					cfg.TypeName = typeName
-					configs[typeName] = cfg
+				if typeName == "" || documented[typeName] {
+					continue
 				}
+				cfg.TypeName = typeName
+				configs[typeName] = cfg
 			}
 		}
 	}
 
-	return configs
+	return configs, nil
 }
 
-// findNextTypeDeclaration finds the next type declaration after the given position
+// findNextTypeDeclaration finds the type spec the comment at pos is attached to.
+// A comment belongs to the spec whose trailing line comment contains it,
+// otherwise to the first spec declared after it. Specs inside grouped
+// "type ( ... )" blocks are considered individually.
 func (p *Parser) findNextTypeDeclaration(node *ast.File, pos token.Pos) string {
 	for _, decl := range node.Decls {
-		if decl.Pos() <= pos {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE || genDecl.End() <= pos {
 			continue
 		}
-
-		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
-			for _, spec := range genDecl.Specs {
-				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
-					return typeSpec.Name.Name
-				}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			if typeSpec.Comment != nil && typeSpec.Comment.Pos() <= pos && pos < typeSpec.Comment.End() {
+				return typeSpec.Name.Name
+			}
+			if typeSpec.Name.Pos() > pos {
+				return typeSpec.Name.Name
 			}
 		}
 	}
@@ -3,9 +3,16 @@ package gofile_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
 	"github.com/donutnomad/goenums/generator/gofile"
 	"github.com/donutnomad/goenums/internal/testdata"
 	"github.com/donutnomad/goenums/source"
@@ -196,3 +203,1262 @@ func validateTypeInfo(t *testing.T, info enum.EnumIota) {
 		t.Error("type name is empty")
 	}
 }
+
+func TestParser_GroupedTypeDeclarations(t *testing.T) {
+	t.Parallel()
+	src := `package grouped
+
+type (
+	// goenums: -json
+	color int
+
+	// shape is documented.
+	// goenums: -sql -serde/value
+	shape int
+)
+
+const (
+	red color = iota
+	green
+)
+
+const (
+	circle shape = iota
+	square
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(result))
+	}
+	colorCfg := result[0].Configuration.GetEnumTypeConfig("color")
+	if !colorCfg.Handlers.JSON || colorCfg.Handlers.SQL {
+		t.Errorf("unexpected color config: %+v", colorCfg)
+	}
+	shapeCfg := result[0].Configuration.GetEnumTypeConfig("shape")
+	if !shapeCfg.Handlers.SQL || shapeCfg.Handlers.JSON || shapeCfg.SerializationType != config.SerdeValue {
+		t.Errorf("unexpected shape config: %+v", shapeCfg)
+	}
+}
+
+func TestParser_DirectiveInTypeDocComment(t *testing.T) {
+	t.Parallel()
+	src := `package docs
+
+// color is a documented enum.
+// goenums: -json
+type color int // goenums: -yaml
+
+const (
+	red color = iota
+	green
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := result[0].Configuration.GetEnumTypeConfig("color")
+	if !cfg.Handlers.JSON || cfg.Handlers.YAML {
+		t.Errorf("expected doc comment directive to take precedence, got %+v", cfg)
+	}
+}
+
+func TestParser_DirectiveDefaults(t *testing.T) {
+	t.Parallel()
+	src := `package defaults
+
+// goenums: -yaml -sql=false
+type color int
+
+const (
+	red color = iota
+	green
+)
+
+type shape int
+
+const (
+	circle shape = iota
+	square
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(config.Configuration{
+			Defaults: config.EnumTypeConfig{
+				Handlers:          config.Handlers{JSON: true, SQL: true},
+				SerializationType: config.SerdeValue,
+			},
+		}),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	color := result[0].Configuration.GetEnumTypeConfig("color")
+	if want := (config.Handlers{JSON: true, YAML: true}); color.Handlers != want {
+		t.Errorf("expected directive applied on top of defaults %+v, got %+v", want, color.Handlers)
+	}
+	if color.SerializationType != config.SerdeValue {
+		t.Errorf("expected default serialization type to be kept, got %v", color.SerializationType)
+	}
+	shape := result[0].Configuration.GetEnumTypeConfig("shape")
+	if want := (config.Handlers{JSON: true, SQL: true}); shape.Handlers != want {
+		t.Errorf("expected defaults for type without directive %+v, got %+v", want, shape.Handlers)
+	}
+}
+
+func TestParser_ConstantExpressions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		src  string
+		want []int
+	}{
+		{
+			name: "iota",
+			src:  "type level int\n\nconst (\n\tlow level = iota\n\tmid\n\thigh\n)\n",
+			want: []int{0, 1, 2},
+		},
+		{
+			name: "iota offset with skips",
+			src:  "type level int\n\nconst (\n\tlow level = iota + 1\n\t_\n\thigh\n)\n",
+			want: []int{1, 3},
+		},
+		{
+			name: "constant reference",
+			src:  "const base = 100\n\ntype level int\n\nconst (\n\tlow level = base + iota*10\n\tmid\n\thigh\n)\n",
+			want: []int{100, 110, 120},
+		},
+		{
+			name: "shift",
+			src:  "type level int\n\nconst (\n\tlow level = 1 << iota\n\tmid\n\thigh\n)\n",
+			want: []int{1, 2, 4},
+		},
+		{
+			name: "hex literals",
+			src:  "type level int\n\nconst (\n\tlow level = 0x10\n\tmid level = 0x20\n\thigh level = 0x40\n)\n",
+			want: []int{16, 32, 64},
+		},
+		{
+			name: "negative iota offset",
+			src:  "type level int\n\nconst (\n\tlow level = iota - 1\n\tmid\n\thigh\n)\n",
+			want: []int{-1, 0, 1},
+		},
+		{
+			name: "negative and zero values",
+			src:  "type level int\n\nconst (\n\tlow level = -5\n\tmid level = 0\n\thigh level = 7\n)\n",
+			want: []int{-5, 0, 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			parser := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader("package levels\n\n"+tt.src))),
+				gofile.WithParserConfiguration(testdata.DefaultConfig),
+			)
+			result, err := parser.Parse(t.Context())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []int
+			for _, e := range result[0].EnumIotas[0].Enums {
+				got = append(got, e.Index)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected indexes %v, got %v", tt.want, got)
+			}
+			if start := result[0].EnumIotas[0].StartIndex; start != tt.want[0] {
+				t.Errorf("expected start index %d, got %d", tt.want[0], start)
+			}
+		})
+	}
+}
+
+func TestParser_LargeValues(t *testing.T) {
+	t.Parallel()
+	src := "package flags\n\ntype flag uint64\n\nconst (\n\tlow flag = 1\n\ttop flag = 0x8000000000000000\n\tall flag = 1<<64 - 1\n)\n"
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, e := range result[0].EnumIotas[0].Enums {
+		got = append(got, e.Name+"="+e.Literal())
+	}
+	want := []string{"low=1", "top=9223372036854775808", "all=18446744073709551615"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestParser_MultipleConstBlocks(t *testing.T) {
+	t.Parallel()
+	src := `package steps
+
+type step int
+
+const (
+	stepCreated step = iota + 1
+	stepValidated
+	maxSteps = 3
+)
+
+const (
+	stepQueued step = iota + 10
+	_
+	stepRunning
+)
+
+const stepDone step = 20
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	enumIota := result[0].EnumIotas[0]
+	var got []string
+	for _, e := range enumIota.Enums {
+		got = append(got, fmt.Sprintf("%s=%d", e.Name, e.Index))
+	}
+	want := []string{"stepCreated=1", "stepValidated=2", "stepQueued=10", "stepRunning=12", "stepDone=20"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if enumIota.StartIndex != 1 {
+		t.Errorf("expected start index of the first block 1, got %d", enumIota.StartIndex)
+	}
+}
+
+func TestParser_ConstantFromSiblingFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module example.com/levels\n\ngo 1.24\n",
+		"base.go":  "package levels\n\nconst base = 100\n",
+		"level.go": "package levels\n\ntype level int\n\nconst (\n\tlow level = base + iota\n\thigh\n)\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromFile(filepath.Join(dir, "level.go"))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []int
+	for _, e := range result[0].EnumIotas[0].Enums {
+		got = append(got, e.Index)
+	}
+	if want := []int{100, 101}; !slices.Equal(got, want) {
+		t.Errorf("expected indexes %v, got %v", want, got)
+	}
+}
+
+func TestParser_PackageSource(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module example.com/levels\n\ngo 1.24\n",
+		"base.go":  "package levels\n\nconst base = 1 << 4\n",
+		"level.go": "package levels\n\ntype level int\n\nconst (\n\tlow level = base + iota*2\n\thigh\n)\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	src := source.FromPackages(".")
+	src.Dir = dir
+	sources, err := src.Sources(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	i := slices.IndexFunc(sources, func(s enum.Source) bool { return filepath.Base(s.Filename()) == "level.go" })
+	if i < 0 {
+		t.Fatalf("level.go not among the sources %v", sources)
+	}
+	parser := gofile.NewParser(
+		gofile.WithSource(sources[i]),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []int
+	for _, e := range result[0].EnumIotas[0].Enums {
+		got = append(got, e.Index)
+	}
+	if want := []int{16, 18}; !slices.Equal(got, want) {
+		t.Errorf("expected indexes %v, got %v", want, got)
+	}
+}
+
+func TestParser_ImportedType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		src  string
+		want string
+		err  error
+	}{
+		{
+			name: "qualified",
+			src:  "package orders\n\nimport \"example.com/shop/types\"\n\n// goenums: -json\nconst (\n\tpending types.Status = iota\n\tshipped\n)\n",
+			want: "types.Status",
+		},
+		{
+			name: "dot-imported",
+			src:  "package orders\n\nimport . \"example.com/shop/types\"\n\n// goenums: -wrapper=OrderStatus\nconst (\n\tpending Status = iota\n\tshipped\n)\n",
+			want: "types.Status",
+		},
+		{
+			name: "dot-imported without wrapper",
+			src:  "package orders\n\nimport . \"example.com/shop/types\"\n\n// goenums: -json\nconst (\n\tpending Status = iota\n\tshipped\n)\n",
+			err:  config.ErrUnsupportedCombination,
+		},
+		{
+			name: "alias declared",
+			src:  "package orders\n\nimport \"example.com/shop/types\"\n\ntype status int\n\n// goenums: -json\nconst (\n\tpending types.Status = iota\n)\n",
+			err:  gofile.ErrParseGoSource,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			files := map[string]string{
+				"go.mod":          "module example.com/shop\n\ngo 1.24\n",
+				"types/types.go":  "package types\n\ntype Status int\n",
+				"orders/order.go": tt.src,
+			}
+			for name, content := range files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			result, err := gofile.NewParser(
+				gofile.WithSource(source.FromFile(filepath.Join(dir, "orders", "order.go"))),
+				gofile.WithParserConfiguration(testdata.DefaultConfig),
+			).Parse(t.Context())
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			enumIota := result[0].EnumIotas[0]
+			if enumIota.Type != "status" || enumIota.ImportedType != tt.want || enumIota.UnderlyingType != "int" {
+				t.Errorf("expected status aliasing %s of int, got %s aliasing %s of %s",
+					tt.want, enumIota.Type, enumIota.ImportedType, enumIota.UnderlyingType)
+			}
+			if len(enumIota.Enums) != 2 {
+				t.Errorf("expected 2 values, got %d", len(enumIota.Enums))
+			}
+			if want := (enum.Import{Name: "types", Path: "example.com/shop/types"}); !slices.Contains(result[0].FieldImports, want) {
+				t.Errorf("expected import %v, got %v", want, result[0].FieldImports)
+			}
+			if cfg := result[0].Configuration.GetEnumTypeConfig("status"); tt.name == "qualified" && !cfg.Handlers.JSON {
+				t.Errorf("expected the directive of the const block to apply to status")
+			}
+		})
+	}
+}
+
+func TestParser_UnsupportedCombinations(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		directive string
+		wantErr   bool
+	}{
+		{"value json", "-json -serde/value", true},
+		{"value binary", "-binary -serde/value", true},
+		{"value sql", "-sql -text -yaml -serde/value", false},
+		{"name json binary", "-json -binary", false},
+		{"json null", "-json -json/null", false},
+		{"json null without json", "-json/null", true},
+		{"object json", "-json -serde/object", false},
+		{"object without json", "-text -serde/object", true},
+		{"sql column", "-sql -sql/column", false},
+		{"sql column without sql", "-sql/column", true},
+		{"orms", "-sql -sqlboiler -bun", false},
+		{"sqlboiler without sql", "-sqlboiler", true},
+		{"bun without sql", "-bun", true},
+		{"history", "-statemachine -statemachine/history", false},
+		{"history without statemachine", "-statemachine/history", true},
+		{"kafka", "-kafka", false},
+		{"value kafka", "-kafka -serde/value", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			src := "package colors\n\n// goenums: " + tt.directive +
+				"\ntype color string\n\nconst (\n\tred color = \"r\"\n\tgreen color = \"g\"\n)\n"
+			parser := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader(src))),
+				gofile.WithParserConfiguration(testdata.DefaultConfig),
+			)
+			_, err := parser.Parse(t.Context())
+			if tt.wantErr != errors.Is(err, config.ErrUnsupportedCombination) {
+				t.Errorf("expected unsupported combination error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParser_Default(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		doc     string
+		want    string
+		wantErr error
+	}{
+		{name: "declared", doc: "// Step is a job step.\n//\n// default: step1Initialized\n//\n// goenums: -json -default-on-error\n", want: "step1Initialized"},
+		{name: "without directive", doc: "// default: step1Initialized\n", want: "step1Initialized"},
+		{name: "missing", doc: "// goenums: -json -default-on-error\n", wantErr: config.ErrUnsupportedCombination},
+		{name: "invalid value", doc: "// default: step0Unknown\n// goenums: -json -default-on-error\n", wantErr: gofile.ErrInvalidDefault},
+		{name: "unknown value", doc: "// default: step9Done\n// goenums: -json -default-on-error\n", wantErr: gofile.ErrInvalidDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			src := "package jobs\n\n" + tt.doc +
+				"type step int\n\nconst (\n\tstep0Unknown step = iota // invalid\n\tstep1Initialized\n\tstep2Running\n)\n"
+			parser := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader(src))),
+				gofile.WithParserConfiguration(testdata.DefaultConfig),
+			)
+			reqs, err := parser.Parse(t.Context())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			iota := reqs[0].EnumIotas[0]
+			if iota.Default != tt.want {
+				t.Errorf("expected default %q, got %q", tt.want, iota.Default)
+			}
+			if strings.Contains(iota.Doc, "default:") {
+				t.Errorf("expected the default line to be dropped from the doc, got %q", iota.Doc)
+			}
+		})
+	}
+}
+
+func TestParser_BinaryEncoding(t *testing.T) {
+	t.Parallel()
+	if _, err := (config.EnumTypeConfig{}).ApplyDirectives([]string{"-binary=zigzag"}); !errors.Is(err, config.ErrUnknownDirective) {
+		t.Errorf("expected ErrUnknownDirective for an unknown encoding, got %v", err)
+	}
+	tests := []struct {
+		directive  string
+		wantBinary bool
+		want       string
+	}{
+		{directive: "-binary", wantBinary: true},
+		{directive: "-binary=varint,length-prefixed", wantBinary: true, want: "varint,length-prefixed"},
+		{directive: "-binary=false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.directive, func(t *testing.T) {
+			t.Parallel()
+			src := "package colors\n\n// goenums: " + tt.directive +
+				"\ntype color int\n\nconst (\n\tred color = iota\n\tgreen\n)\n"
+			parser := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader(src))),
+				gofile.WithParserConfiguration(testdata.DefaultConfig),
+			)
+			reqs, err := parser.Parse(t.Context())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cfg := reqs[0].Configuration.GetEnumTypeConfig("color")
+			if cfg.Handlers.Binary != tt.wantBinary || cfg.BinaryEncoding != tt.want {
+				t.Errorf("unexpected binary configuration %v, %q", cfg.Handlers.Binary, cfg.BinaryEncoding)
+			}
+		})
+	}
+}
+
+func TestParser_SQLType(t *testing.T) {
+	t.Parallel()
+	if _, err := (config.EnumTypeConfig{}).ApplyDirectives([]string{"-sqltype=float"}); !errors.Is(err, config.ErrUnknownDirective) {
+		t.Errorf("expected ErrUnknownDirective for an unknown SQL type, got %v", err)
+	}
+	tests := []struct {
+		directive      string
+		underlyingType string
+		wantErr        bool
+	}{
+		{directive: "-sql -sqltype=string", underlyingType: "int"},
+		{directive: "-sql -serde/value -sqltype=string", underlyingType: "float64"},
+		{directive: "-sql -sqltype=int", underlyingType: "uint8"},
+		{directive: "-sql -sqltype=int", underlyingType: "string", wantErr: true},
+		{directive: "-sql -sqltype=bool", underlyingType: "bool"},
+		{directive: "-sql -sqltype=bool", underlyingType: "int", wantErr: true},
+		{directive: "-json -sqltype=string", underlyingType: "int", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.directive+" "+tt.underlyingType, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := (config.EnumTypeConfig{}).ApplyDirectives(strings.Fields(tt.directive))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = cfg.Validate(tt.underlyingType)
+			if tt.wantErr != errors.Is(err, config.ErrUnsupportedCombination) {
+				t.Errorf("expected unsupported combination error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && !slices.Contains(cfg.Directives(), "-sqltype="+cfg.SQLType) {
+				t.Errorf("expected %v to reproduce -sqltype=%s", cfg.Directives(), cfg.SQLType)
+			}
+		})
+	}
+}
+
+func TestParser_Float(t *testing.T) {
+	t.Parallel()
+	for _, directive := range []string{"-float/epsilon=0", "-float/epsilon=tiny", "-float/format=%d", "-float/format=%+.2f"} {
+		if _, err := (config.EnumTypeConfig{}).ApplyDirectives([]string{directive}); !errors.Is(err, config.ErrUnknownDirective) {
+			t.Errorf("expected ErrUnknownDirective for %s, got %v", directive, err)
+		}
+	}
+	tests := []struct {
+		directive      string
+		underlyingType string
+		wantErr        bool
+	}{
+		{directive: "-float/epsilon=1e-06", underlyingType: "float64"},
+		{directive: "-float/epsilon=0.001", underlyingType: "float32"},
+		{directive: "-float/epsilon=1e-06", underlyingType: "int", wantErr: true},
+		{directive: "-float/format=%.2f", underlyingType: "float64"},
+		{directive: "-float/format=%e", underlyingType: "float32"},
+		{directive: "-float/format=%.2f", underlyingType: "string", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.directive+" "+tt.underlyingType, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := (config.EnumTypeConfig{}).ApplyDirectives(strings.Fields(tt.directive))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = cfg.Validate(tt.underlyingType)
+			if tt.wantErr != errors.Is(err, config.ErrUnsupportedCombination) {
+				t.Errorf("expected unsupported combination error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && !slices.Contains(cfg.Directives(), tt.directive) {
+				t.Errorf("expected %v to reproduce %s", cfg.Directives(), tt.directive)
+			}
+		})
+	}
+}
+
+func TestParser_InvalidName(t *testing.T) {
+	t.Parallel()
+	for _, directive := range []string{"-invalid=", "-invalid=unknown", "-invalid=Not-Set"} {
+		if _, err := (config.EnumTypeConfig{}).ApplyDirectives([]string{directive}); !errors.Is(err, config.ErrUnknownDirective) {
+			t.Errorf("expected ErrUnknownDirective for %s, got %v", directive, err)
+		}
+	}
+	tests := []struct {
+		directive string
+		wantErr   error
+	}{
+		{directive: "-invalid=Unknown"},
+		{directive: "-invalid=Unknown -invalid/all"},
+		{directive: "-invalid/all", wantErr: config.ErrUnsupportedCombination},
+		{directive: "-invalid=Off", wantErr: config.ErrUnsupportedCombination},
+	}
+	for _, tt := range tests {
+		t.Run(tt.directive, func(t *testing.T) {
+			t.Parallel()
+			src := "package power\n\n// goenums: " + tt.directive + "\ntype mode int\n\nconst (\n\toff mode = iota\n\ton\n)\n"
+			parser := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader(src))),
+				gofile.WithParserConfiguration(testdata.DefaultConfig),
+			)
+			reqs, err := parser.Parse(t.Context())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			cfg := reqs[0].Configuration.GetEnumTypeConfig("mode")
+			if got := strings.Join(cfg.Directives(), " "); got != tt.directive {
+				t.Errorf("expected directives %q, got %q", tt.directive, got)
+			}
+		})
+	}
+}
+
+func TestParser_WrapperAndReceiver(t *testing.T) {
+	t.Parallel()
+	for _, directive := range []string{"-wrapper=orderState", "-wrapper=Order-State", "-receiver=", "-receiver=_", "-receiver=func"} {
+		if _, err := (config.EnumTypeConfig{}).ApplyDirectives([]string{directive}); !errors.Is(err, config.ErrUnknownDirective) {
+			t.Errorf("expected ErrUnknownDirective for %s, got %v", directive, err)
+		}
+	}
+	src := "package orders\n\n// goenums: -wrapper=OrderState -receiver=os\ntype tokenRequestStatus int\n\nconst (\n\tpending tokenRequestStatus = iota\n\tdone\n)\n"
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	reqs, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := reqs[0].Configuration.GetEnumTypeConfig("tokenRequestStatus")
+	if cfg.WrapperName != "OrderState" || cfg.Receiver != "os" {
+		t.Errorf("expected wrapper OrderState and receiver os, got %q and %q", cfg.WrapperName, cfg.Receiver)
+	}
+}
+
+func TestParser_Plural(t *testing.T) {
+	t.Parallel()
+	for _, directive := range []string{"-plural=schemata", "-plural=", "-plural=Table-Schemata"} {
+		if _, err := (config.EnumTypeConfig{}).ApplyDirectives([]string{directive}); !errors.Is(err, config.ErrUnknownDirective) {
+			t.Errorf("expected ErrUnknownDirective for %s, got %v", directive, err)
+		}
+	}
+	src := "package orders\n\n// goenums: -plural=Schemata\ntype tableSchema int\n\nconst (\n\tpending tableSchema = iota\n\tdone\n)\n"
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	reqs, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg := reqs[0].Configuration.GetEnumTypeConfig("tableSchema"); cfg.Plural != "Schemata" {
+		t.Errorf("expected plural Schemata, got %q", cfg.Plural)
+	}
+	for _, list := range []string{"schema", "schema:", "schema:schema-ta", "1:2"} {
+		if _, err := config.ParsePlurals(list); !errors.Is(err, config.ErrUnknownDirective) {
+			t.Errorf("expected ErrUnknownDirective for -plurals %s, got %v", list, err)
+		}
+	}
+	plurals, err := config.ParsePlurals("Schema:Schemata, status:statii,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plurals) != 2 || plurals["schema"] != "schemata" || plurals["status"] != "statii" {
+		t.Errorf("expected schema:schemata and status:statii, got %v", plurals)
+	}
+}
+
+func TestParser_Order(t *testing.T) {
+	t.Parallel()
+	for _, directive := range []string{"-order=", "-order=iota", "-order=Value"} {
+		if _, err := (config.EnumTypeConfig{}).ApplyDirectives([]string{directive}); !errors.Is(err, config.ErrUnknownDirective) {
+			t.Errorf("expected ErrUnknownDirective for %s, got %v", directive, err)
+		}
+	}
+	src := "package tasks\n\n// goenums: -order=value\ntype priority int\n\nconst (\n\thigh priority = 10\n\tlow priority = 1\n)\n"
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	reqs, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := reqs[0].Configuration.GetEnumTypeConfig("priority")
+	if cfg.Order != config.OrderValue {
+		t.Errorf("expected order value, got %q", cfg.Order)
+	}
+	if got := cfg.Directives(); !slices.Contains(got, "-order=value") {
+		t.Errorf("expected directives to contain -order=value, got %v", got)
+	}
+}
+
+func TestParser_BuildConstraint(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		filename string
+		header   string
+		tags     []string
+		want     string
+	}{
+		{filename: "mode.go", want: ""},
+		{filename: "mode.go", header: "//go:build !purego\n\n", want: "!purego"},
+		{filename: "mode_linux.go", want: "linux"},
+		{filename: "mode_linux_arm64.go", header: "//go:build cgo || purego\n\n", want: "(cgo || purego) && linux && arm64"},
+		{filename: "linux.go", want: ""},
+		{filename: "mode_other.go", tags: []string{"integration"}, want: "integration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename+tt.want, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			src := tt.header + "package plat\n\ntype mode int\n\nconst (\n\tmodeEpoll mode = iota\n\tmodeIOUring\n)\n"
+			if err := memfs.WriteFile(tt.filename, []byte(src), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			parser := gofile.NewParser(
+				gofile.WithSource(source.FromFileSystem(memfs, tt.filename)),
+				gofile.WithParserConfiguration(config.Configuration{BuildTags: tt.tags}),
+			)
+			result, err := parser.Parse(t.Context())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result[0].BuildConstraint != tt.want {
+				t.Errorf("expected build constraint %q, got %q", tt.want, result[0].BuildConstraint)
+			}
+		})
+	}
+}
+
+func TestParser_FieldImports(t *testing.T) {
+	t.Parallel()
+	src := `package shop
+
+import (
+	"time"
+
+	cur "example.com/shop/money"
+	"github.com/acme/geo/v2"
+)
+
+type region int // Currency[cur.Currency], Origin[*geo.Point], Delay[time.Duration]
+
+const (
+	us region = iota // US cur.USD, geo.Home, 2h
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []enum.Import{
+		{Name: "cur", Path: "example.com/shop/money"},
+		{Name: "geo", Path: "github.com/acme/geo/v2"},
+	}
+	if !slices.Equal(result[0].FieldImports, want) {
+		t.Errorf("expected field imports %v, got %v", want, result[0].FieldImports)
+	}
+	if !slices.Equal(result[0].Imports, []string{"time"}) {
+		t.Errorf("expected imports [time], got %v", result[0].Imports)
+	}
+}
+
+func TestParser_ProtoImports(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		imports string
+		want    []enum.Import
+		wantErr bool
+	}{
+		{
+			name:    "imported",
+			imports: `import orderv1 "example.com/shop/gen/order/v1"`,
+			want:    []enum.Import{{Name: "orderv1", Path: "example.com/shop/gen/order/v1"}},
+		},
+		{
+			name:    "not imported",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			src := "package shop\n\n" + tt.imports + `
+
+// goenums: -proto=orderv1.OrderStatus
+type status int
+
+const (
+	pending status = iota
+	shipped
+)
+`
+			parser := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader(src))),
+				gofile.WithParserConfiguration(config.Configuration{}),
+			)
+			result, err := parser.Parse(t.Context())
+			if tt.wantErr {
+				if !errors.Is(err, gofile.ErrParseGoSource) {
+					t.Fatalf("expected a parse error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(result[0].FieldImports, tt.want) {
+				t.Errorf("expected field imports %v, got %v", tt.want, result[0].FieldImports)
+			}
+		})
+	}
+}
+
+func TestParser_SerdeNameOverride(t *testing.T) {
+	t.Parallel()
+	src := `package arch
+
+type state int
+
+const (
+	active   state = iota // Active
+	archived              // Archived json:archived_state
+	// Deleted
+	// json:deleted_state
+	deleted
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		alias     string
+		serdeName string
+	}{
+		{"Active", ""},
+		{"Archived", "archived_state"},
+		{"Deleted", "deleted_state"},
+	}
+	enums := result[0].EnumIota.Enums
+	if len(enums) != len(tests) {
+		t.Fatalf("expected %d enums, got %d", len(tests), len(enums))
+	}
+	for i, tt := range tests {
+		if !slices.Equal(enums[i].Aliases, []string{tt.alias}) {
+			t.Errorf("enum %d: expected aliases [%s], got %v", i, tt.alias, enums[i].Aliases)
+		}
+		if enums[i].SerdeName != tt.serdeName {
+			t.Errorf("enum %d: expected serde name %q, got %q", i, tt.serdeName, enums[i].SerdeName)
+		}
+	}
+}
+
+func TestParser_SerdeFormatOverride(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		typ     string
+		doc     string
+		slow    string
+		want    []string
+		wantErr error
+	}{
+		{name: "trailing", typ: "int", slow: "// Slow serde:value", want: []string{"", "value", ""}},
+		{name: "doc", typ: "int", doc: "\t// serde:name\n", want: []string{"", "", "name"}},
+		{name: "unknown format", typ: "int", slow: "// Slow serde:object", wantErr: gofile.ErrInvalidSerdeFormat},
+		{name: "string value", typ: "string", slow: "// Slow serde:value", wantErr: config.ErrUnsupportedCombination},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			src := "package paint\n\n// goenums: -json\ntype mode " + tt.typ + "\n\nconst (\n" +
+				"\tfast mode = \"fast\"\n\tslow mode = \"slow\" " + tt.slow + "\n" + tt.doc + "\tsteady mode = \"steady\"\n)\n"
+			if tt.typ == "int" {
+				src = "package paint\n\n// goenums: -json\ntype mode int\n\nconst (\n" +
+					"\tfast mode = iota\n\tslow " + tt.slow + "\n" + tt.doc + "\tsteady\n)\n"
+			}
+			parser := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader(src))),
+				gofile.WithParserConfiguration(testdata.DefaultConfig),
+			)
+			reqs, err := parser.Parse(t.Context())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			enums := reqs[0].EnumIota.Enums
+			if len(enums) != len(tt.want) {
+				t.Fatalf("expected %d enums, got %d", len(tt.want), len(enums))
+			}
+			for i, want := range tt.want {
+				if enums[i].SerdeFormat != want {
+					t.Errorf("enum %d: expected serde format %q, got %q", i, want, enums[i].SerdeFormat)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_DeprecatedConstants(t *testing.T) {
+	t.Parallel()
+	src := `package arch
+
+type state int
+
+const (
+	active state = iota // Active
+	// Deleted
+	// Deprecated: use archived instead.
+	deleted
+	archived // Archived
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var deprecated []string
+	for _, e := range result[0].EnumIota.Enums {
+		if e.Deprecated {
+			deprecated = append(deprecated, e.Name)
+		}
+	}
+	if !slices.Equal(deprecated, []string{"deleted"}) {
+		t.Errorf("expected [deleted] to be deprecated, got %v", deprecated)
+	}
+	if aliases := result[0].EnumIota.Enums[1].Aliases; !slices.Equal(aliases, []string{"Deleted"}) {
+		t.Errorf("expected aliases [Deleted], got %v", aliases)
+	}
+}
+
+func TestParser_LegacyAliases(t *testing.T) {
+	t.Parallel()
+	src := `package arch
+
+type state int
+
+const (
+	active state = iota // Active aliases: Enabled, On
+	// Deleted
+	// aliases: Removed
+	deleted
+	archived // Archived
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		aliases []string
+		legacy  []string
+	}{
+		{[]string{"Active"}, []string{"Enabled", "On"}},
+		{[]string{"Deleted"}, []string{"Removed"}},
+		{[]string{"Archived"}, nil},
+	}
+	for i, tt := range tests {
+		e := result[0].EnumIota.Enums[i]
+		if !slices.Equal(e.Aliases, tt.aliases) {
+			t.Errorf("enum %d: expected aliases %v, got %v", i, tt.aliases, e.Aliases)
+		}
+		if !slices.Equal(e.LegacyAliases, tt.legacy) {
+			t.Errorf("enum %d: expected legacy aliases %v, got %v", i, tt.legacy, e.LegacyAliases)
+		}
+	}
+}
+
+func TestParser_Groups(t *testing.T) {
+	t.Parallel()
+	src := `package jobs
+
+type status int
+
+const (
+	pending status = iota // Pending
+	failed                // Failed group: terminal, failure aliases: Errored
+	// TimedOut
+	// group: terminal
+	// group: failure
+	timedOut
+	crashed // Crashed aliases: Panicked group: terminal
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		aliases []string
+		legacy  []string
+		groups  []string
+	}{
+		{[]string{"Pending"}, nil, nil},
+		{[]string{"Failed"}, []string{"Errored"}, []string{"terminal", "failure"}},
+		{[]string{"TimedOut"}, nil, []string{"terminal", "failure"}},
+		{[]string{"Crashed"}, []string{"Panicked"}, []string{"terminal"}},
+	}
+	for i, tt := range tests {
+		e := result[0].EnumIota.Enums[i]
+		if !slices.Equal(e.Aliases, tt.aliases) {
+			t.Errorf("enum %d: expected aliases %v, got %v", i, tt.aliases, e.Aliases)
+		}
+		if !slices.Equal(e.LegacyAliases, tt.legacy) {
+			t.Errorf("enum %d: expected legacy aliases %v, got %v", i, tt.legacy, e.LegacyAliases)
+		}
+		if !slices.Equal(e.Groups, tt.groups) {
+			t.Errorf("enum %d: expected groups %v, got %v", i, tt.groups, e.Groups)
+		}
+	}
+}
+
+func TestParser_Tags(t *testing.T) {
+	t.Parallel()
+	src := `package billing
+
+type plan int
+
+const (
+	free plan = iota // Free tags: internal
+	pro              // Pro tags: billing, external group: paid aliases: Premium
+	// Enterprise
+	// tags: billing
+	// tags: sales
+	enterprise
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		aliases []string
+		tags    []string
+	}{
+		{[]string{"Free"}, []string{"internal"}},
+		{[]string{"Pro"}, []string{"billing", "external"}},
+		{[]string{"Enterprise"}, []string{"billing", "sales"}},
+	}
+	for i, tt := range tests {
+		e := result[0].EnumIota.Enums[i]
+		if !slices.Equal(e.Aliases, tt.aliases) {
+			t.Errorf("enum %d: expected aliases %v, got %v", i, tt.aliases, e.Aliases)
+		}
+		if !slices.Equal(e.Tags, tt.tags) {
+			t.Errorf("enum %d: expected tags %v, got %v", i, tt.tags, e.Tags)
+		}
+	}
+	if pro := result[0].EnumIota.Enums[1]; !slices.Equal(pro.Groups, []string{"paid"}) || !slices.Equal(pro.LegacyAliases, []string{"Premium"}) {
+		t.Errorf("expected the group and legacy aliases of pro, got %v and %v", pro.Groups, pro.LegacyAliases)
+	}
+}
+
+func TestParser_StateEvents(t *testing.T) {
+	t.Parallel()
+	src := `package orders
+
+// goenums: -statemachine
+type orderStatus int
+
+const (
+	// Pending
+	// state: OnPay -> paid, OnCancel -> canceled
+	pending orderStatus = iota
+	paid     // Paid state: -> shipped, OnCancel -> canceled
+	shipped  // Shipped state: [final]
+	canceled // Canceled state: -> pending, shipped
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	result, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		transitions []string
+		events      []string
+		final       bool
+	}{
+		{[]string{"paid", "canceled"}, []string{"OnPay", "OnCancel"}, false},
+		{[]string{"shipped", "canceled"}, []string{"", "OnCancel"}, false},
+		{nil, nil, true},
+		{[]string{"pending", "shipped"}, nil, false},
+	}
+	for i, tt := range tests {
+		e := result[0].EnumIota.Enums[i]
+		if !slices.Equal(e.StateTransitions, tt.transitions) || !slices.Equal(e.StateEvents, tt.events) || e.IsFinalState != tt.final {
+			t.Errorf("enum %d: expected transitions %q, events %q and final %v, got %q, %q and %v",
+				i, tt.transitions, tt.events, tt.final, e.StateTransitions, e.StateEvents, e.IsFinalState)
+		}
+	}
+	if comment := result[0].EnumIota.Enums[0].CustomComment; comment != "" {
+		t.Errorf("expected state annotations to be left out of the comment, got %q", comment)
+	}
+}
+
+func TestParser_StateMachineUnknownState(t *testing.T) {
+	t.Parallel()
+	src := `package orders
+
+// goenums: -statemachine
+type orderStatus int
+
+const (
+	pending orderStatus = iota // Pending state: -> payed
+	paid                       // Paid state: [final]
+)
+`
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	_, err := parser.Parse(t.Context())
+	if !errors.Is(err, enum.ErrUnknownState) {
+		t.Fatalf("expected error %v, got %v", enum.ErrUnknownState, err)
+	}
+	if !strings.Contains(err.Error(), ":7:2: ") {
+		t.Errorf("expected the error to locate pending, got %v", err)
+	}
+}
+
+func TestParser_UnknownDirective(t *testing.T) {
+	t.Parallel()
+	src := "package colors\n\n// goenums: -json -bogus\ntype color int\n\nconst (\n\tred color = iota\n\tgreen\n)\n"
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(testdata.DefaultConfig),
+	)
+	reqs, err := parser.Parse(t.Context())
+	if !errors.Is(err, config.ErrUnknownDirective) || !errors.Is(err, gofile.ErrParseGoSource) {
+		t.Fatalf("expected unknown directive error, got %v", err)
+	}
+	if reqs != nil {
+		t.Errorf("expected no requests, got %d", len(reqs))
+	}
+	if !strings.Contains(err.Error(), ":3:1:") {
+		t.Errorf("expected the position of the directive in %q", err)
+	}
+}
+
+func TestParser_FailfastComments(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "field count",
+			src:  "package planets\n\ntype planet int // Gravity[float64],Radius[float64]\n\nconst (\n\tunknown planet = iota // invalid\n\tmercury // Mercury 0.378\n)\n",
+			want: ":7:",
+		},
+		{
+			name: "stray separator",
+			src:  "package planets\n\ntype planet int\n\nconst (\n\tunknown planet = iota // invalid\n\tearth // Earth,,Terra\n)\n",
+			want: "empty item",
+		},
+		{
+			name: "unbalanced bracket",
+			src:  "package planets\n\ntype planet int // Moons[[]string]\n\nconst (\n\tunknown planet = iota // invalid\n\tmars // Mars [Phobos,Deimos\n)\n",
+			want: "unbalanced '['",
+		},
+		{
+			name: "transition without target",
+			src:  "package orders\n\n// goenums: -statemachine\ntype status int\n\nconst (\n\tpending status = iota // state: -> \n\tshipped\n)\n",
+			want: ":7:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := testdata.DefaultConfig
+			cfg.Failfast = true
+			parser := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader(tt.src))),
+				gofile.WithParserConfiguration(cfg),
+			)
+			_, err := parser.Parse(t.Context())
+			if err == nil {
+				t.Fatal("expected a comment error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error %q to contain %q", err, tt.want)
+			}
+
+			lenient := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader(tt.src))),
+				gofile.WithParserConfiguration(testdata.DefaultConfig),
+			)
+			if _, err := lenient.Parse(t.Context()); err != nil {
+				t.Errorf("expected the lenient parser to accept the comments, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParser_QuotedComments(t *testing.T) {
+	t.Parallel()
+	src := "package vendors\n\ntype vendor int // Legal[string]\n\nconst (\n\tunknown vendor = iota // invalid\n\tacme // \"Acme, Inc.\",acme \"Acme \\\"Rockets\\\", Inc.\"\n)\n"
+	cfg := testdata.DefaultConfig
+	cfg.Failfast = true
+	parser := gofile.NewParser(
+		gofile.WithSource(source.FromReader(strings.NewReader(src))),
+		gofile.WithParserConfiguration(cfg),
+	)
+	reqs, err := parser.Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	acme := reqs[0].EnumIota.Enums[1]
+	if len(acme.Aliases) != 2 || acme.Aliases[0] != "Acme, Inc." || acme.Aliases[1] != "acme" {
+		t.Errorf("unexpected aliases %q", acme.Aliases)
+	}
+	if len(acme.Fields) != 1 || acme.Fields[0].Value != `Acme "Rockets", Inc.` {
+		t.Errorf("unexpected fields %+v", acme.Fields)
+	}
+}
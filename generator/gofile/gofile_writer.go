@@ -2,21 +2,37 @@ package gofile
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
 	"io"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"slices"
+	"strconv"
+	"sync"
 	"text/template"
 	"time"
 	"unicode"
 
 	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/enums"
 	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/avro"
 	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/jsonschema"
+	"github.com/donutnomad/goenums/generator/migration"
+	"github.com/donutnomad/goenums/internal/naming"
 	"github.com/donutnomad/goenums/strings"
 )
 
@@ -25,6 +41,19 @@ var _ enum.Writer = &Writer{}
 var (
 	// ErrWriteGoFile is returned when an error occurs while writing the go file.
 	ErrWriteGoFile = errors.New("error writing go file")
+	// ErrUnknownYAMLLibrary is returned when the configured YAML library is not supported.
+	ErrUnknownYAMLLibrary = errors.New("unknown YAML library")
+	// ErrUnknownSection is returned when the configured section order names an unknown section.
+	ErrUnknownSection = errors.New("unknown section")
+	// ErrSkippedSection is returned when a section that is always written, or
+	// that a written section depends on, is skipped.
+	ErrSkippedSection = errors.New("section cannot be skipped")
+	// ErrReservedName is returned when a wrapper, container or receiver name
+	// is an identifier the generated code declares or refers to, such as
+	// the wrapper of another enum type in the same file.
+	ErrReservedName = errors.New("name is used by the generated code")
+	// ErrUnknownStringer is returned when the configured String lookup is not supported.
+	ErrUnknownStringer = errors.New("unknown stringer")
 )
 
 // Writer implements enum.Writer for go source files.
@@ -34,6 +63,10 @@ type Writer struct {
 	Configuration config.Configuration
 	w             io.Writer
 	fs            file.ReadCreateWriteFileFS
+	// out receives the generated code instead of fs when set
+	out io.Writer
+	// plugins append their code to every generated file
+	plugins []enum.Plugin
 }
 
 // WriterOption is a function that configures a Writer.
@@ -53,9 +86,25 @@ func WithWriterConfiguration(configuration config.Configuration) func(*Writer) {
 	}
 }
 
+// WithOutput writes the formatted code of every request to out, one after
+// the other, instead of to a file next to its source.
+func WithOutput(out io.Writer) func(*Writer) {
+	return func(w *Writer) {
+		w.out = out
+	}
+}
+
+// WithPlugins appends the code of plugins to every generated file, in
+// the order given.
+func WithPlugins(plugins ...enum.Plugin) func(*Writer) {
+	return func(w *Writer) {
+		w.plugins = append(w.plugins, plugins...)
+	}
+}
+
 // NewWriter creates a new go file writer with the specified configuration and filesystem.
-// The writer will write enum definitions to the provided filesystem.
-// When no options are provided, it will write to stdout.
+// The writer will write enum definitions to the provided filesystem, or to
+// the operating system filesystem when none is provided.
 func NewWriter(opts ...WriterOption) *Writer {
 	w := Writer{
 		Configuration: config.Configuration{},
@@ -77,6 +126,53 @@ func (g *Writer) Write(ctx context.Context,
 		if !req.IsValid() {
 			return fmt.Errorf("invalid enum: %s", req.SourceFilename)
 		}
+		switch lib := req.Configuration.YAMLLibrary; lib {
+		case "", config.YAMLLibraryV3, config.YAMLLibraryGoccy, config.YAMLLibrarySigs, config.YAMLLibraryText:
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownYAMLLibrary, lib)
+		}
+		for _, section := range slices.Concat(req.Configuration.SectionOrder,
+			req.Configuration.SkipSections, req.Configuration.OnlySections) {
+			if !slices.Contains(config.DefaultSectionOrder, section) {
+				return fmt.Errorf("%w: %s", ErrUnknownSection, section)
+			}
+		}
+		for _, section := range req.Configuration.SkipSections {
+			if slices.Contains(config.RequiredSections, section) {
+				return fmt.Errorf("%w: %s is always written", ErrSkippedSection, section)
+			}
+		}
+		for _, enumIota := range req.GetEnumIotas() {
+			if err := checkSectionDependencies(req.Configuration, enumIota); err != nil {
+				return err
+			}
+			if err := checkNames(req, enumIota); err != nil {
+				return err
+			}
+			switch stringer := req.Configuration.GetEnumTypeConfig(enumIota.Type).Stringer; stringer {
+			case "", config.StringerMap, config.StringerSwitch:
+			default:
+				return fmt.Errorf("%w: %s", ErrUnknownStringer, stringer)
+			}
+			if cfg := req.Configuration.GetEnumTypeConfig(enumIota.Type); cfg.Handlers.Avro || cfg.Handlers.Kafka {
+				if _, err := avro.FromEnum(enumIota, req.Package, cfg); err != nil {
+					return fmt.Errorf("%w: %w", ErrWriteGoFile, err)
+				}
+			}
+		}
+		if err := checkDuplicateNames(req); err != nil {
+			return err
+		}
+		plugged, err := runPlugins(g.plugins, req)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrWriteGoFile, req.SourceFilename, err)
+		}
+		if g.out != nil {
+			if err := g.writeOutput(req, plugged); err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrWriteGoFile, req.SourceFilename, err)
+			}
+			continue
+		}
 		dirPath := filepath.Dir(req.SourceFilename)
 		if !filepath.IsLocal(dirPath) {
 			return fmt.Errorf("invalid path: %s", dirPath)
@@ -86,26 +182,129 @@ func (g *Writer) Write(ctx context.Context,
 			return fmt.Errorf("%w: '%s' contains invalid characters", ErrWriteGoFile, outFilename)
 		}
 		fullPath := filepath.Clean(filepath.Join(dirPath, outFilename))
-		err := file.WriteToFileAndFormatFS(ctx, g.fs, fullPath, true,
+		err = file.WriteToFileAndFormatFS(ctx, g.fs, fullPath, true,
 			func(w io.Writer) error {
 				g.w = w
-				g.writeEnumGenerationRequest(req)
+				g.writeEnumGenerationRequest(req, plugged)
 				return nil
 			})
 		if err != nil {
 			return fmt.Errorf("%w: %s: %w", ErrWriteGoFile, fullPath, err)
 		}
+		if err := g.writeRegistryFile(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegistryFilename is the file declaring the EnumRegistry of a package,
+// written next to the generated files when a type uses -registry.
+const RegistryFilename = "goenums_registry.go"
+
+var (
+	registryDeclStr = `
+// EnumRegistry holds the enum types of this package generated with
+// -registry. enums.Types lists those of every package of the program.
+var EnumRegistry = enums.NewRegistry()
+`
+	registryFileStr = `
+package {{ .Package }}
+
+import "github.com/donutnomad/goenums/enums"
+` + registryDeclStr
+	registryDeclTemplate = template.Must(template.New("registryDecl").Parse(registryDeclStr))
+	registryFileTemplate = template.Must(template.New("registryFile").Parse(registryFileStr))
+	// registryFileMu serializes the writes of registry files, which files of
+	// the same package generated concurrently share
+	registryFileMu sync.Mutex
+)
+
+// writeRegistryFile writes RegistryFilename next to the source of req when
+// one of its types is registered. Every generated file of the package
+// writes the same content, so the registry is declared once however many
+// files register types in it.
+func (g *Writer) writeRegistryFile(ctx context.Context, req enum.GenerationRequest) error {
+	if !usesRegistry(req) {
+		return nil
+	}
+	fullPath := filepath.Join(filepath.Dir(req.SourceFilename), RegistryFilename)
+	registryFileMu.Lock()
+	defer registryFileMu.Unlock()
+	err := file.WriteToFileAndFormatFS(ctx, g.fs, fullPath, true,
+		func(w io.Writer) error {
+			g.w = w
+			// The registry is shared by all builds of the package
+			req.BuildConstraint = ""
+			g.writeGeneratedComments(req)
+			g.writeTemplate(registryFileTemplate, req)
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrWriteGoFile, fullPath, err)
 	}
 	return nil
 }
 
-func (g *Writer) writeEnumGenerationRequest(req enum.GenerationRequest) {
+// usesRegistry reports whether one of the types of req is registered, which
+// it is not when the registry section is skipped with -skip or -only.
+func usesRegistry(req enum.GenerationRequest) bool {
+	if !sectionWritten(req.Configuration, config.SectionRegistry) {
+		return false
+	}
+	return slices.ContainsFunc(req.GetEnumIotas(), func(e enum.EnumIota) bool {
+		return req.Configuration.GetEnumTypeConfig(e.Type).Registry
+	})
+}
+
+// pluginOutput is what the plugins produced for a request.
+type pluginOutput struct {
+	imports []string
+	code    []byte
+}
+
+// runPlugins collects the imports and the code of every plugin for req.
+func runPlugins(plugins []enum.Plugin, req enum.GenerationRequest) (pluginOutput, error) {
+	var out pluginOutput
+	var b bytes.Buffer
+	for _, p := range plugins {
+		out.imports = append(out.imports, p.Imports(req)...)
+		b.WriteString("\n")
+		if err := p.Write(&b, req); err != nil {
+			return pluginOutput{}, err
+		}
+	}
+	out.code = b.Bytes()
+	return out, nil
+}
+
+// writeOutput writes the formatted code of req to the output writer.
+func (g *Writer) writeOutput(req enum.GenerationRequest, plugged pluginOutput) error {
+	var b bytes.Buffer
+	g.w = &b
+	g.writeEnumGenerationRequest(req, plugged)
+	// No registry file is written next to the output, so the registry is
+	// declared in it for the code to compile on its own
+	if usesRegistry(req) {
+		g.writeTemplate(registryDeclTemplate, req)
+	}
+	formatted, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = g.out.Write(formatted)
+	return err
+}
+
+func (g *Writer) writeEnumGenerationRequest(req enum.GenerationRequest, plugged pluginOutput) {
+	req = aliasFieldImports(req)
+
 	// Get all enum iotas (supports both single and multiple enums)
 	enumIotas := req.GetEnumIotas()
 
 	// Write file header only once
 	g.writeGeneratedComments(req)
-	g.writePackageAndImports(req)
+	g.writePackageAndImports(req, plugged.imports)
 
 	// Write constraints only once if enabled
 	if req.Configuration.Constraints {
@@ -128,6 +327,7 @@ func (g *Writer) writeEnumGenerationRequest(req enum.GenerationRequest) {
 		singleEnumReq := enum.GenerationRequest{
 			Package:        req.Package,
 			Imports:        req.Imports,
+			FieldImports:   req.FieldImports,
 			EnumIota:       enumIota,
 			Version:        req.Version,
 			SourceFilename: req.SourceFilename,
@@ -136,173 +336,782 @@ func (g *Writer) writeEnumGenerationRequest(req enum.GenerationRequest) {
 		}
 
 		// Generate all the enum-specific code
-		g.writeWrapperDefinition(singleEnumReq)
-		g.writeRawTypeAlias(singleEnumReq)
-		g.writeContainerDefinition(singleEnumReq)
-		g.writeInvalidEnumDefinition(singleEnumReq)
-		g.writeAllSliceMethod(singleEnumReq)
-		g.writeIsValidFunction(singleEnumReq)
-		g.writeStringMethod(singleEnumReq)
+		for _, section := range sectionOrder(req.Configuration) {
+			if sectionWritten(req.Configuration, section) {
+				g.writeSection(section, singleEnumReq)
+			}
+		}
+	}
+
+	// The code of plugins follows that of every enum type
+	if _, err := g.w.Write(plugged.code); err != nil {
+		slog.Default().Error("error writing plugin code", "error", err)
+	}
+}
+
+// sectionWritten reports whether section is written for each enum type,
+// as it is unless skipped or left out of the only sections.
+func sectionWritten(cfg config.Configuration, section string) bool {
+	if slices.Contains(config.RequiredSections, section) {
+		return true
+	}
+	if slices.Contains(cfg.SkipSections, section) {
+		return false
+	}
+	return len(cfg.OnlySections) == 0 || slices.Contains(cfg.OnlySections, section)
+}
+
+// sectionDependencies returns the sections the code written in each section
+// for enumIota calls into, beyond RequiredSections.
+func sectionDependencies(cfg config.Configuration, enumIota enum.EnumIota) map[string][]string {
+	enumConfig := cfg.GetEnumTypeConfig(enumIota.Type)
+	deps := make(map[string][]string)
+	// The decode hook, query and proto helpers parse with Parse<Type>
+	if enumConfig.Handlers.Mapstructure || enumConfig.Handlers.HTTP || enumConfig.Proto != "" {
+		deps[config.SectionSerde] = []string{config.SectionParse}
+	}
+	if enumConfig.Match {
+		deps[config.SectionMatch] = []string{config.SectionParse}
+	}
+	if enumConfig.Registry {
+		deps[config.SectionRegistry] = []string{config.SectionParse}
+	}
+	// FilterByTag ranges over the All method of the container
+	if slices.ContainsFunc(enumIota.Enums, func(e enum.Enum) bool { return len(e.Tags) > 0 }) {
+		deps[config.SectionTags] = []string{config.SectionConvenience}
+	}
+	return deps
+}
+
+// checkSectionDependencies reports an error when a section written for
+// enumIota depends on a skipped one.
+func checkSectionDependencies(cfg config.Configuration, enumIota enum.EnumIota) error {
+	for _, section := range config.DefaultSectionOrder {
+		if !sectionWritten(cfg, section) {
+			continue
+		}
+		for _, dep := range sectionDependencies(cfg, enumIota)[section] {
+			if !sectionWritten(cfg, dep) {
+				return fmt.Errorf("%w: %s: %s is required by %s", ErrSkippedSection, enumIota.Type, dep, section)
+			}
+		}
+	}
+	return nil
+}
 
+// sectionOrder returns the configured section order, followed by the
+// sections it leaves out in their default order.
+func sectionOrder(cfg config.Configuration) []string {
+	order := slices.Clone(cfg.SectionOrder)
+	for _, section := range config.DefaultSectionOrder {
+		if !slices.Contains(order, section) {
+			order = append(order, section)
+		}
+	}
+	return order
+}
+
+// writeSection writes one section of the code generated for an enum type.
+func (g *Writer) writeSection(section string, rep enum.GenerationRequest) {
+	switch section {
+	case config.SectionWrapper:
+		g.writeWrapperDefinition(rep)
+	case config.SectionRawType:
+		g.writeRawTypeAlias(rep)
+	case config.SectionContainer:
+		g.writeContainerDefinition(rep)
+	case config.SectionInvalid:
+		g.writeInvalidEnumDefinition(rep)
+	case config.SectionAll:
+		g.writeAllSliceMethod(rep)
+	case config.SectionValidation:
+		g.writeIsValidFunction(rep)
+	case config.SectionString:
+		g.writeStringMethod(rep)
+	case config.SectionParse:
+		g.writeParseFunction(rep)
+	case config.SectionEnum:
 		// Implement Enum interface methods
-		g.writeEnumInterfaceMethods(singleEnumReq)
+		g.writeEnumInterfaceMethods(rep)
+	case config.SectionSerde:
 		// Directly implement serialization interface methods, calling functions in serde.go
-		g.writeSerializationMethods(singleEnumReq)
-
+		g.writeSerializationMethods(rep)
+	case config.SectionConvenience:
 		// Add convenience methods for container type
-		g.writeContainerConvenienceMethods(singleEnumReq)
-		g.writeCompileCheck(singleEnumReq)
-
+		g.writeContainerConvenienceMethods(rep)
+	case config.SectionCompileCheck:
+		g.writeCompileCheck(rep)
+	case config.SectionStateMachine:
 		// Generate state machine methods if enabled
-		enumConfig := singleEnumReq.Configuration.GetEnumTypeConfig(singleEnumReq.EnumIota.Type)
-		if enumConfig.StateMachine {
-			g.writeStateMachineMethods(singleEnumReq)
+		if rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).StateMachine {
+			g.writeStateMachineMethods(rep)
+		}
+	case config.SectionCompat:
+		if rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).ZarldevCompat {
+			g.writeZarldevCompat(rep)
+		}
+	case config.SectionMatch:
+		if rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).Match {
+			g.writeMatchFunction(rep)
+		}
+	case config.SectionRegistry:
+		if rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).Registry {
+			g.writeRegistration(rep)
+		}
+	case config.SectionSchemaHash:
+		if rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).SchemaHash {
+			g.writeSchemaHash(rep)
+		}
+	case config.SectionFields:
+		if rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).FieldAccessors {
+			g.writeFieldAccessors(rep)
+		}
+	case config.SectionGroups:
+		g.writeGroups(rep)
+	case config.SectionTags:
+		g.writeTags(rep)
+	case config.SectionAtomic:
+		if rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).Atomic {
+			g.writeAtomic(rep)
+		}
+	case config.SectionPrometheus:
+		if rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).Prometheus {
+			g.writePrometheus(rep)
 		}
 	}
 }
 
 var (
-	constraintsStr = `
-	 
-	type float interface {
-		float32 | float64
-	}
-	type integer interface {
-		int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | uintptr
+	atomicStr = `
+// atomic{{ .WrapperName }}Values are the {{ .EnumType }} an Atomic{{ .WrapperName }} holds,
+// by their index plus one, so that index 0 is the invalid {{ .WrapperName }}.
+var atomic{{ .WrapperName }}Values = {{ .EnumType }}.allSlice()
+
+// atomic{{ .WrapperName }}Indexes maps the {{ .EnumType }} to their index in
+// atomic{{ .WrapperName }}Values plus one.
+var atomic{{ .WrapperName }}Indexes = func() map[{{ .WrapperName }}]int64 {
+	indexes := make(map[{{ .WrapperName }}]int64, len(atomic{{ .WrapperName }}Values))
+	for i, v := range atomic{{ .WrapperName }}Values {
+		indexes[v] = int64(i) + 1
 	}
-	type number interface {
-		integer | float
+	return indexes
+}()
+
+// Atomic{{ .WrapperName }} is a {{ .WrapperName }} that is loaded and stored atomically,
+// for fields mutated concurrently. It holds the index of the value, and
+// the zero value holds the invalid {{ .WrapperName }}. It must not be copied
+// after first use.
+type Atomic{{ .WrapperName }} struct {
+	v atomic.Int64
+}
+
+// atomic{{ .WrapperName }}Value returns the {{ .WrapperName }} at index i minus one.
+func atomic{{ .WrapperName }}Value(i int64) {{ .WrapperName }} {
+	if i == 0 {
+		return invalid{{ .WrapperName }}
 	}
+	return atomic{{ .WrapperName }}Values[i-1]
+}
+
+// Load atomically loads the {{ .WrapperName }}.
+func (a *Atomic{{ .WrapperName }}) Load() {{ .WrapperName }} {
+	return atomic{{ .WrapperName }}Value(a.v.Load())
+}
+
+// Store atomically stores v. Values that are not in {{ .EnumType }} are
+// stored as the invalid {{ .WrapperName }}.
+func (a *Atomic{{ .WrapperName }}) Store(v {{ .WrapperName }}) {
+	a.v.Store(atomic{{ .WrapperName }}Indexes[v])
+}
+
+// Swap atomically stores v and returns the previous {{ .WrapperName }}.
+func (a *Atomic{{ .WrapperName }}) Swap(v {{ .WrapperName }}) {{ .WrapperName }} {
+	return atomic{{ .WrapperName }}Value(a.v.Swap(atomic{{ .WrapperName }}Indexes[v]))
+}
+
+// CompareAndSwap atomically stores new if the {{ .WrapperName }} is old, and
+// reports whether it did.
+func (a *Atomic{{ .WrapperName }}) CompareAndSwap(old, new {{ .WrapperName }}) bool {
+	return a.v.CompareAndSwap(atomic{{ .WrapperName }}Indexes[old], atomic{{ .WrapperName }}Indexes[new])
+}
 `
-	constraintsTemplate = template.Must(template.New("constraints").Parse(constraintsStr))
+	atomicTemplate = template.Must(template.New("atomic").Parse(atomicStr))
 )
 
-func (g *Writer) writeConstraints(rep enum.GenerationRequest) {
-	g.writeTemplate(constraintsTemplate, rep)
+// writeAtomic writes the Atomic<Type> holder of the enum.
+func (g *Writer) writeAtomic(rep enum.GenerationRequest) {
+	g.writeTemplate(atomicTemplate, struct {
+		WrapperName string
+		EnumType    string
+	}{
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
+	})
 }
 
 var (
-	jsonMarshalStr = `
-// MarshalJSON implements the json.Marshaler interface for {{ .WrapperName }}.
-// It returns the JSON representation of the enum value as a byte slice.
-func ({{ .Receiver }} {{ .WrapperName }}) MarshalJSON() ([]byte, error) {
-	{{- if eq .SerializationType "value" }}
-	return json.Marshal({{ .UnderlyingType }}({{ .Receiver }}.{{ .EnumIota }}))
-	{{- else }}
-	return []byte( "\"" + {{ .Receiver }}.String() + "\""), nil 
+	tagsStr = `
+// {{ .EnumLower }}Tags maps the {{ .EnumType }} to their tags.
+var {{ .EnumLower }}Tags = map[{{ .WrapperName }}][]string{
+	{{- range .Tagged }}
+	{{ $.EnumType }}.{{ .Name }}: { {{- range $i, $t := .Tags }}{{ if $i }}, {{ end }}{{ printf "%q" $t }}{{ end -}} },
 	{{- end }}
 }
-	`
-	jsonMarshalTemplate = template.Must(template.New("jsonMarshal").Parse(jsonMarshalStr))
 
-	jsonUnmarshalStr = `
-// UnmarshalJSON implements the json.Unmarshaler interface for {{ .WrapperName }}.
-// It parses the JSON representation of the enum value from the byte slice.
-// It returns an error if the input is not a valid JSON representation.
-func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalJSON(b []byte) error {
-	{{- if eq .SerializationType "value" }}
-	var value {{ .UnderlyingType }}
-	if err := json.Unmarshal(b, &value); err != nil {
-		return err
-	}
-	new{{ .Receiver }}, err := Parse{{ .WrapperName }}Number({{ .EnumIota }}(value))
-	if err != nil {
-		return err
+// HasTag reports whether {{ .Receiver }} is tagged with tag.
+func ({{ .Receiver }} {{ .WrapperName }}) HasTag(tag string) bool {
+	for _, t := range {{ .EnumLower }}Tags[{{ .Receiver }}] {
+		if t == tag {
+			return true
+		}
 	}
-	*{{ .Receiver }} = new{{ .Receiver }}
-	return nil
-	{{- else }}
-	b = bytes.Trim(bytes.Trim(b, "\""), "\"")
-	new{{ .Receiver }}, err := Parse{{ .WrapperName }}(b)
-	if err != nil {
-		return err
+	return false
+}
+{{ if .Legacy }}
+// FilterByTag returns the {{ .EnumType }} returned by All that are tagged with tag.
+func ({{ .Receiver }} {{ .ContainerType }}) FilterByTag(tag string) []{{ .WrapperName }} {
+	var filtered []{{ .WrapperName }}
+	for _, v := range {{ .Receiver }}.All() {
+		if v.HasTag(tag) {
+			filtered = append(filtered, v)
+		}
 	}
-	*{{ .Receiver }} = new{{ .Receiver }}
-	return nil
-	{{- end }}
+	return filtered
 }
-`
-	jsonUnmarshalTemplate = template.Must(template.New("jsonUnmarshal").Parse(jsonUnmarshalStr))
-	textMarshalStr        = `
-// MarshalText implements the encoding.TextMarshaler interface for {{ .WrapperName }}.
-// It returns the appropriate representation of the enum value as a byte slice
-func ({{ .Receiver }} {{ .WrapperName }}) MarshalText() ([]byte, error) {
-	{{- if eq .SerializationType "value" }}
-	return []byte(fmt.Sprintf("%v", {{ .UnderlyingType }}({{ .Receiver }}.{{ .EnumIota }}))), nil
-	{{- else }}
-	return []byte({{ .Receiver }}.String()), nil
-	{{- end }}
+{{- else }}
+// FilterByTag returns an iterator over the {{ .EnumType }} returned by All that
+// are tagged with tag.
+func ({{ .Receiver }} {{ .ContainerType }}) FilterByTag(tag string) iter.Seq[{{ .WrapperName }}] {
+	return func(yield func({{ .WrapperName }}) bool) {
+		for v := range {{ .Receiver }}.All() {
+			if v.HasTag(tag) && !yield(v) {
+				return
+			}
+		}
+	}
 }
+{{- end }}
 `
+	tagsTemplate = template.Must(template.New("tags").Parse(tagsStr))
 )
 
-type interfaceFunctionData struct {
-	Receiver          string
-	WrapperName       string
-	EnumName          string
-	EnumType          string
-	EnumIota          string
-	UnderlyingType    string
-	SerializationType string
+// writeTags writes the tags of the values, the HasTag method and the
+// FilterByTag method of the container, if any value is tagged.
+func (g *Writer) writeTags(rep enum.GenerationRequest) {
+	type tagged struct {
+		Name string
+		Tags []string
+	}
+	d := struct {
+		Receiver      string
+		WrapperName   string
+		ContainerType string
+		EnumType      string
+		EnumLower     string
+		Legacy        bool
+		Tagged        []tagged
+	}{
+		Receiver:      receiver(rep),
+		WrapperName:   wrapperName(rep),
+		ContainerType: containerType(rep),
+		EnumType:      enumType(rep),
+		EnumLower:     strings.ToLower(rep.EnumIota.Type),
+		Legacy:        rep.Configuration.Legacy,
+	}
+	for _, e := range enumDefinitions(rep) {
+		if len(e.Tags) > 0 {
+			d.Tagged = append(d.Tagged, tagged{Name: e.EnumNameIdentifier, Tags: e.Tags})
+		}
+	}
+	if len(d.Tagged) > 0 {
+		g.writeTemplate(tagsTemplate, d)
+	}
 }
 
-func newInterfaceFunctionData(rep enum.GenerationRequest) interfaceFunctionData {
-	enumConfig := rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type)
-	var serdeType string
-	switch enumConfig.SerializationType {
-	case config.SerdeName:
-		serdeType = "name"
-	case config.SerdeValue:
-		serdeType = "value"
-	default:
-		serdeType = "name"
+var (
+	groupsStr = `
+{{- range .Groups }}
+
+// {{ .Func }} returns the {{ $.EnumType }} in the {{ .Name }} group.
+func {{ .Func }}() []{{ $.WrapperName }} {
+	return []{{ $.WrapperName }}{
+		{{- range .Members }}
+		{{ $.EnumType }}.{{ . }},
+		{{- end }}
 	}
+}
 
-	return interfaceFunctionData{
-		Receiver:          receiver(rep.EnumIota.Type),
-		WrapperName:       wrapperName(rep.EnumIota.Type),
-		EnumName:          strings.ToUpper(rep.EnumIota.Type),
-		EnumType:          enumType(rep),
-		EnumIota:          rep.EnumIota.Type,
-		UnderlyingType:    rep.EnumIota.UnderlyingType,
-		SerializationType: serdeType,
+// Is{{ .Method }} reports whether {{ $.Receiver }} is in the {{ .Name }} group.
+func ({{ $.Receiver }} {{ $.WrapperName }}) Is{{ .Method }}() bool {
+	switch {{ $.Receiver }} {
+	case {{ range $i, $m := .Members }}{{ if $i }}, {{ end }}{{ $.EnumType }}.{{ $m }}{{ end }}:
+		return true
 	}
+	return false
 }
+{{- end }}
+`
+	groupsTemplate = template.Must(template.New("groups").Parse(groupsStr))
+)
 
-func receiver(enumType string) string {
-	if strings.Contains(enumType, ".") {
-		return strings.Split(enumType, ".")[0]
+// reservedGroups are the groups whose predicates would clash with the
+// generated Is methods.
+var reservedGroups = []string{"Valid", "Zero", "Deprecated", "TerminalState"}
+
+// writeGroups writes, for every group of the valid values, a function
+// listing its values and an Is<Group> predicate, in the order the groups
+// first appear.
+func (g *Writer) writeGroups(rep enum.GenerationRequest) {
+	type group struct {
+		Name    string
+		Func    string
+		Method  string
+		Members []string
 	}
-	if len(enumType) == 0 {
-		return "r"
+	d := struct {
+		Receiver    string
+		WrapperName string
+		EnumType    string
+		Groups      []*group
+	}{
+		Receiver:    receiver(rep),
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
+	}
+	groups := make(map[string]*group)
+	for _, e := range enumDefinitions(rep) {
+		if !e.Valid {
+			continue
+		}
+		for _, name := range e.Groups {
+			grp, ok := groups[name]
+			if !ok {
+				method := strings.Camel(name)
+				if slices.Contains(reservedGroups, method) {
+					slog.Default().Warn("skipping group clashing with a generated method",
+						"type", rep.EnumIota.Type, "group", name)
+					continue
+				}
+				grp = &group{Name: name, Func: method + d.EnumType, Method: method}
+				groups[name] = grp
+				d.Groups = append(d.Groups, grp)
+			}
+			if !slices.Contains(grp.Members, e.EnumNameIdentifier) {
+				grp.Members = append(grp.Members, e.EnumNameIdentifier)
+			}
+		}
+	}
+	if len(d.Groups) > 0 {
+		g.writeTemplate(groupsTemplate, d)
 	}
-	firstChar := enumType[0]
-	return string(unicode.ToLower(rune(firstChar)))
 }
 
-// mapToJSONType maps Go types to their JSON-compatible types
-func mapToJSONType(goType string) string {
-	switch goType {
-	case "int", "int8", "int16", "int32", "int64",
-		"uint", "uint8", "uint16", "uint32", "uint64":
-		return "int64"
-	case "float32", "float64":
-		return "float64"
-	case "string":
-		return "string"
-	case "bool":
-		return "bool"
-	default:
-		// For custom types or unknown types, default to int64
-		return "int64"
+var (
+	fieldAccessorsStr = `
+{{- range .Fields }}
+
+// Get{{ .Method }} returns the {{ .Name }} field of {{ $.Receiver }}.
+func ({{ $.Receiver }} {{ $.WrapperName }}) Get{{ .Method }}() {{ .Type }} {
+	return {{ $.Receiver }}.{{ .Name }}
+}
+{{- if .Lookup }}
+
+// {{ $.WrapperName }}By{{ .Method }} returns the first valid {{ $.WrapperName }} whose
+// {{ .Name }} is v, and whether there is one.
+func {{ $.WrapperName }}By{{ .Method }}(v {{ .Type }}) ({{ $.WrapperName }}, bool) {
+	for _, e := range {{ $.EnumType }}.allSlice() {
+		{{- if .Equal }}
+		if e.{{ .Name }}.Equal(v) {
+		{{- else }}
+		if e.{{ .Name }} == v {
+		{{- end }}
+			return e, true
+		}
 	}
+	return invalid{{ $.WrapperName }}, false
 }
+{{- end }}
+{{- if not $.Legacy }}
 
-// mapToSQLType maps Go types to SQL driver.Value compatible types
-// SQL driver.Value supports: int64, float64, bool, []byte, string, time.Time
-func mapToSQLType(goType string) string {
-	switch goType {
-	case "int", "int8", "int16", "int32", "int64",
+// All{{ .Method }} returns an iterator over the valid {{ $.WrapperName }}s and their {{ .Name }}.
+func ({{ $.Receiver }} {{ $.ContainerType }}) All{{ .Method }}() iter.Seq2[{{ $.WrapperName }}, {{ .Type }}] {
+	return func(yield func({{ $.WrapperName }}, {{ .Type }}) bool) {
+		for _, e := range {{ $.Receiver }}.allSlice() {
+			if !yield(e, e.{{ .Name }}) {
+				return
+			}
+		}
+	}
+}
+{{- end }}
+{{- end }}
+`
+	fieldAccessorsTemplate = template.Must(template.New("fieldAccessors").Parse(fieldAccessorsStr))
+)
+
+// writeFieldAccessors writes a getter, a lookup and an iterator for every
+// field of the enum. Lookups compare with ==, or Equal for time.Time, so
+// they are left out for types from other packages, which may not be
+// comparable.
+func (g *Writer) writeFieldAccessors(rep enum.GenerationRequest) {
+	type fieldAccessor struct {
+		Name   string
+		Method string
+		Type   string
+		Lookup bool
+		Equal  bool
+	}
+	d := struct {
+		Receiver      string
+		WrapperName   string
+		ContainerType string
+		EnumType      string
+		Legacy        bool
+		Fields        []fieldAccessor
+	}{
+		Receiver:      receiver(rep),
+		WrapperName:   wrapperName(rep),
+		ContainerType: containerType(rep),
+		EnumType:      enumType(rep),
+		Legacy:        rep.Configuration.Legacy,
+	}
+	for _, f := range rep.EnumIota.Fields {
+		_, isExpr := f.Value.(enum.Expr)
+		isSlice := reflect.ValueOf(f.Value).Kind() == reflect.Slice
+		typ := fieldType(f.Value)
+		d.Fields = append(d.Fields, fieldAccessor{
+			Name:   f.Name,
+			Method: strings.Camel(f.Name),
+			Type:   typ,
+			Lookup: !isExpr && !isSlice,
+			Equal:  typ == "time.Time",
+		})
+	}
+	g.writeTemplate(fieldAccessorsTemplate, d)
+}
+
+var (
+	prometheusStr = `
+// {{ .EnumLower }}LabelValues are the names of the valid {{ .WrapperName }}s.
+var {{ .EnumLower }}LabelValues = []string{
+{{- range .Names }}
+	{{ printf "%q" . }},
+{{- end }}
+}
+
+// {{ .WrapperName }}LabelValues returns the names of the valid {{ .WrapperName }}s, the values
+// of a metric label holding {{ .WrapperName }}.
+func {{ .WrapperName }}LabelValues() []string {
+	return append([]string(nil), {{ .EnumLower }}LabelValues...)
+}
+
+// Init{{ .WrapperName }}Labels creates the series of every valid {{ .WrapperName }} in a metric
+// vector, passed as its WithLabelValues method, such as that of a *prometheus.CounterVec,
+// so that dashboards see every value from the start. {{ .WrapperName }} must be the last
+// label of the vector; otherLabelValues are the values of the labels before it.
+func Init{{ .WrapperName }}Labels[M any](withLabelValues func(...string) M, otherLabelValues ...string) {
+	enums.InitLabelValues(withLabelValues, {{ .EnumLower }}LabelValues, otherLabelValues...)
+}
+`
+	prometheusTemplate = template.Must(template.New("prometheus").Parse(prometheusStr))
+)
+
+// writePrometheus writes the label values of the valid, non-deprecated
+// values of the enum, as String names them, and the function initialising
+// their series.
+func (g *Writer) writePrometheus(rep enum.GenerationRequest) {
+	d := struct {
+		WrapperName string
+		EnumLower   string
+		Names       []string
+	}{
+		WrapperName: wrapperName(rep),
+		EnumLower:   strings.ToLower(rep.EnumIota.Type),
+	}
+	for _, e := range enumDefinitions(rep) {
+		if !e.Valid || e.Deprecated {
+			continue
+		}
+		name := e.EnumName
+		if len(e.Aliases) > 0 {
+			name = e.Aliases[0]
+		}
+		d.Names = append(d.Names, name)
+	}
+	g.writeTemplate(prometheusTemplate, d)
+}
+
+var (
+	schemaHashStr = `
+// {{ .WrapperName }}SchemaHash fingerprints the names and values of the valid
+// {{ .WrapperName }}s. Binaries exchanging serialized {{ .WrapperName }}s compare it
+// with enums.CheckCompatibility to make sure they agree on them.
+const {{ .WrapperName }}SchemaHash = "{{ .Hash }}"
+`
+	schemaHashTemplate = template.Must(template.New("schemaHash").Parse(schemaHashStr))
+)
+
+// writeSchemaHash writes the schema hash constant of the enum, computed
+// with enums.SchemaHash over the serialized names and the values of its
+// valid values.
+func (g *Writer) writeSchemaHash(rep enum.GenerationRequest) {
+	values := make(map[string]string)
+	for _, e := range rep.EnumIota.Enums {
+		if !e.Valid || (len(rep.EnumIota.Fields) > 0 && len(e.Fields) == 0) {
+			continue
+		}
+		name := e.Name
+		if e.SerdeName != "" {
+			name = e.SerdeName
+		} else if len(e.Aliases) > 0 {
+			name = e.Aliases[0]
+		}
+		values[name] = e.Literal()
+	}
+	g.writeTemplate(schemaHashTemplate, struct {
+		WrapperName string
+		Hash        string
+	}{
+		WrapperName: wrapperName(rep),
+		Hash:        enums.SchemaHash(values),
+	})
+}
+
+var (
+	registrationStr = `
+// init registers {{ .WrapperName }} in EnumRegistry, declared in ` + RegistryFilename + `
+// or, with -stdout, at the end of the output.
+func init() {
+	enums.Register(EnumRegistry, "{{ .Package }}", "{{ .WrapperName }}", []{{ .WrapperName }}{
+		{{- range .Values }}
+		{{ $.EnumType }}.{{ . }},
+		{{- end }}
+	}, Parse{{ .WrapperName }})
+}
+`
+	registrationTemplate = template.Must(template.New("registration").Parse(registrationStr))
+)
+
+// writeRegistration writes the init function registering the valid values
+// of the enum, leaving out deprecated ones like All.
+func (g *Writer) writeRegistration(rep enum.GenerationRequest) {
+	d := struct {
+		Package     string
+		WrapperName string
+		EnumType    string
+		Values      []string
+	}{
+		Package:     rep.Package,
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
+	}
+	for _, e := range enumDefinitions(rep) {
+		if e.Valid && !e.Deprecated {
+			d.Values = append(d.Values, e.EnumNameIdentifier)
+		}
+	}
+	g.writeTemplate(registrationTemplate, d)
+}
+
+var (
+	matchFunctionStr = `
+// {{ .WrapperName }}Cases holds a case of Match{{ .WrapperName }} for every valid
+// {{ .WrapperName }}. Written as an unkeyed literal, leaving a case out fails to
+// compile, so adding a {{ .WrapperName }} value flags every match lacking it.
+type {{ .WrapperName }}Cases[T any] struct {
+	{{- range .Values }}
+	{{ . }} func() T
+	{{- end }}
+}
+
+// Match{{ .WrapperName }} returns the result of the case of v. It panics with an
+// *Invalid{{ .WrapperName }}Error if v is invalid, and if the case of v is nil.
+func Match{{ .WrapperName }}[T any](v {{ .WrapperName }}, cases {{ .WrapperName }}Cases[T]) T {
+	var f func() T
+	switch v {
+	{{- range .Values }}
+	case {{ $.EnumType }}.{{ . }}:
+		f = cases.{{ . }}
+	{{- end }}
+	default:
+		panic(&Invalid{{ .WrapperName }}Error{Input: v})
+	}
+	if f == nil {
+		panic(fmt.Sprintf("Match{{ .WrapperName }}: no case for %v", v))
+	}
+	return f()
+}
+`
+	matchFunctionTemplate = template.Must(template.New("matchFunction").Parse(matchFunctionStr))
+)
+
+// writeMatchFunction writes the cases type and the Match function of the
+// enum, with a case for every valid value.
+func (g *Writer) writeMatchFunction(rep enum.GenerationRequest) {
+	d := struct {
+		WrapperName string
+		EnumType    string
+		Values      []string
+	}{
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
+	}
+	for _, e := range enumDefinitions(rep) {
+		if e.Valid {
+			d.Values = append(d.Values, e.EnumNameIdentifier)
+		}
+	}
+	g.writeTemplate(matchFunctionTemplate, d)
+}
+
+var (
+	constraintsStr = `
+	 
+	type float interface {
+		float32 | float64
+	}
+	type integer interface {
+		int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | uintptr
+	}
+	type number interface {
+		integer | float
+	}
+`
+	constraintsTemplate = template.Must(template.New("constraints").Parse(constraintsStr))
+)
+
+func (g *Writer) writeConstraints(rep enum.GenerationRequest) {
+	g.writeTemplate(constraintsTemplate, rep)
+}
+
+var (
+	jsonMarshalStr = `
+// MarshalJSON implements the json.Marshaler interface for {{ .WrapperName }}.
+// It returns the JSON representation of the enum value as a byte slice.
+func ({{ .Receiver }} {{ .WrapperName }}) MarshalJSON() ([]byte, error) {
+	{{- if eq .SerializationType "value" }}
+	return json.Marshal({{ .UnderlyingType }}({{ .Receiver }}.{{ .EnumIota }}))
+	{{- else }}
+	return []byte( "\"" + {{ .Receiver }}.String() + "\""), nil 
+	{{- end }}
+}
+	`
+	jsonMarshalTemplate = template.Must(template.New("jsonMarshal").Parse(jsonMarshalStr))
+
+	jsonUnmarshalStr = `
+// UnmarshalJSON implements the json.Unmarshaler interface for {{ .WrapperName }}.
+// It parses the JSON representation of the enum value from the byte slice.
+// It returns an error if the input is not a valid JSON representation.
+func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalJSON(b []byte) error {
+	{{- if eq .SerializationType "value" }}
+	var value {{ .UnderlyingType }}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	new{{ .Receiver }}, err := Parse{{ .WrapperName }}Number({{ .EnumIota }}(value))
+	if err != nil {
+		return err
+	}
+	*{{ .Receiver }} = new{{ .Receiver }}
+	return nil
+	{{- else }}
+	b = bytes.Trim(bytes.Trim(b, "\""), "\"")
+	new{{ .Receiver }}, err := Parse{{ .WrapperName }}(b)
+	if err != nil {
+		return err
+	}
+	*{{ .Receiver }} = new{{ .Receiver }}
+	return nil
+	{{- end }}
+}
+`
+	jsonUnmarshalTemplate = template.Must(template.New("jsonUnmarshal").Parse(jsonUnmarshalStr))
+	textMarshalStr        = `
+// MarshalText implements the encoding.TextMarshaler interface for {{ .WrapperName }}.
+// It returns the appropriate representation of the enum value as a byte slice
+func ({{ .Receiver }} {{ .WrapperName }}) MarshalText() ([]byte, error) {
+	{{- if eq .SerializationType "value" }}
+	return []byte(fmt.Sprintf("%v", {{ .UnderlyingType }}({{ .Receiver }}.{{ .EnumIota }}))), nil
+	{{- else }}
+	return []byte({{ .Receiver }}.String()), nil
+	{{- end }}
+}
+`
+)
+
+type interfaceFunctionData struct {
+	Receiver          string
+	WrapperName       string
+	EnumName          string
+	EnumType          string
+	EnumIota          string
+	UnderlyingType    string
+	SerializationType string
+}
+
+func newInterfaceFunctionData(rep enum.GenerationRequest) interfaceFunctionData {
+	enumConfig := rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type)
+	var serdeType string
+	switch enumConfig.SerializationType {
+	case config.SerdeName:
+		serdeType = "name"
+	case config.SerdeValue:
+		serdeType = "value"
+	default:
+		serdeType = "name"
+	}
+
+	return interfaceFunctionData{
+		Receiver:          receiver(rep),
+		WrapperName:       wrapperName(rep),
+		EnumName:          strings.ToUpper(rep.EnumIota.Type),
+		EnumType:          enumType(rep),
+		EnumIota:          rep.EnumIota.Type,
+		UnderlyingType:    rep.EnumIota.UnderlyingType,
+		SerializationType: serdeType,
+	}
+}
+
+// receiver returns the receiver of the methods generated for the enum, the
+// -receiver name of the type or the first letter of its name.
+func receiver(rep enum.GenerationRequest) string {
+	if name := rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).Receiver; name != "" {
+		return name
+	}
+	enumType := rep.EnumIota.Type
+	if strings.Contains(enumType, ".") {
+		return strings.Split(enumType, ".")[0]
+	}
+	if len(enumType) == 0 {
+		return "r"
+	}
+	firstChar := enumType[0]
+	return string(unicode.ToLower(rune(firstChar)))
+}
+
+// mapToJSONType maps Go types to their JSON-compatible types
+func mapToJSONType(goType string) string {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return "int64"
+	case "float32", "float64":
+		return "float64"
+	case "string":
+		return "string"
+	case "bool":
+		return "bool"
+	default:
+		// For custom types or unknown types, default to int64
+		return "int64"
+	}
+}
+
+// mapToSQLType maps Go types to SQL driver.Value compatible types
+// SQL driver.Value supports: int64, float64, bool, []byte, string, time.Time
+func mapToSQLType(goType string) string {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64",
 		"uint", "uint8", "uint16", "uint32", "uint64":
 		return "int64"
 	case "float32", "float64":
@@ -323,12 +1132,11 @@ var (
 // This function is used to ensure that all enum values are defined and valid.
 // It is called by the compiler to verify that the enum values are valid.
 func _() {
-// An "invalid array index" compiler error signifies that the constant values have changed.
+	// A "duplicate key false in map literal" compiler error signifies that the constant values have changed.
 	// Re-run the goenums command to generate them again.
-	// Does not identify newly added constant values unless order changes
-	var x [{{len .Enums}}]struct{}
-	{{- range .Enums }}
-	_ = x[{{ .Name }}-{{ .Index }}]
+	// Does not identify newly added constant values
+	{{- range .Checks }}
+	_ = map[bool]struct{}{false: {}, {{ .Name }} == {{ .Value }}: {}}
 	{{- end }}
 }
 	`
@@ -336,17 +1144,32 @@ func _() {
 )
 
 type compileCheckData struct {
-	Enums []enum.Enum
+	Checks []compileCheck
+}
+
+// compileCheck asserts a constant still has the value it was generated with.
+type compileCheck struct {
+	Name  string
+	Value string
 }
 
+// writeCompileCheck asserts the value of each constant by equality rather
+// than as an array index, so that explicit and sparse values such as 1000
+// and 9010 are checked as exactly as iota sequences.
 func (g *Writer) writeCompileCheck(rep enum.GenerationRequest) {
-	// Skip compile check for floating point types as they can't be used as array indices
-	if rep.EnumIota.UnderlyingType == "float32" || rep.EnumIota.UnderlyingType == "float64" {
+	isFloat := rep.EnumIota.UnderlyingType == "float32" || rep.EnumIota.UnderlyingType == "float64"
+	var checks []compileCheck
+	for _, e := range rep.EnumIota.Enums {
+		// The index of a float constant is not its value
+		if e.Value == "" && isFloat {
+			continue
+		}
+		checks = append(checks, compileCheck{Name: e.Name, Value: e.Literal()})
+	}
+	if len(checks) == 0 {
 		return
 	}
-	g.writeTemplate(compileCheckTemplate, compileCheckData{
-		Enums: rep.EnumIota.Enums,
-	})
+	g.writeTemplate(compileCheckTemplate, compileCheckData{Checks: checks})
 }
 
 var (
@@ -359,22 +1182,26 @@ type {{ .WrapperName }}Name string
 const (
     {{- range .EnumDefs }}
     {{- if .Aliases }}
-    {{ $.WrapperName }}Name{{ .EnumNameIdentifier }} {{ $.WrapperName }}Name = "{{ index .Aliases 0 }}"
+    {{ $.WrapperName }}Name{{ .EnumNameIdentifier }} {{ $.WrapperName }}Name = {{ printf "%q" (index .Aliases 0) }}
     {{- else }}
-    {{ $.WrapperName }}Name{{ .EnumNameIdentifier }} {{ $.WrapperName }}Name = "{{ .EnumName }}"
+    {{ $.WrapperName }}Name{{ .EnumNameIdentifier }} {{ $.WrapperName }}Name = {{ printf "%q" .EnumName }}
     {{- end }}
     {{- end }}
 )
 
+{{- if not .Switch }}
+
 // {{ .EnumLower }}NamesMap is a map of enum values to their canonical absolute names
 var {{ .EnumLower }}NamesMap = map[{{ .WrapperName }}]string{
     {{- range .EnumDefs }}
     {{ $.EnumType }}.{{ .EnumNameIdentifier }}: string({{ $.WrapperName }}Name{{ .EnumNameIdentifier }}),
     {{- end }}
 }
+{{- end }}
+{{- else if .Switch }}
 {{- else }}
 // {{ .EnumLower }}Names is a constant string slice containing all enum values cononical absolute names
-const {{ .EnumLower }}Names = "{{ .NameString }}"
+const {{ .EnumLower }}Names = {{ printf "%q" .NameString }}
 
 // {{ .EnumLower }}NamesMap is a map of enum values to their canonical absolute 
 // name positions within the {{ .EnumLower }}Names string slice
@@ -388,9 +1215,27 @@ var {{ .EnumLower }}NamesMap = map[{{ .WrapperName }}]string{
 // String implements the Stringer interface.
 // It returns the canonical absolute name of the enum value.
 func ({{ .Receiver }} {{ .WrapperName }}) String() string {
+    {{- if .Switch }}
+    switch {{ .Receiver }} {
+    {{- range .Names }}
+    case {{ $.EnumType }}.{{ .Identifier }}:
+        {{- if $.GenerateNameConstants }}
+        return string({{ $.WrapperName }}Name{{ .Identifier }})
+        {{- else }}
+        return {{ printf "%q" .Name }}
+        {{- end }}
+    {{- end }}
+    }
+    {{- else }}
     if str, ok := {{ .EnumLower }}NamesMap[{{ .Receiver }}]; ok {
         return str
     }
+    {{- end }}
+    {{- if .InvalidName }}
+    if {{ .Receiver }} == invalid{{ .WrapperName }} {
+        return {{ printf "%q" .InvalidName }}
+    }
+    {{- end }}
     return fmt.Sprintf("{{ .EnumLower }}(%v)", {{ .Receiver }}.{{ .EnumIota }})
 }
 `
@@ -409,6 +1254,11 @@ type stringMethodData struct {
 	ContainerName         string
 	CaseInsensitive       bool
 	GenerateNameConstants bool
+	// Switch selects the switch-based String, returning Names
+	Switch bool
+	Names  []serdeName
+	// InvalidName is the name String returns for the -invalid sentinel
+	InvalidName string
 }
 
 func (g *Writer) writeStringMethod(rep enum.GenerationRequest) {
@@ -438,8 +1288,8 @@ func (g *Writer) writeStringMethod(rep enum.GenerationRequest) {
 		}
 	}
 	d := stringMethodData{
-		Receiver:              receiver(rep.EnumIota.Type),
-		WrapperName:           wrapperName(rep.EnumIota.Type),
+		Receiver:              receiver(rep),
+		WrapperName:           wrapperName(rep),
 		EnumLower:             strings.ToLower(rep.EnumIota.Type),
 		EnumIota:              rep.EnumIota.Type,
 		EnumType:              enumType(rep),
@@ -448,9 +1298,30 @@ func (g *Writer) writeStringMethod(rep enum.GenerationRequest) {
 		NameOffsets:           nameOffsetsForTemplate,
 		CaseInsensitive:       rep.Configuration.Insensitive,
 		GenerateNameConstants: enumConfig.GenerateNameConstants,
+		Switch:                enumConfig.Stringer == config.StringerSwitch,
+		Names:                 canonicalNames(rep),
+		InvalidName:           enumConfig.InvalidName,
 	}
 	g.writeTemplate(stringMethodTemplate, d)
+	g.writeTemplate(namesFunctionTemplate, d)
+}
+
+var (
+	namesFunctionStr = `
+// {{ .WrapperName }}Names returns the names of the valid {{ .WrapperName }} values,
+// such as the arguments of a SQL IN clause.
+func {{ .WrapperName }}Names() []string {
+	values := make([]{{ .WrapperName }}, 0, len({{ .EnumType }}.allSlice()))
+	for _, v := range {{ .EnumType }}.allSlice() {
+		if v.IsValid() {
+			values = append(values, v)
+		}
+	}
+	return enums.Names(values)
 }
+`
+	namesFunctionTemplate = template.Must(template.New("namesFunction").Parse(namesFunctionStr))
+)
 
 var (
 	isValidStr = `
@@ -466,6 +1337,30 @@ var valid{{ .EnumType }} = map[{{ .WrapperName }}]bool{
 func ({{ .Receiver }} {{ .WrapperName }}) IsValid() bool {
 	return valid{{ .EnumType }}[{{ .Receiver }}]
 }
+
+// IsZero reports whether the {{ .WrapperName }} value is invalid, as the zero value
+// is unless it is declared valid. It lets encoding/json leave out fields
+// tagged with omitzero that hold no valid value.
+func ({{ .Receiver }} {{ .WrapperName }}) IsZero() bool {
+	return !{{ .Receiver }}.IsValid()
+}
+{{- if .Deprecated }}
+
+// deprecated{{ .EnumType }} is a set of enum values documented as deprecated
+var deprecated{{ .EnumType }} = map[{{ .WrapperName }}]bool{
+	{{- range .Enums }}
+	{{- if .Deprecated }}
+	{{ $.EnumType }}.{{ .EnumNameIdentifier }}: true,
+	{{- end }}
+	{{- end }}
+}
+
+// IsDeprecated reports whether the {{ .WrapperName }} value is deprecated.
+// Deprecated values can still be parsed but are not returned by All.
+func ({{ .Receiver }} {{ .WrapperName }}) IsDeprecated() bool {
+	return deprecated{{ .EnumType }}[{{ .Receiver }}]
+}
+{{- end }}
 `
 	isValidTemplate = template.Must(template.New("isValid").Parse(isValidStr))
 )
@@ -475,60 +1370,85 @@ type isValidFunctionData struct {
 	EnumType    string
 	WrapperName string
 	Enums       []enumDefinition
+	Deprecated  bool
 }
 
 func (g *Writer) writeIsValidFunction(rep enum.GenerationRequest) {
 	g.writeTemplate(isValidTemplate, isValidFunctionData{
-		Receiver:    receiver(rep.EnumIota.Type),
+		Receiver:    receiver(rep),
 		EnumType:    enumType(rep),
-		WrapperName: wrapperName(rep.EnumIota.Type),
+		WrapperName: wrapperName(rep),
 		Enums:       enumDefinitions(rep),
+		Deprecated:  hasDeprecated(rep),
 	})
 }
 
+// hasDeprecated reports whether any value of the enum is deprecated.
+func hasDeprecated(rep enum.GenerationRequest) bool {
+	return slices.ContainsFunc(rep.EnumIota.Enums, func(e enum.Enum) bool { return e.Deprecated })
+}
+
 func (g *Writer) writeNumberParsingMethods(rep enum.GenerationRequest) {
 	g.writeTemplate(parseIntegerGenericFunctionTemplate, parseNumberFunctionData{
-		Constraints:   rep.Configuration.Constraints,
-		HasStartIndex: rep.EnumIota.StartIndex > 0,
-		StartIndex:    rep.EnumIota.StartIndex,
-		WrapperName:   wrapperName(rep.EnumIota.Type),
-		EnumType:      enumType(rep),
+		Constraints: rep.Configuration.Constraints,
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
 	})
 
 	// Add Parse{{ .WrapperName }}Number method for primitive serialization
 	g.writeTemplate(parseNumberFunctionTemplate, parseNumberFunctionData{
-		Constraints:   rep.Configuration.Constraints,
-		HasStartIndex: rep.EnumIota.StartIndex > 0,
-		StartIndex:    rep.EnumIota.StartIndex,
-		WrapperName:   wrapperName(rep.EnumIota.Type),
-		EnumType:      enumType(rep),
+		Constraints: rep.Configuration.Constraints,
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
 	})
 }
 
+// enumType returns the name of the container variable generated for the
+// enum, the -plural name of the type or the camel case plural of its name.
 func enumType(rep enum.GenerationRequest) string {
-	return strings.Pluralise(strings.Camel(rep.EnumIota.Type))
+	if name := rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).Plural; name != "" {
+		return name
+	}
+	return strings.PluraliseWith(strings.Camel(rep.EnumIota.Type), rep.Configuration.Plurals)
 }
 
 var (
 	invalidEnumStr = `
+	{{- if .InvalidName }}
+	// invalid{{ .WrapperName }} is the invalid sentinel value for {{ .WrapperName }},
+	// exported as {{ .EnumType }}.{{ .InvalidName }}
+	var invalid{{ .WrapperName }} = {{ .WrapperName }}{invalid: true}
+	{{- else }}
 	// invalid{{ .WrapperName }} is an invalid sentinel value for {{ .WrapperName }}
 	var invalid{{ .WrapperName }} = {{ .WrapperName }}{}
+	{{- end }}
 	`
 	invalidEnumTemplate = template.Must(template.New("invalidEnum").Parse(invalidEnumStr))
 )
 
-func (g *Writer) writeInvalidEnumDefinition(enum enum.GenerationRequest) {
-	g.writeTemplate(invalidEnumTemplate, newInterfaceFunctionData(enum))
+func (g *Writer) writeInvalidEnumDefinition(rep enum.GenerationRequest) {
+	g.writeTemplate(invalidEnumTemplate, struct {
+		WrapperName string
+		EnumType    string
+		InvalidName string
+	}{
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
+		InvalidName: rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).InvalidName,
+	})
 }
 
 type wrapperDefinition struct {
 	WrapperName string
 	WrapperType string
 	EnumType    string
-	Fields      []field
+	// ImportedType is the type declared in another package EnumType aliases
+	ImportedType string
+	Fields       []field
 
 	EnumContainerName string
 	Enums             []cenum
+	InvalidName       string
 
 	// Serialization interface flags
 	HasJSON        bool
@@ -548,10 +1468,17 @@ type cenum struct {
 	Name          string
 	EnumType      string
 	CustomComment string
+	// CompatName is the upstream field name aliasing Name, if different
+	CompatName string
 }
 
 var (
 	wrapperDefinitionStr = `
+{{- if .ImportedType }}
+// {{ .EnumType }} is the {{ .ImportedType }} enum type, whose constants are
+// declared in this package.
+type {{ .EnumType }} = {{ .ImportedType }}
+{{ end }}
 // {{ .WrapperName }} is a type that represents a single enum value.
 // It combines the core information about the enum constant and it's defined fields.
 type {{ .WrapperName }} struct {
@@ -559,6 +1486,11 @@ type {{ .WrapperName }} struct {
 	{{- range .Fields }}
 	{{ .Name }} {{ .Type }}
 	{{- end }}
+	{{- if .InvalidName }}
+	// invalid marks the {{ .InvalidName }} sentinel, keeping it distinct
+	// from every constant
+	invalid bool
+	{{- end }}
 }
 
 // Verify that {{ .WrapperName }} implements the Enum interface
@@ -570,6 +1502,15 @@ type {{ .EnumContainerName }} struct {
   {{- range .Enums }}
   {{ .Name }} {{ .EnumType }}{{- if .CustomComment }} // {{ .CustomComment }}{{- end }}
   {{- end }}
+  {{- range .Enums }}
+  {{- if .CompatName }}
+  // Deprecated: {{ .CompatName }} is the zarldev/goenums name of {{ .Name }}.
+  {{ .CompatName }} {{ .EnumType }}
+  {{- end }}
+  {{- end }}
+  {{- if .InvalidName }}
+  {{ .InvalidName }} {{ .WrapperName }} // invalid sentinel
+  {{- end }}
 }
 `
 	wrapperDefinitionTemplate = template.Must(
@@ -581,13 +1522,13 @@ func (g *Writer) writeWrapperDefinition(enum enum.GenerationRequest) {
 	var (
 		fields = make([]field, len(enum.EnumIota.Fields)) // wrapper fields
 		cenums = make([]cenum, len(enum.EnumIota.Enums))  // container enums
-		wName  = wrapperName(enum.EnumIota.Type)          // wrapper name
+		wName  = wrapperName(enum)                        // wrapper name
 		wType  = wrapperType(enum.EnumIota.Type)          // wrapper type
 	)
 	for i, f := range enum.EnumIota.Fields {
 		fields[i] = field{
 			Name: f.Name,
-			Type: strings.AsType(f.Value),
+			Type: fieldType(f.Value),
 		}
 	}
 	for i, e := range enum.EnumIota.Enums {
@@ -595,6 +1536,7 @@ func (g *Writer) writeWrapperDefinition(enum enum.GenerationRequest) {
 			Name:          generateEnumNameIdentifier(e.Name, enumConfig.UppercaseFields),
 			EnumType:      wName,
 			CustomComment: e.CustomComment,
+			CompatName:    compatIdentifier(e.Name, enumConfig),
 		}
 	}
 
@@ -603,8 +1545,10 @@ func (g *Writer) writeWrapperDefinition(enum enum.GenerationRequest) {
 		WrapperType:       wType,
 		Enums:             cenums,
 		EnumType:          enum.EnumIota.Type,
+		ImportedType:      enum.EnumIota.ImportedType,
 		Fields:            fields,
 		EnumContainerName: containerType(enum),
+		InvalidName:       enumConfig.InvalidName,
 		HasJSON:           enumConfig.Handlers.JSON,
 		HasText:           enumConfig.Handlers.Text,
 		HasBinary:         enumConfig.Handlers.Binary,
@@ -615,12 +1559,19 @@ func (g *Writer) writeWrapperDefinition(enum enum.GenerationRequest) {
 	g.writeTemplate(wrapperDefinitionTemplate, d)
 }
 
-func wrapperName(enum string) string {
-	if strings.IsPlural(enum) {
-		enum = strings.Singularise(enum)
-		strings.Camel(enum)
+// wrapperName returns the name of the wrapper type generated for the enum,
+// the -wrapper name of the type or the camel case singular of its name.
+func wrapperName(rep enum.GenerationRequest) string {
+	if name := rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).WrapperName; name != "" {
+		return name
 	}
-	return strings.Camel(enum)
+	return strings.Camel(strings.SingulariseWith(rep.EnumIota.Type, rep.Configuration.Plurals))
+}
+
+// WrapperName returns the name of the type the generated code declares for
+// the values of enumIota, which plugins declare their methods on.
+func WrapperName(cfg config.Configuration, enumIota enum.EnumIota) string {
+	return wrapperName(enum.GenerationRequest{EnumIota: enumIota, Configuration: cfg})
 }
 
 func wrapperType(enum string) string {
@@ -628,8 +1579,11 @@ func wrapperType(enum string) string {
 }
 
 func containerType(enum enum.GenerationRequest) string {
+	if name := enum.Configuration.GetEnumTypeConfig(enum.EnumIota.Type).Plural; name != "" {
+		return strings.Lower1stCharacter(name) + "Container"
+	}
 	cName := strings.Lower1stCharacter(enum.EnumIota.Type)
-	cName = strings.Pluralise(cName)
+	cName = strings.PluraliseWith(cName, enum.Configuration.Plurals)
 	return cName + "Container"
 }
 
@@ -662,6 +1616,10 @@ func (g *Writer) writeTemplate(t *template.Template, d any) {
 }
 
 func (g *Writer) writeGeneratedComments(rep enum.GenerationRequest) {
+	if rep.BuildConstraint != "" {
+		// The constraint must precede the package clause, separated by a blank line
+		fmt.Fprintf(g.w, "//go:build %s\n\n", rep.BuildConstraint)
+	}
 	g.writeTemplate(generatedCommentTemplate, generatedComment{
 		Version:        rep.Version,
 		Time:           time.Now().Format(time.Stamp),
@@ -685,18 +1643,22 @@ import (
 	"{{ . }}"
 {{- end }}
 {{ if .ExternalImports }}
-{{ range .ExternalImports }}
-	"{{ . }}"
-{{ end }}
+{{- range .ExternalImports }}
+	{{ . }}
+{{- end }}
 {{ end }}
 	)
 `
 	packageImportTemplate = template.Must(template.New("packageImport").Parse(packageImportStr))
 )
 
-func (g *Writer) writePackageAndImports(rep enum.GenerationRequest) {
+func (g *Writer) writePackageAndImports(rep enum.GenerationRequest, pluginImports []string) {
 	externalImports := []string{}
 	imports := []string{"fmt"}
+	// errors declares the sentinel of the parse errors
+	if sectionWritten(rep.Configuration, config.SectionParse) {
+		imports = append(imports, "errors")
+	}
 
 	imports = append(imports, rep.Imports...)
 	if !rep.Configuration.Legacy {
@@ -710,25 +1672,104 @@ func (g *Writer) writePackageAndImports(rep enum.GenerationRequest) {
 	enumIotas := rep.GetEnumIotas()
 	needsSQL := false
 	needsYAML := false
+	needsURL := false
+	needsAtomic := false
+	needsBinary := false
+	needsContext := false
+	needsJSON := false
 
+	serde := sectionWritten(rep.Configuration, config.SectionSerde)
 	for _, enumIota := range enumIotas {
 		enumConfig := rep.Configuration.GetEnumTypeConfig(enumIota.Type)
-		if enumConfig.Handlers.SQL {
+		if !serde {
+			enumConfig.Handlers = config.Handlers{}
+		}
+		if !sectionWritten(rep.Configuration, config.SectionStateMachine) {
+			enumConfig.StateMachine = false
+		}
+		if !sectionWritten(rep.Configuration, config.SectionAtomic) {
+			enumConfig.Atomic = false
+		}
+		if enumConfig.Handlers.SQL || enumConfig.Handlers.SQLArray {
 			needsSQL = true
 		}
 		if enumConfig.Handlers.YAML {
 			needsYAML = true
 		}
+		if enumConfig.Handlers.HTTP {
+			needsURL = true
+		}
+		if enumConfig.Atomic {
+			needsAtomic = true
+		}
+		if enumConfig.Handlers.SQLColumn || enumConfig.StateMachine {
+			needsContext = true
+		}
+		if enumConfig.Handlers.Binary && strings.Contains(enumConfig.BinaryEncoding, config.BinaryLittleEndian) {
+			needsBinary = true
+		}
+		// UnmarshalJSON decodes the names it rejects to suggest another
+		if enumConfig.Handlers.JSON && (!enumConfig.DefaultOnError || enumIota.Default == "") &&
+			suggestsNames(enum.GenerationRequest{Configuration: rep.Configuration, EnumIota: enumIota}) {
+			needsJSON = true
+		}
 	}
 
+	if needsURL {
+		imports = append(imports, "net/url")
+	}
+	if needsAtomic {
+		imports = append(imports, "sync/atomic")
+	}
+	if needsBinary {
+		imports = append(imports, "encoding/binary")
+	}
+	if needsJSON {
+		imports = append(imports, "encoding/json")
+	}
+	if needsContext {
+		imports = append(imports, "context")
+	}
 	if needsSQL {
-		externalImports = append(externalImports, "database/sql/driver")
+		imports = append(imports, "database/sql/driver")
 	}
-	if needsYAML {
+	// FromName folds the case of names with strings.EqualFold
+	if rep.Configuration.Insensitive && sectionWritten(rep.Configuration, config.SectionEnum) {
+		imports = append(imports, "strings")
+	}
+	if needsYAML && yamlLibrary(rep.Configuration) == config.YAMLLibraryV3 {
 		externalImports = append(externalImports, "gopkg.in/yaml.v3")
 	}
-
+	// Standard library paths have no dot in their first element
+	for _, imp := range pluginImports {
+		if first, _, _ := strings.Cut(imp, "/"); strings.Contains(first, ".") {
+			externalImports = append(externalImports, imp)
+		} else {
+			imports = append(imports, imp)
+		}
+	}
+	// Field types may need a package the enum types already import, which
+	// is then imported once under its default name
+	for _, imp := range rep.FieldImports {
+		if imp.Name == enum.DefaultImportName(imp.Path) {
+			externalImports = append(externalImports, imp.Path)
+		}
+	}
 	slices.Sort(imports)
+	imports = slices.Compact(imports)
+	slices.Sort(externalImports)
+	externalImports = slices.Compact(externalImports)
+	externalImports = slices.DeleteFunc(externalImports, func(imp string) bool {
+		return slices.Contains(imports, imp)
+	})
+	for i, imp := range externalImports {
+		externalImports[i] = strconv.Quote(imp)
+	}
+	for _, imp := range rep.FieldImports {
+		if imp.Name != enum.DefaultImportName(imp.Path) && !slices.Contains(imports, imp.Path) {
+			externalImports = append(externalImports, imp.Name+" "+strconv.Quote(imp.Path))
+		}
+	}
 	g.writeTemplate(packageImportTemplate, packageImport{
 		PackageName:     rep.Package,
 		Imports:         imports,
@@ -736,11 +1777,185 @@ func (g *Writer) writePackageAndImports(rep enum.GenerationRequest) {
 	})
 }
 
+// generatedImports maps the names of the packages the generated code may
+// import to their paths.
+var generatedImports = map[string]string{
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"iter":    "iter",
+	"enums":   "github.com/donutnomad/goenums/enums",
+	"driver":  "database/sql/driver",
+	"yaml":    "gopkg.in/yaml.v3",
+	"url":     "net/url",
+	"atomic":  "sync/atomic",
+	"binary":  "encoding/binary",
+	"context": "context",
+	"json":    "encoding/json",
+}
+
+// generatedLocalNames are the parameters and variables of the generated
+// methods, which a receiver of the same name would clash with.
+var generatedLocalNames = []string{
+	"ctx", "data", "err", "fieldType", "hooks", "name", "nextInt", "node", "param", "ptr",
+	"result", "shouldBeNull", "src", "tag", "target", "transitions", "value", "yield",
+}
+
+// checkNames reports an error when the wrapper of enumIota has the name of
+// its container, or its -receiver name an identifier the generated
+// methods refer to.
+func checkNames(req enum.GenerationRequest, enumIota enum.EnumIota) error {
+	rep := enum.GenerationRequest{Configuration: req.Configuration, EnumIota: enumIota}
+	cfg := req.Configuration.GetEnumTypeConfig(enumIota.Type)
+	if wrapper := wrapperName(rep); wrapper == enumType(rep) {
+		return fmt.Errorf("%w: %s: the wrapper %s is the name of the container", ErrReservedName, enumIota.Type, wrapper)
+	}
+	// A struct cannot have a field and a method of the same name
+	methods := containerMethods(rep)
+	for _, e := range enumIota.Enums {
+		field := generateEnumNameIdentifier(e.Name, cfg.UppercaseFields)
+		if slices.Contains(methods, field) {
+			return fmt.Errorf("%w: %s: the value %s is the %s method of the container", ErrReservedName, enumIota.Type, e.Name, field)
+		}
+	}
+	name := cfg.Receiver
+	if name == "" {
+		return nil
+	}
+	lower := strings.ToLower(enumIota.Type)
+	reserved := types.Universe.Lookup(name) != nil ||
+		generatedImports[name] != "" ||
+		slices.Contains(generatedLocalNames, name) ||
+		slices.ContainsFunc(req.Imports, func(imp string) bool { return enum.DefaultImportName(imp) == name }) ||
+		slices.ContainsFunc(req.FieldImports, func(imp enum.Import) bool { return imp.Name == name }) ||
+		// Package-level names such as invalid<Type> and <type>NamesMap
+		strings.HasPrefix(name, "invalid") || strings.HasPrefix(name, "valid") ||
+		strings.HasPrefix(name, "scan") || (strings.HasPrefix(name, lower) && name != lower)
+	if reserved {
+		return fmt.Errorf("%w: %s: -receiver=%s", ErrReservedName, enumIota.Type, name)
+	}
+	return nil
+}
+
+// containerMethods returns the names of the methods written on the
+// container whose names the container fields of the values cannot take.
+func containerMethods(rep enum.GenerationRequest) []string {
+	if !sectionWritten(rep.Configuration, config.SectionConvenience) {
+		return nil
+	}
+	wrapper := wrapperName(rep)
+	methods := []string{"Count", "Min" + wrapper, "Max" + wrapper}
+	if sectionWritten(rep.Configuration, config.SectionParse) {
+		methods = append(methods, "Parse", "MustParse")
+	}
+	return methods
+}
+
+// checkDuplicateNames reports an error when two enum types of req generate
+// a wrapper or container of the same name, which would be declared twice
+// in their file.
+func checkDuplicateNames(req enum.GenerationRequest) error {
+	declared := make(map[string]string)
+	for _, enumIota := range req.GetEnumIotas() {
+		rep := enum.GenerationRequest{Configuration: req.Configuration, EnumIota: enumIota}
+		for _, name := range []string{wrapperName(rep), enumType(rep)} {
+			if other, ok := declared[name]; ok {
+				return fmt.Errorf("%w: %s: %s is also generated for %s", ErrReservedName, enumIota.Type, name, other)
+			}
+			declared[name] = enumIota.Type
+		}
+	}
+	return nil
+}
+
+// aliasFieldImports renames field type imports whose package name collides
+// with a package imported by the generated code itself, rewriting the
+// qualifiers of the affected field types and values to the new alias.
+func aliasFieldImports(req enum.GenerationRequest) enum.GenerationRequest {
+	if len(req.FieldImports) == 0 {
+		return req
+	}
+	taken := make(map[string]bool)
+	for name := range generatedImports {
+		taken[name] = true
+	}
+	for _, imp := range req.Imports {
+		taken[enum.DefaultImportName(imp)] = true
+	}
+	renames := make(map[string]string)
+	fieldImports := make([]enum.Import, len(req.FieldImports))
+	for i, imp := range req.FieldImports {
+		// The packages of the generated code are shared rather than renamed
+		if taken[imp.Name] && !slices.Contains(req.Imports, imp.Path) && generatedImports[imp.Name] != imp.Path {
+			alias := imp.Name
+			for n := 1; taken[alias]; n++ {
+				alias = imp.Name + strconv.Itoa(n)
+			}
+			renames[imp.Name] = alias
+			imp.Name = alias
+		}
+		taken[imp.Name] = true
+		fieldImports[i] = imp
+	}
+	req.FieldImports = fieldImports
+	if len(renames) == 0 {
+		return req
+	}
+	// Protobuf enums are qualified with the renamed packages too
+	typeConfigs := maps.Clone(req.Configuration.EnumTypeConfigs)
+	for typeName, cfg := range typeConfigs {
+		if qualifier, name, ok := strings.Cut(cfg.Proto, "."); ok && renames[qualifier] != "" {
+			cfg.Proto = renames[qualifier] + "." + name
+			typeConfigs[typeName] = cfg
+		}
+	}
+	req.Configuration.EnumTypeConfigs = typeConfigs
+	rename := func(v any) any {
+		expr, ok := v.(enum.Expr)
+		if !ok {
+			return v
+		}
+		for from, to := range renames {
+			re := regexp.MustCompile(`\b` + regexp.QuoteMeta(from) + `\.`)
+			expr.Type = re.ReplaceAllString(expr.Type, to+".")
+			expr.Value = re.ReplaceAllString(expr.Value, to+".")
+		}
+		return expr
+	}
+	renameFields := func(fields []enum.Field) []enum.Field {
+		out := make([]enum.Field, len(fields))
+		for i, f := range fields {
+			out[i] = enum.Field{Name: f.Name, Value: rename(f.Value)}
+		}
+		return out
+	}
+	renameIota := func(enumIota enum.EnumIota) enum.EnumIota {
+		if qualifier, name, ok := strings.Cut(enumIota.ImportedType, "."); ok && renames[qualifier] != "" {
+			enumIota.ImportedType = renames[qualifier] + "." + name
+		}
+		enumIota.Fields = renameFields(enumIota.Fields)
+		enums := make([]enum.Enum, len(enumIota.Enums))
+		for i, e := range enumIota.Enums {
+			e.Fields = renameFields(e.Fields)
+			enums[i] = e
+		}
+		enumIota.Enums = enums
+		return enumIota
+	}
+	req.EnumIota = renameIota(req.EnumIota)
+	enumIotas := make([]enum.EnumIota, len(req.EnumIotas))
+	for i, enumIota := range req.EnumIotas {
+		enumIotas[i] = renameIota(enumIota)
+	}
+	req.EnumIotas = enumIotas
+	return req
+}
+
 type containerDefinition struct {
 	WrapperName   string
 	ContainerName string
 	ContainerType string
 	EnumDefs      []enumDefinition
+	InvalidName   string
 }
 
 var (
@@ -750,14 +1965,24 @@ var (
 // operations, with convenience methods for common use cases.
 var {{.ContainerName}} = {{.ContainerType}}{
 {{- range .EnumDefs }}
-	{{.EnumNameIdentifier}}: {{.EnumType}} {
+	{{.EnumNameIdentifier}}: {{ template "value" . }},
+{{- end }}
+{{- range .EnumDefs }}
+	{{- if .CompatIdentifier }}
+	{{.CompatIdentifier}}: {{ template "value" . }},
+	{{- end }}
+{{- end }}
+{{- if .InvalidName }}
+	{{.InvalidName}}: invalid{{.WrapperName}},
+{{- end }}
+}
+{{- define "value" }}{{.EnumType}} {
 		{{.IotaType}}: {{.EnumName}},
 		{{- range .Fields }}
 		{{.Name}}: {{.Value}},
 		{{- end }}
-	},
+	}
 {{- end }}
-}
 `
 	containerDefinitionTemplate = template.Must(template.New("containerDefinition").Parse(containerDefinitionStr))
 )
@@ -765,18 +1990,96 @@ var {{.ContainerName}} = {{.ContainerType}}{
 func (g *Writer) writeContainerDefinition(rep enum.GenerationRequest) {
 	edefs := enumDefinitions(rep)
 	cdef := containerDefinition{
-		WrapperName:   wrapperName(rep.EnumIota.Type),
+		WrapperName:   wrapperName(rep),
 		ContainerType: containerType(rep),
-		ContainerName: strings.Pluralise(strings.Camel(rep.EnumIota.Type)),
+		ContainerName: enumType(rep),
 		EnumDefs:      edefs,
+		InvalidName:   rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).InvalidName,
 	}
 	g.writeTemplate(containerDefinitionTemplate, cdef)
 }
 
+// fieldType returns the Go type of a field value as written in generated code.
+// Slices are written as enums.Slice, keeping the wrapper comparable.
+func fieldType(v any) string {
+	if expr, ok := v.(enum.Expr); ok {
+		return expr.Type
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		return "enums.Slice[" + strings.AsType(reflect.Zero(rv.Type().Elem()).Interface()) + "]"
+	}
+	return strings.AsType(v)
+}
+
+// fieldValue returns a field value as a Go expression for generated code.
+func fieldValue(v any) string {
+	if expr, ok := v.(enum.Expr); ok {
+		return expr.Value
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		elems := make([]string, rv.Len())
+		for i := range elems {
+			elems[i] = strings.Ify(rv.Index(i).Interface())
+		}
+		elem := strings.AsType(reflect.Zero(rv.Type().Elem()).Interface())
+		return "enums.SliceOf[" + elem + "](" + strings.Join(elems, ", ") + ")"
+	}
+	return strings.Ify(v)
+}
+
+// orderedEnums returns the values of the enum in the -order of its type:
+// as declared, by value or by name. Values that compare equal keep the
+// order they are declared in.
+func orderedEnums(rep enum.GenerationRequest) []enum.Enum {
+	enums := slices.Clone(rep.EnumIota.Enums)
+	switch rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).Order {
+	case config.OrderValue:
+		slices.SortStableFunc(enums, compareValues)
+	case config.OrderName:
+		slices.SortStableFunc(enums, func(a, b enum.Enum) int {
+			return cmp.Compare(naming.DisplayName(a), naming.DisplayName(b))
+		})
+	}
+	return enums
+}
+
+// compareValues compares the values of a and b, falling back to their
+// indexes when their literals cannot be ordered.
+func compareValues(a, b enum.Enum) int {
+	x, y := literalValue(a), literalValue(b)
+	switch {
+	case x.Kind() == constant.String && y.Kind() == constant.String,
+		isNumeric(x) && isNumeric(y):
+		if constant.Compare(x, token.LSS, y) {
+			return -1
+		}
+		if constant.Compare(x, token.GTR, y) {
+			return 1
+		}
+		return 0
+	}
+	return cmp.Compare(a.Index, b.Index)
+}
+
+// literalValue returns the constant value of the literal of e, unknown when
+// it cannot be evaluated.
+func literalValue(e enum.Enum) constant.Value {
+	tv, err := types.Eval(token.NewFileSet(), nil, token.NoPos, e.Literal())
+	if err != nil || tv.Value == nil {
+		return constant.MakeUnknown()
+	}
+	return tv.Value
+}
+
+// isNumeric reports whether v is an integer or float constant.
+func isNumeric(v constant.Value) bool {
+	return v.Kind() == constant.Int || v.Kind() == constant.Float
+}
+
 func enumDefinitions(rep enum.GenerationRequest) []enumDefinition {
 	enumConfig := rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type)
 	edefs := make([]enumDefinition, 0)
-	for _, e := range rep.EnumIota.Enums {
+	for _, e := range orderedEnums(rep) {
 		if len(rep.EnumIota.Fields) > 0 &&
 			len(e.Fields) == 0 {
 			continue
@@ -786,33 +2089,29 @@ func enumDefinitions(rep enum.GenerationRequest) []enumDefinition {
 		for j, f := range fields {
 			ffields[j] = enum.Field{
 				Name:  f.Name,
-				Value: strings.Ify(f.Value),
+				Value: fieldValue(f.Value),
 			}
 		}
 		aliases := e.Aliases
-		if rep.Configuration.Insensitive {
-			for _, a := range e.Aliases {
-				lwr := strings.ToLower(a)
-				if lwr == a {
-					continue
-				}
-				if slices.Contains(aliases, lwr) {
-					continue
-				}
-				aliases = append(aliases, strings.ToLower(a))
-			}
-		}
 		edefs = append(edefs, enumDefinition{
 			EnumName:           e.Name,
 			EnumNameIdentifier: generateEnumNameIdentifier(e.Name, enumConfig.UppercaseFields),
-			EnumType:           wrapperName(rep.EnumIota.Type),
+			CompatIdentifier:   compatIdentifier(e.Name, enumConfig),
+			EnumType:           wrapperName(rep),
 			Fields:             ffields,
 			IotaType:           rep.EnumIota.Type,
 			Aliases:            aliases,
 			Valid:              e.Valid,
 			CustomComment:      e.CustomComment,
 			StateTransitions:   e.StateTransitions,
+			StateEvents:        e.StateEvents,
 			IsFinalState:       e.IsFinalState,
+			SerdeName:          e.SerdeName,
+			SerdeFormat:        e.SerdeFormat,
+			LegacyAliases:      e.LegacyAliases,
+			Deprecated:         e.Deprecated,
+			Groups:             e.Groups,
+			Tags:               e.Tags,
 		})
 	}
 	return edefs
@@ -876,10 +2175,10 @@ func ({{.Receiver}} {{.ContainerType}}) allSlice() []{{.WrapperName}} {
 
 func (g *Writer) writeAllFunction(rep enum.GenerationRequest) {
 	allData := allFunctionData{
-		Receiver:      receiver(rep.EnumIota.Type),
+		Receiver:      receiver(rep),
 		ContainerType: containerType(rep),
-		ContainerName: strings.Pluralise(strings.Camel(rep.EnumIota.Type)),
-		WrapperName:   wrapperName(rep.EnumIota.Type),
+		ContainerName: enumType(rep),
+		WrapperName:   wrapperName(rep),
 		EnumDefs:      enumDefinitions(rep),
 		Legacy:        rep.Configuration.Legacy,
 	}
@@ -889,10 +2188,10 @@ func (g *Writer) writeAllFunction(rep enum.GenerationRequest) {
 // writeAllSliceMethod writes only the allSlice method (without the All method)
 func (g *Writer) writeAllSliceMethod(rep enum.GenerationRequest) {
 	allData := allFunctionData{
-		Receiver:      receiver(rep.EnumIota.Type),
+		Receiver:      receiver(rep),
 		ContainerType: containerType(rep),
-		ContainerName: strings.Pluralise(strings.Camel(rep.EnumIota.Type)),
-		WrapperName:   wrapperName(rep.EnumIota.Type),
+		ContainerName: enumType(rep),
+		WrapperName:   wrapperName(rep),
 		EnumDefs:      enumDefinitions(rep),
 		Legacy:        rep.Configuration.Legacy,
 	}
@@ -900,71 +2199,151 @@ func (g *Writer) writeAllSliceMethod(rep enum.GenerationRequest) {
 }
 
 type parseFunctionData struct {
-	WrapperName string
-	FailFast    bool
-	Enums       []enum.Enum
+	WrapperName  string
+	EnumType     string
+	EnumLower    string
+	Suggest      bool
+	SuggestNames []string
 }
 
 var (
 	parseFunctionStr = `
-// Parse{{.WrapperName}} parses the input value into an enum value.
-// It returns the parsed enum value or an error if the input is invalid.
-// It is a convenience function that can be used to parse enum values from
-// various input types, such as strings, byte slices, or other enum types.
-func Parse{{.WrapperName}}(input any) ({{.WrapperName}}, error) {
-	var res = invalid{{.WrapperName}}
+{{- if .Suggest }}
+// {{.EnumLower}}SuggestNames lists the valid names offered as suggestions
+// when parsing a near-miss name fails.
+var {{.EnumLower}}SuggestNames = []string{
+	{{- range .SuggestNames }}
+	{{ printf "%q" . }},
+	{{- end }}
+}
+{{ end }}
+// ErrInvalid{{.WrapperName}} is the sentinel wrapped by every error returned
+// when an input cannot be parsed into a {{.WrapperName}}.
+var ErrInvalid{{.WrapperName}} = errors.New("invalid {{.WrapperName}}")
+
+// Invalid{{.WrapperName}}Error is returned when an input cannot be parsed into
+// a {{.WrapperName}}. It carries the offending input and, when one is known,
+// the closest valid name.
+type Invalid{{.WrapperName}}Error struct {
+	Input      any
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *Invalid{{.WrapperName}}Error) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("invalid {{.WrapperName}} value %v, did you mean %q?", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("invalid {{.WrapperName}} value %v", e.Input)
+}
+
+// Unwrap returns ErrInvalid{{.WrapperName}} so callers can use errors.Is.
+func (e *Invalid{{.WrapperName}}Error) Unwrap() error {
+	return ErrInvalid{{.WrapperName}}
+}
+
+{{- if .Suggest }}
+
+// invalid{{.WrapperName}}Error returns the *Invalid{{.WrapperName}}Error of input,
+// suggesting the closest valid name to strings and byte slices. Parse{{.WrapperName}}
+// and the unmarshalers of {{.WrapperName}} return it for the names they reject.
+func invalid{{.WrapperName}}Error(input any) *Invalid{{.WrapperName}}Error {
+	invalid := &Invalid{{.WrapperName}}Error{Input: input}
 	switch v := input.(type) {
-	case {{.WrapperName}}:
-		return v, nil
 	case string:
-		res = stringTo{{.WrapperName}}(v)
-	case fmt.Stringer:
-		res = stringTo{{.WrapperName}}(v.String())
+		invalid.Suggestion = enums.Suggest(v, {{.EnumLower}}SuggestNames)
 	case []byte:
-		res = stringTo{{.WrapperName}}(string(v))
-	case int:
-		res = numberTo{{.WrapperName}}(v)
-	case int8:
-		res = numberTo{{.WrapperName}}(v)
-	case int16:
-		res = numberTo{{.WrapperName}}(v)
-	case int32:
-		res = numberTo{{.WrapperName}}(v)
-	case int64:
-		res = numberTo{{.WrapperName}}(v)
-	case uint:
-		res = numberTo{{.WrapperName}}(v)
-	case uint8:
-		res = numberTo{{.WrapperName}}(v)
-	case uint16:
-		res = numberTo{{.WrapperName}}(v)
-	case uint32:
-		res = numberTo{{.WrapperName}}(v)
-	case uint64:
-		res = numberTo{{.WrapperName}}(v)
-	case float32:
-		res = numberTo{{.WrapperName}}(v)
-	case float64:
-		res = numberTo{{.WrapperName}}(v)
-	default:
-		return res, fmt.Errorf("invalid type %T", input)
+		invalid.Suggestion = enums.Suggest(string(v), {{.EnumLower}}SuggestNames)
 	}
-	{{- if .FailFast}}
-	if res == invalid{{.WrapperName}} {
-	  return res, fmt.Errorf("invalid value %v", input)
+	return invalid
+}
+{{- end }}
+
+// Parse{{.WrapperName}} parses the input value into an enum value.
+// It returns the parsed enum value or an *Invalid{{.WrapperName}}Error if the input is invalid.
+// It is a convenience function that can be used to parse enum values from
+// various input types, such as strings, byte slices, or underlying values.
+func Parse{{.WrapperName}}(input any) ({{.WrapperName}}, error) {
+	res, err := enums.Parse({{.WrapperName}}{}, input)
+	if err != nil {
+		{{- if .Suggest }}
+		return invalid{{.WrapperName}}, invalid{{.WrapperName}}Error(input)
+		{{- else }}
+		return invalid{{.WrapperName}}, &Invalid{{.WrapperName}}Error{Input: input}
+		{{- end }}
+	}
+	return res, nil
+}
+
+// MustParse{{.WrapperName}} parses the input value into an enum value.
+// It panics if the input is invalid, which makes it suitable for
+// initialization code where the input is known to be valid.
+func MustParse{{.WrapperName}}(input any) {{.WrapperName}} {
+	res, err := Parse{{.WrapperName}}(input)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Parse{{.WrapperName}}Or parses the input value into an enum value.
+// It returns def if the input is invalid.
+func Parse{{.WrapperName}}Or(input any, def {{.WrapperName}}) {{.WrapperName}} {
+	res, err := Parse{{.WrapperName}}(input)
+	if err != nil {
+		return def
+	}
+	return res
+}
+
+// Parse{{.WrapperName}}Slice parses each of inputs, such as the values of a
+// comma-separated query parameter, into an enum value. It returns the error
+// of the first invalid input.
+func Parse{{.WrapperName}}Slice(inputs []string) ([]{{.WrapperName}}, error) {
+	res := make([]{{.WrapperName}}, len(inputs))
+	for i, input := range inputs {
+		v, err := Parse{{.WrapperName}}(input)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
 	}
-	{{- end}}
 	return res, nil
 }
 `
 	parseFunctionTemplate = template.Must(template.New("parseFunction").Parse(parseFunctionStr))
 )
 
+// suggestsNames reports whether the unmarshalers of the type of rep suggest
+// the closest valid name for the names they reject, with -suggest for types
+// serialized by name. The suggestions are declared in the parse section.
+func suggestsNames(rep enum.GenerationRequest) bool {
+	cfg := rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type)
+	return cfg.Suggest && sectionWritten(rep.Configuration, config.SectionParse) &&
+		cfg.SerializationType == config.SerdeName
+}
+
 func (g *Writer) writeParseFunction(rep enum.GenerationRequest) {
+	enumConfig := rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type)
+	var suggestNames []string
+	if enumConfig.Suggest {
+		for _, e := range enumDefinitions(rep) {
+			if !e.Valid || e.Deprecated {
+				continue
+			}
+			if len(e.Aliases) > 0 {
+				suggestNames = append(suggestNames, e.Aliases[0])
+			} else {
+				suggestNames = append(suggestNames, e.EnumName)
+			}
+		}
+	}
 	g.writeTemplate(parseFunctionTemplate, parseFunctionData{
-		WrapperName: wrapperName(rep.EnumIota.Type),
-		Enums:       rep.EnumIota.Enums,
-		FailFast:    rep.Configuration.Failfast,
+		WrapperName:  wrapperName(rep),
+		EnumType:     enumType(rep),
+		EnumLower:    strings.ToLower(rep.EnumIota.Type),
+		Suggest:      enumConfig.Suggest,
+		SuggestNames: suggestNames,
 	})
 }
 
@@ -986,7 +2365,15 @@ type enumDefinition struct {
 	Valid              bool
 	CustomComment      string
 	StateTransitions   []string
+	StateEvents        []string
 	IsFinalState       bool
+	SerdeName          string
+	SerdeFormat        string
+	LegacyAliases      []string
+	Deprecated         bool
+	Groups             []string
+	Tags               []string
+	CompatIdentifier   string // upstream container field aliasing EnumNameIdentifier, if any
 }
 
 var (
@@ -997,7 +2384,7 @@ var {{.EnumNameMap}} = map[string]{{.WrapperName}}{
 {{- range .Enums }}
   {{- $enum := . }}
   {{- range .Aliases }}
-    "{{ . }}": {{ $.EnumType }}.{{ $enum.EnumNameIdentifier }},
+    {{ printf "%q" . }}: {{ $.EnumType }}.{{ $enum.EnumNameIdentifier }},
   {{- end }}
 {{- end }}
 }
@@ -1017,7 +2404,7 @@ func stringTo{{.WrapperName}}(s string) {{.WrapperName}} {
 
 func (g *Writer) writeStringParsingMethod(rep enum.GenerationRequest) {
 	g.writeTemplate(parseStringFunctionTemplate, parseStringFunctionData{
-		WrapperName:     wrapperName(rep.EnumIota.Type),
+		WrapperName:     wrapperName(rep),
 		EnumNameMap:     enumNameMap(rep.EnumIota.Type),
 		EnumType:        enumType(rep),
 		Enums:           enumDefinitions(rep),
@@ -1026,11 +2413,9 @@ func (g *Writer) writeStringParsingMethod(rep enum.GenerationRequest) {
 }
 
 type parseNumberFunctionData struct {
-	Constraints   bool
-	WrapperName   string
-	EnumType      string
-	StartIndex    int
-	HasStartIndex bool
+	Constraints bool
+	WrapperName string
+	EnumType    string
 }
 
 var (
@@ -1045,18 +2430,17 @@ var (
 func numberTo{{.WrapperName}}[T constraints.Integer | constraints.Float](num T) {{.WrapperName}} {
 {{- end }}
 	f := float64(num)
-    if math.Floor(f) != f {
-        return invalid{{.WrapperName}}
-    }
-	i := int(f)
-	if i <= 0 || i > len({{.EnumType}}.allSlice()) {
+	if math.Floor(f) != f {
 		return invalid{{.WrapperName}}
 	}
-	{{- if .StartIndex }}
-	return {{.EnumType}}.allSlice()[i-{{.StartIndex}}]
-	{{- else }}
-	return {{.EnumType}}.allSlice()[i]
-	{{- end }}
+	// Values are looked up rather than indexed, as they may be zero,
+	// negative or sparse
+	for _, v := range {{.EnumType}}.allSlice() {
+		if float64(v.Val()) == f {
+			return v
+		}
+	}
+	return invalid{{.WrapperName}}
 }
 
 `))
@@ -1124,7 +2508,7 @@ type rawTypeAliasData struct {
 
 func (g *Writer) writeRawTypeAlias(rep enum.GenerationRequest) {
 	data := rawTypeAliasData{
-		RawTypeName: wrapperName(rep.EnumIota.Type) + "Raw",
+		RawTypeName: wrapperName(rep) + "Raw",
 		EnumType:    rep.EnumIota.Type,
 	}
 	g.writeTemplate(rawTypeAliasTemplate, data)
@@ -1140,6 +2524,19 @@ func generateEnumNameIdentifier(name string, uppercaseFields bool) string {
 	return strings.Camel(name)
 }
 
+// compatIdentifier returns the uppercase container field name generated by
+// zarldev/goenums for the enum value, or "" when compatibility is off or the
+// name is already the one generated.
+func compatIdentifier(name string, cfg config.EnumTypeConfig) string {
+	if !cfg.ZarldevCompat || cfg.UppercaseFields {
+		return ""
+	}
+	if upper := generateEnumNameIdentifier(name, true); upper != generateEnumNameIdentifier(name, false) {
+		return upper
+	}
+	return ""
+}
+
 // writeEnumInterfaceMethods writes all methods required by the Enum interface
 func (g *Writer) writeEnumInterfaceMethods(rep enum.GenerationRequest) {
 	g.writeEnumValueMethod(rep)
@@ -1157,14 +2554,54 @@ var (
 func ({{ .Receiver }} {{ .WrapperName }}) Val() {{ .UnderlyingType }} {
 	return {{ .UnderlyingType }}({{ .Receiver }}.{{ .EnumIota }})
 }
+{{- if .FloatFormat }}
+
+// ValString returns the underlying enum value formatted as {{ .FloatFormat }}.
+// MarshalJSON and MarshalText write it when serializing by value.
+func ({{ .Receiver }} {{ .WrapperName }}) ValString() string {
+	return fmt.Sprintf({{ printf "%q" .FloatFormat }}, {{ .Receiver }}.Val())
+}
+{{- end }}
 `
 	enumValueMethodTemplate = template.Must(template.New("enumValueMethod").Parse(enumValueMethodStr))
 
 	enumValuesMethodStr = `
 // All implements the Enum interface.
+{{- if .Deprecated }}
+// It returns an iterator over all enum values that are not deprecated.
+{{- else }}
 // It returns an iterator over all enum values.
+{{- end }}
 func ({{ .Receiver }} {{ .WrapperName }}) All() iter.Seq[{{ .WrapperName }}] {
 	return func(yield func({{ .WrapperName }}) bool) {
+		{{- if .InvalidInAll }}
+		if !yield(invalid{{ .WrapperName }}) {
+			return
+		}
+		{{- end }}
+		for _, v := range {{ .EnumType }}.allSlice() {
+			{{- if .Deprecated }}
+			if v.IsDeprecated() {
+				continue
+			}
+			{{- end }}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+{{- if .Deprecated }}
+
+// AllIncludingDeprecated returns an iterator over all enum values,
+// including deprecated ones.
+func ({{ .Receiver }} {{ .WrapperName }}) AllIncludingDeprecated() iter.Seq[{{ .WrapperName }}] {
+	return func(yield func({{ .WrapperName }}) bool) {
+		{{- if .InvalidInAll }}
+		if !yield(invalid{{ .WrapperName }}) {
+			return
+		}
+		{{- end }}
 		for _, v := range {{ .EnumType }}.allSlice() {
 			if !yield(v) {
 				return
@@ -1172,36 +2609,120 @@ func ({{ .Receiver }} {{ .WrapperName }}) All() iter.Seq[{{ .WrapperName }}] {
 		}
 	}
 }
+{{- end }}
 `
 	enumValuesMethodTemplate = template.Must(template.New("enumValuesMethod").Parse(enumValuesMethodStr))
 
 	enumFindByNameMethodStr = `
+{{- if .Aliases }}
+// {{ .EnumLower }}AliasesMap maps the enum values to all the names they are
+// parsed from, the canonical name returned by String first.
+var {{ .EnumLower }}AliasesMap = map[{{ .WrapperName }}][]string{
+	{{- range .Aliases }}
+	{{ $.EnumType }}.{{ .Identifier }}: { {{- range $i, $a := .Names }}{{ if $i }}, {{ end }}{{ printf "%q" $a }}{{ end -}} },
+	{{- end }}
+}
+{{ end }}
+{{- if .FoldNames }}
+// {{ .EnumLower }}FoldNames lists the names FromName matches regardless of
+// case, by Unicode case folding, when no name matches exactly.
+var {{ .EnumLower }}FoldNames = []struct {
+	name string
+	enum {{ .WrapperName }}
+}{
+	{{- range .FoldNames }}
+	{ {{- printf "%q" .Name }}, {{ $.EnumType }}.{{ .Identifier -}} },
+	{{- end }}
+}
+{{ end }}
+{{- if .LegacyNames }}
+// {{ .EnumLower }}LegacyNamesMap is a map of historic names to the enum values
+// they were renamed to. Legacy names are accepted when parsing but never serialized.
+var {{ .EnumLower }}LegacyNamesMap = map[string]{{ .WrapperName }}{
+	{{- range .LegacyNames }}
+	{{ printf "%q" .Name }}: {{ $.EnumType }}.{{ .Identifier }},
+	{{- end }}
+}
+
+// Canonicalize{{ .WrapperName }} returns the canonical name of the enum value
+// identified by name, resolving legacy names to the name they were renamed to.
+// It is intended for migrating stored data and reports false for unknown names.
+func Canonicalize{{ .WrapperName }}(name string) (string, bool) {
+	v, ok := {{ .WrapperName }}{}.FromName(name)
+	if !ok {
+		return "", false
+	}
+	return v.Name(), true
+}
+{{ end }}
 // FromName implements the Enum interface.
 // It finds an enum value by name and returns the enum instance and a boolean indicating if found.
 func ({{ .Receiver }} {{ .WrapperName }}) FromName(name string) ({{ .WrapperName }}, bool) {
-	for enum, enumName := range {{ .EnumLower }}NamesMap {
+	{{- if .SerdeNames }}
+	for enum, enumName := range {{ .EnumLower }}SerdeNamesMap {
 		if enumName == name {
 			return enum, true
 		}
 	}
-	var zero {{ .WrapperName }}
-	return zero, false
-}
-`
-	enumFindByNameMethodTemplate = template.Must(template.New("enumFindByNameMethod").Parse(enumFindByNameMethodStr))
-
-	enumFindByValueMethodStr = `
-// FromValue implements the Enum interface.
-// It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
-func ({{ .Receiver }} {{ .WrapperName }}) FromValue(value {{ .UnderlyingType }}) ({{ .WrapperName }}, bool) {
-	for v := range {{ .Receiver }}.All() {
-		if v.Val() == value {
-			return v, true
-		}
+	{{- end }}
+	{{- if .SwitchNames }}
+	switch name {
+	{{- range .SwitchNames }}
+	case {{ printf "%q" .Name }}:
+		return {{ $.EnumType }}.{{ .Identifier }}, true
+	{{- end }}
 	}
-	var zero {{ .WrapperName }}
-	return zero, false
-}
+	{{- else }}
+	for enum, enumName := range {{ .EnumLower }}NamesMap {
+		if enumName == name {
+			return enum, true
+		}
+	}
+	{{- end }}
+	{{- if .Aliases }}
+	for enum, aliases := range {{ .EnumLower }}AliasesMap {
+		for _, alias := range aliases[1:] {
+			if alias == name {
+				return enum, true
+			}
+		}
+	}
+	{{- end }}
+	{{- if .LegacyNames }}
+	if enum, ok := {{ .EnumLower }}LegacyNamesMap[name]; ok {
+		return enum, true
+	}
+	{{- end }}
+	{{- if .FoldNames }}
+	for _, n := range {{ .EnumLower }}FoldNames {
+		if strings.EqualFold(n.name, name) {
+			return n.enum, true
+		}
+	}
+	{{- end }}
+	return invalid{{ .WrapperName }}, false
+}
+`
+	enumFindByNameMethodTemplate = template.Must(template.New("enumFindByNameMethod").Parse(enumFindByNameMethodStr))
+
+	enumFindByValueMethodStr = `
+// FromValue implements the Enum interface.
+// It finds an enum instance by its underlying value and returns the enum instance and a boolean indicating if found.
+{{- if .FloatEpsilon }}
+// Values within {{ .FloatEpsilon }} of an enum value match it.
+{{- end }}
+func ({{ .Receiver }} {{ .WrapperName }}) FromValue(value {{ .UnderlyingType }}) ({{ .WrapperName }}, bool) {
+	for _, v := range {{ .EnumType }}.allSlice() {
+		{{- if .FloatEpsilon }}
+		if d := v.Val() - value; d >= -{{ .FloatEpsilon }} && d <= {{ .FloatEpsilon }} {
+		{{- else }}
+		if v.Val() == value {
+		{{- end }}
+			return v, true
+		}
+	}
+	return invalid{{ .WrapperName }}, false
+}
 `
 	enumFindByValueMethodTemplate = template.Must(template.New("enumFindByValueMethod").Parse(enumFindByValueMethodStr))
 
@@ -1215,17 +2736,55 @@ func ({{ .Receiver }} {{ .WrapperName }}) SerdeFormat() enums.Format {
 	return enums.FormatName
 	{{- end }}
 }
+{{- if .FormatOverrides }}
+
+// ValueFormat implements the enums.FormatOverrider interface.
+// It returns the format the value is serialized in, which "serde:"
+// annotations override for some values.
+func ({{ .Receiver }} {{ .WrapperName }}) ValueFormat() enums.Format {
+	switch {{ .Receiver }} {
+	{{- range .FormatOverrides }}
+	case {{ $.EnumType }}.{{ .Identifier }}:
+		return enums.{{ .Name }}
+	{{- end }}
+	}
+	return {{ .Receiver }}.SerdeFormat()
+}
+{{- end }}
 `
 	enumFormatMethodTemplate = template.Must(template.New("enumFormatMethod").Parse(enumFormatMethodStr))
 
 	enumNameMethodStr = `
+{{- if .SerdeNames }}
+// {{ .EnumLower }}SerdeNamesMap is a map of enum values to the names used when
+// serializing by name. It differs from {{ .EnumLower }}NamesMap for constants
+// annotated with a json:<name> override.
+var {{ .EnumLower }}SerdeNamesMap = map[{{ .WrapperName }}]string{
+	{{- range .SerdeNames }}
+	{{ $.EnumType }}.{{ .Identifier }}: {{ printf "%q" .Name }},
+	{{- end }}
+}
+{{ end }}
 // Name implements the Enum interface.
 // It returns the name of the current enum value.
 func ({{ .Receiver }} {{ .WrapperName }}) Name() string {
+	{{- if .SerdeNames }}
+	if str, ok := {{ .EnumLower }}SerdeNamesMap[{{ .Receiver }}]; ok {
+		return str
+	}
+	{{- end }}
+	{{- if .SwitchNames }}
+	return {{ .Receiver }}.String()
+	{{- else }}
 	if str, ok := {{ .EnumLower }}NamesMap[{{ .Receiver }}]; ok {
 		return str
 	}
+	{{- if .InvalidName }}
+	return {{ .Receiver }}.String()
+	{{- else }}
 	return fmt.Sprintf("{{ .EnumLower }}(%v)", {{ .Receiver }}.{{ .EnumIota }})
+	{{- end }}
+	{{- end }}
 }
 `
 	enumNameMethodTemplate = template.Must(template.New("enumNameMethod").Parse(enumNameMethodStr))
@@ -1240,6 +2799,217 @@ type enumInterfaceMethodData struct {
 	SerializationType string
 	EnumNameMap       string
 	EnumLower         string
+	SerdeNames        []serdeName
+	LegacyNames       []serdeName
+	Aliases           []aliasNames
+	FoldNames         []serdeName
+	Deprecated        bool
+	JSONNull          bool
+	// JSONObject is set with -serde/object, whose JSON objects hold the
+	// custom fields in ObjectFields
+	JSONObject   bool
+	ObjectFields []objectField
+	// ScanKind selects the driver values Scan converts without reflection,
+	// see scanKind
+	ScanKind string
+	// SQLFormat is "name" or "value" with -sqltype, whose driver values
+	// Value converts the underlying value to SQLDriverType for
+	SQLFormat     string
+	SQLDriverType string
+	// BinaryOptions is the enums.BinaryOptions literal of -binary=<encoding>
+	BinaryOptions string
+	// Default is the identifier of the value invalid input unmarshals to with
+	// -default-on-error
+	Default string
+	// SwitchNames are the canonical names of the values with -stringer=switch,
+	// which generates no names map
+	SwitchNames []serdeName
+	// FormatOverrides pair the values with a "serde:" annotation with the
+	// enums.Format constant they are serialized in
+	FormatOverrides []serdeName
+	// FloatEpsilon is the Go literal of the tolerance of -float/epsilon, and
+	// FloatFormat the fmt verb of -float/format
+	FloatEpsilon string
+	FloatFormat  string
+	// InvalidName is the -invalid name of the sentinel, which Name returns
+	// for it, and InvalidInAll makes All yield the sentinel first
+	InvalidName  string
+	InvalidInAll bool
+	// Suggest is set with -suggest for types serialized by name, whose
+	// unmarshalers return the *Invalid<Type>Error of Parse<Type> with the
+	// closest valid name
+	Suggest bool
+	// HTTP is set with -http, whose UnmarshalText fails with the
+	// *Invalid<Type>Error of UnmarshalParam for the binders using either
+	HTTP bool
+}
+
+// serdeName pairs a container field with a name its enum value is known by.
+type serdeName struct {
+	Identifier string
+	Name       string
+}
+
+// canonicalNames returns the canonical names of all enum values, which
+// String returns.
+func canonicalNames(rep enum.GenerationRequest) []serdeName {
+	edefs := enumDefinitions(rep)
+	names := make([]serdeName, 0, len(edefs))
+	for _, e := range edefs {
+		name := e.EnumName
+		if len(e.Aliases) > 0 {
+			name = e.Aliases[0]
+		}
+		names = append(names, serdeName{Identifier: e.EnumNameIdentifier, Name: name})
+	}
+	return names
+}
+
+// serdeNames returns the serialization names of all enum values, or nil when
+// no constant overrides its display name.
+func serdeNames(rep enum.GenerationRequest) []serdeName {
+	edefs := enumDefinitions(rep)
+	if !slices.ContainsFunc(edefs, func(e enumDefinition) bool { return e.SerdeName != "" }) {
+		return nil
+	}
+	names := make([]serdeName, 0, len(edefs))
+	for _, e := range edefs {
+		name := e.SerdeName
+		switch {
+		case name != "":
+		case len(e.Aliases) > 0:
+			name = e.Aliases[0]
+		default:
+			name = e.EnumName
+		}
+		names = append(names, serdeName{Identifier: e.EnumNameIdentifier, Name: name})
+	}
+	return names
+}
+
+// formatOverrides returns the values whose "serde:" annotation overrides
+// the serialization format of their type.
+func formatOverrides(rep enum.GenerationRequest) []serdeName {
+	var overrides []serdeName
+	for _, e := range enumDefinitions(rep) {
+		switch e.SerdeFormat {
+		case enum.SerdeFormatName:
+			overrides = append(overrides, serdeName{Identifier: e.EnumNameIdentifier, Name: "FormatName"})
+		case enum.SerdeFormatValue:
+			overrides = append(overrides, serdeName{Identifier: e.EnumNameIdentifier, Name: "FormatValue"})
+		}
+	}
+	return overrides
+}
+
+// legacyNames returns the legacy aliases of all enum values.
+func legacyNames(rep enum.GenerationRequest) []serdeName {
+	var names []serdeName
+	for _, e := range enumDefinitions(rep) {
+		for _, alias := range e.LegacyAliases {
+			names = append(names, serdeName{Identifier: e.EnumNameIdentifier, Name: alias})
+		}
+	}
+	return names
+}
+
+// aliasNames are the names an enum value is parsed from, its canonical name
+// first.
+type aliasNames struct {
+	Identifier string
+	Names      []string
+}
+
+// enumAliases returns the names of all enum values, or nil when none of
+// them declares more than one alias.
+func enumAliases(rep enum.GenerationRequest) []aliasNames {
+	if !slices.ContainsFunc(rep.EnumIota.Enums, func(e enum.Enum) bool { return len(e.Aliases) > 1 }) {
+		return nil
+	}
+	enumConfig := rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type)
+	var names []aliasNames
+	for _, e := range orderedEnums(rep) {
+		if len(rep.EnumIota.Fields) > 0 && len(e.Fields) == 0 {
+			continue
+		}
+		aliases := e.Aliases
+		if len(aliases) == 0 {
+			aliases = []string{e.Name}
+		}
+		names = append(names, aliasNames{
+			Identifier: generateEnumNameIdentifier(e.Name, enumConfig.UppercaseFields),
+			Names:      aliases,
+		})
+	}
+	return names
+}
+
+// foldNames returns every name FromName accepts, matched regardless of
+// case with -insensitive, or nil without it. Names only differing in case
+// from a name of the same value are left out.
+func foldNames(rep enum.GenerationRequest) []serdeName {
+	if !rep.Configuration.Insensitive {
+		return nil
+	}
+	all := append(serdeNames(rep), canonicalNames(rep)...)
+	for _, a := range enumAliases(rep) {
+		for _, name := range a.Names[1:] {
+			all = append(all, serdeName{Identifier: a.Identifier, Name: name})
+		}
+	}
+	all = append(all, legacyNames(rep)...)
+	var names []serdeName
+	for _, n := range all {
+		if !slices.ContainsFunc(names, func(m serdeName) bool {
+			return m.Identifier == n.Identifier && strings.EqualFold(m.Name, n.Name)
+		}) {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// objectField is a custom field written to the JSON objects of -serde/object.
+type objectField struct {
+	Key   string
+	Field string
+}
+
+// scanKind returns which driver values the generated Scan converts without
+// reflection: "name" for enums serialized by name, or the kind of the
+// underlying type, "int", "uint", "float" or "string", for those serialized
+// by value. It returns "" for the underlying types left to enums.SQLScan.
+func scanKind(serdeType, underlyingType string) string {
+	if serdeType == "name" {
+		return "name"
+	}
+	switch underlyingType {
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		return "int"
+	case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		return "uint"
+	case "float32", "float64":
+		return "float"
+	case "string":
+		return "string"
+	}
+	return ""
+}
+
+// binaryOptions returns the enums.BinaryOptions literal of a -binary encoding.
+func binaryOptions(encoding string) string {
+	encodings := strings.Split(encoding, ",")
+	var fields []string
+	if slices.Contains(encodings, config.BinaryLittleEndian) {
+		fields = append(fields, "ByteOrder: binary.LittleEndian")
+	}
+	if slices.Contains(encodings, config.BinaryVarint) {
+		fields = append(fields, "Varint: true")
+	}
+	if slices.Contains(encodings, config.BinaryLengthPrefixed) {
+		fields = append(fields, "LengthPrefixed: true")
+	}
+	return "enums.BinaryOptions{" + strings.Join(fields, ", ") + "}"
 }
 
 func newEnumInterfaceMethodData(rep enum.GenerationRequest) enumInterfaceMethodData {
@@ -1254,16 +3024,62 @@ func newEnumInterfaceMethodData(rep enum.GenerationRequest) enumInterfaceMethodD
 		serdeType = "name"
 	}
 
-	return enumInterfaceMethodData{
-		Receiver:          receiver(rep.EnumIota.Type),
-		WrapperName:       wrapperName(rep.EnumIota.Type),
+	d := enumInterfaceMethodData{
+		Receiver:          receiver(rep),
+		WrapperName:       wrapperName(rep),
 		EnumType:          enumType(rep),
 		EnumIota:          rep.EnumIota.Type,
 		UnderlyingType:    rep.EnumIota.UnderlyingType,
 		SerializationType: serdeType,
 		EnumNameMap:       enumNameMap(rep.EnumIota.Type),
 		EnumLower:         strings.ToLower(rep.EnumIota.Type),
+		SerdeNames:        serdeNames(rep),
+		LegacyNames:       legacyNames(rep),
+		Aliases:           enumAliases(rep),
+		FoldNames:         foldNames(rep),
+		FormatOverrides:   formatOverrides(rep),
+		Deprecated:        hasDeprecated(rep),
+		JSONNull:          enumConfig.JSONNull,
+		FloatFormat:       enumConfig.FloatFormat,
+		InvalidName:       enumConfig.InvalidName,
+		InvalidInAll:      enumConfig.InvalidInAll,
+		Suggest:           suggestsNames(rep),
+		HTTP:              enumConfig.Handlers.HTTP,
+	}
+	if enumConfig.FloatEpsilon != 0 {
+		d.FloatEpsilon = strconv.FormatFloat(enumConfig.FloatEpsilon, 'g', -1, 64)
+	}
+	if enumConfig.SerializationType == config.SerdeObject {
+		d.JSONObject = true
+		for _, f := range rep.EnumIota.Fields {
+			d.ObjectFields = append(d.ObjectFields, objectField{Key: strings.Lower1stCharacter(f.Name), Field: f.Name})
+		}
 	}
+	switch enumConfig.SQLType {
+	case config.SQLTypeString:
+		d.SQLFormat = "name"
+	case config.SQLTypeInt, config.SQLTypeBool:
+		d.SQLFormat = "value"
+		d.SQLDriverType = mapToSQLType(rep.EnumIota.UnderlyingType)
+	}
+	switch {
+	case enumConfig.SQLType == config.SQLTypeBool:
+		d.ScanKind = "bool"
+	case d.SQLFormat != "":
+		d.ScanKind = scanKind(d.SQLFormat, rep.EnumIota.UnderlyingType)
+	default:
+		d.ScanKind = scanKind(serdeType, rep.EnumIota.UnderlyingType)
+	}
+	if enumConfig.BinaryEncoding != "" {
+		d.BinaryOptions = binaryOptions(enumConfig.BinaryEncoding)
+	}
+	if enumConfig.DefaultOnError {
+		d.Default = generateEnumNameIdentifier(rep.EnumIota.Default, enumConfig.UppercaseFields)
+	}
+	if enumConfig.Stringer == config.StringerSwitch {
+		d.SwitchNames = canonicalNames(rep)
+	}
+	return d
 }
 
 func (g *Writer) writeEnumValueMethod(rep enum.GenerationRequest) {
@@ -1297,18 +3113,146 @@ func (g *Writer) writeSerializationMethods(rep enum.GenerationRequest) {
 	if enumConfig.Handlers.JSON {
 		g.writeJSONSerializationMethods(rep)
 	}
-	if enumConfig.Handlers.Text {
+	// The YAML text library, the HTTP binders and Avro rely on the text
+	// methods too
+	if enumConfig.Handlers.Text || enumConfig.Handlers.HTTP || enumConfig.Handlers.Avro ||
+		(enumConfig.Handlers.YAML && yamlLibrary(rep.Configuration) == config.YAMLLibraryText) {
 		g.writeTextSerializationMethods(rep)
 	}
 	if enumConfig.Handlers.Binary {
 		g.writeBinarySerializationMethods(rep)
 	}
 	if enumConfig.Handlers.YAML {
-		g.writeYAMLSerializationMethods(rep)
+		switch yamlLibrary(rep.Configuration) {
+		case config.YAMLLibraryGoccy:
+			g.writeTemplate(yamlMarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
+			g.writeTemplate(yamlFuncUnmarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
+		case config.YAMLLibrarySigs:
+			if !enumConfig.Handlers.JSON {
+				g.writeTemplate(yamlJSONSerdeTemplate, newEnumInterfaceMethodData(rep))
+			}
+		case config.YAMLLibraryText:
+			// Written with the text methods above
+		default:
+			g.writeYAMLSerializationMethods(rep)
+		}
 	}
 	if enumConfig.Handlers.SQL {
 		g.writeSQLSerializationMethods(rep)
 	}
+	if enumConfig.Handlers.SQLArray {
+		g.writeTemplate(sqlArrayTemplate, newEnumInterfaceMethodData(rep))
+	}
+	if enumConfig.Handlers.SQLColumn {
+		g.writeTemplate(sqlColumnTemplate, newSQLColumnData(rep, enumConfig))
+	}
+	if enumConfig.Handlers.SQLBoiler {
+		g.writeTemplate(sqlBoilerTemplate, newSQLBoilerData(rep, enumConfig))
+	}
+	if enumConfig.Handlers.Bun {
+		g.writeTemplate(bunTemplate, newEnumInterfaceMethodData(rep))
+	}
+	if enumConfig.Handlers.Avro || enumConfig.Handlers.Kafka {
+		g.writeAvroSchema(rep, enumConfig)
+	}
+	if enumConfig.Handlers.Kafka {
+		g.writeKafka(rep, enumConfig)
+	}
+	if enumConfig.Handlers.Mapstructure {
+		g.writeTemplate(decodeHookTemplate, newEnumInterfaceMethodData(rep))
+	}
+	if enumConfig.Handlers.HTTP {
+		g.writeTemplate(unmarshalParamTemplate, newEnumInterfaceMethodData(rep))
+		g.writeTemplate(fromQueryTemplate, newEnumInterfaceMethodData(rep))
+	}
+	if enumConfig.Handlers.Redis {
+		g.writeTemplate(redisTemplate, struct {
+			enumInterfaceMethodData
+			Binary bool
+		}{newEnumInterfaceMethodData(rep), enumConfig.Handlers.Binary})
+	}
+	if enumConfig.Proto != "" {
+		g.writeProtoConversions(rep, enumConfig)
+	}
+}
+
+// writeAvroSchema writes the Avro schema of the enum as a constant, for
+// registering it or parsing it with the Avro library. Write has checked
+// that the enum has one.
+func (g *Writer) writeAvroSchema(rep enum.GenerationRequest, enumConfig config.EnumTypeConfig) {
+	s, _ := avro.FromEnum(rep.EnumIota, rep.Package, enumConfig)
+	b, _ := json.Marshal(s)
+	g.writeTemplate(avroSchemaTemplate, struct {
+		WrapperName string
+		Schema      string
+	}{wrapperName(rep), string(b)})
+}
+
+// writeKafka writes the JSON schema of the enum, the schemas registered
+// for it in a Confluent Schema Registry and its Kafka serializer, whose
+// symbols are those of the Avro schema written by writeAvroSchema.
+func (g *Writer) writeKafka(rep enum.GenerationRequest, enumConfig config.EnumTypeConfig) {
+	s, _ := avro.FromEnum(rep.EnumIota, rep.Package, enumConfig)
+	js := jsonschema.FromEnum(rep.EnumIota, enumConfig)
+	b, _ := json.Marshal(js)
+	g.writeTemplate(kafkaTemplate, struct {
+		WrapperName string
+		EnumLower   string
+		Schema      string
+		Symbols     []string
+	}{wrapperName(rep), strings.ToLower(rep.EnumIota.Type), string(b), s.Symbols})
+}
+
+// protoConversionData is the template data of the protobuf conversions.
+type protoConversionData struct {
+	Receiver    string
+	WrapperName string
+	EnumType    string
+	Proto       string
+	Values      []protoValue
+}
+
+// protoValue maps a container field to its protobuf enum constant.
+type protoValue struct {
+	Identifier string
+	Constant   string
+}
+
+// writeProtoConversions writes the conversions between the enum and the
+// protobuf enum named by -proto. Its value constants are named after the
+// valid enum values in the protoc-gen-go convention,
+// "pkg.EnumName_ENUM_NAME_VALUE", unless -proto/prefix replaces
+// "EnumName_ENUM_NAME_".
+func (g *Writer) writeProtoConversions(rep enum.GenerationRequest, enumConfig config.EnumTypeConfig) {
+	pkg, name, _ := strings.Cut(enumConfig.Proto, ".")
+	prefix := enumConfig.ProtoPrefix
+	if prefix == "" {
+		prefix = name + "_" + strings.ToUpper(strings.Snake(name)) + "_"
+	}
+	d := protoConversionData{
+		Receiver:    receiver(rep),
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
+		Proto:       enumConfig.Proto,
+	}
+	for _, e := range enumDefinitions(rep) {
+		if !e.Valid {
+			continue
+		}
+		d.Values = append(d.Values, protoValue{
+			Identifier: e.EnumNameIdentifier,
+			Constant:   pkg + "." + prefix + strings.ToUpper(strings.Snake(e.EnumName)),
+		})
+	}
+	g.writeTemplate(protoConversionTemplate, d)
+}
+
+// yamlLibrary returns the configured YAML library, defaulting to yaml.v3.
+func yamlLibrary(cfg config.Configuration) string {
+	if cfg.YAMLLibrary == "" {
+		return config.YAMLLibraryV3
+	}
+	return cfg.YAMLLibrary
 }
 
 // writeJSONSerializationMethods writes JSON marshaling and unmarshaling methods
@@ -1323,151 +3267,803 @@ func (g *Writer) writeTextSerializationMethods(rep enum.GenerationRequest) {
 	g.writeTemplate(textUnmarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
 }
 
-// writeBinarySerializationMethods writes Binary marshaling and unmarshaling methods
-func (g *Writer) writeBinarySerializationMethods(rep enum.GenerationRequest) {
-	g.writeTemplate(binaryMarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
-	g.writeTemplate(binaryUnmarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
+// writeBinarySerializationMethods writes Binary marshaling and unmarshaling methods
+func (g *Writer) writeBinarySerializationMethods(rep enum.GenerationRequest) {
+	g.writeTemplate(binaryMarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
+	g.writeTemplate(binaryUnmarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
+}
+
+// writeYAMLSerializationMethods writes YAML marshaling and unmarshaling methods
+func (g *Writer) writeYAMLSerializationMethods(rep enum.GenerationRequest) {
+	g.writeTemplate(yamlMarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
+	g.writeTemplate(yamlUnmarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
+}
+
+// writeSQLSerializationMethods writes SQL Scan and Value methods
+func (g *Writer) writeSQLSerializationMethods(rep enum.GenerationRequest) {
+	g.writeTemplate(sqlScanSerdeTemplate, newEnumInterfaceMethodData(rep))
+	g.writeTemplate(sqlValueSerdeTemplate, newEnumInterfaceMethodData(rep))
+}
+
+var (
+	jsonMarshalSerdeStr = `
+// MarshalJSON implements the json.Marshaler interface for {{ .WrapperName }}.
+// It returns the JSON representation of the enum value as a byte slice.
+func ({{ .Receiver }} {{ .WrapperName }}) MarshalJSON() ([]byte, error) {
+	{{- if .JSONNull }}
+	if {{ .Receiver }}.IsZero() {
+		return []byte("null"), nil
+	}
+	{{- end }}
+	{{- if .JSONObject }}
+	return enums.MarshalJSONObject({{ .Receiver }}
+		{{- range .ObjectFields }},
+		enums.ObjectField{Name: "{{ .Key }}", Value: {{ $.Receiver }}.{{ .Field }}}
+		{{- end }})
+	{{- else }}
+	return enums.MarshalJSON({{ .Receiver }}, {{ if .FloatFormat }}{{ .Receiver }}.ValString(){{ else }}{{ .Receiver }}.{{ .EnumIota }}{{ end }})
+	{{- end }}
+}
+`
+	jsonMarshalSerdeTemplate = template.Must(template.New("jsonMarshalSerde").Parse(jsonMarshalSerdeStr))
+
+	jsonUnmarshalSerdeStr = `
+// UnmarshalJSON implements the json.Unmarshaler interface for {{ .WrapperName }}.
+// It parses the JSON representation of the enum value from the byte slice.
+{{ if .Default -}}
+// Invalid input stores {{ .EnumType }}.{{ .Default }} instead of returning an error.
+{{ else -}}
+// It returns an error if the input is not a valid JSON representation.
+{{ end -}}
+func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalJSON(data []byte) error {
+	{{- if .JSONNull }}
+	if string(data) == "null" {
+		return nil
+	}
+	{{- end }}
+	result, err := enums.{{ if .JSONObject }}UnmarshalJSONObject{{ else }}UnmarshalJSON{{ end }}(*{{ .Receiver }}, data)
+	if err != nil {
+		{{- if and .Suggest (not .Default) }}
+		// Reject names with the error of Parse{{ .WrapperName }}, which suggests the closest
+		var name string
+		if json.Unmarshal(data, &name) == nil {
+			return invalid{{ .WrapperName }}Error(name)
+		}
+		{{- end }}
+		{{- if .Default }}
+		*{{ .Receiver }} = {{ .EnumType }}.{{ .Default }}
+		return nil
+		{{- else }}
+		return err
+		{{- end }}
+	}
+	*{{ .Receiver }} = *result
+	return nil
+}
+
+// UnmarshalJSONLenient is UnmarshalJSON, except that unknown names and values,
+// such as those added by newer producers, decode to the invalid {{ .WrapperName }}
+// instead of returning an error. Malformed JSON still returns an error.
+func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalJSONLenient(data []byte) error {
+	{{- if .JSONNull }}
+	if string(data) == "null" {
+		return nil
+	}
+	{{- end }}
+	result, err := enums.{{ if .JSONObject }}UnmarshalJSONObject{{ else }}UnmarshalJSON{{ end }}(*{{ .Receiver }}, data, enums.WithLenient())
+	if err != nil {
+		return err
+	}
+	*{{ .Receiver }} = *result
+	return nil
+}
+
+// Validate{{ .WrapperName }}JSON checks that data is the JSON of a valid {{ .WrapperName }},
+// as UnmarshalJSON decodes it, without decoding it into a {{ .WrapperName }}, for
+// validating requests before they are handled. Its errors wrap enums.ErrInvalidValue.
+{{- if .JSONNull }}
+// null is rejected: UnmarshalJSON decodes it as the invalid zero value.
+{{- end }}
+func Validate{{ .WrapperName }}JSON(data []byte) error {
+	return enums.{{ if .JSONObject }}ValidateJSONObject{{ else }}ValidateJSON{{ end }}({{ .WrapperName }}{}, data)
+}
+`
+	jsonUnmarshalSerdeTemplate = template.Must(template.New("jsonUnmarshalSerde").Parse(jsonUnmarshalSerdeStr))
+
+	avroSchemaStr = `
+// {{ .WrapperName }}AvroSchema is the Avro enum schema of {{ .WrapperName }}. Avro
+// libraries such as github.com/hamba/avro encode {{ .WrapperName }} with its
+// text methods as one of the schema's symbols.
+const {{ .WrapperName }}AvroSchema = {{ printf "%q" .Schema }}
+`
+	avroSchemaTemplate = template.Must(template.New("avroSchema").Parse(avroSchemaStr))
+
+	kafkaStr = `
+// {{ .WrapperName }}JSONSchema is the JSON Schema of the names of {{ .WrapperName }}.
+const {{ .WrapperName }}JSONSchema = {{ printf "%q" .Schema }}
+
+// {{ .EnumLower }}KafkaSymbols are the symbols of {{ .WrapperName }}AvroSchema,
+// whose indexes Avro encodes.
+var {{ .EnumLower }}KafkaSymbols = []string{
+	{{- range .Symbols }}
+	{{ printf "%q" . }},
+	{{- end }}
+}
+
+// {{ .WrapperName }}RegistrySchema returns the schema of {{ .WrapperName }} of
+// schemaType, {{ .WrapperName }}AvroSchema or {{ .WrapperName }}JSONSchema, to
+// register under subject in a Confluent Schema Registry, so topic contracts
+// list its exact symbols.
+func {{ .WrapperName }}RegistrySchema(subject string, schemaType enums.SchemaType) enums.RegistrySchema {
+	schema := {{ .WrapperName }}AvroSchema
+	if schemaType == enums.SchemaTypeJSON {
+		schema = {{ .WrapperName }}JSONSchema
+	}
+	return enums.RegistrySchema{Subject: subject, SchemaType: schemaType, Schema: schema}
+}
+
+// New{{ .WrapperName }}KafkaSerde returns the serializer and deserializer of
+// {{ .WrapperName }} Kafka records in the Confluent wire format, with the ID
+// its schema of schemaType was registered with. Its methods have the shape of
+// those of the Serializer and Deserializer interfaces of confluent-kafka-go.
+func New{{ .WrapperName }}KafkaSerde(schemaID int, schemaType enums.SchemaType) *enums.KafkaSerde[{{ .WrapperName }}] {
+	return &enums.KafkaSerde[{{ .WrapperName }}]{
+		SchemaID:   schemaID,
+		SchemaType: schemaType,
+		Symbols:    {{ .EnumLower }}KafkaSymbols,
+		FromName:   {{ .WrapperName }}{}.FromName,
+	}
+}
+`
+	kafkaTemplate = template.Must(template.New("kafka").Parse(kafkaStr))
+
+	decodeHookStr = `
+// {{ .WrapperName }}DecodeHook returns a mapstructure decode hook converting the
+// names and values read from configuration files into {{ .WrapperName }}, for
+// viper.DecodeHook. Combine it with the hooks of other types using
+// enums.ComposeDecodeHooks.
+func {{ .WrapperName }}DecodeHook() enums.DecodeHookFunc {
+	return enums.DecodeHook(Parse{{ .WrapperName }})
+}
+`
+	decodeHookTemplate = template.Must(template.New("decodeHook").Parse(decodeHookStr))
+
+	unmarshalParamStr = `
+// UnmarshalParam implements the echo.BindUnmarshaler interface for {{ .WrapperName }},
+// which Gin's form binding uses too, so {{ .WrapperName }} and *{{ .WrapperName }}
+// fields can be bound from path, query and form parameters. It accepts the
+// names and values Parse{{ .WrapperName }} does.
+func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalParam(param string) error {
+	result, err := Parse{{ .WrapperName }}(param)
+	if err != nil {
+		return err
+	}
+	*{{ .Receiver }} = result
+	return nil
+}
+`
+	unmarshalParamTemplate = template.Must(template.New("unmarshalParam").Parse(unmarshalParamStr))
+
+	protoConversionStr = `
+// ToProto converts {{ .WrapperName }} to the protobuf enum {{ .Proto }}; invalid
+// values convert to its zero value. Every {{ .WrapperName }} value is mapped to
+// its {{ .Proto }} constant, so a value missing from the protobuf enum fails
+// to compile.
+func ({{ .Receiver }} {{ .WrapperName }}) ToProto() {{ .Proto }} {
+	switch {{ .Receiver }} {
+	{{- range .Values }}
+	case {{ $.EnumType }}.{{ .Identifier }}:
+		return {{ .Constant }}
+	{{- end }}
+	}
+	return 0
+}
+
+// {{ .WrapperName }}FromProto converts the protobuf enum {{ .Proto }} to a
+// {{ .WrapperName }}. It returns an *Invalid{{ .WrapperName }}Error for values
+// without a {{ .WrapperName }} counterpart, such as the zero value.
+func {{ .WrapperName }}FromProto(v {{ .Proto }}) ({{ .WrapperName }}, error) {
+	switch v {
+	{{- range .Values }}
+	case {{ .Constant }}:
+		return {{ $.EnumType }}.{{ .Identifier }}, nil
+	{{- end }}
+	}
+	return invalid{{ .WrapperName }}, &Invalid{{ .WrapperName }}Error{Input: v}
+}
+`
+	protoConversionTemplate = template.Must(template.New("protoConversion").Parse(protoConversionStr))
+
+	fromQueryStr = `
+// {{ .WrapperName }}FromQuery parses the value of key in values, such as
+// r.URL.Query() or r.Form of an HTTP request, into a {{ .WrapperName }}.
+// It returns an *Invalid{{ .WrapperName }}Error if the value is invalid and
+// an error wrapping enums.ErrMissingValue if key is absent.
+func {{ .WrapperName }}FromQuery(values url.Values, key string) ({{ .WrapperName }}, error) {
+	return enums.FromValues(values, key, Parse{{ .WrapperName }})
+}
+`
+	fromQueryTemplate = template.Must(template.New("fromQuery").Parse(fromQueryStr))
+
+	textMarshalSerdeStr = `
+// MarshalText implements the encoding.TextMarshaler interface for {{ .WrapperName }}.
+// It returns the text representation of the enum value as a byte slice.
+func ({{ .Receiver }} {{ .WrapperName }}) MarshalText() ([]byte, error) {
+	return enums.MarshalText({{ .Receiver }}, {{ if .FloatFormat }}{{ .Receiver }}.ValString(){{ else }}{{ .Receiver }}.{{ .EnumIota }}{{ end }})
+}
+`
+	textMarshalSerdeTemplate = template.Must(template.New("textMarshalSerde").Parse(textMarshalSerdeStr))
+
+	textUnmarshalSerdeStr = `
+// UnmarshalText implements the encoding.TextUnmarshaler interface for {{ .WrapperName }}.
+// It parses the text representation of the enum value from the byte slice.
+{{ if .Default -}}
+// Invalid input stores {{ .EnumType }}.{{ .Default }} instead of returning an error.
+{{ else -}}
+// It returns an error if the byte slice does not contain a valid enum value.
+{{ end -}}
+func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalText(data []byte) error {
+	result, err := enums.UnmarshalText(*{{ .Receiver }}, data)
+	if err != nil {
+		{{- if .Default }}
+		*{{ .Receiver }} = {{ .EnumType }}.{{ .Default }}
+		return nil
+		{{- else if .Suggest }}
+		return invalid{{ .WrapperName }}Error(string(data))
+		{{- else if .HTTP }}
+		return &Invalid{{ .WrapperName }}Error{Input: string(data)}
+		{{- else }}
+		return err
+		{{- end }}
+	}
+	*{{ .Receiver }} = *result
+	return nil
+}
+`
+	textUnmarshalSerdeTemplate = template.Must(template.New("textUnmarshalSerde").Parse(textUnmarshalSerdeStr))
+
+	binaryMarshalSerdeStr = `
+{{- if .BinaryOptions }}
+// {{ .EnumLower }}BinaryOptions is the binary encoding of {{ .WrapperName }}.
+var {{ .EnumLower }}BinaryOptions = {{ .BinaryOptions }}
+{{ end }}
+// MarshalBinary implements the encoding.BinaryMarshaler interface for {{ .WrapperName }}.
+// It returns the binary representation of the enum value as a byte slice.
+func ({{ .Receiver }} {{ .WrapperName }}) MarshalBinary() ([]byte, error) {
+	return enums.MarshalBinary({{ .Receiver }}, {{ .Receiver }}.{{ .EnumIota }}{{ if .BinaryOptions }}, {{ .EnumLower }}BinaryOptions{{ end }})
+}
+`
+	binaryMarshalSerdeTemplate = template.Must(template.New("binaryMarshalSerde").Parse(binaryMarshalSerdeStr))
+
+	binaryUnmarshalSerdeStr = `
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface for {{ .WrapperName }}.
+// It parses the binary representation of the enum value from the byte slice.
+{{ if .Default -}}
+// Invalid input stores {{ .EnumType }}.{{ .Default }} instead of returning an error.
+{{ else -}}
+// It returns an error if the byte slice does not contain a valid enum value.
+{{ end -}}
+func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalBinary(data []byte) error {
+	result, err := enums.UnmarshalBinary(*{{ .Receiver }}, data{{ if .BinaryOptions }}, enums.WithBinaryOptions({{ .EnumLower }}BinaryOptions){{ end }})
+	if err != nil {
+		{{- if .Default }}
+		*{{ .Receiver }} = {{ .EnumType }}.{{ .Default }}
+		return nil
+		{{- else }}
+		return err
+		{{- end }}
+	}
+	*{{ .Receiver }} = *result
+	return nil
+}
+`
+	binaryUnmarshalSerdeTemplate = template.Must(template.New("binaryUnmarshalSerde").Parse(binaryUnmarshalSerdeStr))
+
+	yamlMarshalSerdeStr = `
+// MarshalYAML implements the yaml.Marshaler interface for {{ .WrapperName }}.
+// It returns the YAML representation of the enum value.
+func ({{ .Receiver }} {{ .WrapperName }}) MarshalYAML() (any, error) {
+	return enums.MarshalYAML({{ .Receiver }}, {{ .Receiver }}.{{ .EnumIota }})
+}
+`
+	yamlMarshalSerdeTemplate = template.Must(template.New("yamlMarshalSerde").Parse(yamlMarshalSerdeStr))
+
+	yamlUnmarshalSerdeStr = `
+// UnmarshalYAML implements the yaml.Unmarshaler interface for {{ .WrapperName }}.
+// It parses the YAML representation of the enum value.
+{{ if .Default -}}
+// Invalid input stores {{ .EnumType }}.{{ .Default }} instead of returning an error.
+{{ else -}}
+// It returns an error if the YAML does not contain a valid enum value.
+{{ end -}}
+func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalYAML(node *yaml.Node) error {
+	result, err := enums.UnmarshalYAML(*{{ .Receiver }}, node)
+	if err != nil {
+		{{- if .Default }}
+		*{{ .Receiver }} = {{ .EnumType }}.{{ .Default }}
+		return nil
+		{{- else }}
+		return err
+		{{- end }}
+	}
+	*{{ .Receiver }} = *result
+	return nil
+}
+`
+	yamlUnmarshalSerdeTemplate = template.Must(template.New("yamlUnmarshalSerde").Parse(yamlUnmarshalSerdeStr))
+
+	yamlFuncUnmarshalSerdeStr = `
+// UnmarshalYAML implements the function-based YAML unmarshaler interface of
+// github.com/goccy/go-yaml and gopkg.in/yaml.v2 for {{ .WrapperName }}.
+{{ if .Default -}}
+// Invalid input stores {{ .EnumType }}.{{ .Default }} instead of returning an error.
+{{ else -}}
+// It returns an error if the YAML does not contain a valid enum value.
+{{ end -}}
+func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalYAML(unmarshal func(any) error) error {
+	result, err := enums.UnmarshalYAML(*{{ .Receiver }}, enums.YAMLDecodeFunc(unmarshal))
+	if err != nil {
+		{{- if .Default }}
+		*{{ .Receiver }} = {{ .EnumType }}.{{ .Default }}
+		return nil
+		{{- else }}
+		return err
+		{{- end }}
+	}
+	*{{ .Receiver }} = *result
+	return nil
+}
+`
+	yamlFuncUnmarshalSerdeTemplate = template.Must(template.New("yamlFuncUnmarshalSerde").Parse(yamlFuncUnmarshalSerdeStr))
+
+	yamlJSONSerdeStr = `
+// MarshalJSON implements the json.Marshaler interface for {{ .WrapperName }}
+// following the YAML marshaling rules, for sigs.k8s.io/yaml which converts
+// YAML to JSON.
+func ({{ .Receiver }} {{ .WrapperName }}) MarshalJSON() ([]byte, error) {
+	return enums.MarshalYAMLJSON({{ .Receiver }}, {{ .Receiver }}.{{ .EnumIota }})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for {{ .WrapperName }}
+// following the YAML unmarshaling rules, for sigs.k8s.io/yaml which converts
+// YAML to JSON.
+func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalJSON(data []byte) error {
+	result, err := enums.UnmarshalYAMLJSON(*{{ .Receiver }}, data)
+	if err != nil {
+		{{- if .Default }}
+		*{{ .Receiver }} = {{ .EnumType }}.{{ .Default }}
+		return nil
+		{{- else }}
+		return err
+		{{- end }}
+	}
+	*{{ .Receiver }} = *result
+	return nil
+}
+`
+	yamlJSONSerdeTemplate = template.Must(template.New("yamlJSONSerde").Parse(yamlJSONSerdeStr))
+
+	sqlScanSerdeStr = `
+// Scan implements the database/sql.Scanner interface for {{ .WrapperName }}.
+// It parses the database value and stores it in the enum.
+// It returns an error if the value cannot be parsed.
+func ({{ .Receiver }} *{{ .WrapperName }}) Scan(value any) error {
+	result, err := scan{{ .WrapperName }}(*{{ .Receiver }}, value)
+	if err != nil {
+		return err
+	}
+	*{{ .Receiver }} = *result
+	return nil
+}
+
+// scan{{ .WrapperName }} converts the values database drivers usually return for
+// {{ .WrapperName }} without reflection, and the others with enums.SQLScan.
+func scan{{ .WrapperName }}(zero {{ .WrapperName }}, value any) (*{{ .WrapperName }}, error) {
+	{{- if .ScanKind }}
+	switch v := value.(type) {
+	{{- if eq .ScanKind "name" }}
+	case string:
+		return enums.ScanName(zero, v, value)
+	case []byte:
+		return enums.ScanName(zero, string(v), value)
+	{{- else if eq .ScanKind "string" }}
+	case string:
+		return enums.ScanValue(zero, v, value)
+	case []byte:
+		return enums.ScanValue(zero, string(v), value)
+	{{- else if eq .ScanKind "int" }}
+	case int64:
+		if int64({{ .UnderlyingType }}(v)) == v {
+			return enums.ScanValue(zero, {{ .UnderlyingType }}(v), value)
+		}
+	{{- else if eq .ScanKind "uint" }}
+	case int64:
+		if v >= 0 && int64({{ .UnderlyingType }}(v)) == v {
+			return enums.ScanValue(zero, {{ .UnderlyingType }}(v), value)
+		}
+	{{- else if eq .ScanKind "float" }}
+	case float64:
+		if float64({{ .UnderlyingType }}(v)) == v {
+			return enums.ScanValue(zero, {{ .UnderlyingType }}(v), value)
+		}
+	{{- else if eq .ScanKind "bool" }}
+	case bool:
+		return enums.ScanValue(zero, {{ .UnderlyingType }}(v), value)
+	{{- end }}
+	}
+	{{- end }}
+	{{- if eq .SQLFormat "name" }}
+	return enums.SQLScanFormat(zero, enums.FormatName, value)
+	{{- else if eq .SQLFormat "value" }}
+	return enums.SQLScanFormat(zero, enums.FormatValue, value)
+	{{- else }}
+	return enums.SQLScan(zero, value)
+	{{- end }}
+}
+`
+	sqlScanSerdeTemplate = template.Must(template.New("sqlScanSerde").Parse(sqlScanSerdeStr))
+
+	sqlValueSerdeStr = `
+// Value implements the database/sql/driver.Valuer interface for {{ .WrapperName }}.
+// It returns the database representation of the enum value.
+func ({{ .Receiver }} {{ .WrapperName }}) Value() (driver.Value, error) {
+	{{- if eq .SQLFormat "name" }}
+	return {{ .Receiver }}.Name(), nil
+	{{- else if eq .SQLFormat "value" }}
+	return {{ .SQLDriverType }}({{ .Receiver }}.Val()), nil
+	{{- else }}
+	return enums.SQLValue({{ .Receiver }})
+	{{- end }}
+}
+`
+	sqlValueSerdeTemplate = template.Must(template.New("sqlValueSerde").Parse(sqlValueSerdeStr))
+
+	sqlArrayStr = `
+// {{ .WrapperName }}Slice is a slice of {{ .WrapperName }} stored in a Postgres array
+// column, such as text[] or int[], without wrapping it in pq.Array.
+type {{ .WrapperName }}Slice []{{ .WrapperName }}
+
+// Scan implements the database/sql.Scanner interface for {{ .WrapperName }}Slice.
+// It parses the array returned by the database, and NULL as a nil slice.
+func ({{ .Receiver }} *{{ .WrapperName }}Slice) Scan(value any) error {
+	result, err := enums.SliceScan({{ .WrapperName }}{}, value)
+	if err != nil {
+		return err
+	}
+	*{{ .Receiver }} = result
+	return nil
+}
+
+// Value implements the database/sql/driver.Valuer interface for {{ .WrapperName }}Slice.
+// It returns the array literal of the database representations of the values.
+func ({{ .Receiver }} {{ .WrapperName }}Slice) Value() (driver.Value, error) {
+	return enums.SliceValue({{ .Receiver }})
+}
+`
+	sqlArrayTemplate = template.Must(template.New("sqlArray").Parse(sqlArrayStr))
+
+	sqlColumnStr = `
+// {{ .EnumLower }}ColumnValues are the valid {{ .WrapperName }} values, deprecated ones
+// included, that the column storing {{ .WrapperName }} accepts.
+var {{ .EnumLower }}ColumnValues = []{{ .WrapperName }}{
+{{- range .Identifiers }}
+	{{ $.EnumType }}.{{ . }},
+{{- end }}
+}
+
+// {{ .WrapperName }}ColumnDefinition returns the definition of the {{ .Table }}.{{ .Column }} column
+// storing {{ .WrapperName }} in dialect: ENUM(...) for enums.DialectMySQL when stored by
+// name, and a CHECK constraint listing the values otherwise.
+func {{ .WrapperName }}ColumnDefinition(dialect string) string {
+	return enums.ColumnDefinition(dialect, {{ printf "%q" .Column }}, {{ .EnumLower }}ColumnValues)
+}
+
+// {{ .WrapperName }}ColumnDrift compares the values of {{ .WrapperName }} with those the
+// {{ .Table }}.{{ .Column }} column accepts in the database, to detect schema drift.
+func {{ .WrapperName }}ColumnDrift(ctx context.Context, db enums.Querier, dialect string) (enums.ColumnDrift, error) {
+	return enums.CheckColumnDrift(ctx, db, dialect, {{ printf "%q" .Table }}, {{ printf "%q" .Column }}, {{ .EnumLower }}ColumnValues)
+}
+`
+	sqlColumnTemplate = template.Must(template.New("sqlColumn").Parse(sqlColumnStr))
+
+	sqlBoilerStr = `
+// {{ .EnumLower }}RandomizeValues are the {{ .WrapperName }} values Randomize picks from.
+var {{ .EnumLower }}RandomizeValues = []{{ .WrapperName }}{
+{{- range .Identifiers }}
+	{{ $.EnumType }}.{{ . }},
+{{- end }}
+}
+
+// Randomize implements the Randomizer interface of github.com/volatiletech/randomize,
+// which the tests sqlboiler generates fill models with. It stores a valid value picked
+// with nextInt.
+func ({{ .Receiver }} *{{ .WrapperName }}) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if len({{ .EnumLower }}RandomizeValues) == 0 {
+		return
+	}
+	*{{ .Receiver }} = {{ .EnumLower }}RandomizeValues[uint64(nextInt())%uint64(len({{ .EnumLower }}RandomizeValues))]
+}
+
+// Null{{ .WrapperName }} is a nullable {{ .WrapperName }}, in the style of the types of
+// github.com/volatiletech/null sqlboiler uses for nullable columns. It is NULL
+// unless Valid is true.
+type Null{{ .WrapperName }} struct {
+	{{ .WrapperName }} {{ .WrapperName }}
+	Valid bool
+}
+
+// NewNull{{ .WrapperName }} returns a Null{{ .WrapperName }} holding v, NULL unless valid is true.
+func NewNull{{ .WrapperName }}(v {{ .WrapperName }}, valid bool) Null{{ .WrapperName }} {
+	return Null{{ .WrapperName }}{ {{- .WrapperName }}: v, Valid: valid}
+}
+
+// Null{{ .WrapperName }}From returns a valid Null{{ .WrapperName }} holding v.
+func Null{{ .WrapperName }}From(v {{ .WrapperName }}) Null{{ .WrapperName }} {
+	return NewNull{{ .WrapperName }}(v, true)
+}
+
+// Null{{ .WrapperName }}FromPtr returns a Null{{ .WrapperName }} holding *ptr, NULL if ptr is nil.
+func Null{{ .WrapperName }}FromPtr(ptr *{{ .WrapperName }}) Null{{ .WrapperName }} {
+	if ptr == nil {
+		return Null{{ .WrapperName }}{}
+	}
+	return Null{{ .WrapperName }}From(*ptr)
+}
+
+// Ptr returns a pointer to the value of n, or nil if n is NULL.
+func (n Null{{ .WrapperName }}) Ptr() *{{ .WrapperName }} {
+	if !n.Valid {
+		return nil
+	}
+	return &n.{{ .WrapperName }}
+}
+
+// IsZero reports whether n is NULL.
+func (n Null{{ .WrapperName }}) IsZero() bool {
+	return !n.Valid
+}
+
+// SetValid stores v in n and makes it valid.
+func (n *Null{{ .WrapperName }}) SetValid(v {{ .WrapperName }}) {
+	n.{{ .WrapperName }} = v
+	n.Valid = true
 }
 
-// writeYAMLSerializationMethods writes YAML marshaling and unmarshaling methods
-func (g *Writer) writeYAMLSerializationMethods(rep enum.GenerationRequest) {
-	g.writeTemplate(yamlMarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
-	g.writeTemplate(yamlUnmarshalSerdeTemplate, newEnumInterfaceMethodData(rep))
+// Scan implements the database/sql.Scanner interface for Null{{ .WrapperName }}.
+// It scans NULL as a NULL Null{{ .WrapperName }}, and other values as {{ .WrapperName }} does.
+func (n *Null{{ .WrapperName }}) Scan(value any) error {
+	if value == nil {
+		*n = Null{{ .WrapperName }}{}
+		return nil
+	}
+	if err := n.{{ .WrapperName }}.Scan(value); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
 }
 
-// writeSQLSerializationMethods writes SQL Scan and Value methods
-func (g *Writer) writeSQLSerializationMethods(rep enum.GenerationRequest) {
-	g.writeTemplate(sqlScanSerdeTemplate, newEnumInterfaceMethodData(rep))
-	g.writeTemplate(sqlValueSerdeTemplate, newEnumInterfaceMethodData(rep))
+// Value implements the database/sql/driver.Valuer interface for Null{{ .WrapperName }}.
+// It returns NULL when n is NULL.
+func (n Null{{ .WrapperName }}) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.{{ .WrapperName }}.Value()
 }
+{{- if .JSON }}
 
-var (
-	jsonMarshalSerdeStr = `
-// MarshalJSON implements the json.Marshaler interface for {{ .WrapperName }}.
-// It returns the JSON representation of the enum value as a byte slice.
-func ({{ .Receiver }} {{ .WrapperName }}) MarshalJSON() ([]byte, error) {
-	return enums.MarshalJSON({{ .Receiver }}, {{ .Receiver }}.{{ .EnumIota }})
+// MarshalJSON implements the json.Marshaler interface for Null{{ .WrapperName }}.
+// It returns null when n is NULL.
+func (n Null{{ .WrapperName }}) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.{{ .WrapperName }}.MarshalJSON()
 }
-`
-	jsonMarshalSerdeTemplate = template.Must(template.New("jsonMarshalSerde").Parse(jsonMarshalSerdeStr))
 
-	jsonUnmarshalSerdeStr = `
-// UnmarshalJSON implements the json.Unmarshaler interface for {{ .WrapperName }}.
-// It parses the JSON representation of the enum value from the byte slice.
-// It returns an error if the input is not a valid JSON representation.
-func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalJSON(data []byte) error {
-	result, err := enums.UnmarshalJSON(*{{ .Receiver }}, data)
-	if err != nil {
+// UnmarshalJSON implements the json.Unmarshaler interface for Null{{ .WrapperName }}.
+// It unmarshals null as a NULL Null{{ .WrapperName }}.
+func (n *Null{{ .WrapperName }}) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = Null{{ .WrapperName }}{}
+		return nil
+	}
+	if err := n.{{ .WrapperName }}.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	*{{ .Receiver }} = *result
+	n.Valid = true
 	return nil
 }
-`
-	jsonUnmarshalSerdeTemplate = template.Must(template.New("jsonUnmarshalSerde").Parse(jsonUnmarshalSerdeStr))
+{{- end }}
 
-	textMarshalSerdeStr = `
-// MarshalText implements the encoding.TextMarshaler interface for {{ .WrapperName }}.
-// It returns the text representation of the enum value as a byte slice.
-func ({{ .Receiver }} {{ .WrapperName }}) MarshalText() ([]byte, error) {
-	return enums.MarshalText({{ .Receiver }}, {{ .Receiver }}.{{ .EnumIota }})
+// Randomize implements the Randomizer interface of github.com/volatiletech/randomize.
+// It makes n NULL when shouldBeNull is true, and stores a random valid value otherwise.
+func (n *Null{{ .WrapperName }}) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*n = Null{{ .WrapperName }}{}
+		return
+	}
+	n.{{ .WrapperName }}.Randomize(nextInt, fieldType, false)
+	n.Valid = true
 }
 `
-	textMarshalSerdeTemplate = template.Must(template.New("textMarshalSerde").Parse(textMarshalSerdeStr))
+	sqlBoilerTemplate = template.Must(template.New("sqlBoiler").Parse(sqlBoilerStr))
+
+	bunStr = `
+// SQLLiteral returns the SQL literal of the value {{ .WrapperName }} is stored as, such as
+// 'active' or 1. Its quotes are escaped, so it can be passed to bun.Safe where bun
+// queries cannot take a placeholder, such as in DDL.
+func ({{ .Receiver }} {{ .WrapperName }}) SQLLiteral() string {
+	return enums.SQLLiteral({{ .Receiver }})
+}
+`
+	bunTemplate = template.Must(template.New("bun").Parse(bunStr))
 
-	textUnmarshalSerdeStr = `
-// UnmarshalText implements the encoding.TextUnmarshaler interface for {{ .WrapperName }}.
-// It parses the text representation of the enum value from the byte slice.
-// It returns an error if the byte slice does not contain a valid enum value.
-func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalText(data []byte) error {
-	result, err := enums.UnmarshalText(*{{ .Receiver }}, data)
+	redisStr = `
+// RedisArg implements the redis.Argument interface of github.com/gomodule/redigo.
+// It returns the text representation of the enum value, as MarshalText does.
+func ({{ .Receiver }} {{ .WrapperName }}) RedisArg() any {
+	return enums.RedisArg({{ .Receiver }}, {{ if .FloatFormat }}{{ .Receiver }}.ValString(){{ else }}{{ .Receiver }}.{{ .EnumIota }}{{ end }})
+}
+
+// RedisScan implements the redis.Scanner interface of github.com/gomodule/redigo.
+// It parses the text representation of the enum value from a Redis reply.
+{{ if .Default -}}
+// Invalid replies store {{ .EnumType }}.{{ .Default }} instead of returning an error.
+{{ else -}}
+// It returns an error if the reply does not hold a valid enum value.
+{{ end -}}
+func ({{ .Receiver }} *{{ .WrapperName }}) RedisScan(src any) error {
+	result, err := enums.RedisScan(*{{ .Receiver }}, src)
 	if err != nil {
+		{{- if .Default }}
+		*{{ .Receiver }} = {{ .EnumType }}.{{ .Default }}
+		return nil
+		{{- else }}
 		return err
+		{{- end }}
 	}
 	*{{ .Receiver }} = *result
 	return nil
 }
-`
-	textUnmarshalSerdeTemplate = template.Must(template.New("textUnmarshalSerde").Parse(textUnmarshalSerdeStr))
+{{- if not .Binary }}
 
-	binaryMarshalSerdeStr = `
-// MarshalBinary implements the encoding.BinaryMarshaler interface for {{ .WrapperName }}.
-// It returns the binary representation of the enum value as a byte slice.
+// MarshalBinary implements the encoding.BinaryMarshaler interface for {{ .WrapperName }},
+// which github.com/redis/go-redis stores arguments with. It returns the text
+// representation of the enum value, as MarshalText does.
 func ({{ .Receiver }} {{ .WrapperName }}) MarshalBinary() ([]byte, error) {
-	return enums.MarshalBinary({{ .Receiver }}, {{ .Receiver }}.{{ .EnumIota }})
+	return enums.MarshalText({{ .Receiver }}, {{ if .FloatFormat }}{{ .Receiver }}.ValString(){{ else }}{{ .Receiver }}.{{ .EnumIota }}{{ end }})
 }
-`
-	binaryMarshalSerdeTemplate = template.Must(template.New("binaryMarshalSerde").Parse(binaryMarshalSerdeStr))
 
-	binaryUnmarshalSerdeStr = `
-// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface for {{ .WrapperName }}.
-// It parses the binary representation of the enum value from the byte slice.
-// It returns an error if the byte slice does not contain a valid enum value.
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface for {{ .WrapperName }},
+// which github.com/redis/go-redis scans replies with. It parses the text
+// representation of the enum value, as UnmarshalText does.
 func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalBinary(data []byte) error {
-	result, err := enums.UnmarshalBinary(*{{ .Receiver }}, data)
+	result, err := enums.UnmarshalText(*{{ .Receiver }}, data)
 	if err != nil {
+		{{- if .Default }}
+		*{{ .Receiver }} = {{ .EnumType }}.{{ .Default }}
+		return nil
+		{{- else }}
 		return err
+		{{- end }}
 	}
 	*{{ .Receiver }} = *result
 	return nil
 }
+{{- end }}
 `
-	binaryUnmarshalSerdeTemplate = template.Must(template.New("binaryUnmarshalSerde").Parse(binaryUnmarshalSerdeStr))
+	redisTemplate = template.Must(template.New("redis").Parse(redisStr))
+)
 
-	yamlMarshalSerdeStr = `
-// MarshalYAML implements the yaml.Marshaler interface for {{ .WrapperName }}.
-// It returns the YAML representation of the enum value.
-func ({{ .Receiver }} {{ .WrapperName }}) MarshalYAML() (any, error) {
-	return enums.MarshalYAML({{ .Receiver }}, {{ .Receiver }}.{{ .EnumIota }})
+type sqlBoilerData struct {
+	Receiver    string
+	WrapperName string
+	EnumType    string
+	EnumLower   string
+	JSON        bool
+	// Identifiers are the container fields of the values Randomize picks
+	Identifiers []string
 }
-`
-	yamlMarshalSerdeTemplate = template.Must(template.New("yamlMarshalSerde").Parse(yamlMarshalSerdeStr))
 
-	yamlUnmarshalSerdeStr = `
-// UnmarshalYAML implements the yaml.Unmarshaler interface for {{ .WrapperName }}.
-// It parses the YAML representation of the enum value.
-// It returns an error if the YAML does not contain a valid enum value.
-func ({{ .Receiver }} *{{ .WrapperName }}) UnmarshalYAML(node *yaml.Node) error {
-	result, err := enums.UnmarshalYAML(*{{ .Receiver }}, node)
-	if err != nil {
-		return err
+func newSQLBoilerData(rep enum.GenerationRequest, enumConfig config.EnumTypeConfig) sqlBoilerData {
+	d := sqlBoilerData{
+		Receiver:    receiver(rep),
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
+		EnumLower:   strings.ToLower(rep.EnumIota.Type),
+		JSON:        enumConfig.Handlers.JSON,
 	}
-	*{{ .Receiver }} = *result
-	return nil
+	for _, e := range enumDefinitions(rep) {
+		if e.Valid && !e.Deprecated {
+			d.Identifiers = append(d.Identifiers, e.EnumNameIdentifier)
+		}
+	}
+	return d
 }
-`
-	yamlUnmarshalSerdeTemplate = template.Must(template.New("yamlUnmarshalSerde").Parse(yamlUnmarshalSerdeStr))
 
-	sqlScanSerdeStr = `
-// Scan implements the database/sql.Scanner interface for {{ .WrapperName }}.
-// It parses the database value and stores it in the enum.
-// It returns an error if the value cannot be parsed.
-func ({{ .Receiver }} *{{ .WrapperName }}) Scan(value any) error {
-	result, err := enums.SQLScan(*{{ .Receiver }}, value)
-	if err != nil {
-		return err
-	}
-	*{{ .Receiver }} = *result
-	return nil
+type sqlColumnData struct {
+	WrapperName string
+	EnumType    string
+	EnumLower   string
+	Table       string
+	Column      string
+	// Identifiers are the container fields of the valid values
+	Identifiers []string
 }
-`
-	sqlScanSerdeTemplate = template.Must(template.New("sqlScanSerde").Parse(sqlScanSerdeStr))
 
-	sqlValueSerdeStr = `
-// Value implements the database/sql/driver.Valuer interface for {{ .WrapperName }}.
-// It returns the database representation of the enum value.
-func ({{ .Receiver }} {{ .WrapperName }}) Value() (driver.Value, error) {
-	return enums.SQLValue({{ .Receiver }})
+func newSQLColumnData(rep enum.GenerationRequest, enumConfig config.EnumTypeConfig) sqlColumnData {
+	d := sqlColumnData{
+		WrapperName: wrapperName(rep),
+		EnumType:    enumType(rep),
+		EnumLower:   strings.ToLower(rep.EnumIota.Type),
+	}
+	d.Table, d.Column = migration.Column(rep.EnumIota.Type, enumConfig)
+	for _, e := range enumDefinitions(rep) {
+		if e.Valid {
+			d.Identifiers = append(d.Identifiers, e.EnumNameIdentifier)
+		}
+	}
+	return d
 }
-`
-	sqlValueSerdeTemplate = template.Must(template.New("sqlValueSerde").Parse(sqlValueSerdeStr))
-)
 
 // writeContainerConvenienceMethods writes convenience methods for the container type
 func (g *Writer) writeContainerConvenienceMethods(rep enum.GenerationRequest) {
 	g.writeTemplate(containerValuesMethodTemplate, newContainerMethodData(rep))
+	g.writeTemplate(containerParseMethodsTemplate, newContainerMethodData(rep))
 	g.writeTemplate(containerFindByNameMethodTemplate, newContainerMethodData(rep))
 	g.writeTemplate(containerFindByValueMethodTemplate, newContainerMethodData(rep))
+	g.writeTemplate(pointerMethodsTemplate, newContainerMethodData(rep))
+	g.writeContainerBoundsMethods(rep)
+}
+
+// writeContainerBoundsMethods writes the Count, Min<Type> and Max<Type>
+// methods of the container, with the bounds found by comparing the valid
+// values when generating. Invalid values are left out, so that a value
+// between Min<Type> and Max<Type> is in the range of the valid ones.
+func (g *Writer) writeContainerBoundsMethods(rep enum.GenerationRequest) {
+	edefs := enumDefinitions(rep)
+	enums := orderedEnums(rep)
+	if len(rep.EnumIota.Fields) > 0 {
+		// enumDefinitions leaves out the values without fields
+		enums = slices.DeleteFunc(enums, func(e enum.Enum) bool { return len(e.Fields) == 0 })
+	}
+	count, minIndex, maxIndex := 0, -1, -1
+	for i, e := range enums {
+		if !e.Valid {
+			continue
+		}
+		count++
+		if minIndex < 0 || compareValues(e, enums[minIndex]) < 0 {
+			minIndex = i
+		}
+		if maxIndex < 0 || compareValues(e, enums[maxIndex]) > 0 {
+			maxIndex = i
+		}
+	}
+	if count == 0 {
+		return
+	}
+	d := newContainerMethodData(rep)
+	g.writeTemplate(containerBoundsMethodsTemplate, containerBoundsData{
+		containerMethodData: d,
+		EnumType:            enumType(rep),
+		Count:               count,
+		Min:                 edefs[minIndex].EnumNameIdentifier,
+		Max:                 edefs[maxIndex].EnumNameIdentifier,
+	})
+}
+
+// containerBoundsData is the template data of the Count, Min<Type> and
+// Max<Type> methods.
+type containerBoundsData struct {
+	containerMethodData
+	EnumType string
+	Count    int
+	// Min and Max are the container fields of the valid values with the
+	// lowest and highest underlying value
+	Min, Max string
 }
 
 type containerMethodData struct {
@@ -1475,14 +4071,22 @@ type containerMethodData struct {
 	ContainerType  string
 	WrapperName    string
 	UnderlyingType string
+	Deprecated     bool
+	EnumLower      string
+	Aliases        bool
+	Parse          bool
 }
 
 func newContainerMethodData(rep enum.GenerationRequest) containerMethodData {
 	return containerMethodData{
-		Receiver:       receiver(rep.EnumIota.Type),
+		Receiver:       receiver(rep),
 		ContainerType:  containerType(rep),
-		WrapperName:    wrapperName(rep.EnumIota.Type),
+		WrapperName:    wrapperName(rep),
 		UnderlyingType: rep.EnumIota.UnderlyingType,
+		Deprecated:     hasDeprecated(rep),
+		EnumLower:      strings.ToLower(rep.EnumIota.Type),
+		Aliases:        enumAliases(rep) != nil,
+		Parse:          sectionWritten(rep.Configuration, config.SectionParse),
 	}
 }
 
@@ -1493,15 +4097,52 @@ var (
 func ({{ .Receiver }} {{ .ContainerType }}) All() iter.Seq[{{ .WrapperName }}] {
 	return {{ .WrapperName }}{}.All()
 }
+{{- if .Deprecated }}
+
+// AllIncludingDeprecated returns an iterator over all enum values, including deprecated ones.
+// This is a convenience method that delegates to the zero value enum instance.
+func ({{ .Receiver }} {{ .ContainerType }}) AllIncludingDeprecated() iter.Seq[{{ .WrapperName }}] {
+	return {{ .WrapperName }}{}.AllIncludingDeprecated()
+}
+{{- end }}
 `
 	containerValuesMethodTemplate = template.Must(template.New("containerValuesMethod").Parse(containerValuesMethodStr))
 
+	containerParseMethodsStr = `
+{{- if .Parse }}
+// Parse parses the input value into an enum value.
+// This is a convenience method that delegates to Parse{{ .WrapperName }}.
+func ({{ .Receiver }} {{ .ContainerType }}) Parse(input any) ({{ .WrapperName }}, error) {
+	return Parse{{ .WrapperName }}(input)
+}
+
+// MustParse parses the input value into an enum value, panicking if it is invalid.
+// This is a convenience method that delegates to MustParse{{ .WrapperName }}.
+func ({{ .Receiver }} {{ .ContainerType }}) MustParse(input any) {{ .WrapperName }} {
+	return MustParse{{ .WrapperName }}(input)
+}
+{{- end }}
+`
+	containerParseMethodsTemplate = template.Must(template.New("containerParseMethods").Parse(containerParseMethodsStr))
+
 	containerFindByNameMethodStr = `
 // FromName finds an enum value by name and returns the enum instance and a boolean indicating if found.
 // This is a convenience method that delegates to the zero value enum instance.
 func ({{ .Receiver }} {{ .ContainerType }}) FromName(name string) ({{ .WrapperName }}, bool) {
 	return {{ .WrapperName }}{}.FromName(name)
 }
+{{- if .Aliases }}
+
+// AliasesOf returns the names v is parsed from, the canonical name returned
+// by String first. It returns nil for values that are not declared.
+func ({{ .Receiver }} {{ .ContainerType }}) AliasesOf(v {{ .WrapperName }}) []string {
+	aliases, ok := {{ .EnumLower }}AliasesMap[v]
+	if !ok {
+		return nil
+	}
+	return append([]string(nil), aliases...)
+}
+{{- end }}
 `
 	containerFindByNameMethodTemplate = template.Must(template.New("containerFindByNameMethod").Parse(containerFindByNameMethodStr))
 
@@ -1513,6 +4154,43 @@ func ({{ .Receiver }} {{ .ContainerType }}) FromValue(value {{ .UnderlyingType }
 }
 `
 	containerFindByValueMethodTemplate = template.Must(template.New("containerFindByValueMethod").Parse(containerFindByValueMethodStr))
+
+	pointerMethodsStr = `
+// Ptr returns a pointer to a copy of {{ .Receiver }}, for optional {{ .WrapperName }}
+// fields and parameters.
+func ({{ .Receiver }} {{ .WrapperName }}) Ptr() *{{ .WrapperName }} {
+	return &{{ .Receiver }}
+}
+
+// FromPtr returns the {{ .WrapperName }} ptr points to, and whether ptr is non-nil and
+// holds a valid value. It returns the invalid {{ .WrapperName }} if ptr is nil.
+func ({{ .Receiver }} {{ .ContainerType }}) FromPtr(ptr *{{ .WrapperName }}) ({{ .WrapperName }}, bool) {
+	if ptr == nil {
+		return invalid{{ .WrapperName }}, false
+	}
+	return *ptr, ptr.IsValid()
+}
+`
+	pointerMethodsTemplate = template.Must(template.New("pointerMethods").Parse(pointerMethodsStr))
+
+	containerBoundsMethodsStr = `
+// Count returns the number of valid {{ .WrapperName }} values, for sizing arrays
+// and maps indexed by them.
+func ({{ .Receiver }} {{ .ContainerType }}) Count() int {
+	return {{ .Count }}
+}
+
+// Min{{ .WrapperName }} returns the valid {{ .WrapperName }} with the lowest underlying value.
+func ({{ .Receiver }} {{ .ContainerType }}) Min{{ .WrapperName }}() {{ .WrapperName }} {
+	return {{ .EnumType }}.{{ .Min }}
+}
+
+// Max{{ .WrapperName }} returns the valid {{ .WrapperName }} with the highest underlying value.
+func ({{ .Receiver }} {{ .ContainerType }}) Max{{ .WrapperName }}() {{ .WrapperName }} {
+	return {{ .EnumType }}.{{ .Max }}
+}
+`
+	containerBoundsMethodsTemplate = template.Must(template.New("containerBoundsMethods").Parse(containerBoundsMethodsStr))
 )
 
 // writeEnumSeparator writes a beautiful separator line for enum types
@@ -1554,6 +4232,12 @@ func (g *Writer) writeStateMachineMethods(rep enum.GenerationRequest) {
 	g.writeCanTransitionToMethod(rep)
 	g.writeValidTransitionsMethod(rep)
 	g.writeIsTerminalStateMethod(rep)
+	g.writeTransitionMethods(rep)
+	g.writeEventMethods(rep)
+	if rep.Configuration.GetEnumTypeConfig(rep.EnumIota.Type).StateHistory {
+		g.writeTemplate(historyTemplate, newStateMachineMethodData(rep))
+	}
+	g.writeTemplate(machineTemplate, newStateMachineMethodData(rep))
 }
 
 type stateMachineMethodData struct {
@@ -1561,34 +4245,35 @@ type stateMachineMethodData struct {
 	WrapperName string
 	EnumType    string
 	Enums       []enumDefinition
+	// Events are the distinct events named in the state annotations, in
+	// order of appearance
+	Events []stateEvent
+	// Firings are the transitions fired by events
+	Firings []stateFiring
+	// iota resolves the states named by transitions
+	iota enum.EnumIota
 }
 
-// FindEnumByName finds the enum identifier by transition name (either alias or enum name)
-func (s stateMachineMethodData) FindEnumByName(transitionName string) string {
-	// First, try to find by exact enum name
-	for _, enum := range s.Enums {
-		if enum.EnumName == transitionName {
-			return enum.EnumNameIdentifier
-		}
-	}
+// stateEvent is an event of a state machine and its generated constant.
+type stateEvent struct {
+	Name       string
+	Identifier string
+}
 
-	// Second, try to find by alias (comment name)
-	for _, enum := range s.Enums {
-		for _, alias := range enum.Aliases {
-			if alias == transitionName {
-				return enum.EnumNameIdentifier
-			}
-		}
-	}
+// stateFiring is a transition fired by an event, as the container fields of
+// its states and the constant of its event.
+type stateFiring struct {
+	From  string
+	Event string
+	To    string
+}
 
-	// Third, try to match by removing common prefixes like "Order"
-	if strings.HasPrefix(transitionName, "Order") {
-		withoutPrefix := transitionName[5:] // Remove "Order" prefix
+// FindEnumByName finds the enum identifier by transition name (either alias or enum name)
+func (s stateMachineMethodData) FindEnumByName(transitionName string) string {
+	if state, ok := s.iota.State(transitionName); ok {
 		for _, enum := range s.Enums {
-			for _, alias := range enum.Aliases {
-				if alias == withoutPrefix {
-					return enum.EnumNameIdentifier
-				}
+			if enum.EnumName == state.Name {
+				return enum.EnumNameIdentifier
 			}
 		}
 	}
@@ -1600,12 +4285,33 @@ func (s stateMachineMethodData) FindEnumByName(transitionName string) string {
 func newStateMachineMethodData(rep enum.GenerationRequest) stateMachineMethodData {
 	enums := enumDefinitions(rep)
 
-	return stateMachineMethodData{
-		Receiver:    receiver(rep.EnumIota.Type),
-		WrapperName: wrapperName(rep.EnumIota.Type),
+	data := stateMachineMethodData{
+		Receiver:    receiver(rep),
+		WrapperName: wrapperName(rep),
 		EnumType:    enumType(rep),
 		Enums:       enums,
+		iota:        rep.EnumIota,
 	}
+	seen := make(map[string]string)
+	for _, e := range enums {
+		for i, event := range e.StateEvents {
+			if event == "" {
+				continue
+			}
+			ident, ok := seen[event]
+			if !ok {
+				ident = data.WrapperName + "Event" + strings.Camel(event)
+				seen[event] = ident
+				data.Events = append(data.Events, stateEvent{Name: event, Identifier: ident})
+			}
+			data.Firings = append(data.Firings, stateFiring{
+				From:  e.EnumNameIdentifier,
+				Event: ident,
+				To:    data.FindEnumByName(e.StateTransitions[i]),
+			})
+		}
+	}
+	return data
 }
 
 var (
@@ -1659,6 +4365,215 @@ func ({{ .Receiver }} {{ .WrapperName }}) IsTerminalState() bool {
 }
 `
 	isTerminalStateMethodTemplate = template.Must(template.New("isTerminalStateMethod").Parse(isTerminalStateMethodStr))
+
+	transitionMethodsStr = `
+// TransitionTo returns target if the state can transition to it, and an
+// error wrapping enums.ErrInvalidTransition otherwise.
+func ({{ .Receiver }} {{ .WrapperName }}) TransitionTo(target {{ .WrapperName }}) ({{ .WrapperName }}, error) {
+	if !{{ .Receiver }}.CanTransitionTo(target) {
+		return {{ .Receiver }}, fmt.Errorf("%w: %v to %v", enums.ErrInvalidTransition, {{ .Receiver }}, target)
+	}
+	return target, nil
+}
+
+// {{ .WrapperName }}TransitionHooks attaches domain logic to the transitions
+// made with TransitionWithHooks. Embed Nop{{ .WrapperName }}TransitionHooks to
+// implement only some of its methods.
+type {{ .WrapperName }}TransitionHooks interface {
+	// GuardTransition is called first, and vetoes the transition by
+	// returning an error
+	GuardTransition(ctx context.Context, from, to {{ .WrapperName }}) error
+	// BeforeTransition is called once the guard allowed the transition,
+	// which an error aborts
+	BeforeTransition(ctx context.Context, from, to {{ .WrapperName }}) error
+	// AfterTransition is called once the transition is made
+	AfterTransition(ctx context.Context, from, to {{ .WrapperName }})
+}
+
+// Nop{{ .WrapperName }}TransitionHooks implements {{ .WrapperName }}TransitionHooks
+// with methods that allow every transition and do nothing.
+type Nop{{ .WrapperName }}TransitionHooks struct{}
+
+func (Nop{{ .WrapperName }}TransitionHooks) GuardTransition(context.Context, {{ .WrapperName }}, {{ .WrapperName }}) error {
+	return nil
+}
+
+func (Nop{{ .WrapperName }}TransitionHooks) BeforeTransition(context.Context, {{ .WrapperName }}, {{ .WrapperName }}) error {
+	return nil
+}
+
+func (Nop{{ .WrapperName }}TransitionHooks) AfterTransition(context.Context, {{ .WrapperName }}, {{ .WrapperName }}) {}
+
+// TransitionWithHooks transitions to target like TransitionTo, calling the
+// guard and the hooks around the transition unless hooks is nil. Errors of
+// GuardTransition and BeforeTransition are returned as is, with the state
+// unchanged.
+func ({{ .Receiver }} {{ .WrapperName }}) TransitionWithHooks(ctx context.Context, target {{ .WrapperName }}, hooks {{ .WrapperName }}TransitionHooks) ({{ .WrapperName }}, error) {
+	next, err := {{ .Receiver }}.TransitionTo(target)
+	if err != nil || hooks == nil {
+		return next, err
+	}
+	if err := hooks.GuardTransition(ctx, {{ .Receiver }}, next); err != nil {
+		return {{ .Receiver }}, err
+	}
+	if err := hooks.BeforeTransition(ctx, {{ .Receiver }}, next); err != nil {
+		return {{ .Receiver }}, err
+	}
+	hooks.AfterTransition(ctx, {{ .Receiver }}, next)
+	return next, nil
+}
+`
+	transitionMethodsTemplate = template.Must(template.New("transitionMethods").Parse(transitionMethodsStr))
+
+	eventMethodsStr = `
+// {{ .WrapperName }}Event is an event named in the state annotations of
+// {{ .WrapperName }}, firing transitions with Fire.
+type {{ .WrapperName }}Event string
+
+// Events of {{ .WrapperName }}.
+const (
+	{{- range .Events }}
+	{{ .Identifier }} {{ $.WrapperName }}Event = "{{ .Name }}"
+	{{- end }}
+)
+
+// ValidEvents returns the events firing a transition from this state.
+func ({{ .Receiver }} {{ .WrapperName }}) ValidEvents() []{{ .WrapperName }}Event {
+	var events []{{ .WrapperName }}Event
+	{{- range .Firings }}
+	if {{ $.Receiver }} == {{ $.EnumType }}.{{ .From }} {
+		events = append(events, {{ .Event }})
+	}
+	{{- end }}
+	return events
+}
+
+// Fire returns the state event moves this state to, and an error wrapping
+// enums.ErrInvalidTransition when no transition from this state is fired
+// by event.
+func ({{ .Receiver }} {{ .WrapperName }}) Fire(event {{ .WrapperName }}Event) ({{ .WrapperName }}, error) {
+	switch {
+	{{- range .Firings }}
+	case {{ $.Receiver }} == {{ $.EnumType }}.{{ .From }} && event == {{ .Event }}:
+		return {{ $.EnumType }}.{{ .To }}, nil
+	{{- end }}
+	}
+	return {{ .Receiver }}, fmt.Errorf("%w: %s in state %v", enums.ErrInvalidTransition, event, {{ .Receiver }})
+}
+`
+	eventMethodsTemplate = template.Must(template.New("eventMethods").Parse(eventMethodsStr))
+
+	historyStr = `
+// {{ .WrapperName }}History records the transitions of {{ .WrapperName }} made with
+// its methods, or with TransitionWithHooks when passed as the hooks, for
+// auditing. Its zero value is an empty history ready to use, and a pointer
+// to it marshals to JSON as the list of transitions.
+type {{ .WrapperName }}History struct {
+	Nop{{ .WrapperName }}TransitionHooks
+	enums.History[{{ .WrapperName }}]
+}
+
+// TransitionTo transitions from to target like from.TransitionTo, and
+// records the transition when it is allowed.
+func (h *{{ .WrapperName }}History) TransitionTo(from, target {{ .WrapperName }}) ({{ .WrapperName }}, error) {
+	next, err := from.TransitionTo(target)
+	if err == nil {
+		h.Record(from, next, "")
+	}
+	return next, err
+}
+{{- if .Events }}
+
+// Fire fires event from from like from.Fire, and records the transition
+// when one is fired.
+func (h *{{ .WrapperName }}History) Fire(from {{ .WrapperName }}, event {{ .WrapperName }}Event) ({{ .WrapperName }}, error) {
+	next, err := from.Fire(event)
+	if err == nil {
+		h.Record(from, next, string(event))
+	}
+	return next, err
+}
+{{- end }}
+
+// AfterTransition records the transitions made with TransitionWithHooks.
+func (h *{{ .WrapperName }}History) AfterTransition(_ context.Context, from, to {{ .WrapperName }}) {
+	h.Record(from, to, "")
+}
+`
+	historyTemplate = template.Must(template.New("history").Parse(historyStr))
+
+	machineStr = `
+// {{ .WrapperName }}Machine drives the state of entities stored by a
+// persister: it loads the current state of an entity, enforces the
+// transitions and saves the new state. Transitions of the same entity must
+// not run concurrently unless the persister serializes them.
+type {{ .WrapperName }}Machine struct {
+	persister enums.StatePersister[{{ .WrapperName }}]
+	hooks     {{ .WrapperName }}TransitionHooks
+}
+
+// New{{ .WrapperName }}Machine returns a machine storing states with persister, and
+// calling hooks around the transitions unless it is nil.
+func New{{ .WrapperName }}Machine(persister enums.StatePersister[{{ .WrapperName }}], hooks {{ .WrapperName }}TransitionHooks) *{{ .WrapperName }}Machine {
+	return &{{ .WrapperName }}Machine{persister: persister, hooks: hooks}
+}
+
+// Current returns the current state of the entity id.
+func (m *{{ .WrapperName }}Machine) Current(ctx context.Context, id string) ({{ .WrapperName }}, error) {
+	return m.persister.Load(ctx, id)
+}
+
+// TransitionTo moves the entity id to target if its current state can
+// transition to it, and returns the new state.
+func (m *{{ .WrapperName }}Machine) TransitionTo(ctx context.Context, id string, target {{ .WrapperName }}) ({{ .WrapperName }}, error) {
+	current, err := m.persister.Load(ctx, id)
+	if err != nil {
+		return current, err
+	}
+	next, err := current.TransitionTo(target)
+	if err != nil {
+		return current, err
+	}
+	return m.transition(ctx, id, current, next)
+}
+{{- if .Events }}
+
+// Fire fires event from the current state of the entity id, and returns
+// the new state.
+func (m *{{ .WrapperName }}Machine) Fire(ctx context.Context, id string, event {{ .WrapperName }}Event) ({{ .WrapperName }}, error) {
+	current, err := m.persister.Load(ctx, id)
+	if err != nil {
+		return current, err
+	}
+	next, err := current.Fire(event)
+	if err != nil {
+		return current, err
+	}
+	return m.transition(ctx, id, current, next)
+}
+{{- end }}
+
+// transition saves next as the state of the entity id between the guard
+// and BeforeTransition hooks, which may veto it, and AfterTransition.
+func (m *{{ .WrapperName }}Machine) transition(ctx context.Context, id string, current, next {{ .WrapperName }}) ({{ .WrapperName }}, error) {
+	if m.hooks != nil {
+		if err := m.hooks.GuardTransition(ctx, current, next); err != nil {
+			return current, err
+		}
+		if err := m.hooks.BeforeTransition(ctx, current, next); err != nil {
+			return current, err
+		}
+	}
+	if err := m.persister.Save(ctx, id, next); err != nil {
+		return current, err
+	}
+	if m.hooks != nil {
+		m.hooks.AfterTransition(ctx, current, next)
+	}
+	return next, nil
+}
+`
+	machineTemplate = template.Must(template.New("machine").Parse(machineStr))
 )
 
 func (g *Writer) writeCanTransitionToMethod(rep enum.GenerationRequest) {
@@ -1672,3 +4587,17 @@ func (g *Writer) writeValidTransitionsMethod(rep enum.GenerationRequest) {
 func (g *Writer) writeIsTerminalStateMethod(rep enum.GenerationRequest) {
 	g.writeTemplate(isTerminalStateMethodTemplate, newStateMachineMethodData(rep))
 }
+
+func (g *Writer) writeTransitionMethods(rep enum.GenerationRequest) {
+	g.writeTemplate(transitionMethodsTemplate, newStateMachineMethodData(rep))
+}
+
+// writeEventMethods generates the event type and Fire method of state
+// machines whose annotations name events.
+func (g *Writer) writeEventMethods(rep enum.GenerationRequest) {
+	data := newStateMachineMethodData(rep)
+	if len(data.Events) == 0 {
+		return
+	}
+	g.writeTemplate(eventMethodsTemplate, data)
+}
@@ -3,12 +3,24 @@ package gofile_test
 
 import (
 	"errors"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/enums"
 	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
 	"github.com/donutnomad/goenums/generator/gofile"
 	"github.com/donutnomad/goenums/internal/testdata"
+	"github.com/donutnomad/goenums/source"
 )
 
 func TestWriter_Write(t *testing.T) {
@@ -33,3 +45,2340 @@ func TestWriter_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_CompileCheck(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "sparse values",
+			src:  "type code int\n\nconst (\n\tok code = 1000\n\tmissing code = 9010\n)\n",
+			want: []string{
+				"_ = map[bool]struct{}{false: {}, ok == 1000: {}}",
+				"_ = map[bool]struct{}{false: {}, missing == 9010: {}}",
+			},
+		},
+		{
+			name: "negative values",
+			src:  "type offset int\n\nconst (\n\tbehind offset = iota - 1\n\tlevel\n)\n",
+			want: []string{
+				"_ = map[bool]struct{}{false: {}, behind == -1: {}}",
+				"_ = map[bool]struct{}{false: {}, level == 0: {}}",
+			},
+		},
+		{
+			name: "floats",
+			src:  "type ratio float64\n\nconst (\n\thalf ratio = 0.5\n\ttenth ratio = 0.1\n)\n",
+			want: []string{
+				"_ = map[bool]struct{}{false: {}, half == 0.5: {}}",
+				"_ = map[bool]struct{}{false: {}, tenth == 0.1: {}}",
+			},
+		},
+		{
+			name: "strings",
+			src:  "type tone string\n\nconst (\n\tlow tone = \"low\"\n)\n",
+			want: []string{`_ = map[bool]struct{}{false: {}, low == "low": {}}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reqs, err := gofile.NewParser(
+				gofile.WithSource(source.FromReader(strings.NewReader("package levels\n\n"+tt.src))),
+				gofile.WithParserConfiguration(testdata.DefaultConfig),
+			).Parse(t.Context())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			memfs := file.NewMemFS()
+			if err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), reqs); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := memfs.ReadFile(reqs[0].OutputFilename + "_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(b), want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_FieldImports(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	writer := gofile.NewWriter(gofile.WithFileSystem(memfs))
+	code := func(value string) []enum.Field {
+		return []enum.Field{{Name: "Code", Value: enum.Expr{Type: "errors.Code", Value: value}}}
+	}
+	err := writer.Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "shop",
+		Version:        "v0.0.0",
+		SourceFilename: "shop.go",
+		OutputFilename: "shop",
+		FieldImports:   []enum.Import{{Name: "errors", Path: "example.com/shop/errors"}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "region",
+			UnderlyingType: "int",
+			Fields:         code(""),
+			Enums: []enum.Enum{
+				{Name: "us", Index: 1, Valid: true, Aliases: []string{"US"}, Fields: code("errors.NotFound")},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("shop_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		`errors1 "example.com/shop/errors"`,
+		"Code errors1.Code",
+		"Code:   errors1.NotFound",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_MultipleEnumImports(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		configs      map[string]config.EnumTypeConfig
+		fieldImports []enum.Import
+		want         []string
+		notWant      []string
+	}{
+		{
+			name: "merged",
+			configs: map[string]config.EnumTypeConfig{
+				"color": {Handlers: config.Handlers{YAML: true}},
+				"size":  {Handlers: config.Handlers{SQL: true, YAML: true}},
+			},
+			want: []string{`"database/sql/driver"`, `"gopkg.in/yaml.v3"`},
+		},
+		{
+			name: "field import",
+			configs: map[string]config.EnumTypeConfig{
+				"color": {Handlers: config.Handlers{YAML: true}},
+			},
+			fieldImports: []enum.Import{{Name: "yaml", Path: "gopkg.in/yaml.v3"}},
+			want:         []string{`"gopkg.in/yaml.v3"`},
+			notWant:      []string{`"database/sql/driver"`},
+		},
+		{
+			name:    "none",
+			configs: map[string]config.EnumTypeConfig{"size": {}},
+			notWant: []string{`"database/sql/driver"`, `"gopkg.in/yaml.v3"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "paint",
+				Version:        "v0.0.0",
+				SourceFilename: "paint.go",
+				OutputFilename: "paint",
+				FieldImports:   tt.fieldImports,
+				Configuration:  config.Configuration{Constraints: true, EnumTypeConfigs: tt.configs},
+				EnumIotas: []enum.EnumIota{
+					{Type: "color", UnderlyingType: "int", Enums: []enum.Enum{{Name: "red", Index: 0, Valid: true}}},
+					{Type: "size", UnderlyingType: "int", Enums: []enum.Enum{{Name: "small", Index: 0, Valid: true}}},
+				},
+			}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := memfs.ReadFile("paint_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			out := string(b)
+			for _, want := range append(tt.want, "type number interface") {
+				if n := strings.Count(out, want); n != 1 {
+					t.Errorf("expected output to contain %q once, got %d", want, n)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out, notWant) {
+					t.Errorf("expected output not to contain %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_MultipleEnumDuplicateNames(t *testing.T) {
+	t.Parallel()
+	err := gofile.NewWriter(gofile.WithFileSystem(file.NewMemFS())).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		EnumIotas: []enum.EnumIota{
+			{Type: "status", UnderlyingType: "int", Enums: []enum.Enum{{Name: "open", Index: 0, Valid: true}}},
+			{Type: "statuses", UnderlyingType: "int", Enums: []enum.Enum{{Name: "closed", Index: 0, Valid: true}}},
+		},
+	}})
+	if !errors.Is(err, gofile.ErrReservedName) {
+		t.Errorf("expected ErrReservedName, got %v", err)
+	}
+}
+
+func TestWriter_ImportedType(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "orders",
+		Version:        "v0.0.0",
+		SourceFilename: "orders.go",
+		OutputFilename: "orders",
+		FieldImports:   []enum.Import{{Name: "enums", Path: "example.com/shop/enums"}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "status",
+			ImportedType:   "enums.Status",
+			UnderlyingType: "int",
+			Enums:          []enum.Enum{{Name: "pending", Index: 0, Valid: true}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("orders_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	// The package shares its name with the enums runtime and is renamed
+	for _, want := range []string{
+		`enums1 "example.com/shop/enums"`,
+		"type status = enums1.Status",
+		"type Status struct {\n\tstatus\n}",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_Output(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	var out strings.Builder
+	writer := gofile.NewWriter(gofile.WithFileSystem(memfs), gofile.WithOutput(&out))
+	err := writer.Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "shop",
+		Version:        "v0.0.0",
+		SourceFilename: "-",
+		OutputFilename: "-",
+		EnumIotas: []enum.EnumIota{{
+			Type:           "region",
+			UnderlyingType: "int",
+			Enums:          []enum.Enum{{Name: "us", Index: 1, Valid: true, Aliases: []string{"US"}}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "package shop\n") || !strings.Contains(out.String(), "type Region struct") {
+		t.Errorf("expected the generated code to be written to the output, got:\n%s", out.String())
+	}
+	if _, err := memfs.ReadFile("-_enums.go"); err == nil {
+		t.Errorf("expected no file to be written")
+	}
+}
+
+func TestWriter_YAMLLibrary(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		library string
+		want    []string
+		notWant []string
+		err     error
+	}{
+		{library: "", want: []string{`"gopkg.in/yaml.v3"`, "UnmarshalYAML(node *yaml.Node)"}},
+		{library: config.YAMLLibraryGoccy, want: []string{"UnmarshalYAML(unmarshal func(any) error)", "MarshalYAML()"}, notWant: []string{"gopkg.in/yaml.v3"}},
+		{library: config.YAMLLibrarySigs, want: []string{"enums.MarshalYAMLJSON(", "enums.UnmarshalYAMLJSON("}, notWant: []string{"gopkg.in/yaml.v3", "MarshalYAML()"}},
+		{library: config.YAMLLibraryText, want: []string{"MarshalText()", "UnmarshalText("}, notWant: []string{"gopkg.in/yaml.v3", "MarshalYAML"}},
+		{library: "yaml.v1", err: gofile.ErrUnknownYAMLLibrary},
+	}
+	for _, tt := range tests {
+		t.Run(tt.library, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			cfg := config.Configuration{
+				YAMLLibrary: tt.library,
+				EnumTypeConfigs: map[string]config.EnumTypeConfig{
+					"color": {TypeName: "color", Handlers: config.Handlers{YAML: true}},
+				},
+			}
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "paint",
+				Version:        "v0.0.0",
+				SourceFilename: "paint.go",
+				OutputFilename: "paint",
+				Configuration:  cfg,
+				EnumIotas: []enum.EnumIota{{
+					Type:           "color",
+					UnderlyingType: "int",
+					Enums:          []enum.Enum{{Name: "red", Index: 0, Valid: true}},
+				}},
+			}})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			b, err := memfs.ReadFile("paint_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(b), want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(b), notWant) {
+					t.Errorf("expected output not to contain %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_BuildConstraint(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:         "plat",
+		BuildConstraint: "!purego && linux",
+		Version:         "v0.0.0",
+		SourceFilename:  "mode_linux.go",
+		OutputFilename:  "mode_linux",
+		EnumIotas: []enum.EnumIota{{
+			Type:           "mode",
+			UnderlyingType: "int",
+			Enums:          []enum.Enum{{Name: "modeEpoll", Index: 0, Valid: true}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("mode_linux_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	if want := "//go:build !purego && linux\n\n"; !strings.HasPrefix(string(b), want) {
+		t.Errorf("expected output to start with %q, got %q", want, string(b[:min(len(b), 40)]))
+	}
+}
+
+func TestWriter_DecodeHook(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Handlers: config.Handlers{Mapstructure: true}}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "color",
+			UnderlyingType: "int",
+			Enums:          []enum.Enum{{Name: "red", Index: 0, Valid: true}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	if want := "func ColorDecodeHook() enums.DecodeHookFunc {\n\treturn enums.DecodeHook(ParseColor)\n}"; !strings.Contains(string(b), want) {
+		t.Errorf("expected output to contain %q", want)
+	}
+}
+
+func TestWriter_Avro(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Handlers: config.Handlers{Avro: true}}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "color",
+			UnderlyingType: "int",
+			Enums:          []enum.Enum{{Name: "red", Index: 0, Valid: true}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		`const ColorAvroSchema = "{\"type\":\"enum\",\"name\":\"Color\",\"namespace\":\"paint\",\"symbols\":[\"red\"]}"`,
+		"func (c Color) MarshalText() ([]byte, error) {",
+		"func (c *Color) UnmarshalText(data []byte) error {",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_Match(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Match: true}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "color",
+			UnderlyingType: "int",
+			Enums: []enum.Enum{
+				{Name: "unknown", Index: 0},
+				{Name: "red", Index: 1, Valid: true},
+				{Name: "green", Index: 2, Valid: true},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"type ColorCases[T any] struct {\n\tRed   func() T\n\tGreen func() T\n}",
+		"func MatchColor[T any](v Color, cases ColorCases[T]) T {",
+		"\tcase Colors.Green:\n\t\tf = cases.Green\n\tdefault:\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_Registry(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:         "paint",
+		Version:         "v0.0.0",
+		SourceFilename:  "paint/paint.go",
+		OutputFilename:  "paint",
+		BuildConstraint: "linux",
+		Configuration:   config.Configuration{Defaults: config.EnumTypeConfig{Registry: true}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "color",
+			UnderlyingType: "int",
+			Enums: []enum.Enum{
+				{Name: "unknown", Index: 0},
+				{Name: "red", Index: 1, Valid: true},
+				{Name: "green", Index: 2, Valid: true, Deprecated: true},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint/paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	want := "func init() {\n\tenums.Register(EnumRegistry, \"paint\", \"Color\", []Color{\n\t\tColors.Red,\n\t}, ParseColor)\n}"
+	if !strings.Contains(string(b), want) {
+		t.Errorf("expected output to contain %q", want)
+	}
+	b, err = memfs.ReadFile("paint/" + gofile.RegistryFilename)
+	if err != nil {
+		t.Fatalf("expected the registry file to be written: %v", err)
+	}
+	if !strings.Contains(string(b), "var EnumRegistry = enums.NewRegistry()") || strings.Contains(string(b), "go:build") {
+		t.Errorf("unexpected registry file\n%s", b)
+	}
+}
+
+func TestWriter_RegistrySkipped(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		skip []string
+		only []string
+	}{
+		{name: "skip", skip: []string{config.SectionRegistry}},
+		{name: "only", only: []string{config.SectionString}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "paint",
+				Version:        "v0.0.0",
+				SourceFilename: "paint/paint.go",
+				OutputFilename: "paint",
+				Configuration: config.Configuration{
+					Defaults:     config.EnumTypeConfig{Registry: true},
+					SkipSections: tt.skip,
+					OnlySections: tt.only,
+				},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "color",
+					UnderlyingType: "int",
+					Enums: []enum.Enum{
+						{Name: "unknown", Index: 0},
+						{Name: "red", Index: 1, Valid: true},
+					},
+				}},
+			}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := memfs.ReadFile("paint/paint_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			if strings.Contains(string(b), "EnumRegistry") {
+				t.Error("expected no registration when the registry section is not written")
+			}
+			if _, err := memfs.ReadFile("paint/" + gofile.RegistryFilename); err == nil {
+				t.Error("expected no registry file when the registry section is not written")
+			}
+		})
+	}
+}
+
+func TestWriter_RegistryOutput(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	var out strings.Builder
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs), gofile.WithOutput(&out)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint/paint.go",
+		OutputFilename: "paint",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Registry: true}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "color",
+			UnderlyingType: "int",
+			Enums: []enum.Enum{
+				{Name: "unknown", Index: 0},
+				{Name: "red", Index: 1, Valid: true},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := memfs.ReadFile("paint/" + gofile.RegistryFilename); err == nil {
+		t.Error("expected no registry file to be written with an output writer")
+	}
+	// The output is the whole package, so it must declare the registry it
+	// registers the type in
+	for _, want := range []string{"enums.Register(EnumRegistry,", "var EnumRegistry = enums.NewRegistry()"} {
+		if strings.Count(out.String(), want) != 1 {
+			t.Errorf("expected output to contain %q once\n%s", want, out.String())
+		}
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "paint_enums.go", out.String(), 0); err != nil {
+		t.Errorf("expected the output to parse: %v", err)
+	}
+}
+
+func TestWriter_SchemaHash(t *testing.T) {
+	t.Parallel()
+	hash := func(values ...enum.Enum) string {
+		t.Helper()
+		memfs := file.NewMemFS()
+		err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+			Package:        "paint",
+			Version:        "v0.0.0",
+			SourceFilename: "paint.go",
+			OutputFilename: "paint",
+			Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{SchemaHash: true}},
+			EnumIotas:      []enum.EnumIota{{Type: "color", UnderlyingType: "int", Enums: values}},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := memfs.ReadFile("paint_enums.go")
+		if err != nil {
+			t.Fatalf("expected output to be written: %v", err)
+		}
+		_, after, ok := strings.Cut(string(b), "const ColorSchemaHash = \"")
+		if !ok {
+			t.Fatalf("expected output to declare ColorSchemaHash\n%s", b)
+		}
+		return after[:strings.Index(after, "\"")]
+	}
+	unknown := enum.Enum{Name: "unknown", Index: 0}
+	red := enum.Enum{Name: "red", Index: 1, Valid: true, Aliases: []string{"RED"}}
+	green := enum.Enum{Name: "green", Index: 2, Valid: true}
+
+	got := hash(unknown, red, green)
+	if want := enums.SchemaHash(map[string]string{"RED": "1", "green": "2"}); got != want {
+		t.Errorf("expected hash %s, got %s", want, got)
+	}
+	if reordered := hash(green, red, unknown); reordered != got {
+		t.Errorf("expected the hash not to depend on declaration order, got %s and %s", got, reordered)
+	}
+	green.Index = 3
+	if changed := hash(unknown, red, green); changed == got {
+		t.Errorf("expected changing a value to change the hash %s", got)
+	}
+}
+
+func TestWriter_FieldAccessors(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "solar",
+		Version:        "v0.0.0",
+		SourceFilename: "solar.go",
+		OutputFilename: "solar",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{FieldAccessors: true}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "planet",
+			UnderlyingType: "int",
+			Fields: []enum.Field{
+				{Name: "Gravity", Value: 0.0},
+				{Name: "Moons", Value: 0},
+				{Name: "Mass", Value: enum.Expr{Type: "units.Mass"}},
+				{Name: "Day", Value: time.Duration(0)},
+				{Name: "Tags", Value: []string{}},
+			},
+			Enums: []enum.Enum{{Name: "earth", Index: 1, Valid: true, Fields: []enum.Field{
+				{Name: "Gravity", Value: 1.0},
+				{Name: "Moons", Value: 1},
+				{Name: "Mass", Value: enum.Expr{Type: "units.Mass", Value: "units.Mass(5)"}},
+				{Name: "Day", Value: 24 * time.Hour},
+				{Name: "Tags", Value: []string{"rocky", "inner"}},
+			}}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("solar_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"func (p Planet) GetGravity() float64 {\n\treturn p.Gravity\n}",
+		"func PlanetByGravity(v float64) (Planet, bool) {",
+		"\t\tif e.Moons == v {\n",
+		"func (p planetsContainer) AllMass() iter.Seq2[Planet, units.Mass] {",
+		"func (p Planet) GetMass() units.Mass {",
+		"24 * time.Hour,",
+		`enums.SliceOf[string]("rocky", "inner"),`,
+		"func (p Planet) GetTags() enums.Slice[string] {",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(string(b), "func PlanetByMass(") {
+		t.Error("expected no lookup by a field of another package")
+	}
+	if strings.Contains(string(b), "func PlanetByTags(") {
+		t.Error("expected no lookup by a slice field")
+	}
+}
+
+func TestWriter_Groups(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "jobs",
+		Version:        "v0.0.0",
+		SourceFilename: "jobs.go",
+		OutputFilename: "jobs",
+		EnumIotas: []enum.EnumIota{{
+			Type:           "status",
+			UnderlyingType: "int",
+			Enums: []enum.Enum{
+				{Name: "unknown", Index: 0, Groups: []string{"terminal"}},
+				{Name: "failed", Index: 1, Valid: true, Groups: []string{"terminal", "failure"}},
+				{Name: "done", Index: 2, Valid: true, Groups: []string{"terminal", "valid"}},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("jobs_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"func TerminalStatuses() []Status {\n\treturn []Status{\n\t\tStatuses.Failed,\n\t\tStatuses.Done,\n\t}\n}",
+		"func (s Status) IsTerminal() bool {\n\tswitch s {\n\tcase Statuses.Failed, Statuses.Done:\n\t\treturn true",
+		"func FailureStatuses() []Status {",
+		"func (s Status) IsFailure() bool {",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Count(string(b), ") IsValid() bool {") != 1 {
+		t.Error("expected the valid group to be skipped")
+	}
+}
+
+func TestWriter_Tags(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "billing",
+		Version:        "v0.0.0",
+		SourceFilename: "billing.go",
+		OutputFilename: "billing",
+		EnumIotas: []enum.EnumIota{{
+			Type:           "plan",
+			UnderlyingType: "int",
+			Enums: []enum.Enum{
+				{Name: "free", Index: 0, Valid: true},
+				{Name: "pro", Index: 1, Valid: true, Tags: []string{"billing", "external"}},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("billing_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"var planTags = map[Plan][]string{\n\tPlans.Pro: {\"billing\", \"external\"},\n}",
+		"func (p Plan) HasTag(tag string) bool {",
+		"func (p plansContainer) FilterByTag(tag string) iter.Seq[Plan] {",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_Atomic(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "alerts",
+		Version:        "v0.0.0",
+		SourceFilename: "alerts.go",
+		OutputFilename: "alerts",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Atomic: true}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "alert",
+			UnderlyingType: "string",
+			Enums: []enum.Enum{
+				{Name: "warning", Index: 0, Valid: true},
+				{Name: "critical", Index: 1, Valid: true},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("alerts_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		`"sync/atomic"`,
+		"var atomicAlertValues = Alerts.allSlice()",
+		"type AtomicAlert struct {\n\tv atomic.Int64\n}",
+		"func (a *AtomicAlert) Load() Alert {",
+		"func (a *AtomicAlert) Store(v Alert) {",
+		"func (a *AtomicAlert) Swap(v Alert) Alert {",
+		"func (a *AtomicAlert) CompareAndSwap(old, new Alert) bool {",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_ZeroValues(t *testing.T) {
+	t.Parallel()
+	write := func(typeConfig config.EnumTypeConfig) string {
+		memfs := file.NewMemFS()
+		err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+			Package:        "paint",
+			Version:        "v0.0.0",
+			SourceFilename: "paint.go",
+			OutputFilename: "paint",
+			Configuration:  config.Configuration{Defaults: typeConfig},
+			EnumIotas: []enum.EnumIota{{
+				Type:           "color",
+				UnderlyingType: "int",
+				Enums: []enum.Enum{
+					{Name: "unknown", Index: 0},
+					{Name: "red", Index: 1, Valid: true},
+				},
+			}},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := memfs.ReadFile("paint_enums.go")
+		if err != nil {
+			t.Fatalf("expected output to be written: %v", err)
+		}
+		return string(b)
+	}
+
+	isZero := "func (c Color) IsZero() bool {\n\treturn !c.IsValid()\n}"
+	marshalNull := "\tif c.IsZero() {\n\t\treturn []byte(\"null\"), nil\n\t}"
+	unmarshalNull := "\tif string(data) == \"null\" {\n\t\treturn nil\n\t}"
+
+	out := write(config.EnumTypeConfig{Handlers: config.Handlers{JSON: true}})
+	if !strings.Contains(out, isZero) {
+		t.Errorf("expected output to contain %q", isZero)
+	}
+	if strings.Contains(out, marshalNull) || strings.Contains(out, unmarshalNull) {
+		t.Error("expected invalid values not to marshal as null without -json/null")
+	}
+
+	for _, want := range []string{
+		"func (c Color) Ptr() *Color {\n\treturn &c\n}",
+		"func (c colorsContainer) FromPtr(ptr *Color) (Color, bool) {",
+		"result, err := enums.UnmarshalJSON(*c, data, enums.WithLenient())",
+		"func ValidateColorJSON(data []byte) error {\n\treturn enums.ValidateJSON(Color{}, data)\n}",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+
+	out = write(config.EnumTypeConfig{Handlers: config.Handlers{JSON: true}, JSONNull: true})
+	for _, want := range []string{marshalNull, unmarshalNull} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	// null decodes as the invalid zero value, so it is not a valid Color
+	validateNull := "func ValidateColorJSON(data []byte) error {\n\treturn enums.ValidateJSON(Color{}, data)\n}"
+	if !strings.Contains(out, validateNull) {
+		t.Errorf("expected output to contain %q", validateNull)
+	}
+}
+
+func TestWriter_DefaultOnError(t *testing.T) {
+	t.Parallel()
+	write := func(typeConfig config.EnumTypeConfig) string {
+		memfs := file.NewMemFS()
+		err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+			Package:        "jobs",
+			Version:        "v0.0.0",
+			SourceFilename: "jobs.go",
+			OutputFilename: "jobs",
+			Configuration:  config.Configuration{Defaults: typeConfig},
+			EnumIotas: []enum.EnumIota{{
+				Type:           "step",
+				UnderlyingType: "int",
+				Default:        "step1Initialized",
+				Enums: []enum.Enum{
+					{Name: "step0Unknown", Index: 0},
+					{Name: "step1Initialized", Index: 1, Valid: true},
+					{Name: "step2Running", Index: 2, Valid: true},
+				},
+			}},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := memfs.ReadFile("jobs_enums.go")
+		if err != nil {
+			t.Fatalf("expected output to be written: %v", err)
+		}
+		return string(b)
+	}
+
+	fallback := "\tif err != nil {\n\t\t*s = Steps.Step1Initialized\n\t\treturn nil\n\t}"
+	handlers := config.Handlers{JSON: true, Text: true, Binary: true, YAML: true}
+
+	out := write(config.EnumTypeConfig{Handlers: handlers})
+	if strings.Contains(out, fallback) {
+		t.Error("expected unmarshalers to return errors without -default-on-error")
+	}
+
+	out = write(config.EnumTypeConfig{Handlers: handlers, DefaultOnError: true})
+	if n := strings.Count(out, fallback); n != 4 {
+		t.Errorf("expected the JSON, text, binary and YAML unmarshalers to fall back to the default, got %d\n%s", n, out)
+	}
+	if !strings.Contains(out, "result, err := enums.UnmarshalJSON(*s, data, enums.WithLenient())\n\tif err != nil {\n\t\treturn err\n\t}") {
+		t.Error("expected UnmarshalJSONLenient to keep returning errors for malformed JSON")
+	}
+}
+
+func TestWriter_SerdeObject(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "accounts",
+		Version:        "v0.0.0",
+		SourceFilename: "accounts.go",
+		OutputFilename: "accounts",
+		Configuration: config.Configuration{Defaults: config.EnumTypeConfig{
+			Handlers:          config.Handlers{JSON: true, Text: true},
+			SerializationType: config.SerdeObject,
+		}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "state",
+			UnderlyingType: "int",
+			Fields:         []enum.Field{{Name: "Description", Value: ""}, {Name: "Billable", Value: false}},
+			Enums: []enum.Enum{
+				{Name: "unknown", Index: 0},
+				{Name: "active", Index: 1, Valid: true, Fields: []enum.Field{{Name: "Description", Value: "In use"}, {Name: "Billable", Value: true}}},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("accounts_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	out := string(b)
+	for _, want := range []string{
+		"return enums.MarshalJSONObject(s,\n\t\tenums.ObjectField{Name: \"description\", Value: s.Description},\n\t\tenums.ObjectField{Name: \"billable\", Value: s.Billable})",
+		"result, err := enums.UnmarshalJSONObject(*s, data)",
+		"result, err := enums.UnmarshalJSONObject(*s, data, enums.WithLenient())",
+		"return enums.ValidateJSONObject(State{}, data)",
+		"return enums.MarshalText(s, s.state)",
+		"return enums.FormatName",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestWriter_BinaryEncoding(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"color": {Handlers: config.Handlers{Binary: true}, BinaryEncoding: "varint,little-endian"},
+			"shade": {Handlers: config.Handlers{Binary: true}},
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "color", UnderlyingType: "int", Enums: []enum.Enum{{Name: "red", Index: 0, Valid: true}}},
+			{Type: "shade", UnderlyingType: "int", Enums: []enum.Enum{{Name: "dark", Index: 0, Valid: true}}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	out := string(b)
+	for _, want := range []string{
+		"\t\"encoding/binary\"\n",
+		"var colorBinaryOptions = enums.BinaryOptions{ByteOrder: binary.LittleEndian, Varint: true}",
+		"return enums.MarshalBinary(c, c.color, colorBinaryOptions)",
+		"result, err := enums.UnmarshalBinary(*c, data, enums.WithBinaryOptions(colorBinaryOptions))",
+		"return enums.MarshalBinary(s, s.shade)\n",
+		"result, err := enums.UnmarshalBinary(*s, data)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_Stringer(t *testing.T) {
+	t.Parallel()
+	write := func(stringer string) (string, error) {
+		memfs := file.NewMemFS()
+		err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+			Package:        "paint",
+			Version:        "v0.0.0",
+			SourceFilename: "paint.go",
+			OutputFilename: "paint",
+			Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Stringer: stringer}},
+			EnumIotas: []enum.EnumIota{{
+				Type:           "color",
+				UnderlyingType: "int",
+				Enums:          []enum.Enum{{Name: "darkRed", Index: 0, Valid: true, Aliases: []string{"DARK_RED"}}},
+			}},
+		}})
+		if err != nil {
+			return "", err
+		}
+		b, err := memfs.ReadFile("paint_enums.go")
+		return string(b), err
+	}
+
+	out, err := write(config.StringerSwitch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"\tswitch c {\n\tcase Colors.DarkRed:\n\t\treturn \"DARK_RED\"\n\t}\n",
+		"\tswitch name {\n\tcase \"DARK_RED\":\n\t\treturn Colors.DarkRed, true\n\t}\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(out, "colorNames") {
+		t.Error("expected no names map or constant")
+	}
+	if _, err := write("fast"); !errors.Is(err, gofile.ErrUnknownStringer) {
+		t.Errorf("expected ErrUnknownStringer, got %v", err)
+	}
+}
+
+func TestWriter_SQLArray(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Handlers: config.Handlers{SQLArray: true}}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "color",
+			UnderlyingType: "int",
+			Enums:          []enum.Enum{{Name: "red", Index: 0, Valid: true}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"\t\"database/sql/driver\"\n",
+		"type ColorSlice []Color\n",
+		"func (c *ColorSlice) Scan(value any) error {\n\tresult, err := enums.SliceScan(Color{}, value)",
+		"func (c ColorSlice) Value() (driver.Value, error) {\n\treturn enums.SliceValue(c)\n}",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_SQLScan(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	value := config.EnumTypeConfig{Handlers: config.Handlers{SQL: true}, SerializationType: config.SerdeValue}
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"color": {Handlers: config.Handlers{SQL: true}},
+			"shade": value,
+			"hue":   value,
+			"tint":  value,
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "color", UnderlyingType: "int", Enums: []enum.Enum{{Name: "red", Index: 0, Valid: true}}},
+			{Type: "shade", UnderlyingType: "uint8", Enums: []enum.Enum{{Name: "dark", Index: 0, Valid: true}}},
+			{Type: "hue", UnderlyingType: "float32", Enums: []enum.Enum{{Name: "warm", Index: 0, Valid: true}}},
+			{Type: "tint", UnderlyingType: "bool", Enums: []enum.Enum{{Name: "pale", Index: 0, Valid: true}}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"func (c *Color) Scan(value any) error {\n\tresult, err := scanColor(*c, value)",
+		"\tcase []byte:\n\t\treturn enums.ScanName(zero, string(v), value)\n",
+		"\tcase int64:\n\t\tif v >= 0 && int64(uint8(v)) == v {\n\t\t\treturn enums.ScanValue(zero, uint8(v), value)\n\t\t}\n",
+		"\tcase float64:\n\t\tif float64(float32(v)) == v {\n",
+		"func scanTint(zero Tint, value any) (*Tint, error) {\n\treturn enums.SQLScan(zero, value)\n}",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_SQLType(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"color": {Handlers: config.Handlers{SQL: true}, SQLType: config.SQLTypeInt},
+			"shade": {Handlers: config.Handlers{SQL: true}, SerializationType: config.SerdeValue, SQLType: config.SQLTypeString},
+			"tint":  {Handlers: config.Handlers{SQL: true}, SQLType: config.SQLTypeBool},
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "color", UnderlyingType: "uint16", Enums: []enum.Enum{{Name: "red", Index: 0, Valid: true}}},
+			{Type: "shade", UnderlyingType: "int", Enums: []enum.Enum{{Name: "dark", Index: 0, Valid: true}}},
+			{Type: "tint", UnderlyingType: "bool", Enums: []enum.Enum{{Name: "pale", Index: 0, Valid: true}}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"func (c Color) Value() (driver.Value, error) {\n\treturn int64(c.Val()), nil\n}",
+		"\tcase int64:\n\t\tif v >= 0 && int64(uint16(v)) == v {\n",
+		"\treturn enums.SQLScanFormat(zero, enums.FormatValue, value)\n}",
+		"func (s Shade) Value() (driver.Value, error) {\n\treturn s.Name(), nil\n}",
+		"\tcase string:\n\t\treturn enums.ScanName(zero, v, value)\n",
+		"\treturn enums.SQLScanFormat(zero, enums.FormatName, value)\n}",
+		"func (t Tint) Value() (driver.Value, error) {\n\treturn bool(t.Val()), nil\n}",
+		"\tcase bool:\n\t\treturn enums.ScanValue(zero, bool(v), value)\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_Float(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"ratio": {
+				Handlers:          config.Handlers{JSON: true, Text: true},
+				SerializationType: config.SerdeValue,
+				FloatEpsilon:      1e-6,
+				FloatFormat:       "%.2f",
+			},
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "ratio", UnderlyingType: "float64", Enums: []enum.Enum{{Name: "half", Value: "0.5", Valid: true}}},
+			{Type: "gloss", UnderlyingType: "float64", Enums: []enum.Enum{{Name: "matte", Value: "0.5", Valid: true}}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"\t\tif d := v.Val() - value; d >= -1e-06 && d <= 1e-06 {\n",
+		"func (r Ratio) ValString() string {\n\treturn fmt.Sprintf(\"%.2f\", r.Val())\n}",
+		"\treturn enums.MarshalJSON(r, r.ValString())\n",
+		"\treturn enums.MarshalText(r, r.ValString())\n",
+		"\t\tif v.Val() == value {\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(string(b), "func (g Gloss) ValString()") {
+		t.Error("expected no ValString without -float/format")
+	}
+}
+
+func TestWriter_SQLColumn(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"orderStatus": {Handlers: config.Handlers{SQL: true, SQLColumn: true}},
+			"color":       {Handlers: config.Handlers{SQL: true, SQLColumn: true}, MigrationTable: "paints", MigrationColumn: "hue"},
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "orderStatus", UnderlyingType: "int", Enums: []enum.Enum{
+				{Name: "unknown", Index: 0},
+				{Name: "pending", Index: 1, Valid: true},
+				{Name: "shipped", Index: 2, Valid: true, Deprecated: true},
+			}},
+			{Type: "color", UnderlyingType: "int", Enums: []enum.Enum{{Name: "red", Index: 0, Valid: true}}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"\t\"context\"\n",
+		"var orderstatusColumnValues = []OrderStatus{\n\tOrderStatuses.Pending,\n\tOrderStatuses.Shipped,\n}",
+		"return enums.ColumnDefinition(dialect, \"order_status\", orderstatusColumnValues)",
+		"func OrderStatusColumnDrift(ctx context.Context, db enums.Querier, dialect string) (enums.ColumnDrift, error) {\n" +
+			"\treturn enums.CheckColumnDrift(ctx, db, dialect, \"order_statuses\", \"order_status\", orderstatusColumnValues)",
+		"return enums.CheckColumnDrift(ctx, db, dialect, \"paints\", \"hue\", colorColumnValues)",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_ORMs(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"color": {Handlers: config.Handlers{SQL: true, JSON: true, SQLBoiler: true, Bun: true}},
+			"shade": {Handlers: config.Handlers{SQL: true, SQLBoiler: true}},
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "color", UnderlyingType: "int", Enums: []enum.Enum{
+				{Name: "unknown", Index: 0},
+				{Name: "red", Index: 1, Valid: true},
+				{Name: "green", Index: 2, Valid: true, Deprecated: true},
+			}},
+			{Type: "shade", UnderlyingType: "int", Enums: []enum.Enum{{Name: "dark", Index: 0, Valid: true}}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	out := string(b)
+	for _, want := range []string{
+		"var colorRandomizeValues = []Color{\n\tColors.Red,\n}",
+		"func (c *Color) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {",
+		"type NullColor struct {\n\tColor Color\n\tValid bool\n}",
+		"return NullColor{Color: v, Valid: valid}",
+		"func (n *NullColor) Scan(value any) error {",
+		"func (n NullColor) MarshalJSON() ([]byte, error) {",
+		"func (c Color) SQLLiteral() string {\n\treturn enums.SQLLiteral(c)\n}",
+		"func (n *NullShade) Randomize(",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	for _, unwanted := range []string{"func (n NullShade) MarshalJSON", "func (s Shade) SQLLiteral"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("expected output not to contain %q", unwanted)
+		}
+	}
+}
+
+func TestWriter_Prometheus(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"color": {Prometheus: true},
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "color", UnderlyingType: "int", Enums: []enum.Enum{
+				{Name: "unknown", Index: 0},
+				{Name: "red", Index: 1, Valid: true, Aliases: []string{"Crimson"}},
+				{Name: "green", Index: 2, Valid: true},
+				{Name: "blue", Index: 3, Valid: true, Deprecated: true},
+			}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"var colorLabelValues = []string{\n\t\"Crimson\",\n\t\"green\",\n}",
+		"func ColorLabelValues() []string {\n\treturn append([]string(nil), colorLabelValues...)\n}",
+		"func InitColorLabels[M any](withLabelValues func(...string) M, otherLabelValues ...string) {\n" +
+			"\tenums.InitLabelValues(withLabelValues, colorLabelValues, otherLabelValues...)\n}",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_StateMachine(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "orders",
+		Version:        "v0.0.0",
+		SourceFilename: "orders.go",
+		OutputFilename: "orders",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"orderStatus": {StateMachine: true, StateHistory: true},
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "orderStatus", UnderlyingType: "int", Enums: []enum.Enum{
+				{Name: "pending", Index: 0, Valid: true, StateTransitions: []string{"paid", "canceled"}, StateEvents: []string{"onPay", "OnCancel"}},
+				{Name: "paid", Index: 1, Valid: true, StateTransitions: []string{"shipped", "canceled"}, StateEvents: []string{"", "OnCancel"}},
+				{Name: "shipped", Index: 2, Valid: true, IsFinalState: true},
+				{Name: "canceled", Index: 3, Valid: true, IsFinalState: true},
+			}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("orders_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"\t\"context\"\n",
+		"func (o OrderStatus) TransitionTo(target OrderStatus) (OrderStatus, error) {\n\tif !o.CanTransitionTo(target) {\n" +
+			"\t\treturn o, fmt.Errorf(\"%w: %v to %v\", enums.ErrInvalidTransition, o, target)",
+		"type OrderStatusTransitionHooks interface {",
+		"GuardTransition(ctx context.Context, from, to OrderStatus) error",
+		"type NopOrderStatusTransitionHooks struct{}",
+		"func (o OrderStatus) TransitionWithHooks(ctx context.Context, target OrderStatus, hooks OrderStatusTransitionHooks) (OrderStatus, error) {",
+		"\thooks.AfterTransition(ctx, o, next)\n\treturn next, nil\n}",
+		"const (\n\tOrderStatusEventOnPay    OrderStatusEvent = \"onPay\"\n\tOrderStatusEventOnCancel OrderStatusEvent = \"OnCancel\"\n)",
+		"\tcase o == OrderStatuses.Pending && event == OrderStatusEventOnPay:\n\t\treturn OrderStatuses.Paid, nil\n",
+		"\tcase o == OrderStatuses.Paid && event == OrderStatusEventOnCancel:\n\t\treturn OrderStatuses.Canceled, nil\n",
+		"\treturn o, fmt.Errorf(\"%w: %s in state %v\", enums.ErrInvalidTransition, event, o)",
+		"type OrderStatusHistory struct {\n\tNopOrderStatusTransitionHooks\n\tenums.History[OrderStatus]\n}",
+		"func (h *OrderStatusHistory) TransitionTo(from, target OrderStatus) (OrderStatus, error) {",
+		"func (h *OrderStatusHistory) Fire(from OrderStatus, event OrderStatusEvent) (OrderStatus, error) {\n" +
+			"\tnext, err := from.Fire(event)\n\tif err == nil {\n\t\th.Record(from, next, string(event))",
+		"func (h *OrderStatusHistory) AfterTransition(_ context.Context, from, to OrderStatus) {",
+		"func NewOrderStatusMachine(persister enums.StatePersister[OrderStatus], hooks OrderStatusTransitionHooks) *OrderStatusMachine {",
+		"func (m *OrderStatusMachine) Fire(ctx context.Context, id string, event OrderStatusEvent) (OrderStatus, error) {",
+		"\tif err := m.persister.Save(ctx, id, next); err != nil {\n\t\treturn current, err\n\t}\n" +
+			"\tif m.hooks != nil {\n\t\tm.hooks.AfterTransition(ctx, current, next)\n\t}\n\treturn next, nil\n}",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_HTTP(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Handlers: config.Handlers{HTTP: true}}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "color",
+			UnderlyingType: "int",
+			Enums:          []enum.Enum{{Name: "red", Index: 0, Valid: true}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	if !strings.Contains(string(b), "\t\"net/url\"\n") {
+		t.Error("expected net/url to be imported")
+	}
+	for _, want := range []string{
+		"func (c *Color) UnmarshalParam(param string) error {\n\tresult, err := ParseColor(param)",
+		"func (c *Color) UnmarshalText(data []byte) error {",
+		"\t\treturn &InvalidColorError{Input: string(data)}\n",
+		"func (c Color) MarshalText() ([]byte, error) {",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if want := "func ColorFromQuery(values url.Values, key string) (Color, error) {\n\treturn enums.FromValues(values, key, ParseColor)\n}"; !strings.Contains(string(b), want) {
+		t.Errorf("expected output to contain %q", want)
+	}
+}
+
+func TestWriter_Proto(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		prefix string
+		want   string
+	}{
+		{name: "default prefix", want: "pb.Color_COLOR_DARK_RED"},
+		{name: "custom prefix", prefix: "Color_", want: "pb.Color_DARK_RED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			typeConfig := config.EnumTypeConfig{Proto: "pb.Color", ProtoPrefix: tt.prefix}
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "paint",
+				Version:        "v0.0.0",
+				SourceFilename: "paint.go",
+				OutputFilename: "paint",
+				FieldImports:   []enum.Import{{Name: "pb", Path: "example.com/paint/pb"}},
+				Configuration:  config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{"color": typeConfig}},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "color",
+					UnderlyingType: "int",
+					Enums: []enum.Enum{
+						{Name: "unknown", Index: 0},
+						{Name: "darkRed", Index: 1, Valid: true},
+					},
+				}},
+			}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := memfs.ReadFile("paint_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			for _, want := range []string{
+				"func (c Color) ToProto() pb.Color {",
+				"\tcase Colors.DarkRed:\n\t\treturn " + tt.want + "\n",
+				"func ColorFromProto(v pb.Color) (Color, error) {",
+				"\tcase " + tt.want + ":\n\t\treturn Colors.DarkRed, nil\n",
+			} {
+				if !strings.Contains(string(b), want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+			if strings.Contains(string(b), "UNKNOWN") {
+				t.Error("expected invalid values not to be mapped")
+			}
+		})
+	}
+}
+
+func TestWriter_ZarldevCompat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		defaults config.EnumTypeConfig
+		want     []string
+		notWant  []string
+	}{
+		{
+			name:     "disabled",
+			defaults: config.EnumTypeConfig{},
+			notWant:  []string{"RED Color", "func ExhaustiveColors"},
+		},
+		{
+			name:     "enabled",
+			defaults: config.EnumTypeConfig{ZarldevCompat: true},
+			want: []string{
+				"// Deprecated: RED is the zarldev/goenums name of Red.\n\tRED Color\n",
+				"\tRED: Color{\n",
+				"func ExhaustiveColors(f func(Color)) {",
+			},
+		},
+		{
+			name:     "uppercase fields",
+			defaults: config.EnumTypeConfig{ZarldevCompat: true, UppercaseFields: true},
+			want:     []string{"func ExhaustiveColors(f func(Color)) {"},
+			notWant:  []string{"// Deprecated: RED"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "paint",
+				Version:        "v0.0.0",
+				SourceFilename: "paint.go",
+				OutputFilename: "paint",
+				Configuration:  config.Configuration{Defaults: tt.defaults},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "color",
+					UnderlyingType: "int",
+					Enums:          []enum.Enum{{Name: "red", Index: 0, Valid: true}},
+				}},
+			}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out, err := memfs.ReadFile("paint_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(out), notWant) {
+					t.Errorf("expected output not to contain %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_SectionOrder(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		order []string
+		first string
+		err   error
+	}{
+		{name: "default", first: "type Color struct"},
+		{name: "configured", order: []string{config.SectionCompileCheck, config.SectionString}, first: "func _() {"},
+		{name: "unknown", order: []string{"footer"}, err: gofile.ErrUnknownSection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "paint",
+				Version:        "v0.0.0",
+				SourceFilename: "paint.go",
+				OutputFilename: "paint",
+				Configuration:  config.Configuration{SectionOrder: tt.order},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "color",
+					UnderlyingType: "int",
+					Enums:          []enum.Enum{{Name: "red", Index: 0, Valid: true}},
+				}},
+			}})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			out, err := memfs.ReadFile("paint_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			sections := []string{"type Color struct", "func _() {", "func (c Color) String() string"}
+			first := slices.MinFunc(sections, func(a, b string) int {
+				return strings.Index(string(out), a) - strings.Index(string(out), b)
+			})
+			if first != tt.first {
+				t.Errorf("expected %q to be written first, got %q", tt.first, first)
+			}
+		})
+	}
+}
+
+func TestWriter_SkipSections(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		skip    []string
+		only    []string
+		match   bool
+		want    []string
+		notWant []string
+		err     error
+	}{
+		{name: "default", want: []string{"func ParseColor(", "\"errors\"", "func (c Color) MarshalJSON()",
+			"func (c colorsContainer) Parse(input any) (Color, error) {\n\treturn ParseColor(input)\n}",
+			"func (c colorsContainer) MustParse(input any) Color {\n\treturn MustParseColor(input)\n}"}},
+		{name: "skip", skip: []string{config.SectionParse, config.SectionCompileCheck},
+			want: []string{"func (c Color) MarshalJSON()"}, notWant: []string{"func ParseColor(", "\"errors\"", "func _() {", "colorsContainer) Parse("}},
+		{name: "only", only: []string{config.SectionParse},
+			want: []string{"func ParseColor(", "func (c Color) String() string"}, notWant: []string{"func (c Color) MarshalJSON()", "type ColorRaw"}},
+		{name: "unknown", skip: []string{"footer"}, err: gofile.ErrUnknownSection},
+		{name: "unknown only", only: []string{"footer"}, err: gofile.ErrUnknownSection},
+		{name: "required", skip: []string{config.SectionContainer}, err: gofile.ErrSkippedSection},
+		{name: "dependency", skip: []string{config.SectionParse}, match: true, err: gofile.ErrSkippedSection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "paint",
+				Version:        "v0.0.0",
+				SourceFilename: "paint.go",
+				OutputFilename: "paint",
+				Configuration: config.Configuration{
+					SkipSections: tt.skip,
+					OnlySections: tt.only,
+					EnumTypeConfigs: map[string]config.EnumTypeConfig{
+						"color": {Handlers: config.Handlers{JSON: true}, Match: tt.match},
+					},
+				},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "color",
+					UnderlyingType: "int",
+					Enums:          []enum.Enum{{Name: "red", Index: 0, Valid: true}},
+				}},
+			}})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			out, err := memfs.ReadFile("paint_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(out), notWant) {
+					t.Errorf("expected output not to contain %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_SerdeFormat(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "paint",
+		Version:        "v0.0.0",
+		SourceFilename: "paint.go",
+		OutputFilename: "paint",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"mode": {Handlers: config.Handlers{JSON: true}},
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "mode", UnderlyingType: "int", Enums: []enum.Enum{
+				{Name: "fast", Index: 1, Valid: true},
+				{Name: "slow", Index: 2, Valid: true, SerdeFormat: enum.SerdeFormatValue},
+			}},
+			{Type: "tone", UnderlyingType: "int", Enums: []enum.Enum{{Name: "warm", Index: 1, Valid: true}}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("paint_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"func (m Mode) ValueFormat() enums.Format {\n",
+		"\tcase Modes.Slow:\n\t\treturn enums.FormatValue\n",
+		"\treturn m.SerdeFormat()\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(string(b), "func (t Tone) ValueFormat()") {
+		t.Error("expected no ValueFormat without overrides")
+	}
+}
+
+func TestWriter_InvalidName(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "power",
+		Version:        "v0.0.0",
+		SourceFilename: "power.go",
+		OutputFilename: "power",
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"mode": {InvalidName: "Unknown", InvalidInAll: true},
+		}},
+		EnumIotas: []enum.EnumIota{
+			{Type: "mode", UnderlyingType: "int", Enums: []enum.Enum{
+				{Name: "off", Index: 0, Valid: true},
+				{Name: "on", Index: 1, Valid: true},
+			}},
+			{Type: "state", UnderlyingType: "int", Enums: []enum.Enum{{Name: "idle", Index: 1, Valid: true}}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("power_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"\tinvalid bool\n}",
+		"\tUnknown Mode // invalid sentinel\n",
+		"\tUnknown: invalidMode,\n",
+		"var invalidMode = Mode{invalid: true}\n",
+		"\tif m == invalidMode {\n\t\treturn \"Unknown\"\n\t}\n",
+		"\t\tif !yield(invalidMode) {\n",
+		"var invalidState = State{}\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(string(b), "\t\tif !yield(invalidState) {\n") {
+		t.Error("expected All to leave out the sentinel without -invalid/all")
+	}
+}
+
+func TestWriter_WrapperAndReceiver(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		wrapper  string
+		receiver string
+		want     []string
+		err      error
+	}{
+		{name: "default", want: []string{"type TokenRequestStatus struct {", "func (t TokenRequestStatus) String() string"}},
+		{name: "overridden", wrapper: "OrderState", receiver: "os", want: []string{
+			"type OrderState struct {",
+			"func (os OrderState) String() string",
+			"func ParseOrderState(input any) (OrderState, error)",
+			"type OrderStateRaw = tokenRequestStatus",
+		}},
+		{name: "container", wrapper: "TokenRequestStatuses", err: gofile.ErrReservedName},
+		{name: "local", receiver: "value", err: gofile.ErrReservedName},
+		{name: "package", receiver: "fmt", err: gofile.ErrReservedName},
+		{name: "predeclared", receiver: "string", err: gofile.ErrReservedName},
+		{name: "package-level", receiver: "tokenrequeststatusNamesMap", err: gofile.ErrReservedName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "orders",
+				Version:        "v0.0.0",
+				SourceFilename: "orders.go",
+				OutputFilename: "orders",
+				Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+					"tokenRequestStatus": {WrapperName: tt.wrapper, Receiver: tt.receiver},
+				}},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "tokenRequestStatus",
+					UnderlyingType: "int",
+					Enums:          []enum.Enum{{Name: "pending", Index: 0, Valid: true}},
+				}},
+			}})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			out, err := memfs.ReadFile("orders_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_Plurals(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		typ     string
+		plural  string
+		plurals map[string]string
+		want    []string
+		err     error
+	}{
+		{name: "default", typ: "tableSchema", want: []string{"var TableSchemas = tableSchemasContainer{", "type TableSchema struct {"}},
+		{name: "directive", typ: "tableSchema", plural: "Schemata", want: []string{"var Schemata = schemataContainer{", "type TableSchema struct {"}},
+		{name: "table", typ: "tableSchema", plurals: map[string]string{"schema": "schemata"}, want: []string{
+			"var TableSchemata = tableSchemataContainer{",
+			"type TableSchema struct {",
+		}},
+		{name: "singular", typ: "tableSchemata", plurals: map[string]string{"schema": "schemata"}, want: []string{
+			"var TableSchemata = tableSchemataContainer{",
+			"type TableSchema struct {",
+		}},
+		{name: "wrapper", typ: "status", plural: "Status", err: gofile.ErrReservedName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "orders",
+				Version:        "v0.0.0",
+				SourceFilename: "orders.go",
+				OutputFilename: "orders",
+				Configuration: config.Configuration{
+					Plurals:         tt.plurals,
+					EnumTypeConfigs: map[string]config.EnumTypeConfig{tt.typ: {Plural: tt.plural}},
+				},
+				EnumIotas: []enum.EnumIota{{
+					Type:           tt.typ,
+					UnderlyingType: "int",
+					Enums:          []enum.Enum{{Name: "pending", Index: 0, Valid: true}},
+				}},
+			}})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			out, err := memfs.ReadFile("orders_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_ContainerMethodNames(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		value string
+		skip  []string
+		err   error
+	}{
+		{name: "parse", value: "parse", err: gofile.ErrReservedName},
+		{name: "must parse", value: "mustParse", err: gofile.ErrReservedName},
+		{name: "parse skipped", value: "parse", skip: []string{config.SectionParse}},
+		{name: "convenience skipped", value: "mustParse", skip: []string{config.SectionConvenience}},
+		{name: "count", value: "count", skip: []string{config.SectionParse}, err: gofile.ErrReservedName},
+		{name: "max", value: "maxOp", err: gofile.ErrReservedName},
+		{name: "count skipped", value: "count", skip: []string{config.SectionConvenience}},
+		{name: "other", value: "parsed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "ops",
+				Version:        "v0.0.0",
+				SourceFilename: "ops.go",
+				OutputFilename: "ops",
+				Configuration:  config.Configuration{SkipSections: tt.skip},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "op",
+					UnderlyingType: "int",
+					Enums: []enum.Enum{
+						{Name: "unknown", Index: 0},
+						{Name: tt.value, Index: 1, Valid: true},
+					},
+				}},
+			}})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			out, err := memfs.ReadFile("ops_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			typeCheck(t, map[string]string{
+				"ops.go":       "package ops\n\ntype op int\n\nconst (\n\tunknown op = iota\n\t" + tt.value + "\n)\n",
+				"ops_enums.go": string(out),
+			})
+		})
+	}
+}
+
+func TestWriter_SuggestUnmarshalers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		config config.EnumTypeConfig
+		def    string
+		want   []string
+		unwant []string
+	}{
+		{
+			name:   "suggest",
+			config: config.EnumTypeConfig{Suggest: true},
+			want: []string{
+				"\t\"encoding/json\"\n",
+				"if json.Unmarshal(data, &name) == nil {\n\t\t\treturn invalidOpError(name)\n",
+				"\t\treturn invalidOpError(string(data))\n",
+				"\t\treturn invalidOp, invalidOpError(input)\n",
+			},
+		},
+		{
+			name:   "default on error",
+			config: config.EnumTypeConfig{Suggest: true, DefaultOnError: true},
+			def:    "read",
+			want:   []string{"\t\treturn invalidOp, invalidOpError(input)\n"},
+			unwant: []string{"\"encoding/json\"", "json.Unmarshal(data, &name)", "invalidOpError(string(data))"},
+		},
+		{
+			name:   "no suggest",
+			config: config.EnumTypeConfig{},
+			unwant: []string{"\"encoding/json\"", "invalidOpError"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.config.Handlers = config.Handlers{JSON: true, Text: true}
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "ops",
+				Version:        "v0.0.0",
+				SourceFilename: "ops.go",
+				OutputFilename: "ops",
+				Configuration:  config.Configuration{Defaults: tt.config},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "op",
+					UnderlyingType: "int",
+					Default:        tt.def,
+					Enums: []enum.Enum{
+						{Name: "unknown", Index: 0},
+						{Name: "read", Index: 1, Valid: true},
+						{Name: "write", Index: 2, Valid: true},
+					},
+				}},
+			}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out, err := memfs.ReadFile("ops_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+			for _, unwant := range tt.unwant {
+				if strings.Contains(string(out), unwant) {
+					t.Errorf("expected output not to contain %q", unwant)
+				}
+			}
+			typeCheck(t, map[string]string{
+				"ops.go":       "package ops\n\ntype op int\n\nconst (\n\tunknown op = iota\n\tread\n\twrite\n)\n",
+				"ops_enums.go": string(out),
+			})
+		})
+	}
+}
+
+// typeCheck type-checks the files of a package, failing the test with the
+// errors the compiler reports for generated code that does not build.
+func typeCheck(t *testing.T, files map[string]string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		f, err := parser.ParseFile(fset, name, files[name], 0)
+		if err != nil {
+			t.Fatalf("parsing %s: %v", name, err)
+		}
+		parsed = append(parsed, f)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check(parsed[0].Name.Name, fset, parsed, nil); err != nil {
+		t.Fatalf("generated code does not compile: %v", err)
+	}
+}
+
+func TestWriter_Order(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		order string
+		want  string
+	}{
+		{order: "", want: "\t\tPriorities.High,\n\t\tPriorities.Lowest,\n\t\tPriorities.Low,\n"},
+		{order: config.OrderValue, want: "\t\tPriorities.Lowest,\n\t\tPriorities.Low,\n\t\tPriorities.High,\n"},
+		{order: config.OrderName, want: "\t\tPriorities.High,\n\t\tPriorities.Low,\n\t\tPriorities.Lowest,\n"},
+	}
+	for _, tt := range tests {
+		t.Run("order="+tt.order, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "tasks",
+				Version:        "v0.0.0",
+				SourceFilename: "tasks.go",
+				OutputFilename: "tasks",
+				Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Order: tt.order}},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "priority",
+					UnderlyingType: "int",
+					Enums: []enum.Enum{
+						{Name: "high", Index: 10, Value: "10", Valid: true},
+						{Name: "lowest", Index: -1, Value: "-1", Valid: true},
+						{Name: "low", Index: 1, Value: "1", Valid: true},
+					},
+				}},
+			}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out, err := memfs.ReadFile("tasks_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			if !strings.Contains(string(out), "return []Priority{\n"+tt.want+"\t}") {
+				t.Errorf("expected allSlice to list the values as\n%s\ngot\n%s", tt.want, out)
+			}
+		})
+	}
+}
+
+func TestWriter_ContainerBounds(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "tasks",
+		Version:        "v0.0.0",
+		SourceFilename: "tasks.go",
+		OutputFilename: "tasks",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Order: config.OrderName}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "priority",
+			UnderlyingType: "int",
+			Enums: []enum.Enum{
+				{Name: "high", Index: 10, Value: "10", Valid: true},
+				{Name: "lowest", Index: -1, Value: "-1", Valid: true},
+				{Name: "low", Index: 1, Value: "1", Valid: true},
+				// Invalid values are neither counted nor bounds
+				{Name: "zero", Index: -5, Value: "-5"},
+				{Name: "unset", Index: 99, Value: "99"},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := memfs.ReadFile("tasks_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"func (p prioritiesContainer) Count() int {\n\treturn 3\n}",
+		"func (p prioritiesContainer) MinPriority() Priority {\n\treturn Priorities.Lowest\n}",
+		"func (p prioritiesContainer) MaxPriority() Priority {\n\treturn Priorities.High\n}",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_SliceParsingAndNames(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "orders",
+		Version:        "v0.0.0",
+		SourceFilename: "orders.go",
+		OutputFilename: "orders",
+		EnumIotas: []enum.EnumIota{{
+			Type:           "status",
+			UnderlyingType: "int",
+			Enums: []enum.Enum{
+				{Name: "unknown", Index: 0, Valid: false},
+				{Name: "pending", Index: 1, Valid: true},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := memfs.ReadFile("orders_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"func ParseStatusSlice(inputs []string) ([]Status, error) {",
+		"\t\tv, err := ParseStatus(input)\n",
+		"func StatusNames() []string {",
+		"\t\tif v.IsValid() {\n",
+		"\treturn enums.Names(values)\n",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriter_Redis(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		binary  bool
+		want    string
+		wantNot string
+	}{
+		{name: "text", want: "\treturn enums.MarshalText(s, s.status)\n"},
+		{name: "binary", binary: true, want: "\treturn enums.MarshalBinary(s, s.status)\n", wantNot: "\treturn enums.MarshalText(s, s.status)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			memfs := file.NewMemFS()
+			err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+				Package:        "orders",
+				Version:        "v0.0.0",
+				SourceFilename: "orders.go",
+				OutputFilename: "orders",
+				Configuration: config.Configuration{Defaults: config.EnumTypeConfig{
+					Handlers: config.Handlers{Redis: true, Binary: tt.binary},
+				}},
+				EnumIotas: []enum.EnumIota{{
+					Type:           "status",
+					UnderlyingType: "int",
+					Enums:          []enum.Enum{{Name: "pending", Index: 0, Valid: true}},
+				}},
+			}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := memfs.ReadFile("orders_enums.go")
+			if err != nil {
+				t.Fatalf("expected output to be written: %v", err)
+			}
+			out := string(b)
+			for _, want := range []string{
+				"func (s Status) RedisArg() any {\n\treturn enums.RedisArg(s, s.status)\n}",
+				"func (s *Status) RedisScan(src any) error {\n\tresult, err := enums.RedisScan(*s, src)\n",
+				tt.want,
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+			if n := strings.Count(out, ") MarshalBinary() ([]byte, error)"); n != 1 {
+				t.Errorf("expected one MarshalBinary method, got %d", n)
+			}
+			if tt.wantNot != "" && strings.Contains(out, tt.wantNot) {
+				t.Errorf("expected output not to contain %q", tt.wantNot)
+			}
+		})
+	}
+}
+
+func TestWriter_Kafka(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "orders",
+		Version:        "v0.0.0",
+		SourceFilename: "orders.go",
+		OutputFilename: "orders",
+		Configuration:  config.Configuration{Defaults: config.EnumTypeConfig{Handlers: config.Handlers{Kafka: true}}},
+		EnumIotas: []enum.EnumIota{{
+			Type:           "status",
+			UnderlyingType: "int",
+			Enums: []enum.Enum{
+				{Name: "unknown", Index: 0, Valid: false},
+				{Name: "pending", Index: 1, Valid: true},
+				{Name: "shipped", Index: 2, Valid: true},
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("orders_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"const StatusAvroSchema = ",
+		"const StatusJSONSchema = ",
+		"var statusKafkaSymbols = []string{\n\t\"pending\",\n\t\"shipped\",\n}",
+		"func StatusRegistrySchema(subject string, schemaType enums.SchemaType) enums.RegistrySchema {",
+		"func NewStatusKafkaSerde(schemaID int, schemaType enums.SchemaType) *enums.KafkaSerde[Status] {",
+		"\t\tFromName:   Status{}.FromName,\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(string(b), "MarshalText") {
+		t.Error("expected no text methods without -avro or -text")
+	}
+}
+
+func TestWriter_QuotedNames(t *testing.T) {
+	t.Parallel()
+	for _, stringer := range []string{"", "switch"} {
+		memfs := file.NewMemFS()
+		err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+			Package:        "http",
+			Version:        "v0.0.0",
+			SourceFilename: "status.go",
+			OutputFilename: "status",
+			Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+				"status": {Stringer: stringer},
+			}},
+			EnumIotas: []enum.EnumIota{{Type: "status", UnderlyingType: "int", Enums: []enum.Enum{
+				{Name: "notFound", Index: 0, Valid: true, Aliases: []string{"Not Found, Retryable", "nf"}},
+				{Name: "gone", Index: 1, Valid: true, Aliases: []string{`Gone "for good"`}},
+				{Name: "cafe", Index: 2, Valid: true, Aliases: []string{"Café ☕"}},
+			}}},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := memfs.ReadFile("status_enums.go")
+		if err != nil {
+			t.Fatalf("expected output to be written: %v", err)
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), "status_enums.go", b, 0); err != nil {
+			t.Fatalf("expected valid Go with -stringer=%s: %v", stringer, err)
+		}
+		wants := []string{`"Not Found, RetryableGone \"for good\"Café ☕"`}
+		if stringer == "switch" {
+			wants = []string{`"Not Found, Retryable"`, `"Gone \"for good\""`, `"Café ☕"`}
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(b), want) {
+				t.Errorf("expected output with -stringer=%s to contain %s", stringer, want)
+			}
+		}
+	}
+}
+
+func TestWriter_Aliases(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "orders",
+		Version:        "v0.0.0",
+		SourceFilename: "status.go",
+		OutputFilename: "status",
+		EnumIotas: []enum.EnumIota{
+			{Type: "status", UnderlyingType: "int", Enums: []enum.Enum{
+				{Name: "pending", Index: 0, Valid: true, Aliases: []string{"Pending", "waiting", "queued"}},
+				{Name: "shipped", Index: 1, Valid: true},
+			}},
+			{Type: "color", UnderlyingType: "int", Enums: []enum.Enum{
+				{Name: "red", Index: 0, Valid: true, Aliases: []string{"Red"}},
+			}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("status_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"var statusAliasesMap = map[Status][]string{\n",
+		"\tStatuses.Pending: {\"Pending\", \"waiting\", \"queued\"},\n",
+		"\tStatuses.Shipped: {\"shipped\"},\n",
+		"\t\tfor _, alias := range aliases[1:] {\n",
+		"func (s statusesContainer) AliasesOf(v Status) []string {\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(string(b), "colorAliasesMap") || strings.Contains(string(b), "colorsContainer) AliasesOf") {
+		t.Error("expected no aliases map for types without several aliases for a value")
+	}
+}
+
+func TestWriter_Insensitive(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	err := gofile.NewWriter(gofile.WithFileSystem(memfs)).Write(t.Context(), []enum.GenerationRequest{{
+		Package:        "accounts",
+		Version:        "v0.0.0",
+		SourceFilename: "state.go",
+		OutputFilename: "state",
+		Configuration:  config.Configuration{Insensitive: true},
+		EnumIotas: []enum.EnumIota{{Type: "state", UnderlyingType: "int", Enums: []enum.Enum{
+			{Name: "active", Index: 0, Valid: true, Aliases: []string{"ÄKTIV", "äktiv", "Enabled"}},
+			{Name: "closed", Index: 1, Valid: true, LegacyAliases: []string{"Removed"}},
+		}}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("state_enums.go")
+	if err != nil {
+		t.Fatalf("expected output to be written: %v", err)
+	}
+	for _, want := range []string{
+		"\t\"strings\"\n",
+		"\t{\"ÄKTIV\", States.Active},\n\t{\"closed\", States.Closed},\n\t{\"Enabled\", States.Active},\n\t{\"Removed\", States.Closed},\n}",
+		"\t\tif strings.EqualFold(n.name, name) {\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(string(b), "{\"äktiv\", States.Active}") {
+		t.Error("expected names only differing in case to be folded once")
+	}
+}
@@ -0,0 +1,182 @@
+// Package jsonschema exports enum types as JSON Schema documents.
+//
+// The Writer emits one "<enum>.schema.json" file per enum type, next to the
+// source it was parsed from, listing the values accepted by the generated
+// JSON handlers. API gateways and validators can consume these documents
+// instead of keeping their own copy of the value list in sync by hand.
+//
+// Schemas follow draft 2020-12. Value names and descriptions are also
+// recorded in the x-enum-varnames and x-enum-descriptions extensions
+// understood by most OpenAPI code generators.
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/internal/naming"
+	"github.com/donutnomad/goenums/strings"
+)
+
+var _ enum.Writer = &Writer{}
+
+// ErrWriteSchema is returned when a schema document cannot be written.
+var ErrWriteSchema = errors.New("error writing json schema")
+
+// Draft is the JSON Schema dialect of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the JSON Schema of a single enum type.
+// It is also a valid OpenAPI 3.1 schema object once Schema is cleared.
+type Schema struct {
+	Schema       string   `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+	Title        string   `json:"title" yaml:"title"`
+	Description  string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Type         string   `json:"type" yaml:"type"`
+	Enum         []any    `json:"enum" yaml:"enum"`
+	VarNames     []string `json:"x-enum-varnames" yaml:"x-enum-varnames"`
+	Descriptions []string `json:"x-enum-descriptions,omitempty" yaml:"x-enum-descriptions,omitempty"`
+}
+
+// FromEnum returns the schema of enumIota as serialized with cfg: the names
+// of its values when serialized by name, or their underlying values with
+// -serde/value. Invalid values are left out.
+func FromEnum(enumIota enum.EnumIota, cfg config.EnumTypeConfig) Schema {
+	s := Schema{
+		Schema:      Draft,
+		Title:       naming.TypeName(enumIota.Type),
+		Description: enumIota.Doc,
+		Type:        "string",
+		Enum:        []any{},
+		VarNames:    []string{},
+	}
+	numeric := cfg.SerializationType == config.SerdeValue && enumIota.UnderlyingType != "string"
+	if cfg.WrapperName != "" {
+		s.Title = cfg.WrapperName
+	}
+	if numeric {
+		s.Type = "integer"
+		if strings.HasPrefix(enumIota.UnderlyingType, "float") {
+			s.Type = "number"
+		}
+	}
+	var described bool
+	for _, e := range enumIota.Enums {
+		if !e.Valid || (len(enumIota.Fields) > 0 && len(e.Fields) == 0) {
+			continue
+		}
+		if numeric {
+			s.Enum = append(s.Enum, number(e))
+		} else {
+			s.Enum = append(s.Enum, wireName(e))
+		}
+		varName := strings.Camel(e.Name)
+		if cfg.UppercaseFields {
+			varName = strings.ToUpper(e.Name)
+		}
+		s.VarNames = append(s.VarNames, varName)
+		s.Descriptions = append(s.Descriptions, e.CustomComment)
+		described = described || e.CustomComment != ""
+	}
+	if !described {
+		s.Descriptions = nil
+	}
+	return s
+}
+
+// number returns the value e is serialized as: an int, or a uint64 or
+// float64 for the values an int cannot hold.
+func number(e enum.Enum) any {
+	lit := e.Literal()
+	if i, err := strconv.Atoi(lit); err == nil {
+		return i
+	}
+	if u, err := strconv.ParseUint(lit, 10, 64); err == nil {
+		return u
+	}
+	if f, err := strconv.ParseFloat(lit, 64); err == nil {
+		return f
+	}
+	return e.Index
+}
+
+// wireName returns the name e is serialized as.
+func wireName(e enum.Enum) string {
+	switch {
+	case e.SerdeName != "":
+		return e.SerdeName
+	case len(e.Aliases) > 0:
+		return e.Aliases[0]
+	default:
+		return e.Name
+	}
+}
+
+// Writer implements enum.Writer and writes a JSON Schema document for
+// every enum type in a request.
+type Writer struct {
+	Configuration config.Configuration
+	fs            file.ReadCreateWriteFileFS
+}
+
+// WriterOption is a function that configures a Writer.
+type WriterOption func(*Writer)
+
+// WithFileSystem sets the filesystem to use for writing files.
+func WithFileSystem(fs file.ReadCreateWriteFileFS) WriterOption {
+	return func(w *Writer) {
+		w.fs = fs
+	}
+}
+
+// WithWriterConfiguration sets the configuration for the writer.
+func WithWriterConfiguration(configuration config.Configuration) WriterOption {
+	return func(w *Writer) {
+		w.Configuration = configuration
+	}
+}
+
+// NewWriter creates a new JSON Schema writer, writing to the operating
+// system filesystem by default.
+func NewWriter(opts ...WriterOption) *Writer {
+	w := Writer{
+		Configuration: config.Configuration{},
+		fs:            &file.OSReadWriteFileFS{},
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return &w
+}
+
+// Write emits "<enum>.schema.json" for each enum type of the requests,
+// in the directory of the source it was parsed from.
+func (w *Writer) Write(ctx context.Context, reqs []enum.GenerationRequest) error {
+	for _, req := range reqs {
+		if !req.IsValid() {
+			return fmt.Errorf("invalid enum: %s", req.SourceFilename)
+		}
+		for _, enumIota := range req.GetEnumIotas() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s := FromEnum(enumIota, req.Configuration.GetEnumTypeConfig(enumIota.Type))
+			b, err := json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrWriteSchema, enumIota.Type, err)
+			}
+			path := filepath.Join(filepath.Dir(req.SourceFilename), strings.Snake(s.Title)+".schema.json")
+			if err := w.fs.WriteFile(path, append(b, '\n'), file.DefaultFilePerms); err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrWriteSchema, path, err)
+			}
+		}
+	}
+	return nil
+}
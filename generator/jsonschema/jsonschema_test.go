@@ -0,0 +1,145 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/generator/jsonschema"
+	"github.com/donutnomad/goenums/source"
+)
+
+const statusGo = `package orders
+
+// status is the lifecycle of an order.
+//
+// goenums: -json
+type status int
+
+const (
+	unknown status = iota // invalid
+	// Waiting for payment
+	pending // Pending
+	shipped // Shipped
+)
+`
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	if err := memfs.WriteFile("orders/status.go", []byte(statusGo), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reqs, err := gofile.NewParser(gofile.WithSource(source.FromFileSystem(memfs, "orders/status.go"))).Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := jsonschema.NewWriter(jsonschema.WithFileSystem(memfs)).Write(t.Context(), reqs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("orders/status.schema.json")
+	if err != nil {
+		t.Fatalf("expected status.schema.json to be written: %v", err)
+	}
+	var got jsonschema.Schema
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+	want := jsonschema.Schema{
+		Schema:       jsonschema.Draft,
+		Title:        "Status",
+		Description:  "status is the lifecycle of an order.",
+		Type:         "string",
+		Enum:         []any{"Pending", "Shipped"},
+		VarNames:     []string{"Pending", "Shipped"},
+		Descriptions: []string{"Waiting for payment", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected schema\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestFromEnum(t *testing.T) {
+	t.Parallel()
+	enumIota := enum.EnumIota{
+		Type:           "levels",
+		UnderlyingType: "uint8",
+		Enums: []enum.Enum{
+			{Name: "low", Index: 1, Valid: true, SerdeName: "LOW"},
+			{Name: "high", Index: 5, Valid: true},
+		},
+	}
+	tests := []struct {
+		name      string
+		cfg       config.EnumTypeConfig
+		wantType  string
+		wantEnum  []any
+		wantNames []string
+	}{
+		{
+			name:      "by name",
+			wantType:  "string",
+			wantEnum:  []any{"LOW", "high"},
+			wantNames: []string{"Low", "High"},
+		},
+		{
+			name:      "by value",
+			cfg:       config.EnumTypeConfig{SerializationType: config.SerdeValue, UppercaseFields: true},
+			wantType:  "integer",
+			wantEnum:  []any{1, 5},
+			wantNames: []string{"LOW", "HIGH"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := jsonschema.FromEnum(enumIota, tt.cfg)
+			if s.Title != "Level" || s.Type != tt.wantType || s.Descriptions != nil {
+				t.Errorf("unexpected schema %+v", s)
+			}
+			if !reflect.DeepEqual(s.Enum, tt.wantEnum) || !reflect.DeepEqual(s.VarNames, tt.wantNames) {
+				t.Errorf("expected values %v named %v, got %v named %v", tt.wantEnum, tt.wantNames, s.Enum, s.VarNames)
+			}
+		})
+	}
+}
+
+func TestFromEnum_Values(t *testing.T) {
+	t.Parallel()
+	cfg := config.EnumTypeConfig{SerializationType: config.SerdeValue}
+	tests := []struct {
+		name           string
+		underlyingType string
+		enums          []enum.Enum
+		want           []any
+	}{
+		{
+			name:           "uint64",
+			underlyingType: "uint64",
+			enums: []enum.Enum{
+				{Name: "low", Index: 1, Value: "1", Valid: true},
+				{Name: "high", Value: "18446744073709551615", Valid: true},
+			},
+			want: []any{1, uint64(18446744073709551615)},
+		},
+		{
+			name:           "float64",
+			underlyingType: "float64",
+			enums:          []enum.Enum{{Name: "half", Value: "0.5", Valid: true}},
+			want:           []any{0.5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := jsonschema.FromEnum(enum.EnumIota{Type: "level", UnderlyingType: tt.underlyingType, Enums: tt.enums}, cfg)
+			if !reflect.DeepEqual(s.Enum, tt.want) {
+				t.Errorf("expected values %v, got %v", tt.want, s.Enum)
+			}
+		})
+	}
+}
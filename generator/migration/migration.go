@@ -0,0 +1,307 @@
+// Package migration generates incremental database migrations for enum types.
+//
+// Every time the enum set of a type changes, the Writer emits a timestamped
+// migration with up and down steps that update the CHECK constraint (or
+// PostgreSQL native enum type) guarding the column that stores the enum.
+// The set of values the database currently knows about is tracked in a
+// snapshot file kept alongside the migrations, so unchanged enums produce
+// no new migrations.
+//
+// Two layouts are supported:
+//   - golang-migrate: paired {version}_{name}.up.sql / .down.sql files
+//   - Liquibase: one XML changelog per change containing a rollback block
+package migration
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/strings"
+)
+
+var _ enum.Writer = &Writer{}
+
+var (
+	// ErrWriteMigration is returned when a migration or snapshot cannot be written.
+	ErrWriteMigration = errors.New("error writing migration")
+	// ErrUnknownMigrationFormat is returned when the configured layout is not supported.
+	ErrUnknownMigrationFormat = errors.New("unknown migration format")
+)
+
+// versionLayout is the timestamp layout used as the migration version prefix.
+const versionLayout = "20060102150405"
+
+// Writer implements enum.Writer and writes incremental migrations for
+// every enum type whose set of values differs from its stored snapshot.
+type Writer struct {
+	Configuration config.Configuration
+	fs            file.ReadCreateWriteFileFS
+	now           func() time.Time
+}
+
+// WriterOption is a function that configures a Writer.
+type WriterOption func(*Writer)
+
+// WithFileSystem sets the filesystem migrations and snapshots are written to.
+func WithFileSystem(fs file.ReadCreateWriteFileFS) WriterOption {
+	return func(w *Writer) {
+		w.fs = fs
+	}
+}
+
+// WithWriterConfiguration sets the configuration for the writer.
+func WithWriterConfiguration(configuration config.Configuration) WriterOption {
+	return func(w *Writer) {
+		w.Configuration = configuration
+	}
+}
+
+// WithClock sets the function used to timestamp migration versions.
+func WithClock(now func() time.Time) WriterOption {
+	return func(w *Writer) {
+		w.now = now
+	}
+}
+
+// NewWriter creates a new migration writer. By default it writes to the
+// operating system filesystem and timestamps migrations with the current UTC time.
+func NewWriter(opts ...WriterOption) *Writer {
+	w := Writer{
+		Configuration: config.Configuration{},
+		fs:            &file.OSReadWriteFileFS{},
+		now:           func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return &w
+}
+
+// Write emits a migration for each enum type in the requests whose values
+// changed since the last run and refreshes the snapshot for that type.
+func (w *Writer) Write(ctx context.Context, reqs []enum.GenerationRequest) error {
+	format := w.Configuration.MigrationFormat
+	if format == "" {
+		format = config.MigrationFormatGolangMigrate
+	}
+	if format != config.MigrationFormatGolangMigrate && format != config.MigrationFormatLiquibase {
+		return fmt.Errorf("%w: %s", ErrUnknownMigrationFormat, format)
+	}
+	version := w.now().Format(versionLayout)
+	for _, req := range reqs {
+		for _, enumIota := range req.GetEnumIotas() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			cfg := req.Configuration.GetEnumTypeConfig(enumIota.Type)
+			if err := w.writeEnumMigration(format, version, enumIota, cfg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *Writer) writeEnumMigration(format, version string, enumIota enum.EnumIota, cfg config.EnumTypeConfig) error {
+	c := newChange(enumIota, cfg)
+	snapshotPath := filepath.Join(w.Configuration.MigrationsDir, "goenums_"+c.Name+".snapshot")
+	previous, err := w.readSnapshot(snapshotPath)
+	if err != nil {
+		return err
+	}
+	if previous != nil && slices.Equal(previous, c.Values) {
+		return nil
+	}
+	c.Previous = previous
+	up, down := c.upSQL(), c.downSQL()
+	base := filepath.Join(w.Configuration.MigrationsDir, version+"_"+c.Name+"_enum")
+	switch format {
+	case config.MigrationFormatLiquibase:
+		var b bytes.Buffer
+		if err := liquibaseTemplate.Execute(&b, liquibaseData{
+			ID:   version + "-" + c.Name,
+			Up:   up,
+			Down: down,
+		}); err != nil {
+			return fmt.Errorf("%w: %w", ErrWriteMigration, err)
+		}
+		if err := w.writeFile(base+".xml", b.Bytes()); err != nil {
+			return err
+		}
+	default:
+		if err := w.writeFile(base+".up.sql", []byte(up)); err != nil {
+			return err
+		}
+		if err := w.writeFile(base+".down.sql", []byte(down)); err != nil {
+			return err
+		}
+	}
+	return w.writeFile(snapshotPath, []byte(strings.Join(c.Values, "\n")+"\n"))
+}
+
+func (w *Writer) readSnapshot(path string) ([]string, error) {
+	b, err := w.fs.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrWriteMigration, path, err)
+	}
+	values := []string{}
+	for _, line := range strings.Split(string(b), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			values = append(values, line)
+		}
+	}
+	return values, nil
+}
+
+func (w *Writer) writeFile(path string, data []byte) error {
+	if err := w.fs.WriteFile(path, data, file.DefaultFilePerms); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrWriteMigration, path, err)
+	}
+	return nil
+}
+
+// change describes the transition of one enum type from its previous
+// snapshot to its current set of serialized values.
+type change struct {
+	Name     string
+	Table    string
+	Column   string
+	Style    config.MigrationStyle
+	Numeric  bool
+	Values   []string
+	Previous []string
+}
+
+// Column returns the table and column storing the enum type typeName:
+// the MigrationTable and MigrationColumn of cfg, defaulting to the
+// pluralised and singular snake_case forms of the type name.
+func Column(typeName string, cfg config.EnumTypeConfig) (table, column string) {
+	name := strings.Snake(typeName)
+	table, column = cfg.MigrationTable, cfg.MigrationColumn
+	if table == "" {
+		table = strings.Pluralise(name)
+	}
+	if column == "" {
+		column = name
+	}
+	return table, column
+}
+
+func newChange(enumIota enum.EnumIota, cfg config.EnumTypeConfig) change {
+	c := change{
+		Name:    strings.Snake(enumIota.Type),
+		Style:   cfg.MigrationStyle,
+		Numeric: cfg.SerializationType == config.SerdeValue,
+	}
+	c.Table, c.Column = Column(enumIota.Type, cfg)
+	for _, e := range enumIota.Enums {
+		if !e.Valid {
+			continue
+		}
+		switch {
+		case c.Numeric:
+			c.Values = append(c.Values, e.Literal())
+		case len(e.Aliases) > 0:
+			c.Values = append(c.Values, e.Aliases[0])
+		default:
+			c.Values = append(c.Values, e.Name)
+		}
+	}
+	return c
+}
+
+func (c change) constraintName() string {
+	return "chk_" + c.Table + "_" + c.Column
+}
+
+func (c change) literals(values []string) string {
+	lits := make([]string, len(values))
+	for i, v := range values {
+		if c.Numeric && c.Style == config.MigrationCheck {
+			lits[i] = v
+			continue
+		}
+		lits[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return strings.Join(lits, ", ")
+}
+
+func (c change) checkSQL(values []string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;\nALTER TABLE %s ADD CONSTRAINT %s CHECK (%s IN (%s));\n",
+		c.Table, c.constraintName(), c.Table, c.constraintName(), c.Column, c.literals(values))
+}
+
+func (c change) upSQL() string {
+	if c.Style == config.MigrationNativeEnum {
+		if c.Previous == nil {
+			return fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);\n", c.Name, c.literals(c.Values))
+		}
+		var b bytes.Buffer
+		for _, v := range c.Values {
+			if !slices.Contains(c.Previous, v) {
+				fmt.Fprintf(&b, "ALTER TYPE %s ADD VALUE IF NOT EXISTS %s;\n", c.Name, c.literals([]string{v}))
+			}
+		}
+		for _, v := range c.Previous {
+			if !slices.Contains(c.Values, v) {
+				fmt.Fprintf(&b, "-- value %s was removed but PostgreSQL cannot drop enum values in place\n", c.literals([]string{v}))
+			}
+		}
+		return b.String()
+	}
+	return c.checkSQL(c.Values)
+}
+
+func (c change) downSQL() string {
+	if c.Style == config.MigrationNativeEnum {
+		if c.Previous == nil {
+			return fmt.Sprintf("DROP TYPE IF EXISTS %s;\n", c.Name)
+		}
+		return "-- PostgreSQL cannot drop enum values in place; added values are kept\n"
+	}
+	if c.Previous == nil {
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;\n", c.Table, c.constraintName())
+	}
+	return c.checkSQL(c.Previous)
+}
+
+type liquibaseData struct {
+	ID   string
+	Up   string
+	Down string
+}
+
+var liquibaseTemplate = template.Must(template.New("liquibase").Funcs(template.FuncMap{
+	"xml": func(s string) (string, error) {
+		var b bytes.Buffer
+		err := xml.EscapeText(&b, []byte(s))
+		return b.String(), err
+	},
+}).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!-- DO NOT EDIT. generated by goenums -->
+<databaseChangeLog
+    xmlns="http://www.liquibase.org/xml/ns/dbchangelog"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xsi:schemaLocation="http://www.liquibase.org/xml/ns/dbchangelog http://www.liquibase.org/xml/ns/dbchangelog/dbchangelog-latest.xsd">
+    <changeSet id="{{ .ID }}" author="goenums">
+        <sql>{{ xml .Up }}</sql>
+        <rollback>
+            <sql>{{ xml .Down }}</sql>
+        </rollback>
+    </changeSet>
+</databaseChangeLog>
+`))
@@ -0,0 +1,140 @@
+package migration_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/migration"
+)
+
+func orderStatusRequest(cfg config.Configuration, names ...string) []enum.GenerationRequest {
+	enums := make([]enum.Enum, len(names))
+	for i, n := range names {
+		enums[i] = enum.Enum{Name: n, Index: i, Valid: true}
+	}
+	return []enum.GenerationRequest{{
+		Package:        "orders",
+		Version:        "v0.0.0",
+		SourceFilename: "orders.go",
+		Configuration:  cfg,
+		EnumIotas:      []enum.EnumIota{{Type: "orderStatus", UnderlyingType: "int", Enums: enums}},
+	}}
+}
+
+func fixedClock(ts string) func() time.Time {
+	return func() time.Time {
+		t, _ := time.Parse("20060102150405", ts)
+		return t
+	}
+}
+
+func readFile(t *testing.T, fs *file.MemFS, name string) string {
+	t.Helper()
+	b, err := fs.ReadFile(name)
+	if err != nil {
+		t.Fatalf("expected %s to be written: %v", name, err)
+	}
+	return string(b)
+}
+
+func TestWriter_GolangMigrate(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	cfg := config.Configuration{MigrationsDir: "migrations"}
+
+	w := migration.NewWriter(
+		migration.WithWriterConfiguration(cfg),
+		migration.WithFileSystem(memfs),
+		migration.WithClock(fixedClock("20250101000000")))
+	if err := w.Write(t.Context(), orderStatusRequest(cfg, "Pending", "Shipped")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	up := readFile(t, memfs, "migrations/20250101000000_order_status_enum.up.sql")
+	if !strings.Contains(up, "CHECK (order_status IN ('Pending', 'Shipped'))") {
+		t.Errorf("unexpected up migration:\n%s", up)
+	}
+	down := readFile(t, memfs, "migrations/20250101000000_order_status_enum.down.sql")
+	if !strings.Contains(down, "DROP CONSTRAINT IF EXISTS chk_order_statuses_order_status") {
+		t.Errorf("unexpected down migration:\n%s", down)
+	}
+
+	// unchanged enums produce no new migration
+	w = migration.NewWriter(
+		migration.WithWriterConfiguration(cfg),
+		migration.WithFileSystem(memfs),
+		migration.WithClock(fixedClock("20250102000000")))
+	if err := w.Write(t.Context(), orderStatusRequest(cfg, "Pending", "Shipped")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := memfs.Stat("migrations/20250102000000_order_status_enum.up.sql"); err == nil {
+		t.Error("expected no migration for unchanged enum")
+	}
+
+	// added values produce an incremental migration that can be rolled back
+	w = migration.NewWriter(
+		migration.WithWriterConfiguration(cfg),
+		migration.WithFileSystem(memfs),
+		migration.WithClock(fixedClock("20250103000000")))
+	if err := w.Write(t.Context(), orderStatusRequest(cfg, "Pending", "Shipped", "Delivered")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	up = readFile(t, memfs, "migrations/20250103000000_order_status_enum.up.sql")
+	if !strings.Contains(up, "('Pending', 'Shipped', 'Delivered')") {
+		t.Errorf("unexpected up migration:\n%s", up)
+	}
+	down = readFile(t, memfs, "migrations/20250103000000_order_status_enum.down.sql")
+	if !strings.Contains(down, "('Pending', 'Shipped'))") {
+		t.Errorf("unexpected down migration:\n%s", down)
+	}
+}
+
+func TestWriter_LiquibaseNativeEnum(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	cfg := config.Configuration{
+		MigrationsDir:   "changelog",
+		MigrationFormat: config.MigrationFormatLiquibase,
+		EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"orderStatus": {TypeName: "orderStatus", MigrationStyle: config.MigrationNativeEnum},
+		},
+	}
+	w := migration.NewWriter(
+		migration.WithWriterConfiguration(cfg),
+		migration.WithFileSystem(memfs),
+		migration.WithClock(fixedClock("20250101000000")))
+	if err := w.Write(t.Context(), orderStatusRequest(cfg, "Pending")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w = migration.NewWriter(
+		migration.WithWriterConfiguration(cfg),
+		migration.WithFileSystem(memfs),
+		migration.WithClock(fixedClock("20250102000000")))
+	if err := w.Write(t.Context(), orderStatusRequest(cfg, "Pending", "Shipped")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	initial := readFile(t, memfs, "changelog/20250101000000_order_status_enum.xml")
+	if !strings.Contains(initial, "CREATE TYPE order_status AS ENUM (&#39;Pending&#39;);") {
+		t.Errorf("unexpected initial changelog:\n%s", initial)
+	}
+	added := readFile(t, memfs, "changelog/20250102000000_order_status_enum.xml")
+	if !strings.Contains(added, "ALTER TYPE order_status ADD VALUE IF NOT EXISTS &#39;Shipped&#39;;") {
+		t.Errorf("unexpected incremental changelog:\n%s", added)
+	}
+}
+
+func TestWriter_UnknownFormat(t *testing.T) {
+	t.Parallel()
+	cfg := config.Configuration{MigrationsDir: "migrations", MigrationFormat: "flyway"}
+	w := migration.NewWriter(
+		migration.WithWriterConfiguration(cfg),
+		migration.WithFileSystem(file.NewMemFS()))
+	err := w.Write(t.Context(), orderStatusRequest(cfg, "Pending"))
+	if !errors.Is(err, migration.ErrUnknownMigrationFormat) {
+		t.Errorf("expected ErrUnknownMigrationFormat, got %v", err)
+	}
+}
@@ -0,0 +1,322 @@
+// Package openapi imports enum schemas from OpenAPI 3 documents, and
+// exports enum types as reusable OpenAPI schemas.
+//
+// Every schema under components.schemas declaring an enum, including those
+// nested in properties and array items, becomes an enum type:
+//
+//	components:
+//	  schemas:
+//	    Order:
+//	      properties:
+//	        status:
+//	          title: Order Status
+//	          type: string
+//	          enum: [placed, in-transit, delivered]
+//	          x-enum-varnames: [placed, inTransit, delivered]
+//
+// The schema's title names the type ("orderStatus"), falling back to the
+// component name, or the parent and property names for untitled nested
+// schemas. Values are named by x-enum-varnames when present and derived from
+// the values otherwise; x-enum-descriptions document them.
+//
+// String enums are numbered in declaration order and serialized by their
+// values; integer enums keep their values and are serialized by them. Both
+// implement JSON marshaling, as the wire format the document describes.
+// The Parser produces the same enum.GenerationRequest as the Go source
+// parser, and the const block is written by the spec package's Writer.
+//
+// The Writer goes the other way: it declares the enum types of any source
+// under components.schemas of a standalone document, so API documentation
+// can reference the values and descriptions defined in Go.
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/internal/naming"
+	"github.com/donutnomad/goenums/internal/version"
+	"github.com/donutnomad/goenums/source"
+	gostrings "github.com/donutnomad/goenums/strings"
+)
+
+// Compile-time check that Parser implements enum.Parser
+var _ enum.Parser = (*Parser)(nil)
+
+var (
+	// ErrReadDocument indicates an error occurred while reading the document.
+	ErrReadDocument = errors.New("failed to read OpenAPI document")
+	// ErrParseDocument indicates the document is not an OpenAPI 3 document
+	// or declares enums that cannot be generated.
+	ErrParseDocument = errors.New("failed to parse OpenAPI document")
+)
+
+// document is the part of an OpenAPI 3 document enums are read from.
+// JSON documents are read as YAML, of which JSON is a subset.
+type document struct {
+	OpenAPI    string `yaml:"openapi"`
+	Components struct {
+		Schemas map[string]*schema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+type schema struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	// Type is a string, or a list of strings since OpenAPI 3.1
+	Type         yaml.Node          `yaml:"type"`
+	Enum         []yaml.Node        `yaml:"enum"`
+	VarNames     []string           `yaml:"x-enum-varnames"`
+	Descriptions []string           `yaml:"x-enum-descriptions"`
+	Properties   map[string]*schema `yaml:"properties"`
+	Items        *schema            `yaml:"items"`
+}
+
+// types returns the types the schema declares.
+func (s *schema) types() []string {
+	var types []string
+	switch s.Type.Kind {
+	case yaml.ScalarNode:
+		types = []string{s.Type.Value}
+	case yaml.SequenceNode:
+		for _, n := range s.Type.Content {
+			types = append(types, n.Value)
+		}
+	}
+	return types
+}
+
+// IsDocument reports whether the source holds an OpenAPI document rather
+// than a goenums spec, by looking for its top-level "openapi" field.
+func IsDocument(src enum.Source) bool {
+	content, err := src.Content()
+	if err != nil {
+		return false
+	}
+	var doc struct {
+		OpenAPI string `yaml:"openapi"`
+	}
+	return yaml.Unmarshal(content, &doc) == nil && doc.OpenAPI != ""
+}
+
+// Parser implements the enum.Parser interface for OpenAPI 3 documents.
+type Parser struct {
+	Configuration config.Configuration
+	source        enum.Source
+	packageName   string
+}
+
+// ParserOption is a function that configures a Parser.
+type ParserOption func(*Parser)
+
+// WithSource sets the source for the parser.
+func WithSource(source enum.Source) ParserOption {
+	return func(p *Parser) {
+		p.source = source
+	}
+}
+
+// WithParserConfiguration sets the configuration for the parser.
+func WithParserConfiguration(configuration config.Configuration) ParserOption {
+	return func(p *Parser) {
+		p.Configuration = configuration
+	}
+}
+
+// WithPackage sets the name of the package the enums are generated into.
+// By default it is the package of the Go files next to the document, or
+// the name of its directory.
+func WithPackage(name string) ParserOption {
+	return func(p *Parser) {
+		p.packageName = name
+	}
+}
+
+// NewParser creates a new OpenAPI parser with the specified configuration and source.
+func NewParser(opts ...ParserOption) *Parser {
+	p := Parser{
+		Configuration: config.Configuration{},
+		source:        source.FromFile(""),
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return &p
+}
+
+// Parse reads the document and returns a single generation request holding
+// an enum type for every enum schema.
+func (p *Parser) Parse(ctx context.Context) ([]enum.GenerationRequest, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	content, err := p.source.Content()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadDocument, err)
+	}
+	filename := p.source.Filename()
+	slog.Default().DebugContext(ctx, "parsing OpenAPI document", "filename", filename)
+	var doc document
+	if err := yaml.NewDecoder(bytes.NewReader(content)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrParseDocument, filename, err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%w: %s: unsupported OpenAPI version %q", ErrParseDocument, filename, doc.OpenAPI)
+	}
+	packageName := p.packageName
+	if packageName == "" {
+		packageName = naming.DirectoryPackage(filename)
+	}
+	if !token.IsIdentifier(packageName) {
+		return nil, fmt.Errorf("%w: invalid package name %q", ErrParseDocument, packageName)
+	}
+
+	var enumIotas []enum.EnumIota
+	for _, name := range slices.Sorted(maps.Keys(doc.Components.Schemas)) {
+		var err error
+		enumIotas, err = collectEnums(enumIotas, naming.Identifier(name), doc.Components.Schemas[name])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParseDocument, err)
+		}
+	}
+	if len(enumIotas) == 0 {
+		return nil, fmt.Errorf("%w: %w", ErrParseDocument, enum.ErrNoEnumsFound)
+	}
+	naming.PrefixCollidingNames(enumIotas)
+
+	cfg := p.Configuration
+	cfg.EnumTypeConfigs = make(map[string]config.EnumTypeConfig, len(enumIotas))
+	maps.Copy(cfg.EnumTypeConfigs, p.Configuration.EnumTypeConfigs)
+	for _, enumIota := range enumIotas {
+		typeConfig := p.Configuration.Defaults
+		typeConfig.TypeName = enumIota.Type
+		typeConfig.Handlers.JSON = true
+		// String values are the aliases of their enums, integer enums have none
+		typeConfig.SerializationType = config.SerdeName
+		if len(enumIota.Enums[0].Aliases) == 0 {
+			typeConfig.SerializationType = config.SerdeValue
+		}
+		cfg.EnumTypeConfigs[enumIota.Type] = typeConfig
+	}
+
+	base := filepath.Base(filename)
+	if i := strings.Index(base, "."); i > 0 {
+		base = base[:i]
+	}
+	request := enum.GenerationRequest{
+		Package:         packageName,
+		BuildConstraint: strings.Join(p.Configuration.BuildTags, " && "),
+		EnumIotas:       enumIotas,
+		Version:         version.CURRENT,
+		SourceFilename:  filename,
+		OutputFilename:  strings.ToLower(base),
+		Configuration:   cfg,
+	}
+	if len(enumIotas) == 1 {
+		request.EnumIota = enumIotas[0]
+	}
+	return []enum.GenerationRequest{request}, nil
+}
+
+// collectEnums appends the enum types declared by s and the schemas nested
+// in it, where name is the type name used when s has no title.
+func collectEnums(enumIotas []enum.EnumIota, name string, s *schema) ([]enum.EnumIota, error) {
+	if s == nil {
+		return enumIotas, nil
+	}
+	if len(s.Enum) > 0 {
+		if s.Title != "" {
+			name = naming.Identifier(s.Title)
+		}
+		enumIota, err := newEnumIota(name, s)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(enumIotas, func(e enum.EnumIota) bool { return e.Type == enumIota.Type }) {
+			return nil, fmt.Errorf("duplicate enum type %s, give one of the schemas a title", enumIota.Type)
+		}
+		enumIotas = append(enumIotas, enumIota)
+	}
+	for _, property := range slices.Sorted(maps.Keys(s.Properties)) {
+		var err error
+		enumIotas, err = collectEnums(enumIotas, name+gostrings.Camel(naming.Identifier(property)), s.Properties[property])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return collectEnums(enumIotas, name, s.Items)
+}
+
+// newEnumIota converts an enum schema into its enum representation. String
+// values become the aliases of enums numbered in declaration order.
+func newEnumIota(name string, s *schema) (enum.EnumIota, error) {
+	enumIota := enum.EnumIota{
+		Type:           gostrings.Lower1stCharacter(name),
+		UnderlyingType: "int",
+		Doc:            s.Description,
+		Opener:         " ",
+		Closer:         " ",
+	}
+	if !token.IsIdentifier(enumIota.Type) {
+		return enumIota, fmt.Errorf("cannot derive a type name from %q", name)
+	}
+	types := s.types()
+	isString := slices.Contains(types, "string")
+	if !isString && !slices.Contains(types, "integer") {
+		return enumIota, fmt.Errorf("%s: %w: enum of type %v", enumIota.Type, enum.ErrUnsupportedType, types)
+	}
+	if len(s.VarNames) > 0 && len(s.VarNames) != len(s.Enum) {
+		return enumIota, fmt.Errorf("%s: x-enum-varnames has %d names for %d values",
+			enumIota.Type, len(s.VarNames), len(s.Enum))
+	}
+
+	for i, node := range s.Enum {
+		if node.Tag == "!!null" {
+			continue
+		}
+		e := enum.Enum{Index: i, Valid: true}
+		if isString {
+			e.Aliases = []string{node.Value}
+		} else {
+			value, err := strconv.Atoi(node.Value)
+			if err != nil {
+				return enumIota, fmt.Errorf("%s: %w: %q is not an integer", enumIota.Type, enum.ErrParseValue, node.Value)
+			}
+			e.Index = value
+		}
+		switch {
+		case len(s.VarNames) > 0:
+			e.Name = s.VarNames[i]
+		case naming.Identifier(node.Value) != "" && !unicode.IsDigit(rune(naming.Identifier(node.Value)[0])):
+			e.Name = gostrings.Lower1stCharacter(naming.Identifier(node.Value))
+		default:
+			e.Name = enumIota.Type + naming.Identifier(node.Value)
+		}
+		if !token.IsIdentifier(e.Name) {
+			return enumIota, fmt.Errorf("%s: invalid value name %q", enumIota.Type, e.Name)
+		}
+		if i < len(s.Descriptions) {
+			e.CustomComment = s.Descriptions[i]
+		}
+		enumIota.Enums = append(enumIota.Enums, e)
+	}
+	if len(enumIota.Enums) == 0 {
+		return enumIota, fmt.Errorf("%s: %w", enumIota.Type, enum.ErrNoEnumsFound)
+	}
+	enumIota.StartIndex = enumIota.Enums[0].Index
+	return enumIota, nil
+}
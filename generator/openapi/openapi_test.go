@@ -0,0 +1,217 @@
+package openapi_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/openapi"
+	"github.com/donutnomad/goenums/source"
+)
+
+const petstoreYAML = `openapi: 3.0.3
+info: {title: Pets, version: "1"}
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        status:
+          title: Order Status
+          type: string
+          enum: [placed, in-transit, null]
+          x-enum-descriptions: [Order placed, On the way]
+        priority:
+          type: integer
+          enum: [1, 5]
+          x-enum-varnames: [low, high]
+    Size:
+      type: [string, "null"]
+      enum: [low, big]
+`
+
+const petstoreJSON = `{
+	"openapi": "3.1.0",
+	"components": {
+		"schemas": {
+			"Order": {
+				"type": "object",
+				"properties": {
+					"status": {"title": "Order Status", "type": "string", "enum": ["placed", "in-transit"]},
+					"tags": {"type": "array", "items": {"type": "string", "enum": ["new", "7up"]}}
+				}
+			}
+		}
+	}
+}`
+
+func parse(t *testing.T, filename, content string) ([]enum.GenerationRequest, error) {
+	t.Helper()
+	memfs := file.NewMemFS()
+	if err := memfs.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	src := source.FromFileSystem(memfs, filename)
+	if !openapi.IsDocument(src) {
+		t.Fatalf("expected %s to be recognised as an OpenAPI document", filename)
+	}
+	return openapi.NewParser(
+		openapi.WithSource(src),
+		openapi.WithPackage("petstore")).Parse(t.Context())
+}
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+	reqs, err := parse(t, "petstore.yaml", petstoreYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req := reqs[0]
+	if req.Package != "petstore" || req.OutputFilename != "petstore" {
+		t.Errorf("unexpected package %q or output filename %q", req.Package, req.OutputFilename)
+	}
+	enumIotas := req.GetEnumIotas()
+	types := make(map[string]enum.EnumIota)
+	for _, e := range enumIotas {
+		types[e.Type] = e
+	}
+	if len(types) != 3 {
+		t.Fatalf("expected 3 enum types, got %v", enumIotas)
+	}
+
+	status := types["orderStatus"]
+	if len(status.Enums) != 2 || status.Enums[1].Name != "inTransit" || status.Enums[1].Aliases[0] != "in-transit" ||
+		status.Enums[1].Index != 1 || status.Enums[1].CustomComment != "On the way" {
+		t.Errorf("unexpected string enum %+v", status.Enums)
+	}
+	if cfg := req.Configuration.GetEnumTypeConfig("orderStatus"); !cfg.Handlers.JSON || cfg.SerializationType != config.SerdeName {
+		t.Errorf("expected string enums to be serialized by name, got %+v", cfg)
+	}
+
+	// "low" is also a value of size, so both types prefix their values
+	priority := types["orderPriority"]
+	if priority.Enums[1].Name != "orderPriorityHigh" || priority.Enums[1].Index != 5 || len(priority.Enums[1].Aliases) != 0 {
+		t.Errorf("unexpected integer enum %+v", priority.Enums)
+	}
+	if cfg := req.Configuration.GetEnumTypeConfig("orderPriority"); cfg.SerializationType != config.SerdeValue {
+		t.Errorf("expected integer enums to be serialized by value, got %+v", cfg)
+	}
+	if size := types["size"]; size.Enums[0].Name != "sizeLow" {
+		t.Errorf("expected colliding names to be prefixed, got %+v", size.Enums)
+	}
+}
+
+func TestParser_ParseJSON(t *testing.T) {
+	t.Parallel()
+	reqs, err := parse(t, "petstore.json", petstoreJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	enumIotas := reqs[0].GetEnumIotas()
+	if len(enumIotas) != 2 || enumIotas[0].Type != "orderStatus" || enumIotas[1].Type != "orderTags" {
+		t.Fatalf("unexpected enum types %+v", enumIotas)
+	}
+	if name := enumIotas[1].Enums[1].Name; name != "orderTags7up" {
+		t.Errorf("expected values starting with a digit to be prefixed, got %s", name)
+	}
+}
+
+func TestParser_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{
+			name:    "swagger 2",
+			content: "openapi: 2.0\n",
+		},
+		{
+			name:    "no enums",
+			content: "openapi: 3.0.0\ncomponents:\n  schemas:\n    Pet: {type: object}\n",
+			wantErr: enum.ErrNoEnumsFound,
+		},
+		{
+			name:    "number enum",
+			content: "openapi: 3.0.0\ncomponents:\n  schemas:\n    Ratio: {type: number, enum: [0.5]}\n",
+			wantErr: enum.ErrUnsupportedType,
+		},
+		{
+			name:    "varnames mismatch",
+			content: "openapi: 3.0.0\ncomponents:\n  schemas:\n    Size: {type: string, enum: [s, m], x-enum-varnames: [small]}\n",
+		},
+		{
+			name: "duplicate title",
+			content: "openapi: 3.0.0\ncomponents:\n  schemas:\n" +
+				"    A: {title: Size, type: string, enum: [s]}\n    B: {title: Size, type: string, enum: [m]}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parse(t, "api.yaml", tt.content)
+			if !errors.Is(err, openapi.ErrParseDocument) {
+				t.Fatalf("expected ErrParseDocument, got %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIsDocument(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	if err := memfs.WriteFile("orders.enums.yaml", []byte("package: orders\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if openapi.IsDocument(source.FromFileSystem(memfs, "orders.enums.yaml")) {
+		t.Errorf("expected a goenums spec not to be recognised as an OpenAPI document")
+	}
+}
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+	reqs, err := parse(t, "petstore.yaml", petstoreYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	memfs := file.NewMemFS()
+	if err := openapi.NewWriter(openapi.WithFileSystem(memfs)).Write(t.Context(), reqs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("petstore_openapi.yaml")
+	if err != nil {
+		t.Fatalf("expected petstore_openapi.yaml to be written: %v", err)
+	}
+	for _, want := range []string{
+		"# Code generated by goenums from petstore.yaml. DO NOT EDIT.\nopenapi: 3.1.0\n",
+		"    OrderStatus:\n      title: OrderStatus\n      type: string\n",
+		"      x-enum-descriptions:\n        - Order placed\n        - On the way\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, b)
+		}
+	}
+
+	// The written document imports back into the same enums
+	roundTrip, err := parse(t, "petstore_openapi.yaml", string(b))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	enumIotas := roundTrip[0].GetEnumIotas()
+	if len(enumIotas) != 3 {
+		t.Fatalf("expected 3 enum types, got %+v", enumIotas)
+	}
+	for _, e := range enumIotas {
+		if e.Type == "orderPriority" && (e.Enums[1].Name != "OrderPriorityHigh" || e.Enums[1].Index != 5) {
+			t.Errorf("unexpected integer enum %+v", e.Enums)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/jsonschema"
+)
+
+var _ enum.Writer = &Writer{}
+
+// ErrWriteComponents is returned when the components document cannot be written.
+var ErrWriteComponents = errors.New("error writing OpenAPI components")
+
+// Writer implements enum.Writer and writes the enum types of each request
+// as reusable schemas of a standalone OpenAPI 3.1 document,
+// "<output>_openapi.yaml", next to the source they were parsed from.
+// API documents reference them with
+// "$ref: <output>_openapi.yaml#/components/schemas/<Type>".
+type Writer struct {
+	Configuration config.Configuration
+	fs            file.ReadCreateWriteFileFS
+}
+
+// WriterOption is a function that configures a Writer.
+type WriterOption func(*Writer)
+
+// WithFileSystem sets the filesystem to use for writing files.
+func WithFileSystem(fs file.ReadCreateWriteFileFS) WriterOption {
+	return func(w *Writer) {
+		w.fs = fs
+	}
+}
+
+// WithWriterConfiguration sets the configuration for the writer.
+func WithWriterConfiguration(configuration config.Configuration) WriterOption {
+	return func(w *Writer) {
+		w.Configuration = configuration
+	}
+}
+
+// NewWriter creates a new components writer, writing to the operating
+// system filesystem by default.
+func NewWriter(opts ...WriterOption) *Writer {
+	w := Writer{
+		Configuration: config.Configuration{},
+		fs:            &file.OSReadWriteFileFS{},
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return &w
+}
+
+// componentsDocument is the OpenAPI document written by the Writer.
+type componentsDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Components struct {
+		Schemas map[string]jsonschema.Schema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+// Write emits the components document of each request.
+func (w *Writer) Write(ctx context.Context, reqs []enum.GenerationRequest) error {
+	for _, req := range reqs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !req.IsValid() {
+			return fmt.Errorf("invalid enum: %s", req.SourceFilename)
+		}
+		var doc componentsDocument
+		doc.OpenAPI = "3.1.0"
+		doc.Info.Title = req.Package + " enums"
+		doc.Info.Version = req.Version
+		doc.Components.Schemas = make(map[string]jsonschema.Schema)
+		for _, enumIota := range req.GetEnumIotas() {
+			s := jsonschema.FromEnum(enumIota, req.Configuration.GetEnumTypeConfig(enumIota.Type))
+			// The dialect is set by the document
+			s.Schema = ""
+			doc.Components.Schemas[s.Title] = s
+		}
+
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "# Code generated by goenums from %s. DO NOT EDIT.\n", filepath.Base(req.SourceFilename))
+		enc := yaml.NewEncoder(&b)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("%w: %w", ErrWriteComponents, err)
+		}
+		if err := enc.Close(); err != nil {
+			return fmt.Errorf("%w: %w", ErrWriteComponents, err)
+		}
+		path := filepath.Join(filepath.Dir(req.SourceFilename), req.OutputFilename+"_openapi.yaml")
+		if err := w.fs.WriteFile(path, b.Bytes(), file.DefaultFilePerms); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrWriteComponents, path, err)
+		}
+	}
+	return nil
+}
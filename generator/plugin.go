@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/strings"
+)
+
+var (
+	// ErrPlugin is returned when an exec plugin fails or answers with an
+	// invalid response.
+	ErrPlugin = errors.New("plugin failed")
+)
+
+// Plugin appends code of its own to every Go file generated for enums.
+// Register plugins with RegisterPlugin, or give them to the gofile writer
+// with gofile.WithPlugins.
+type Plugin = enum.Plugin
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   []Plugin
+)
+
+// RegisterPlugin registers p to run for every Go file generated by the
+// writers goenums.NewWriters returns, after the plugins already
+// registered. It is meant to be called from init functions of programs
+// embedding goenums.
+func RegisterPlugin(p Plugin) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	plugins = append(plugins, p)
+}
+
+// Plugins returns the registered plugins, in the order they were
+// registered.
+func Plugins() []Plugin {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	return slices.Clone(plugins)
+}
+
+// PluginRequest is the JSON document an exec plugin reads from its
+// standard input, describing the enums of one generated file.
+type PluginRequest struct {
+	// Package is the package of the generated file
+	Package string `json:"package"`
+	// Source is the file the enums were parsed from
+	Source string       `json:"source"`
+	Enums  []PluginEnum `json:"enums"`
+}
+
+// PluginEnum is an enum type of a PluginRequest.
+type PluginEnum struct {
+	// Type is the name of the enum type in the source
+	Type string `json:"type"`
+	// Wrapper is the name of the type generated for the values, which
+	// methods are declared on
+	Wrapper        string        `json:"wrapper"`
+	UnderlyingType string        `json:"underlyingType"`
+	Values         []PluginValue `json:"values"`
+}
+
+// PluginValue is a value of a PluginEnum.
+type PluginValue struct {
+	Name string `json:"name"`
+	// Value is the value of the constant as a Go literal
+	Value   string   `json:"value"`
+	Aliases []string `json:"aliases,omitempty"`
+	Invalid bool     `json:"invalid,omitempty"`
+}
+
+// PluginResponse is the JSON document an exec plugin writes to its
+// standard output.
+type PluginResponse struct {
+	// Imports are the paths of the packages Code uses
+	Imports []string `json:"imports,omitempty"`
+	// Code is the Go declarations appended to the generated file
+	Code string `json:"code"`
+}
+
+// NewPluginRequest returns the PluginRequest describing req.
+func NewPluginRequest(req enum.GenerationRequest) PluginRequest {
+	pr := PluginRequest{Package: req.Package, Source: req.SourceFilename}
+	for _, enumIota := range req.GetEnumIotas() {
+		pe := PluginEnum{
+			Type:           enumIota.Type,
+			Wrapper:        gofile.WrapperName(req.Configuration, enumIota),
+			UnderlyingType: enumIota.UnderlyingType,
+		}
+		for _, e := range enumIota.Enums {
+			value := e.Value
+			if value == "" {
+				value = strconv.Itoa(e.Index)
+			}
+			pe.Values = append(pe.Values, PluginValue{
+				Name:    e.Name,
+				Value:   value,
+				Aliases: e.Aliases,
+				Invalid: !e.Valid,
+			})
+		}
+		pr.Enums = append(pr.Enums, pe)
+	}
+	return pr
+}
+
+// NewExecPlugin returns a Plugin running command, a program followed by
+// its arguments separated by spaces, once per generated file. The program
+// reads a PluginRequest from its standard input and writes a
+// PluginResponse to its standard output; it fails generation by exiting
+// with a non-zero status, its standard error then being reported.
+func NewExecPlugin(command string) Plugin {
+	return &execPlugin{args: strings.Fields(command), responses: make(map[string]execResponse)}
+}
+
+// execPlugin runs a program for each request. The program is run once for
+// both Imports and Write, which are called one after the other.
+type execPlugin struct {
+	args      []string
+	mu        sync.Mutex
+	responses map[string]execResponse
+}
+
+type execResponse struct {
+	resp PluginResponse
+	err  error
+}
+
+// requestKey identifies the generated file of req.
+func requestKey(req enum.GenerationRequest) string {
+	return req.SourceFilename + "\x00" + req.OutputFilename
+}
+
+// Imports runs the program for req and returns the imports of its
+// response. The response is kept for Write, which reports failures.
+func (p *execPlugin) Imports(req enum.GenerationRequest) []string {
+	resp, err := p.run(req)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.responses[requestKey(req)] = execResponse{resp: resp, err: err}
+	return resp.Imports
+}
+
+// Write writes the code of the response to req, running the program
+// unless Imports already did.
+func (p *execPlugin) Write(w io.Writer, req enum.GenerationRequest) error {
+	p.mu.Lock()
+	r, ok := p.responses[requestKey(req)]
+	delete(p.responses, requestKey(req))
+	p.mu.Unlock()
+	if !ok {
+		r.resp, r.err = p.run(req)
+	}
+	if r.err != nil {
+		return r.err
+	}
+	_, err := io.WriteString(w, r.resp.Code)
+	return err
+}
+
+// run runs the program with the PluginRequest of req on its standard
+// input and decodes its response.
+func (p *execPlugin) run(req enum.GenerationRequest) (PluginResponse, error) {
+	if len(p.args) == 0 {
+		return PluginResponse{}, fmt.Errorf("%w: empty command", ErrPlugin)
+	}
+	in, err := json.Marshal(NewPluginRequest(req))
+	if err != nil {
+		return PluginResponse{}, fmt.Errorf("%w: %s: %w", ErrPlugin, p.args[0], err)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(p.args[0], p.args[1:]...)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return PluginResponse{}, fmt.Errorf("%w: %s: %w: %s", ErrPlugin, p.args[0], err, msg)
+		}
+		return PluginResponse{}, fmt.Errorf("%w: %s: %w", ErrPlugin, p.args[0], err)
+	}
+	var resp PluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return PluginResponse{}, fmt.Errorf("%w: %s: invalid response: %w", ErrPlugin, p.args[0], err)
+	}
+	return resp, nil
+}
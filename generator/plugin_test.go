@@ -0,0 +1,188 @@
+package generator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator"
+	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/internal/testdata"
+	"github.com/donutnomad/goenums/source"
+)
+
+const pluginSource = `package orders
+
+type status int
+
+const (
+	unknown status = iota // invalid
+	pending               // Pending
+	shipped               // Shipped
+)
+`
+
+// auditPlugin declares an Audit method on every wrapper.
+type auditPlugin struct {
+	err error
+}
+
+func (p auditPlugin) Imports(enum.GenerationRequest) []string {
+	return []string{"strings", "example.com/audit"}
+}
+
+func (p auditPlugin) Write(w io.Writer, req enum.GenerationRequest) error {
+	if p.err != nil {
+		return p.err
+	}
+	for _, enumIota := range req.GetEnumIotas() {
+		name := gofile.WrapperName(req.Configuration, enumIota)
+		fmt.Fprintf(w, "func (v %s) Audit() string { return audit.Tag(strings.ToUpper(v.String())) }\n", name)
+	}
+	return nil
+}
+
+// generatePlugged generates pluginSource with plugins in memory and
+// returns the generated file.
+func generatePlugged(t *testing.T, plugins ...enum.Plugin) (string, error) {
+	t.Helper()
+	fsys := file.NewOverlayFS(fstest.MapFS{
+		"orders/status.go": &fstest.MapFile{Data: []byte(pluginSource)},
+	})
+	p := generator.New(
+		generator.WithConfig(testdata.DefaultConfig),
+		generator.WithParser(gofile.NewParser(
+			gofile.WithParserConfiguration(testdata.DefaultConfig),
+			gofile.WithSource(source.FromFileSystem(fsys, "orders/status.go")))),
+		generator.WithWriter(gofile.NewWriter(
+			gofile.WithWriterConfiguration(testdata.DefaultConfig),
+			gofile.WithFileSystem(fsys),
+			gofile.WithPlugins(plugins...))))
+	if err := p.ParseAndWrite(t.Context()); err != nil {
+		return "", err
+	}
+	b, err := fsys.ReadFile("orders/status_enums.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b), nil
+}
+
+func TestGenerator_ParseAndWrite_Plugin(t *testing.T) {
+	t.Parallel()
+	got, err := generatePlugged(t, auditPlugin{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"\t\"strings\"\n",
+		"\t\"example.com/audit\"\n",
+		"func (v Status) Audit() string {",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected the generated file to contain %q", want)
+		}
+	}
+
+	errAudit := errors.New("audit unavailable")
+	if _, err := generatePlugged(t, auditPlugin{err: errAudit}); !errors.Is(err, errAudit) {
+		t.Errorf("got error %v, want %v", err, errAudit)
+	}
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	t.Parallel()
+	before := len(generator.Plugins())
+	generator.RegisterPlugin(auditPlugin{})
+	if got := generator.Plugins(); len(got) != before+1 {
+		t.Errorf("got %d plugins, want %d", len(got), before+1)
+	}
+}
+
+// writeScript writes a shell script to a temporary directory and returns
+// the command running it.
+func writeScript(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("exec plugins are tested with shell scripts")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	name := filepath.Join(t.TempDir(), "plugin.sh")
+	if err := os.WriteFile(name, []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return "sh " + name
+}
+
+func TestNewExecPlugin(t *testing.T) {
+	t.Parallel()
+	reqFile := filepath.Join(t.TempDir(), "request.json")
+	script := writeScript(t, `cat > "$1"
+printf '%s' '{"imports":["strings"],"code":"func (v Status) Upper() string { return strings.ToUpper(v.String()) }"}'
+`)
+	got, err := generatePlugged(t, generator.NewExecPlugin(script+" "+reqFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(got, "func (v Status) Upper() string {") {
+		t.Errorf("expected the generated file to contain the code of the plugin:\n%s", got)
+	}
+
+	b, err := os.ReadFile(reqFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req generator.PluginRequest
+	if err := json.Unmarshal(b, &req); err != nil {
+		t.Fatalf("invalid request %s: %v", b, err)
+	}
+	if req.Package != "orders" || req.Source != "orders/status.go" || len(req.Enums) != 1 {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	e := req.Enums[0]
+	if e.Type != "status" || e.Wrapper != "Status" || len(e.Values) != 3 {
+		t.Fatalf("unexpected enum %+v", e)
+	}
+	if v := e.Values[0]; v.Name != "unknown" || v.Value != "0" || !v.Invalid {
+		t.Errorf("unexpected value %+v", v)
+	}
+	if v := e.Values[1]; v.Name != "pending" || v.Value != "1" || v.Invalid {
+		t.Errorf("unexpected value %+v", v)
+	}
+}
+
+func TestNewExecPlugin_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"exit status", "echo boom >&2\nexit 3\n", "boom"},
+		{"invalid response", "echo not json\n", "invalid response"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := generatePlugged(t, generator.NewExecPlugin(writeScript(t, tt.script)))
+			if !errors.Is(err, generator.ErrPlugin) {
+				t.Fatalf("got error %v, want %v", err, generator.ErrPlugin)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error %q to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,459 @@
+// Package protofile imports enums from protobuf definitions, so a .proto
+// file can be the source of truth for enums shared with other languages.
+//
+// Every enum of the file, including those nested in messages, becomes an
+// enum type:
+//
+//	message Order {
+//	  enum Status {
+//	    STATUS_UNSPECIFIED = 0;
+//	    STATUS_PENDING = 1;   // Awaiting payment
+//	    STATUS_SHIPPED = 2 [deprecated = true];
+//	  }
+//	}
+//
+// Nested enums are named after their messages ("orderStatus"). Values are
+// named without the enum's prefix ("pending"), or prefixed with their type
+// name when another type shares the name ("orderStatusPending"), and display
+// and parse as their protobuf names, the names protojson marshals. A zero
+// value ending in _UNSPECIFIED is declared but not valid, and values sharing
+// a number under allow_alias are legacy aliases of the first. The Parser
+// produces the same enum.GenerationRequest as the Go source parser, and the
+// const block is written by the spec package's Writer.
+package protofile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/scanner"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/internal/naming"
+	"github.com/donutnomad/goenums/internal/version"
+	"github.com/donutnomad/goenums/source"
+	gostrings "github.com/donutnomad/goenums/strings"
+)
+
+// Compile-time check that Parser implements enum.Parser
+var _ enum.Parser = (*Parser)(nil)
+
+var (
+	// ErrReadProto indicates an error occurred while reading the proto file.
+	ErrReadProto = errors.New("failed to read proto file")
+	// ErrParseProto indicates the proto file is malformed or declares enums
+	// that cannot be generated.
+	ErrParseProto = errors.New("failed to parse proto file")
+)
+
+// Parser implements the enum.Parser interface for protobuf definitions.
+type Parser struct {
+	Configuration config.Configuration
+	source        enum.Source
+	packageName   string
+}
+
+// ParserOption is a function that configures a Parser.
+type ParserOption func(*Parser)
+
+// WithSource sets the source for the parser.
+func WithSource(source enum.Source) ParserOption {
+	return func(p *Parser) {
+		p.source = source
+	}
+}
+
+// WithParserConfiguration sets the configuration for the parser.
+func WithParserConfiguration(configuration config.Configuration) ParserOption {
+	return func(p *Parser) {
+		p.Configuration = configuration
+	}
+}
+
+// WithPackage sets the name of the package the enums are generated into.
+// By default it is the package of the Go files next to the proto file, or
+// the name of its directory.
+func WithPackage(name string) ParserOption {
+	return func(p *Parser) {
+		p.packageName = name
+	}
+}
+
+// NewParser creates a new proto parser with the specified configuration and source.
+func NewParser(opts ...ParserOption) *Parser {
+	p := Parser{
+		Configuration: config.Configuration{},
+		source:        source.FromFile(""),
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return &p
+}
+
+// Parse reads the proto file and returns a single generation request
+// holding an enum type for every protobuf enum.
+func (p *Parser) Parse(ctx context.Context) ([]enum.GenerationRequest, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	content, err := p.source.Content()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadProto, err)
+	}
+	filename := p.source.Filename()
+	slog.Default().DebugContext(ctx, "parsing proto file", "filename", filename)
+	enums, err := parseProto(filename, string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParseProto, err)
+	}
+	packageName := p.packageName
+	if packageName == "" {
+		packageName = naming.DirectoryPackage(filename)
+	}
+	if !token.IsIdentifier(packageName) {
+		return nil, fmt.Errorf("%w: invalid package name %q", ErrParseProto, packageName)
+	}
+
+	enumIotas := make([]enum.EnumIota, 0, len(enums))
+	for _, e := range enums {
+		enumIota, err := newEnumIota(e)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParseProto, err)
+		}
+		if slices.ContainsFunc(enumIotas, func(e enum.EnumIota) bool { return e.Type == enumIota.Type }) {
+			return nil, fmt.Errorf("%w: duplicate enum type %s", ErrParseProto, enumIota.Type)
+		}
+		enumIotas = append(enumIotas, enumIota)
+	}
+	if len(enumIotas) == 0 {
+		return nil, fmt.Errorf("%w: %w", ErrParseProto, enum.ErrNoEnumsFound)
+	}
+	naming.PrefixCollidingNames(enumIotas)
+
+	cfg := p.Configuration
+	cfg.EnumTypeConfigs = make(map[string]config.EnumTypeConfig, len(enumIotas))
+	maps.Copy(cfg.EnumTypeConfigs, p.Configuration.EnumTypeConfigs)
+	for _, enumIota := range enumIotas {
+		typeConfig := p.Configuration.Defaults
+		typeConfig.TypeName = enumIota.Type
+		// JSON uses the protobuf names, like protojson
+		typeConfig.Handlers.JSON = true
+		typeConfig.SerializationType = config.SerdeName
+		cfg.EnumTypeConfigs[enumIota.Type] = typeConfig
+	}
+
+	base := filepath.Base(filename)
+	if i := strings.Index(base, "."); i > 0 {
+		base = base[:i]
+	}
+	request := enum.GenerationRequest{
+		Package:         packageName,
+		BuildConstraint: strings.Join(p.Configuration.BuildTags, " && "),
+		EnumIotas:       enumIotas,
+		Version:         version.CURRENT,
+		SourceFilename:  filename,
+		OutputFilename:  strings.ToLower(base),
+		Configuration:   cfg,
+	}
+	if len(enumIotas) == 1 {
+		request.EnumIota = enumIotas[0]
+	}
+	return []enum.GenerationRequest{request}, nil
+}
+
+// protoEnum is an enum declaration of a proto file.
+type protoEnum struct {
+	// Scope are the names of the messages the enum is nested in
+	Scope  []string
+	Name   string
+	Doc    string
+	Values []protoValue
+}
+
+type protoValue struct {
+	Name       string
+	Number     int
+	Doc        string
+	Deprecated bool
+}
+
+// newEnumIota converts a protobuf enum into its enum representation.
+func newEnumIota(e protoEnum) (enum.EnumIota, error) {
+	typeName := strings.Join(append(slices.Clone(e.Scope), e.Name), "")
+	enumIota := enum.EnumIota{
+		Type:           gostrings.Lower1stCharacter(typeName),
+		UnderlyingType: "int32",
+		Doc:            e.Doc,
+		Opener:         " ",
+		Closer:         " ",
+	}
+	if len(e.Values) == 0 {
+		return enumIota, fmt.Errorf("%s: %w", e.Name, enum.ErrNoEnumsFound)
+	}
+	// Values are conventionally prefixed with the upper snake case name of
+	// their enum, and may be prefixed with their messages' names too
+	prefixes := []string{
+		strings.ToUpper(gostrings.Snake(typeName)) + "_",
+		strings.ToUpper(gostrings.Snake(e.Name)) + "_",
+	}
+	for _, v := range e.Values {
+		if i := slices.IndexFunc(enumIota.Enums, func(e enum.Enum) bool { return e.Index == v.Number }); i >= 0 {
+			// allow_alias gives a number several names
+			enumIota.Enums[i].LegacyAliases = append(enumIota.Enums[i].LegacyAliases, v.Name)
+			continue
+		}
+		name := v.Name
+		for _, prefix := range prefixes {
+			if trimmed, ok := strings.CutPrefix(name, prefix); ok && trimmed != "" {
+				name = trimmed
+				break
+			}
+		}
+		enumIota.Enums = append(enumIota.Enums, enum.Enum{
+			Name:          gostrings.Lower1stCharacter(naming.Identifier(strings.ToLower(name))),
+			Index:         v.Number,
+			Aliases:       []string{v.Name},
+			Valid:         v.Number != 0 || !strings.HasSuffix(v.Name, "_UNSPECIFIED"),
+			CustomComment: v.Doc,
+			Deprecated:    v.Deprecated,
+		})
+	}
+	enumIota.StartIndex = enumIota.Enums[0].Index
+	return enumIota, nil
+}
+
+// protoParser reads the enum declarations of a proto file, skipping every
+// other statement. Comments are kept to document the enums and values.
+type protoParser struct {
+	s    scanner.Scanner
+	tok  rune
+	text string
+	line int
+	// doc are the comments on the lines before the current token, trailing
+	// the comment on the line of the previous token
+	doc, trailing string
+	errs          []error
+	enums         []protoEnum
+}
+
+// parseProto returns the enums declared in a proto file.
+func parseProto(filename, content string) ([]protoEnum, error) {
+	var p protoParser
+	p.s.Init(strings.NewReader(content))
+	p.s.Filename = filename
+	// Single quoted strings are not Go char literals, so are read as tokens
+	p.s.Mode = scanner.GoTokens &^ (scanner.SkipComments | scanner.ScanChars)
+	p.s.Error = func(s *scanner.Scanner, msg string) {
+		p.errs = append(p.errs, fmt.Errorf("%s: %s", s.Position, msg))
+	}
+	p.next()
+	p.parseBody(nil, scanner.EOF)
+	if len(p.errs) > 0 {
+		return nil, p.errs[0]
+	}
+	return p.enums, nil
+}
+
+// next advances to the next token that is not a comment.
+func (p *protoParser) next() {
+	p.doc, p.trailing = "", ""
+	prevLine := p.line
+	for {
+		p.tok = p.s.Scan()
+		p.text = p.s.TokenText()
+		p.line = p.s.Position.Line
+		if p.tok != scanner.Comment {
+			return
+		}
+		text := commentText(p.text)
+		switch {
+		case p.line == prevLine && p.trailing == "" && p.doc == "":
+			p.trailing = text
+		case p.doc == "":
+			p.doc = text
+		default:
+			p.doc += "\n" + text
+		}
+	}
+}
+
+// commentText returns the text of a line or block comment.
+func commentText(comment string) string {
+	if text, ok := strings.CutPrefix(comment, "//"); ok {
+		return strings.TrimSpace(text)
+	}
+	text := strings.TrimSuffix(strings.TrimPrefix(comment, "/*"), "*/")
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (p *protoParser) errorf(format string, args ...any) {
+	p.errs = append(p.errs, fmt.Errorf("%s: %s", p.s.Position, fmt.Sprintf(format, args...)))
+}
+
+// expect consumes the token tok, reporting an error when it is missing.
+func (p *protoParser) expect(tok rune) bool {
+	if p.tok != tok {
+		p.errorf("expected %s, found %q", scanner.TokenString(tok), p.text)
+		return false
+	}
+	p.next()
+	return true
+}
+
+// parseBody reads the statements of the file or of a message until end,
+// where scope are the names of the enclosing messages.
+func (p *protoParser) parseBody(scope []string, end rune) {
+	for p.tok != end && len(p.errs) == 0 {
+		switch {
+		case p.tok == scanner.EOF:
+			p.errorf("unexpected end of file")
+		case p.tok == scanner.Ident && p.text == "message":
+			p.next()
+			name := p.text
+			if !p.expect(scanner.Ident) || !p.expect('{') {
+				return
+			}
+			p.parseBody(append(slices.Clone(scope), name), '}')
+			p.next()
+		case p.tok == scanner.Ident && p.text == "enum":
+			doc := p.doc
+			p.next()
+			name := p.text
+			if !p.expect(scanner.Ident) || !p.expect('{') {
+				return
+			}
+			p.enums = append(p.enums, protoEnum{Scope: scope, Name: name, Doc: doc, Values: p.parseEnum()})
+		default:
+			p.skipStatement()
+		}
+	}
+}
+
+// parseEnum reads the values of an enum up to and including its closing
+// brace, skipping options and reserved ranges.
+func (p *protoParser) parseEnum() []protoValue {
+	var values []protoValue
+	for p.tok != '}' && len(p.errs) == 0 {
+		if p.tok == scanner.Ident && (p.text == "option" || p.text == "reserved") {
+			p.skipStatement()
+			continue
+		}
+		if p.tok == ';' {
+			p.next()
+			continue
+		}
+		v := protoValue{Name: p.text, Doc: p.doc}
+		if !p.expect(scanner.Ident) || !p.expect('=') {
+			return nil
+		}
+		sign := ""
+		if p.tok == '-' {
+			sign = "-"
+			p.next()
+		}
+		number, err := strconv.ParseInt(sign+p.text, 0, 32)
+		if err != nil {
+			p.errorf("invalid number %q for %s", sign+p.text, v.Name)
+			return nil
+		}
+		v.Number = int(number)
+		if !p.expect(scanner.Int) {
+			return nil
+		}
+		if p.tok == '[' {
+			v.Deprecated = p.parseValueOptions()
+		}
+		if !token.IsIdentifier(v.Name) {
+			p.errorf("invalid value name %q", v.Name)
+			return nil
+		}
+		if p.tok != ';' {
+			p.expect(';')
+			return nil
+		}
+		p.next()
+		if v.Doc == "" {
+			v.Doc = p.trailing
+		}
+		values = append(values, v)
+	}
+	p.next()
+	return values
+}
+
+// parseValueOptions reads the bracketed options of an enum value and
+// reports whether they mark it deprecated.
+func (p *protoParser) parseValueOptions() bool {
+	deprecated := false
+	depth := 0
+	for len(p.errs) == 0 {
+		switch p.tok {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		case scanner.EOF:
+			p.errorf("unexpected end of file")
+			return false
+		case scanner.Ident:
+			if p.text == "deprecated" && depth == 1 {
+				p.next()
+				if p.tok == '=' {
+					p.next()
+					deprecated = p.text == "true"
+				}
+				continue
+			}
+		}
+		p.next()
+		if depth == 0 {
+			break
+		}
+	}
+	return deprecated
+}
+
+// skipStatement skips a statement ending in a semicolon or a braced block.
+func (p *protoParser) skipStatement() {
+	depth := 0
+	for len(p.errs) == 0 {
+		switch p.tok {
+		case scanner.EOF:
+			if depth > 0 {
+				p.errorf("unexpected end of file")
+			}
+			return
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth < 0 {
+				p.errorf("unexpected '}'")
+				return
+			}
+			if depth == 0 {
+				p.next()
+				return
+			}
+		case ';':
+			if depth == 0 {
+				p.next()
+				return
+			}
+		}
+		p.next()
+	}
+}
@@ -0,0 +1,157 @@
+package protofile_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/protofile"
+	"github.com/donutnomad/goenums/source"
+)
+
+const ordersProto = `syntax = "proto3";
+
+package orders.v1;
+
+option go_package = "example.com/orders/v1;ordersv1";
+
+import "google/protobuf/timestamp.proto";
+
+// Priority of an order.
+enum Priority {
+  option allow_alias = true;
+  PRIORITY_LOW = 0;
+  PRIORITY_NORMAL = 0;
+  PRIORITY_HIGH = -1;
+  reserved 2, 5 to 9;
+}
+
+message Order {
+  string id = 1;
+  map<string, int32> counts = 2 [json_name = "counts"];
+  google.protobuf.Timestamp placed = 3;
+
+  /* Status is the lifecycle
+   * of an order. */
+  enum Status {
+    STATUS_UNSPECIFIED = 0;
+    STATUS_PENDING = 1; // Awaiting payment
+    // On the way
+    ORDER_STATUS_IN_TRANSIT = 0x2 [(custom) = {a: "}"}, deprecated = true];
+  }
+  message Line {
+    oneof kind { string sku = 1; }
+    enum Kind { KIND_LOW = 0; DEFAULT = 1; }
+  }
+  Status status = 4;
+}
+
+service Orders {
+  rpc Get(Order) returns (Order) { option idempotency_level = NO_SIDE_EFFECTS; }
+}
+`
+
+func parse(t *testing.T, content string) ([]enum.GenerationRequest, error) {
+	t.Helper()
+	memfs := file.NewMemFS()
+	if err := memfs.WriteFile("orders.proto", []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return protofile.NewParser(
+		protofile.WithSource(source.FromFileSystem(memfs, "orders.proto")),
+		protofile.WithPackage("orders")).Parse(t.Context())
+}
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+	reqs, err := parse(t, ordersProto)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req := reqs[0]
+	if req.Package != "orders" || req.OutputFilename != "orders" {
+		t.Errorf("unexpected package %q or output filename %q", req.Package, req.OutputFilename)
+	}
+	enumIotas := req.GetEnumIotas()
+	if len(enumIotas) != 3 {
+		t.Fatalf("expected 3 enum types, got %+v", enumIotas)
+	}
+
+	priority := enumIotas[0]
+	if priority.Type != "priority" || priority.UnderlyingType != "int32" || priority.Doc != "Priority of an order." {
+		t.Errorf("unexpected enum type %+v", priority)
+	}
+	if len(priority.Enums) != 2 || priority.Enums[0].Name != "priorityLow" || !priority.Enums[0].Valid ||
+		len(priority.Enums[0].LegacyAliases) != 1 || priority.Enums[0].LegacyAliases[0] != "PRIORITY_NORMAL" ||
+		priority.Enums[1].Index != -1 {
+		t.Errorf("unexpected aliased enum %+v", priority.Enums)
+	}
+
+	status := enumIotas[1]
+	if status.Type != "orderStatus" || status.Doc != "Status is the lifecycle\nof an order." {
+		t.Errorf("unexpected nested enum type %+v", status)
+	}
+	if len(status.Enums) != 3 || status.Enums[0].Name != "unspecified" || status.Enums[0].Valid {
+		t.Fatalf("expected an invalid unspecified value, got %+v", status.Enums)
+	}
+	if e := status.Enums[1]; e.Name != "pending" || e.Aliases[0] != "STATUS_PENDING" || e.CustomComment != "Awaiting payment" {
+		t.Errorf("unexpected value %+v", e)
+	}
+	if e := status.Enums[2]; e.Name != "inTransit" || e.Index != 2 || !e.Deprecated || e.CustomComment != "On the way" {
+		t.Errorf("unexpected value %+v", e)
+	}
+	// "low" is also a value of priority, and "default" a keyword
+	if kind := enumIotas[2]; kind.Type != "orderLineKind" || kind.Enums[0].Name != "orderLineKindLow" ||
+		kind.Enums[1].Name != "orderLineKindDefault" {
+		t.Errorf("unexpected doubly nested enum %+v", kind.Enums)
+	}
+	if cfg := req.Configuration.GetEnumTypeConfig("orderStatus"); !cfg.Handlers.JSON || cfg.SerializationType != config.SerdeName {
+		t.Errorf("expected enums to be serialized by name, got %+v", cfg)
+	}
+}
+
+func TestParser_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{
+			name:    "no enums",
+			content: "syntax = \"proto3\";\nmessage Order { string id = 1; }\n",
+			wantErr: enum.ErrNoEnumsFound,
+		},
+		{
+			name:    "empty enum",
+			content: "enum Size {}\n",
+			wantErr: enum.ErrNoEnumsFound,
+		},
+		{
+			name:    "missing number",
+			content: "enum Size { SIZE_SMALL; }\n",
+		},
+		{
+			name:    "unterminated",
+			content: "message Order { enum Size { SIZE_SMALL = 0; }\n",
+		},
+		{
+			name:    "duplicate type",
+			content: "message Order { enum Size { A = 0; } }\nenum OrderSize { B = 0; }\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parse(t, tt.content)
+			if !errors.Is(err, protofile.ErrParseProto) {
+				t.Fatalf("expected ErrParseProto, got %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package spec reads enums from declarative spec files and writes the Go
+// declarations they describe.
+//
+// A spec is a YAML or JSON document that serves as a language-neutral source
+// of truth for enum types shared between teams and services:
+//
+//	package: orders
+//	enums:
+//	  - type: orderStatus
+//	    directives: [-json, -statemachine]
+//	    fields:
+//	      - {name: Refundable, type: bool}
+//	    values:
+//	      - name: orderPending
+//	        aliases: [Pending]
+//	        fields: {Refundable: true}
+//	        transitions: [orderShipped]
+//	      - name: orderShipped
+//	        aliases: [Shipped]
+//	        fields: {Refundable: false}
+//	        final: true
+//
+// The Parser turns a spec into the same enum.GenerationRequest the Go source
+// parser produces, so every writer applies unchanged. The Writer emits the
+// type declarations and const blocks the generated code refers to, which a
+// Go source file would otherwise declare by hand.
+package spec
+
+// File is the document read from a spec file.
+type File struct {
+	// Package is the name of the Go package the enums are generated into
+	Package string `json:"package" yaml:"package"`
+	// Enums are the enum types of the package
+	Enums []Type `json:"enums" yaml:"enums"`
+}
+
+// Type describes an enum type.
+type Type struct {
+	// Type is the name of the Go type, e.g. "orderStatus"
+	Type string `json:"type" yaml:"type"`
+	// Underlying is the underlying integer type, "int" when empty
+	Underlying string `json:"underlying,omitempty" yaml:"underlying,omitempty"`
+	// Comment documents the type
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	// Directives are the "// goenums:" directives of the type, e.g. "-json"
+	Directives []string `json:"directives,omitempty" yaml:"directives,omitempty"`
+	// Fields are the custom fields every value carries
+	Fields []Field `json:"fields,omitempty" yaml:"fields,omitempty"`
+	// Values are the enum values in declaration order
+	Values []Value `json:"values" yaml:"values"`
+}
+
+// Field describes a custom field of an enum type.
+type Field struct {
+	Name string `json:"name" yaml:"name"`
+	// Type is the Go type of the field, e.g. "string" or "time.Duration"
+	Type string `json:"type" yaml:"type"`
+}
+
+// Value describes an enum value.
+type Value struct {
+	// Name is the name of the Go constant
+	Name string `json:"name" yaml:"name"`
+	// Value is the constant value; when omitted it is one more than the
+	// previous value, or 0 for the first
+	Value *int `json:"value,omitempty" yaml:"value,omitempty"`
+	// Aliases are the names the value parses from, the first being its
+	// display name
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	// Fields are the values of the type's custom fields, keyed by field name
+	Fields map[string]Scalar `json:"fields,omitempty" yaml:"fields,omitempty"`
+	// Comment documents the value
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	// Transitions are the names of the values the state machine may move to
+	Transitions []string `json:"transitions,omitempty" yaml:"transitions,omitempty"`
+	// Final marks a terminal state of the state machine
+	Final bool `json:"final,omitempty" yaml:"final,omitempty"`
+	// Invalid marks a value that is declared but not valid
+	Invalid bool `json:"invalid,omitempty" yaml:"invalid,omitempty"`
+	// Deprecated marks a value that still parses but is left out of All
+	Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	// Serde overrides the name used when serializing by name
+	Serde string `json:"serde,omitempty" yaml:"serde,omitempty"`
+	// Legacy are historic names that still parse to the value
+	Legacy []string `json:"legacy,omitempty" yaml:"legacy,omitempty"`
+}
@@ -0,0 +1,288 @@
+package spec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/internal/version"
+	"github.com/donutnomad/goenums/source"
+)
+
+// Compile-time check that Parser implements enum.Parser
+var _ enum.Parser = (*Parser)(nil)
+
+var (
+	// ErrReadSpec indicates an error occurred while reading the spec file.
+	ErrReadSpec = errors.New("failed to read enum spec")
+	// ErrParseSpec indicates the spec file is malformed or describes enums
+	// that cannot be generated.
+	ErrParseSpec = errors.New("failed to parse enum spec")
+)
+
+// integerTypes are the underlying types an enum type may be declared with.
+var integerTypes = []string{
+	"int", "int8", "int16", "int32", "int64",
+	"uint", "uint8", "uint16", "uint32", "uint64", "byte",
+}
+
+// Scalar is a field value as written in the spec. Strings, numbers and
+// booleans are all accepted and parsed according to the field's type.
+type Scalar string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *Scalar) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("field value must be a scalar, got %s", node.ShortTag())
+	}
+	*s = Scalar(node.Value)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Scalar) UnmarshalJSON(b []byte) error {
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case string:
+		*s = Scalar(v)
+	case float64, bool:
+		*s = Scalar(b)
+	default:
+		return fmt.Errorf("field value must be a scalar, got %s", b)
+	}
+	return nil
+}
+
+// Parser implements the enum.Parser interface for YAML and JSON spec files.
+// Files ending in ".json" are decoded as JSON, anything else as YAML.
+type Parser struct {
+	Configuration config.Configuration
+	source        enum.Source
+}
+
+// ParserOption is a function that configures a Parser.
+type ParserOption func(*Parser)
+
+// WithSource sets the source for the parser.
+func WithSource(source enum.Source) ParserOption {
+	return func(p *Parser) {
+		p.source = source
+	}
+}
+
+// WithParserConfiguration sets the configuration for the parser.
+func WithParserConfiguration(configuration config.Configuration) ParserOption {
+	return func(p *Parser) {
+		p.Configuration = configuration
+	}
+}
+
+// NewParser creates a new spec parser with the specified configuration and source.
+func NewParser(opts ...ParserOption) *Parser {
+	p := Parser{
+		Configuration: config.Configuration{},
+		source:        source.FromFile(""),
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return &p
+}
+
+// Parse reads the spec and returns a single generation request holding all
+// of its enum types.
+func (p *Parser) Parse(ctx context.Context) ([]enum.GenerationRequest, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	content, err := p.source.Content()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadSpec, err)
+	}
+	filename := p.source.Filename()
+	slog.Default().DebugContext(ctx, "parsing enum spec", "filename", filename)
+	spec, err := decode(filename, content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrParseSpec, filename, err)
+	}
+	if !token.IsIdentifier(spec.Package) {
+		return nil, fmt.Errorf("%w: invalid package name %q", ErrParseSpec, spec.Package)
+	}
+	if len(spec.Enums) == 0 {
+		return nil, fmt.Errorf("%w: %w", ErrParseSpec, enum.ErrNoEnumsFound)
+	}
+
+	cfg := p.Configuration
+	cfg.EnumTypeConfigs = make(map[string]config.EnumTypeConfig, len(spec.Enums))
+	maps.Copy(cfg.EnumTypeConfigs, p.Configuration.EnumTypeConfigs)
+
+	enumIotas := make([]enum.EnumIota, 0, len(spec.Enums))
+	for _, t := range spec.Enums {
+		if slices.ContainsFunc(enumIotas, func(e enum.EnumIota) bool { return e.Type == t.Type }) {
+			return nil, fmt.Errorf("%w: duplicate type %s", ErrParseSpec, t.Type)
+		}
+		enumIota, err := newEnumIota(t)
+		if err != nil {
+			return nil, fmt.Errorf("%w: type %s: %w", ErrParseSpec, t.Type, err)
+		}
+		typeConfig, err := p.Configuration.Defaults.ApplyDirectives(t.Directives)
+		if err != nil {
+			return nil, fmt.Errorf("%w: type %s: %w", ErrParseSpec, t.Type, err)
+		}
+		typeConfig.TypeName = t.Type
+		if typeConfig.Proto != "" {
+			// Specs have no imports to resolve the protobuf package from
+			return nil, fmt.Errorf("%w: type %s: -proto is only supported in Go sources", ErrParseSpec, t.Type)
+		}
+		if err := typeConfig.Validate(enumIota.UnderlyingType); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParseSpec, err)
+		}
+		cfg.EnumTypeConfigs[t.Type] = typeConfig
+		enumIotas = append(enumIotas, enumIota)
+	}
+
+	// "orders.enums.yaml" generates "orders_enums.go", not "orders.enums_enums.go"
+	base := filepath.Base(filename)
+	if i := strings.Index(base, "."); i > 0 {
+		base = base[:i]
+	}
+	request := enum.GenerationRequest{
+		Package:         spec.Package,
+		BuildConstraint: strings.Join(p.Configuration.BuildTags, " && "),
+		Imports:         enum.ExtractImports(enumIotas),
+		EnumIotas:       enumIotas,
+		Version:         version.CURRENT,
+		SourceFilename:  filename,
+		OutputFilename:  strings.ToLower(base),
+		Configuration:   cfg,
+	}
+	if len(enumIotas) == 1 {
+		request.EnumIota = enumIotas[0]
+	}
+	return []enum.GenerationRequest{request}, nil
+}
+
+// decode reads the spec as JSON or YAML depending on the file extension,
+// rejecting keys the schema does not know.
+func decode(filename string, content []byte) (File, error) {
+	var spec File
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.DisallowUnknownFields()
+		return spec, dec.Decode(&spec)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	return spec, dec.Decode(&spec)
+}
+
+// newEnumIota converts a spec type into its enum representation.
+func newEnumIota(t Type) (enum.EnumIota, error) {
+	enumIota := enum.EnumIota{
+		Type:           t.Type,
+		UnderlyingType: t.Underlying,
+		Doc:            t.Comment,
+		Opener:         " ",
+		Closer:         " ",
+	}
+	if !token.IsIdentifier(t.Type) {
+		return enumIota, fmt.Errorf("invalid type name %q", t.Type)
+	}
+	if enumIota.UnderlyingType == "" {
+		enumIota.UnderlyingType = "int"
+	}
+	if !slices.Contains(integerTypes, enumIota.UnderlyingType) {
+		return enumIota, fmt.Errorf("unsupported underlying type %s", enumIota.UnderlyingType)
+	}
+	for _, f := range t.Fields {
+		value := enum.FieldToType(f.Type)
+		if _, isExpr := value.(enum.Expr); value == nil || isExpr {
+			return enumIota, fmt.Errorf("field %s: %w: %s", f.Name, enum.ErrUnsupportedType, f.Type)
+		}
+		enumIota.Fields = append(enumIota.Fields, enum.Field{Name: f.Name, Value: value})
+	}
+	if len(t.Values) == 0 {
+		return enumIota, enum.ErrNoEnumsFound
+	}
+
+	names := make([]string, 0, len(t.Values))
+	next := 0
+	for _, v := range t.Values {
+		if !token.IsIdentifier(v.Name) {
+			return enumIota, fmt.Errorf("invalid value name %q", v.Name)
+		}
+		if slices.Contains(names, v.Name) {
+			return enumIota, fmt.Errorf("duplicate value %s", v.Name)
+		}
+		names = append(names, v.Name)
+		if v.Value != nil {
+			next = *v.Value
+		}
+		fields, err := valueFields(enumIota.Fields, v)
+		if err != nil {
+			return enumIota, err
+		}
+		enumIota.Enums = append(enumIota.Enums, enum.Enum{
+			Name:             v.Name,
+			Index:            next,
+			Fields:           fields,
+			Aliases:          v.Aliases,
+			Valid:            !v.Invalid,
+			CustomComment:    v.Comment,
+			StateTransitions: v.Transitions,
+			IsFinalState:     v.Final,
+			SerdeName:        v.Serde,
+			LegacyAliases:    v.Legacy,
+			Deprecated:       v.Deprecated,
+		})
+		next++
+	}
+	enumIota.StartIndex = enumIota.Enums[0].Index
+
+	for _, e := range enumIota.Enums {
+		for _, to := range e.StateTransitions {
+			if !slices.Contains(names, to) {
+				return enumIota, fmt.Errorf("value %s: transition to unknown value %s", e.Name, to)
+			}
+		}
+	}
+	return enumIota, nil
+}
+
+// valueFields parses the field values of v in the order the type declares
+// its fields. Every field must be given a value.
+func valueFields(fields []enum.Field, v Value) ([]enum.Field, error) {
+	for name := range v.Fields {
+		if !slices.ContainsFunc(fields, func(f enum.Field) bool { return f.Name == name }) {
+			return nil, fmt.Errorf("value %s: unknown field %s", v.Name, name)
+		}
+	}
+	values := make([]enum.Field, 0, len(fields))
+	for _, f := range fields {
+		raw, ok := v.Fields[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("value %s: missing field %s", v.Name, f.Name)
+		}
+		value, err := enum.ParseValue(string(raw), f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("value %s: field %s: %w", v.Name, f.Name, err)
+		}
+		values = append(values, enum.Field{Name: f.Name, Value: value})
+	}
+	return values, nil
+}
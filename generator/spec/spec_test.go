@@ -0,0 +1,210 @@
+package spec_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/spec"
+	"github.com/donutnomad/goenums/source"
+)
+
+const ordersYAML = `package: orders
+enums:
+  - type: orderStatus
+    comment: orderStatus is the lifecycle of an order.
+    directives: [-json, -statemachine]
+    fields:
+      - {name: Refundable, type: bool}
+      - {name: Timeout, type: time.Duration}
+    values:
+      - name: orderPending
+        aliases: [Pending]
+        comment: Waiting for payment
+        fields: {Refundable: true, Timeout: 1h}
+        transitions: [orderShipped]
+      - name: orderShipped
+        value: 10
+        aliases: [Shipped]
+        deprecated: true
+        fields: {Refundable: false, Timeout: 2h}
+        final: true
+      - name: orderLost
+        fields: {Refundable: "false", Timeout: 3h}
+`
+
+const ordersJSON = `{
+	"package": "orders",
+	"enums": [{
+		"type": "orderStatus",
+		"directives": ["-json", "-statemachine"],
+		"fields": [{"name": "Refundable", "type": "bool"}, {"name": "Timeout", "type": "time.Duration"}],
+		"values": [
+			{"name": "orderPending", "aliases": ["Pending"], "fields": {"Refundable": true, "Timeout": "1h"}, "transitions": ["orderShipped"]},
+			{"name": "orderShipped", "value": 10, "aliases": ["Shipped"], "fields": {"Refundable": false, "Timeout": "2h"}, "final": true},
+			{"name": "orderLost", "fields": {"Refundable": "false", "Timeout": "3h"}}
+		]
+	}]
+}`
+
+func parse(t *testing.T, filename, content string, cfg config.Configuration) ([]enum.GenerationRequest, error) {
+	t.Helper()
+	memfs := file.NewMemFS()
+	if err := memfs.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	parser := spec.NewParser(
+		spec.WithSource(source.FromFileSystem(memfs, filename)),
+		spec.WithParserConfiguration(cfg))
+	return parser.Parse(t.Context())
+}
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		filename string
+		content  string
+	}{
+		{filename: "orders.enums.yaml", content: ordersYAML},
+		{filename: "orders.enums.json", content: ordersJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			t.Parallel()
+			reqs, err := parse(t, tt.filename, tt.content, config.Configuration{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(reqs) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(reqs))
+			}
+			req := reqs[0]
+			if req.Package != "orders" || req.OutputFilename != "orders" {
+				t.Errorf("unexpected package %q or output filename %q", req.Package, req.OutputFilename)
+			}
+			if !req.IsValid() {
+				t.Errorf("expected a valid request")
+			}
+			cfg := req.Configuration.GetEnumTypeConfig("orderStatus")
+			if !cfg.Handlers.JSON || !cfg.StateMachine {
+				t.Errorf("expected the directives to apply, got %+v", cfg)
+			}
+			enums := req.EnumIota.Enums
+			if len(enums) != 3 {
+				t.Fatalf("expected 3 values, got %d", len(enums))
+			}
+			for i, want := range []int{0, 10, 11} {
+				if enums[i].Index != want {
+					t.Errorf("expected %s to be %d, got %d", enums[i].Name, want, enums[i].Index)
+				}
+			}
+			if got := enums[0].Fields; len(got) != 2 || got[0].Value != true || got[1].Value != time.Hour {
+				t.Errorf("unexpected fields %+v", got)
+			}
+			if enums[0].StateTransitions[0] != "orderShipped" || !enums[1].IsFinalState {
+				t.Errorf("unexpected state machine %+v", enums)
+			}
+			if len(req.Imports) != 1 || req.Imports[0] != "time" {
+				t.Errorf("expected the time import, got %v", req.Imports)
+			}
+		})
+	}
+}
+
+func TestParser_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{
+			name:    "unknown key",
+			content: "package: orders\nenums:\n  - type: status\n    colour: red\n    values: [{name: a}]\n",
+		},
+		{
+			name:    "no enums",
+			content: "package: orders\n",
+			wantErr: enum.ErrNoEnumsFound,
+		},
+		{
+			name:    "invalid type name",
+			content: "package: orders\nenums:\n  - type: order-status\n    values: [{name: a}]\n",
+		},
+		{
+			name:    "unsupported underlying type",
+			content: "package: orders\nenums:\n  - type: status\n    underlying: string\n    values: [{name: a}]\n",
+		},
+		{
+			name:    "duplicate value",
+			content: "package: orders\nenums:\n  - type: status\n    values: [{name: a}, {name: a}]\n",
+		},
+		{
+			name:    "missing field",
+			content: "package: orders\nenums:\n  - type: status\n    fields: [{name: Code, type: int}]\n    values: [{name: a}]\n",
+		},
+		{
+			name:    "unsupported field type",
+			content: "package: orders\nenums:\n  - type: status\n    fields: [{name: Price, type: money.Amount}]\n    values: [{name: a}]\n",
+			wantErr: enum.ErrUnsupportedType,
+		},
+		{
+			name:    "bad field value",
+			content: "package: orders\nenums:\n  - type: status\n    fields: [{name: Code, type: int}]\n    values: [{name: a, fields: {Code: x}}]\n",
+			wantErr: enum.ErrParseValue,
+		},
+		{
+			name:    "unknown transition",
+			content: "package: orders\nenums:\n  - type: status\n    values: [{name: a, transitions: [b]}]\n",
+		},
+		{
+			name:    "unknown directive",
+			content: "package: orders\nenums:\n  - type: status\n    directives: [-xml]\n    values: [{name: a}]\n",
+			wantErr: config.ErrUnknownDirective,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parse(t, "orders.enums.yaml", tt.content, config.Configuration{})
+			if !errors.Is(err, spec.ErrParseSpec) {
+				t.Fatalf("expected ErrParseSpec, got %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+	reqs, err := parse(t, "orders.enums.yaml", ordersYAML, config.Configuration{BuildTags: []string{"integration"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	memfs := file.NewMemFS()
+	w := spec.NewWriter(spec.WithFileSystem(memfs))
+	if err := w.Write(t.Context(), reqs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("orders_consts.go")
+	if err != nil {
+		t.Fatalf("expected orders_consts.go to be written: %v", err)
+	}
+	for _, want := range []string{
+		"//go:build integration\n\n// Code generated by goenums from orders.enums.yaml. DO NOT EDIT.",
+		"// orderStatus is the lifecycle of an order.\ntype orderStatus int",
+		"\t// Waiting for payment\n\torderPending orderStatus = 0\n",
+		"\t// Deprecated: orderShipped is kept for compatibility.\n\torderShipped orderStatus = 10\n",
+		"\torderLost    orderStatus = 11\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, b)
+		}
+	}
+}
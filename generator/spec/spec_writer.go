@@ -0,0 +1,143 @@
+package spec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+)
+
+var _ enum.Writer = &Writer{}
+
+// ErrWriteConsts is returned when the Go declarations cannot be written.
+var ErrWriteConsts = errors.New("error writing enum declarations")
+
+// Writer implements enum.Writer and writes the type declaration and const
+// block of every enum type in a request to "<output>_consts.go", next to
+// the spec it was read from.
+type Writer struct {
+	Configuration config.Configuration
+	fs            file.ReadCreateWriteFileFS
+}
+
+// WriterOption is a function that configures a Writer.
+type WriterOption func(*Writer)
+
+// WithFileSystem sets the filesystem to use for writing files.
+func WithFileSystem(fs file.ReadCreateWriteFileFS) WriterOption {
+	return func(w *Writer) {
+		w.fs = fs
+	}
+}
+
+// WithWriterConfiguration sets the configuration for the writer.
+func WithWriterConfiguration(configuration config.Configuration) WriterOption {
+	return func(w *Writer) {
+		w.Configuration = configuration
+	}
+}
+
+// NewWriter creates a new declaration writer, writing to the operating
+// system filesystem by default.
+func NewWriter(opts ...WriterOption) *Writer {
+	w := Writer{
+		Configuration: config.Configuration{},
+		fs:            &file.OSReadWriteFileFS{},
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return &w
+}
+
+var (
+	constsStr = `
+{{- if .BuildConstraint }}
+//go:build {{ .BuildConstraint }}
+
+{{ end -}}
+// Code generated by goenums from {{ .Spec }}. DO NOT EDIT.
+
+package {{ .Package }}
+{{ range $enum := .EnumIotas }}
+{{- if .Doc }}
+{{ comment .Doc }}
+{{- end }}
+type {{ .Type }} {{ .UnderlyingType }}
+
+const (
+{{- range .Enums }}
+	{{- if .CustomComment }}
+	{{ comment .CustomComment }}
+	{{- if .Deprecated }}
+	//
+	{{- end }}
+	{{- end }}
+	{{- if .Deprecated }}
+	// Deprecated: {{ .Name }} is kept for compatibility.
+	{{- end }}
+	{{ .Name }} {{ $enum.Type }} = {{ .Literal }}
+{{- end }}
+)
+{{ end }}`
+	constsTemplate = template.Must(template.New("consts").Funcs(template.FuncMap{
+		"comment": comment,
+	}).Parse(constsStr))
+)
+
+// comment formats text as a line comment, one line per line of text.
+func comment(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace("// " + line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+type constsData struct {
+	Spec            string
+	Package         string
+	BuildConstraint string
+	EnumIotas       []enum.EnumIota
+}
+
+// Write emits the Go declarations of each request's enum types.
+func (w *Writer) Write(ctx context.Context, reqs []enum.GenerationRequest) error {
+	for _, req := range reqs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !req.IsValid() {
+			return fmt.Errorf("invalid enum: %s", req.SourceFilename)
+		}
+		dirPath := filepath.Dir(req.SourceFilename)
+		if !filepath.IsLocal(dirPath) {
+			return fmt.Errorf("invalid path: %s", dirPath)
+		}
+		outFilename := fmt.Sprintf("%s_consts.go", req.OutputFilename)
+		if strings.ContainsAny(outFilename, " /") {
+			return fmt.Errorf("%w: '%s' contains invalid characters", ErrWriteConsts, outFilename)
+		}
+		fullPath := filepath.Clean(filepath.Join(dirPath, outFilename))
+		err := file.WriteToFileAndFormatFS(ctx, w.fs, fullPath, true,
+			func(out io.Writer) error {
+				return constsTemplate.Execute(out, constsData{
+					Spec:            filepath.Base(req.SourceFilename),
+					Package:         req.Package,
+					BuildConstraint: req.BuildConstraint,
+					EnumIotas:       req.GetEnumIotas(),
+				})
+			})
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrWriteConsts, fullPath, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package xstate exports state machines as XState machine configurations.
+//
+// The Writer emits one "<enum>.xstate.json" file per enum type generated
+// with -statemachine, next to the source it was parsed from. The document
+// is the JSON form of an XState (https://stately.ai/docs/xstate) machine
+// config, so frontends and visual statechart editors can load the same
+// transitions the generated Go code enforces.
+package xstate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/internal/naming"
+	"github.com/donutnomad/goenums/strings"
+)
+
+var _ enum.Writer = &Writer{}
+
+// ErrWriteMachine is returned when a machine document cannot be written.
+var ErrWriteMachine = errors.New("error writing xstate machine")
+
+// Machine is the XState machine config of a single enum type.
+type Machine struct {
+	ID          string           `json:"id"`
+	Description string           `json:"description,omitempty"`
+	Initial     string           `json:"initial,omitempty"`
+	States      map[string]State `json:"states"`
+}
+
+// State is a state node of a Machine.
+type State struct {
+	// Type is "final" for final states
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	// On maps the events leaving the state to their target state
+	On map[string]string `json:"on,omitempty"`
+}
+
+// FromEnum returns the machine of enumIota. States are named as the
+// generated String method names their values, and the initial state is the
+// first valid value. Transitions without an event are taken on the event
+// named as their target; of several transitions fired by the same event,
+// the first declared is kept, as Fire does. Invalid values are left out.
+func FromEnum(enumIota enum.EnumIota) (Machine, error) {
+	m := Machine{
+		ID:          naming.TypeName(enumIota.Type),
+		Description: enumIota.Doc,
+		States:      make(map[string]State),
+	}
+	for _, e := range enumIota.Enums {
+		if !e.Valid {
+			continue
+		}
+		name := naming.DisplayName(e)
+		if m.Initial == "" {
+			m.Initial = name
+		}
+		s := State{Description: e.CustomComment}
+		if e.IsFinalState {
+			s.Type = "final"
+		}
+		for i, t := range e.StateTransitions {
+			to, ok := enumIota.State(t)
+			if !ok {
+				return Machine{}, fmt.Errorf("%w: %s -> %s", enum.ErrUnknownState, e.Name, t)
+			}
+			event := naming.DisplayName(to)
+			if i < len(e.StateEvents) && e.StateEvents[i] != "" {
+				event = e.StateEvents[i]
+			}
+			if s.On == nil {
+				s.On = make(map[string]string)
+			}
+			if _, ok := s.On[event]; !ok {
+				s.On[event] = naming.DisplayName(to)
+			}
+		}
+		m.States[name] = s
+	}
+	return m, nil
+}
+
+// Writer implements enum.Writer and writes an XState machine config for
+// every state machine in a request.
+type Writer struct {
+	Configuration config.Configuration
+	fs            file.ReadCreateWriteFileFS
+}
+
+// WriterOption is a function that configures a Writer.
+type WriterOption func(*Writer)
+
+// WithFileSystem sets the filesystem to use for writing files.
+func WithFileSystem(fs file.ReadCreateWriteFileFS) WriterOption {
+	return func(w *Writer) {
+		w.fs = fs
+	}
+}
+
+// WithWriterConfiguration sets the configuration for the writer.
+func WithWriterConfiguration(configuration config.Configuration) WriterOption {
+	return func(w *Writer) {
+		w.Configuration = configuration
+	}
+}
+
+// NewWriter creates a new XState machine writer, writing to the operating
+// system filesystem by default.
+func NewWriter(opts ...WriterOption) *Writer {
+	w := Writer{
+		Configuration: config.Configuration{},
+		fs:            &file.OSReadWriteFileFS{},
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return &w
+}
+
+// Write emits "<enum>.xstate.json" for each enum type of the requests
+// generated with -statemachine, in the directory of the source it was
+// parsed from. Other enum types are skipped.
+func (w *Writer) Write(ctx context.Context, reqs []enum.GenerationRequest) error {
+	for _, req := range reqs {
+		if !req.IsValid() {
+			return fmt.Errorf("invalid enum: %s", req.SourceFilename)
+		}
+		for _, enumIota := range req.GetEnumIotas() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !req.Configuration.GetEnumTypeConfig(enumIota.Type).StateMachine {
+				continue
+			}
+			m, err := FromEnum(enumIota)
+			if err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrWriteMachine, enumIota.Type, err)
+			}
+			b, err := json.MarshalIndent(m, "", "  ")
+			if err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrWriteMachine, enumIota.Type, err)
+			}
+			path := filepath.Join(filepath.Dir(req.SourceFilename), strings.Snake(m.ID)+".xstate.json")
+			if err := w.fs.WriteFile(path, append(b, '\n'), file.DefaultFilePerms); err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrWriteMachine, path, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package xstate_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/generator/xstate"
+	"github.com/donutnomad/goenums/source"
+)
+
+const statusGo = `package orders
+
+// status is the lifecycle of an order.
+// goenums: -statemachine
+type status int
+
+const (
+	unknown  status = iota // invalid
+	pending                // Pending state: OnPay -> paid, OnCancel -> canceled
+	paid                   // Paid state: -> shipped, OnCancel -> canceled
+	shipped                // Shipped state: [final]
+	canceled               // Canceled state: [final]
+)
+
+type color int
+
+const (
+	red color = iota // Red
+)
+`
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+	memfs := file.NewMemFS()
+	if err := memfs.WriteFile("orders/status.go", []byte(statusGo), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reqs, err := gofile.NewParser(gofile.WithSource(source.FromFileSystem(memfs, "orders/status.go"))).Parse(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := xstate.NewWriter(xstate.WithFileSystem(memfs)).Write(t.Context(), reqs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := memfs.ReadFile("orders/status.xstate.json")
+	if err != nil {
+		t.Fatalf("expected status.xstate.json to be written: %v", err)
+	}
+	var got xstate.Machine
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("invalid machine: %v", err)
+	}
+	want := xstate.Machine{
+		ID:          "Status",
+		Description: "status is the lifecycle of an order.",
+		Initial:     "Pending",
+		States: map[string]xstate.State{
+			"Pending":  {On: map[string]string{"OnPay": "Paid", "OnCancel": "Canceled"}},
+			"Paid":     {On: map[string]string{"Shipped": "Shipped", "OnCancel": "Canceled"}},
+			"Shipped":  {Type: "final"},
+			"Canceled": {Type: "final"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected machine\n got: %+v\nwant: %+v", got, want)
+	}
+	if _, err := memfs.ReadFile("orders/color.xstate.json"); err == nil {
+		t.Error("expected no machine for an enum without -statemachine")
+	}
+}
+
+func TestFromEnum_UnknownState(t *testing.T) {
+	t.Parallel()
+	_, err := xstate.FromEnum(enum.EnumIota{Type: "status", Enums: []enum.Enum{
+		{Name: "pending", Valid: true, StateTransitions: []string{"payed"}},
+	}})
+	if !errors.Is(err, enum.ErrUnknownState) {
+		t.Errorf("expected error %v, got %v", enum.ErrUnknownState, err)
+	}
+}
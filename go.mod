@@ -4,4 +4,12 @@ go 1.24
 
 require golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b
 
-require gopkg.in/yaml.v3 v3.0.1 // indirect
+require gopkg.in/yaml.v3 v3.0.1
+
+require sigs.k8s.io/yaml v1.4.0
+
+require (
+	golang.org/x/mod v0.25.0
+	golang.org/x/sync v0.15.0 // indirect
+	golang.org/x/tools v0.34.0
+)
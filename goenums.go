@@ -82,9 +82,27 @@
 //	    fmt.Println("Status:", status.String())
 //	}
 //
+// ## Spec Files
+//
+// Enums can also be declared in a language-neutral YAML or JSON spec, such as
+// orders.enums.yaml, from which both the Go const block (orders_consts.go)
+// and the enum implementation (orders_enums.go) are generated:
+//
+//	package: orders
+//	enums:
+//	  - type: orderStatus
+//	    directives: [-json]
+//	    values:
+//	      - {name: orderPending, aliases: [Pending]}
+//	      - {name: orderShipped, aliases: [Shipped]}
+//
+// OpenAPI 3 documents are recognised by their "openapi" field; every enum
+// schema they declare is generated the same way, as is every enum of a
+// .proto file.
+//
 // # Command Line Options
 //
-//	goenums [options] file.go
+//	goenums [options] file.go|file.enums.yaml|dir|dir/...|url [...]
 //
 //	-f, -failfast      Fail on invalid enum values during parsing
 //	-l, -legacy        Generate code without Go 1.23+ iterator support
@@ -92,16 +110,130 @@
 //	-c, -constraints   Generate constraints locally instead of importing
 //	-v, -version       Show version information
 //	-h, -help          Show help information
-//	-vv, -verbose      Enable verbose output
-//	-o, -output        Specify output format (default: go)
+//	-vv, -verbose      Enable verbose output, with timings of every file
+//	-q, -quiet         Only log errors
+//	-log-level         Minimum level logged: debug, info, warn or error
+//	-log-format        Format logs are written in: text (default) or json
+//	-o, -output        Comma-separated output formats: go, jsonschema, openapi, avro, xstate (default: go)
+//	-j, -jobs          Maximum number of files generated concurrently (default: GOMAXPROCS)
+//	-stdout            Write the generated Go code to stdout instead of a file
+//	-diagram           Comma-separated formats state machines are drawn in: mermaid, dot
+//	-migrations        Write incremental SQL migrations for changed enums to a directory
+//	-migration-format  Migration layout: golang-migrate (default) or liquibase
+//	-yaml-library      YAML library targeted by -yaml: yaml.v3 (default), goccy, sigs.k8s.io or text
+//	-tags              Comma-separated build tags the generated file is guarded by
+//	-section-order     Comma-separated order of the sections generated for each enum
+//	-skip              Comma-separated sections not generated for each enum
+//	-only              Comma-separated optional sections generated for each enum
+//	-plurals           Comma-separated singular:plural words naming containers and wrappers
+//	-cpuprofile        Write a CPU profile of the run to a file
+//	-memprofile        Write a memory profile at the end of the run to a file
+//	-trace             Write an execution trace of the run to a file
+//	-plugin            Command appending its code to every generated file, see below; repeatable
+//	-compat            Generate the methods of stringer or enumer, see below
+//
+// Every per-type directive (-json, -json/null, -yaml, -text, -binary, -sql,
+// -sql/array, -sql/column, -sqlboiler, -bun, -avro, -mapstructure, -http, -redis, -kafka,
+// -serde/value, -serde/object, -genName, -uppercaseFields, -statemachine,
+// -statemachine/history, -suggest, -match, -registry, -schemahash, -fields,
+// -default-on-error, -atomic, -prometheus, -stringer, -order, -sqltype, -invalid,
+// -invalid/all, -migrate/enum, -compat/zarldev) is also accepted as a flag and becomes the
+// default for all enum types. Directives are applied on top of these
+// defaults; "-json=false" and the like switch a default off for a single type.
+//
+// # Generating Many Files
+//
+// Any number of inputs may be given. A directory stands for the enum sources
+// it contains: Go files with a goenums directive or go:generate line, and
+// "*.enums.yaml", "*.enums.yml" or "*.enums.json" specs. "dir/..." also
+// searches its subdirectories. Files are generated concurrently, at most
+// -jobs at a time; every file is attempted and all failures are reported
+// with their filename, unless -failfast stops at the first one.
+//
+// # Plugins
+//
+// -plugin runs a command once per generated Go file, such as
+// "-plugin ./tools/audit-methods". The command reads a JSON description of
+// the enums of the file from stdin and writes to stdout a JSON object
+// whose "code" is appended to the file and whose "imports" are added to its
+// imports; see generator.PluginRequest and generator.PluginResponse.
+// Programs embedding goenums register Go plugins with
+// generator.RegisterPlugin instead.
+//
+// # Piping
+//
+// "-" reads Go source from stdin, and -stdout writes the generated code to
+// stdout instead of a file next to the source; reading from stdin implies
+// -stdout. Both take a single input and the go output only, and logs are
+// written to stderr:
+//
+//	goenums - < status.go > status_enums.go
+//
+// # Listing Enums
+//
+//	goenums list [-json] [path ...]
+//
+// prints every enum type detected in the inputs, with its values, aliases,
+// handlers and output file, without generating anything. Inputs that yield
+// no enums are listed with the reason, and make the command exit with
+// status 1. Paths default to the current directory and are expanded like
+// generation inputs.
+//
+// # Comparing Revisions
+//
+//	goenums diff [-json] [old] new
+//
+// reports the constants added, removed, renamed, renumbered or given another
+// serialized name between two revisions of a source. A revision is a file,
+// or "rev:path" naming a file in a git revision, and a single file is
+// compared with its version at HEAD. Changes that make persisted data decode
+// differently, such as renumbering an enum serialized by value, are marked
+// breaking and make the command exit with status 1.
+//
+// # Verifying Generated Files
+//
+//	goenums verify [path ...]
+//
+// reports generated files whose goenums version differs in major or minor
+// version from the goenums runtime required in go.mod, and exits with status
+// 1 if there are any. Paths default to the current directory and are
+// searched recursively.
+//
+// # Removing Generated Files
+//
+//	goenums clean [-n] [path ...]
+//
+// removes every file carrying the goenums generated header, such as the
+// outputs left behind when a source is renamed, and prints their names. With
+// -n the files are only printed. Paths default to the current directory and
+// are searched recursively.
+//
+// # Migrating from stringer and enumer
+//
+//	goenums -compat=stringer|enumer -type=T[,T...] [flags] [dir|files]
+//
+// generates the methods stringer or enumer would, on the constant type
+// itself, and accepts their flags: -type, -output, -trimprefix, -linecomment
+// and -tags, and for enumer -transform, -json, -text, -yaml and -sql.
+// Replacing the command in existing go:generate lines is enough to move a
+// package to goenums; the types can then be converted one at a time.
+//
+//	goenums migrate [-n] [path ...]
+//
+// does the replacing: it rewrites the go:generate lines running stringer or
+// enumer to run goenums -compat with the same flags, and regenerates their
+// files. With -n the rewritten lines are only printed. Lines using flags
+// goenums does not accept, go-enum, and files generated by these tools
+// without a go:generate line next to them are reported, and make the
+// command exit with status 1.
 //
 // # Design Philosophy
 //
 // The tool follows a modular, interface-based architecture that separates
 // content sourcing, parsing, and code generation. This design enables:
 //
-//   - Support for different input formats (currently Go, extensible to others)
-//   - Multiple output targets (currently Go, extensible to other languages)
+//   - Support for different input formats (currently Go and YAML/JSON specs)
+//   - Multiple output targets (currently Go, JSON Schema, OpenAPI, Avro and XState)
 //   - Clean separation of concerns between components
 //   - Easy testing and maintenance of individual components
 //   - Future extensibility without breaking existing functionality
@@ -115,28 +247,37 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"os/signal"
-	"path/filepath"
+	"slices"
 	"syscall"
 	"text/template"
 
-	"github.com/donutnomad/goenums/enum"
-
-	"github.com/donutnomad/goenums/generator"
 	"github.com/donutnomad/goenums/generator/config"
-	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/internal/clean"
+	"github.com/donutnomad/goenums/internal/verify"
 	"github.com/donutnomad/goenums/internal/version"
 	"github.com/donutnomad/goenums/logging"
-	"github.com/donutnomad/goenums/source"
 	"github.com/donutnomad/goenums/strings"
 )
 
 // Define flag groups
 type flags struct {
 	help, version, failfast, legacy, insensitive, verbose, constraints bool
-	output                                                             string
+	stdout, quiet                                                      bool
+	output, migrations, migrationFormat, yamlLibrary, tags, logFormat  string
+	cpuProfile, memProfile, trace                                      string
+	sectionOrder, skip, only, diagrams                                 string
+	jobs                                                               int
+	plurals                                                            map[string]string
+	plugins                                                            []string
+	// logLevel is set by -log-level, overriding -verbose and -quiet
+	logLevel *slog.Level
+	// defaults mirrors the "// goenums:" directives, applied to every enum type
+	defaults                             config.EnumTypeConfig
+	serdeValue, serdeObject, migrateEnum bool
 	// Deprecated: uppercaseFields and generateNameConstants are now specified per-enum-type in goenums comments
 }
 
@@ -160,12 +301,169 @@ func parseFlags() (flags, []string) {
 	flag.BoolVar(&f.verbose, "verbose", false,
 		"Enable verbose mode - prints out the generated code (default: false)")
 	flag.BoolVar(&f.verbose, "vv", false, "")
+	flag.BoolVar(&f.quiet, "quiet", false,
+		"Only log errors (default: false)")
+	flag.BoolVar(&f.quiet, "q", false, "")
+	flag.Func("log-level",
+		"Minimum level of the logs written: debug, info, warn or error; overrides -verbose and -quiet (default: info)",
+		func(value string) error {
+			level, err := logging.ParseLevel(value)
+			f.logLevel = &level
+			return err
+		})
+	flag.Func("log-format",
+		"Format the logs are written in: text or json (default: text)",
+		func(value string) error {
+			if value != logging.FormatText && value != logging.FormatJSON {
+				return fmt.Errorf("unknown log format %q: must be text or json", value)
+			}
+			f.logFormat = value
+			return nil
+		})
 	flag.StringVar(&f.output, "output", "",
-		"Specify the output format (default: go)")
+		"Comma-separated output formats: go, jsonschema, openapi, avro, xstate (default: go)")
 	flag.StringVar(&f.output, "o", "", "")
 	flag.BoolVar(&f.constraints, "constraints", false,
 		"Specify whether to generate the float and integer constraints or import 'golang.org/x/exp/constraints' (default: false - imports)")
 	flag.BoolVar(&f.constraints, "c", false, "")
+	flag.StringVar(&f.diagrams, "diagram", "",
+		"Comma-separated formats the state machines are drawn in: mermaid, dot (default: none)")
+	flag.StringVar(&f.migrations, "migrations", "",
+		"Write incremental SQL migrations for changed enums to the given directory (default: disabled)")
+	flag.StringVar(&f.migrationFormat, "migration-format", "",
+		"Specify the migration layout: golang-migrate or liquibase (default: golang-migrate)")
+	flag.StringVar(&f.yamlLibrary, "yaml-library", "",
+		"Specify the YAML library targeted by -yaml: yaml.v3, goccy, sigs.k8s.io or text (default: yaml.v3)")
+	flag.StringVar(&f.sectionOrder, "section-order", "",
+		"Comma-separated order of the sections generated for each enum; omitted sections follow in the default order (default: "+strings.Join(config.DefaultSectionOrder, ",")+")")
+	flag.StringVar(&f.skip, "skip", "",
+		"Comma-separated sections not generated for each enum (default: none)")
+	flag.StringVar(&f.only, "only", "",
+		"Comma-separated optional sections generated for each enum, leaving out the others; "+strings.Join(config.RequiredSections, ",")+" are always generated (default: all)")
+	flag.Func("plurals",
+		"Comma-separated singular:plural words, such as schema:schemata, overriding the plurals naming the containers and the singulars naming the wrappers (default: none)",
+		func(list string) error {
+			plurals, err := config.ParsePlurals(list)
+			if err != nil {
+				return err
+			}
+			f.plurals = plurals
+			return nil
+		})
+	flag.Func("plugin",
+		"Command run for every generated Go file, reading the enums as JSON from stdin and writing the code appended to the file; repeatable (default: none)",
+		func(command string) error {
+			if strings.TrimSpace(command) == "" {
+				return errors.New("empty plugin command")
+			}
+			f.plugins = append(f.plugins, command)
+			return nil
+		})
+	flag.BoolVar(&f.stdout, "stdout", false,
+		"Write the generated Go code of a single input to stdout instead of a file; implied when reading from stdin with - (default: false)")
+	flag.IntVar(&f.jobs, "jobs", 0,
+		"Maximum number of files generated concurrently (default: GOMAXPROCS)")
+	flag.IntVar(&f.jobs, "j", 0, "")
+	flag.StringVar(&f.cpuProfile, "cpuprofile", "",
+		"Write a CPU profile of the run to the given file (default: disabled)")
+	flag.StringVar(&f.memProfile, "memprofile", "",
+		"Write a memory profile at the end of the run to the given file (default: disabled)")
+	flag.StringVar(&f.trace, "trace", "",
+		"Write an execution trace of the run to the given file (default: disabled)")
+	flag.StringVar(&f.tags, "tags", "",
+		"Comma-separated build tags to generate for; the output only compiles with them (default: none)")
+	// Only shown in the help: -compat is handled before the flags are parsed
+	flag.String("compat", "",
+		"Generate the methods of stringer or enumer instead, accepting their flags: -compat=stringer|enumer -type=T (default: disabled)")
+	// Defaults for the per-type directives, named after the directives themselves
+	flag.BoolVar(&f.defaults.Handlers.JSON, "json", false,
+		"Generate JSON marshaling for every enum, like the -json directive (default: false)")
+	flag.BoolVar(&f.defaults.JSONNull, "json/null", false,
+		"Marshal invalid values of every enum as JSON null, like the -json/null directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.YAML, "yaml", false,
+		"Generate YAML marshaling for every enum, like the -yaml directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.Text, "text", false,
+		"Generate text marshaling for every enum, like the -text directive (default: false)")
+	flag.Var(binaryFlag{&f.defaults}, "binary",
+		"Generate binary marshaling for every enum, like the -binary directive; -binary=varint,little-endian,length-prefixed selects the encoding (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.SQL, "sql", false,
+		"Generate SQL Scanner and Valuer for every enum, like the -sql directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.SQLArray, "sql/array", false,
+		"Generate a slice type stored in Postgres array columns for every enum, like the -sql/array directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.SQLColumn, "sql/column", false,
+		"Generate the column definition and a schema drift check for every enum, like the -sql/column directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.SQLBoiler, "sqlboiler", false,
+		"Generate a nullable type and sqlboiler Randomize methods for every enum, like the -sqlboiler directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.Bun, "bun", false,
+		"Generate the SQL literal of values for bun.Safe for every enum, like the -bun directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.Avro, "avro", false,
+		"Generate the Avro schema and text methods for every enum, like the -avro directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.Mapstructure, "mapstructure", false,
+		"Generate a mapstructure decode hook for every enum, like the -mapstructure directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.HTTP, "http", false,
+		"Generate query and form parsing helpers and Gin and Echo binding for every enum, like the -http directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.Redis, "redis", false,
+		"Generate redigo and go-redis methods storing every enum as text, like the -redis directive (default: false)")
+	flag.BoolVar(&f.defaults.Handlers.Kafka, "kafka", false,
+		"Generate the Schema Registry schemas and a Kafka serializer for every enum, like the -kafka directive (default: false)")
+	flag.BoolVar(&f.serdeValue, "serde/value", false,
+		"Serialize every enum by its underlying value, like the -serde/value directive (default: false - by name)")
+	flag.BoolVar(&f.serdeObject, "serde/object", false,
+		"Serialize every enum to JSON as an object of its name, value and fields, like the -serde/object directive (default: false - by name)")
+	flag.BoolVar(&f.defaults.GenerateNameConstants, "genName", false,
+		"Generate name constants for every enum, like the -genName directive (default: false)")
+	flag.BoolVar(&f.defaults.UppercaseFields, "uppercaseFields", false,
+		"Generate uppercase container fields for every enum, like the -uppercaseFields directive (default: false)")
+	flag.BoolVar(&f.defaults.StateMachine, "statemachine", false,
+		"Generate state machine methods for every enum, like the -statemachine directive (default: false)")
+	flag.BoolVar(&f.defaults.StateHistory, "statemachine/history", false,
+		"Generate a transition history type for every state machine, like the -statemachine/history directive (default: false)")
+	flag.BoolVar(&f.defaults.Suggest, "suggest", false,
+		"Suggest the closest name on parse failures for every enum, like the -suggest directive (default: false)")
+	flag.StringVar(&f.defaults.Stringer, "stringer", "",
+		"Look up names in String with a map or a switch for every enum, like the -stringer directive (default: map)")
+	flag.Func("order",
+		"Order the values of every enum in All, the container and the names maps by source, value or name, like the -order directive (default: source)",
+		func(order string) error {
+			cfg, err := f.defaults.ApplyDirectives([]string{"-order=" + order})
+			if err != nil {
+				return err
+			}
+			f.defaults = cfg
+			return nil
+		})
+	flag.StringVar(&f.defaults.SQLType, "sqltype", "",
+		"Store every enum as a string, int or bool in SQL, like the -sqltype directive (default: following the serialization)")
+	flag.BoolVar(&f.defaults.Match, "match", false,
+		"Generate an exhaustive Match function for every enum, like the -match directive (default: false)")
+	flag.BoolVar(&f.defaults.Registry, "registry", false,
+		"Register every enum in the EnumRegistry of its package, like the -registry directive (default: false)")
+	flag.BoolVar(&f.defaults.SchemaHash, "schemahash", false,
+		"Generate a schema fingerprint constant for every enum, like the -schemahash directive (default: false)")
+	flag.BoolVar(&f.defaults.FieldAccessors, "fields", false,
+		"Generate field getters, lookups and iterators for every enum with fields, like the -fields directive (default: false)")
+	flag.BoolVar(&f.defaults.DefaultOnError, "default-on-error", false,
+		"Unmarshal invalid input of every enum to its declared default, like the -default-on-error directive (default: false)")
+	flag.BoolVar(&f.defaults.Atomic, "atomic", false,
+		"Generate an atomic holder type for every enum, like the -atomic directive (default: false)")
+	flag.BoolVar(&f.defaults.Prometheus, "prometheus", false,
+		"Generate metric label values and series initialisation for every enum, like the -prometheus directive (default: false)")
+	flag.BoolVar(&f.defaults.ZarldevCompat, "compat/zarldev", false,
+		"Generate the zarldev/goenums API as deprecated aliases for every enum, like the -compat/zarldev directive (default: false)")
+	flag.Func("invalid",
+		"Export the invalid value of every enum under the given name, like the -invalid directive (default: unexported)",
+		func(name string) error {
+			cfg, err := f.defaults.ApplyDirectives([]string{"-invalid=" + name})
+			if err != nil {
+				return err
+			}
+			f.defaults = cfg
+			return nil
+		})
+	flag.BoolVar(&f.defaults.InvalidInAll, "invalid/all", false,
+		"Include the named invalid value of every enum in All, like the -invalid/all directive (default: false)")
+	flag.BoolVar(&f.migrateEnum, "migrate/enum", false,
+		"Constrain every enum with a native enum type in migrations, like the -migrate/enum directive (default: false - CHECK)")
 	// Deprecated: These flags are now specified per-enum-type in goenums comments
 	// flag.BoolVar(&f.uppercaseFields, "uppercase-fields", false,
 	//	"Generate container struct field names in uppercase (e.g., STEP1INITIALIZED) instead of camelCase (default: false - camelCase)")
@@ -174,9 +472,47 @@ func parseFlags() (flags, []string) {
 	//	"Generate enum name constants (e.g., TokenRequestStatusName) instead of string slicing for NamesMap (default: false)")
 	// flag.BoolVar(&f.generateNameConstants, "g", false, "")
 	flag.Parse()
+	if f.serdeValue {
+		f.defaults.SerializationType = config.SerdeValue
+	}
+	if f.serdeObject {
+		f.defaults.SerializationType = config.SerdeObject
+	}
+	if f.migrateEnum {
+		f.defaults.MigrationStyle = config.MigrationNativeEnum
+	}
 	return f, flag.Args()
 }
 
+// binaryFlag is the -binary flag, which like the -binary directive is
+// either a boolean or an encoding.
+type binaryFlag struct {
+	defaults *config.EnumTypeConfig
+}
+
+func (b binaryFlag) String() string {
+	if b.defaults == nil || !b.defaults.Handlers.Binary {
+		return "false"
+	}
+	if b.defaults.BinaryEncoding != "" {
+		return b.defaults.BinaryEncoding
+	}
+	return "true"
+}
+
+func (b binaryFlag) Set(value string) error {
+	cfg, err := b.defaults.ApplyDirectives([]string{"-binary=" + value})
+	if err != nil {
+		return err
+	}
+	*b.defaults = cfg
+	return nil
+}
+
+func (b binaryFlag) IsBoolFlag() bool {
+	return true
+}
+
 const (
 	colorReset       = "\033[0m"
 	colorBlue        = "\033[34m"
@@ -247,10 +583,21 @@ func main() {
 	if err != nil {
 		return
 	}
-	logging.Configure(config.Verbose)
-
-	logo()
-	slog.Default().Info(fmt.Sprintf("\t\tversion: %s", version.CURRENT))
+	if config.Stdout {
+		// Keep stdout for the generated code
+		configureLogging(os.Stderr, config)
+	} else {
+		configureLogging(os.Stdout, config)
+		// The logo is noise in quiet runs and would break JSON logs
+		if config.LogLevel <= slog.LevelInfo && config.LogFormat != logging.FormatJSON {
+			logo()
+		}
+	}
+	if config.LogFormat == logging.FormatJSON {
+		slog.Default().Info("goenums", slog.String("version", version.CURRENT))
+	} else {
+		slog.Default().Info(fmt.Sprintf("\t\tversion: %s", version.CURRENT))
+	}
 	slog.Default().Debug("starting generation...")
 	slog.Default().Debug("config settings",
 		slog.Int("file_count", len(config.Filenames)),
@@ -259,72 +606,33 @@ func main() {
 		slog.Bool("failfast", config.Failfast),
 		slog.Bool("legacy", config.Legacy),
 		slog.Bool("insensitive", config.Insensitive),
-		slog.Bool("verbose", config.Verbose))
-
-	for _, filename := range config.Filenames {
-		filename = strings.TrimSpace(filename)
-		if filename == "" {
-			continue
-		}
-		slog.Default().Info("processing file", slog.String("filename", filename))
-		var (
-			parser enum.Parser
-			writer enum.Writer
-		)
-
-		inExt := filepath.Ext(filename)
-		switch inExt {
-		case ".go":
-			slog.Default().Debug("initializing go parser")
-			parser = gofile.NewParser(
-				gofile.WithParserConfiguration(config),
-				gofile.WithSource(source.FromFile(filename)))
-		default:
-			slog.Default().Error("only .go files are supported")
-			return
-		}
-
-		switch config.OutputFormat {
-		case "", "go":
-			slog.Default().Debug("initializing gofile writer")
-			writer = gofile.NewWriter(gofile.WithWriterConfiguration(config))
-		default:
-			slog.Default().Error("only outputting to go files is supported")
-			return
-		}
+		slog.Bool("verbose", config.Verbose),
+		slog.String("migrations", config.MigrationsDir),
+		slog.Int("jobs", config.Jobs))
 
-		slog.Default().Debug("initializing generator")
-		gen := generator.New(
-			generator.WithConfig(config),
-			generator.WithParser(parser),
-			generator.WithWriter(writer))
-		slog.Default().Info("starting parsing and generation")
-		if err := gen.ParseAndWrite(ctx); err != nil {
-			if errors.Is(err, enum.ErrParseSource) {
-				slog.Default().Error("unable to parse file", slog.String("filename", filename))
-				slog.Default().Error("please ensure that the file is a valid input file")
-				slog.Default().Error("for the selected parser")
-			}
-			if errors.Is(err, enum.ErrNoEnumsFound) {
-				slog.Default().Error("no enums found in file", slog.String("filename", filename))
-				slog.Default().Error("please ensure that the file contains enum definitions")
-			}
-			if errors.Is(err, enum.ErrWriteOutput) {
-				slog.Default().Error("could not generate output")
-				slog.Default().Error("please ensure that the output destination is writable")
-				slog.Default().Error("and that input enums contain only valid characters")
-			}
-			slog.Default().Error("could not generate enums", slog.String("error", err.Error()))
-			slog.Default().Error("exiting")
-			return
-		}
-		slog.Default().Info("successfully generated enums")
+	stopProfiling, err := startProfiling(config)
+	if err != nil {
+		slog.Default().Error("could not start profiling", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer stopProfiling()
+	if err := generateAll(ctx, config, config.Filenames); err != nil {
+		slog.Default().Error("exiting")
+		// os.Exit skips the deferred calls, so the profiles are written first
+		stopProfiling()
+		os.Exit(1)
 	}
+	slog.Default().Info("successfully generated enums", slog.Int("file_count", len(config.Filenames)))
 }
 
 var ErrComplete = errors.New("completed")
 
 func configuration(ctx context.Context) (config.Configuration, error) {
+	if mode, args, ok := compatArgs(os.Args[1:]); ok {
+		runCompat(ctx, mode, args)
+		return config.Configuration{}, ErrComplete
+	}
+
 	f, args := parseFlags()
 
 	if f.help {
@@ -337,33 +645,89 @@ func configuration(ctx context.Context) (config.Configuration, error) {
 		return config.Configuration{}, ErrComplete
 	}
 
-	if len(args) < 1 {
-		slog.Default().ErrorContext(ctx, "you must specify at least one input file")
+	if len(args) > 0 && args[0] == "verify" {
+		runVerify(ctx, args[1:])
 		return config.Configuration{}, ErrComplete
 	}
 
-	filenames := args
+	if len(args) > 0 && args[0] == "diff" {
+		runDiff(ctx, newConfiguration(f), args[1:])
+		return config.Configuration{}, ErrComplete
+	}
 
-	for _, filename := range filenames {
-		filename = strings.TrimSpace(filename)
-		if filename == "" {
-			continue
-		}
+	if len(args) > 0 && args[0] == "migrate" {
+		runMigrate(ctx, args[1:])
+		return config.Configuration{}, ErrComplete
+	}
 
-		if _, err := os.Stat(filename); os.IsNotExist(err) {
-			slog.Default().ErrorContext(ctx, "input file does not exist", slog.String("filename", filename))
-			return config.Configuration{}, fmt.Errorf("input file does not exist %s", filename)
-		}
+	if len(args) > 0 && args[0] == "clean" {
+		runClean(ctx, args[1:])
+		return config.Configuration{}, ErrComplete
+	}
+
+	if len(args) > 0 && args[0] == "list" {
+		runList(ctx, newConfiguration(f), args[1:])
+		return config.Configuration{}, ErrComplete
 	}
 
-	config := config.Configuration{
-		Failfast:     f.failfast,
-		Insensitive:  f.insensitive,
-		Legacy:       f.legacy,
-		Verbose:      f.verbose,
-		OutputFormat: f.output,
-		Filenames:    filenames,
-		Constraints:  f.constraints,
+	if len(args) < 1 {
+		slog.Default().ErrorContext(ctx, "you must specify at least one input file")
+		return config.Configuration{}, ErrComplete
+	}
+
+	filenames, err := expandInputs(args)
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "could not read inputs", slog.String("error", err.Error()))
+		return config.Configuration{}, fmt.Errorf("could not read inputs: %w", err)
+	}
+	if len(filenames) == 0 {
+		slog.Default().ErrorContext(ctx, "no enum sources found", slog.String("paths", buildFileList(args)))
+		return config.Configuration{}, ErrComplete
+	}
+	// Standard input has no file to write next to
+	stdout := f.stdout || slices.Contains(filenames, stdinFilename)
+	if stdout && len(filenames) != 1 {
+		slog.Default().ErrorContext(ctx, "-stdout and - take a single input", slog.String("files", buildFileList(filenames)))
+		return config.Configuration{}, ErrComplete
+	}
+	if outputs := config.SplitList(f.output); stdout && len(outputs) > 0 && !slices.Equal(outputs, []string{"go"}) {
+		slog.Default().ErrorContext(ctx, "-stdout only supports the go output", slog.String("output", f.output))
+		return config.Configuration{}, ErrComplete
+	}
+
+	cfg := newConfiguration(f)
+	cfg.Filenames = filenames
+	cfg.Stdout = stdout
+	return cfg, nil
+}
+
+// newConfiguration returns the configuration set by the flags, for any
+// input.
+func newConfiguration(f flags) config.Configuration {
+	return config.Configuration{
+		Failfast:        f.failfast,
+		Insensitive:     f.insensitive,
+		Legacy:          f.legacy,
+		Verbose:         f.verbose,
+		LogLevel:        logLevel(f),
+		LogFormat:       f.logFormat,
+		OutputFormat:    f.output,
+		Jobs:            f.jobs,
+		CPUProfile:      f.cpuProfile,
+		MemProfile:      f.memProfile,
+		Trace:           f.trace,
+		Constraints:     f.constraints,
+		MigrationsDir:   f.migrations,
+		MigrationFormat: f.migrationFormat,
+		YAMLLibrary:     f.yamlLibrary,
+		BuildTags:       config.SplitList(f.tags),
+		SectionOrder:    config.SplitList(f.sectionOrder),
+		SkipSections:    config.SplitList(f.skip),
+		OnlySections:    config.SplitList(f.only),
+		Plurals:         f.plurals,
+		Diagrams:        config.SplitList(f.diagrams),
+		Plugins:         f.plugins,
+		Defaults:        f.defaults,
 		Handlers: config.Handlers{
 			JSON:   false,
 			Text:   false,
@@ -373,13 +737,80 @@ func configuration(ctx context.Context) (config.Configuration, error) {
 		},
 		EnumTypeConfigs: make(map[string]config.EnumTypeConfig),
 	}
-	return config, nil
+}
+
+// logLevel returns the minimum level of the logs written: the -log-level,
+// or error with -quiet, debug with -verbose and info otherwise.
+func logLevel(f flags) slog.Level {
+	switch {
+	case f.logLevel != nil:
+		return *f.logLevel
+	case f.quiet:
+		return slog.LevelError
+	case f.verbose:
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// configureLogging sets up the default logger writing to w as cfg sets.
+func configureLogging(w io.Writer, cfg config.Configuration) {
+	_ = logging.ConfigureWithOptions(w, logging.Options{Level: cfg.LogLevel, Format: cfg.LogFormat})
+}
+
+// runVerify reports generated files whose goenums version does not match
+// the runtime required by their module, exiting with status 1 if any do.
+func runVerify(ctx context.Context, paths []string) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	mismatches, err := verify.Check(paths)
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "could not verify generated files", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	for _, m := range mismatches {
+		slog.Default().ErrorContext(ctx, m.String())
+	}
+	if len(mismatches) > 0 {
+		slog.Default().ErrorContext(ctx, "regenerate the files or update "+verify.ModulePath+" in go.mod")
+		os.Exit(1)
+	}
+	slog.Default().InfoContext(ctx, "generated files match the required goenums runtime")
+}
+
+// runClean removes the generated files found in the paths given in args,
+// or with -n only prints them.
+func runClean(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("clean", flag.ExitOnError)
+	dryRun := fs.Bool("n", false, "Print the generated files without removing them")
+	_ = fs.Parse(args)
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	files, err := clean.Find(paths)
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "could not find generated files", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	for _, name := range files {
+		fmt.Fprintln(os.Stdout, name)
+	}
+	if *dryRun {
+		return
+	}
+	if err := clean.Remove(files); err != nil {
+		slog.Default().ErrorContext(ctx, "could not remove generated files", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	slog.Default().InfoContext(ctx, "removed generated files", slog.Int("count", len(files)))
 }
 
 // printHelp displays usage instructions and command-line options
 func printHelp() {
 	logo()
-	slog.Default().Info("Usage: goenums [options] file.go[,file2.go,...]")
+	slog.Default().Info("Usage: goenums [options] file.go|file.enums.yaml|dir|dir/...|url [...]")
 	slog.Default().Info("Options:")
 	flag.PrintDefaults()
 }
@@ -0,0 +1,110 @@
+// Package clean finds the files goenums generated, so outputs orphaned by
+// renamed or deleted sources can be removed.
+//
+// A file is generated when its leading comments carry the header goenums
+// writes: "// code generated by goenums" in Go files, and "# Code generated
+// by goenums" in YAML documents. Only the comments before the first line of
+// content are read, so sources that merely mention the header, such as
+// goenums' own templates, are never matched.
+package clean
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrClean is returned when the files to clean cannot be read or removed.
+var ErrClean = errors.New("failed to clean generated files")
+
+// generatedHeader matches the header line of every file goenums generates.
+var generatedHeader = regexp.MustCompile(`^(//|#) *[Cc]ode generated by goenums\b`)
+
+// Find returns the generated files found in paths, in lexical order within
+// each path. Directories are searched recursively, skipping hidden
+// directories, vendor and testdata; a trailing "/..." is accepted like in
+// package patterns. Files named explicitly are checked too.
+func Find(paths []string) ([]string, error) {
+	var generated []string
+	for _, path := range paths {
+		if path = strings.TrimSuffix(path, "..."); path == "" {
+			path = "."
+		}
+		err := filepath.WalkDir(path, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				base := d.Name()
+				if name != path && (strings.HasPrefix(base, ".") || base == "vendor" || base == "testdata") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			ok, err := IsGenerated(name)
+			if err != nil {
+				return err
+			}
+			if ok {
+				generated = append(generated, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrClean, err)
+		}
+	}
+	return generated, nil
+}
+
+// IsGenerated reports whether the file carries the goenums header in its
+// leading comments.
+func IsGenerated(name string) (bool, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "":
+			continue
+		case generatedHeader.MatchString(line):
+			return true, nil
+		case strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#"):
+			continue
+		default:
+			return false, nil
+		}
+	}
+	if errors.Is(s.Err(), bufio.ErrTooLong) {
+		// A line this long is not part of a header
+		return false, nil
+	}
+	return false, s.Err()
+}
+
+// Remove deletes the files, attempting every file and reporting those it
+// could not remove.
+func Remove(files []string) error {
+	var errs []error
+	for _, name := range files {
+		if err := os.Remove(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrClean, errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package clean_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/donutnomad/goenums/internal/clean"
+)
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"orders/status.go":           "package orders\n",
+		"orders/status_enums.go":     "// DO NOT EDIT.\n// code generated by goenums v0.4.0 at Jan  1 00:00:00.\n\npackage orders\n",
+		"orders/legacy_enums.go":     "//go:build linux\n\n// DO NOT EDIT.\n// code generated by goenums v0.3.0 at Jan  1 00:00:00.\n\npackage orders\n",
+		"orders/orders_consts.go":    "// Code generated by goenums from orders.enums.yaml. DO NOT EDIT.\n\npackage orders\n",
+		"orders/orders_openapi.yaml": "# Code generated by goenums from orders.go. DO NOT EDIT.\nopenapi: 3.1.0\n",
+		// Mentioning the header after the package clause is not generated
+		"orders/template.go":           "package orders\n\n// code generated by goenums\n",
+		"orders/testdata/old_enums.go": "// code generated by goenums v0.1.0 at Jan  1 00:00:00.\npackage orders\n",
+		".git/old_enums.go":            "// code generated by goenums v0.1.0 at Jan  1 00:00:00.\npackage orders\n",
+	}
+	for name, content := range files {
+		writeFile(t, filepath.Join(dir, name), content)
+	}
+
+	got, err := clean.Find([]string{dir + "/..."})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, name := range got {
+		got[i], _ = filepath.Rel(dir, name)
+	}
+	want := []string{
+		"orders/legacy_enums.go",
+		"orders/orders_consts.go",
+		"orders/orders_openapi.yaml",
+		"orders/status_enums.go",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Find() = %v, want %v", got, want)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	generated := filepath.Join(dir, "status_enums.go")
+	writeFile(t, generated, "// code generated by goenums v0.4.0 at Jan  1 00:00:00.\npackage orders\n")
+	missing := filepath.Join(dir, "missing_enums.go")
+
+	err := clean.Remove([]string{missing, generated})
+	if !errors.Is(err, clean.ErrClean) || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrClean for the missing file, got %v", err)
+	}
+	if _, err := os.Stat(generated); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected %s to be removed after the failure, got %v", generated, err)
+	}
+}
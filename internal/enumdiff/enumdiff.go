@@ -0,0 +1,200 @@
+// Package enumdiff compares the enum types parsed from two revisions of a
+// source, so changes that break persisted data can be caught in review.
+//
+// Whether a change is breaking depends on how the type is serialized. Enums
+// serialized by value persist their numbers, so renumbering a constant makes
+// stored data decode as another value; enums serialized by name persist
+// their names, so renaming the serialized name does. Removing a value or a
+// type, invalidating a value and changing the serialization are breaking
+// either way, since persisted data no longer decodes.
+package enumdiff
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+)
+
+// Kind is the kind of a change.
+type Kind string
+
+// The kinds of changes between two revisions.
+const (
+	TypeAdded            Kind = "type added"
+	TypeRemoved          Kind = "type removed"
+	SerializationChanged Kind = "serialization changed"
+	Added                Kind = "added"
+	Removed              Kind = "removed"
+	// Renamed is a constant replaced by another with the same value
+	Renamed     Kind = "renamed"
+	Renumbered  Kind = "renumbered"
+	NameChanged Kind = "serialized name changed"
+	Invalidated Kind = "invalidated"
+)
+
+// Change is a difference between the enum types of two revisions.
+type Change struct {
+	Type string `json:"type"`
+	Kind Kind   `json:"kind"`
+	// Value is the constant the change is about, empty for type changes
+	Value string `json:"value,omitempty"`
+	// Old and New describe the value, name or serialization before and
+	// after the change; New is the replacing constant of a rename
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+	// Breaking reports whether data persisted before the change no longer
+	// decodes to the same value
+	Breaking bool `json:"breaking"`
+}
+
+func (c Change) String() string {
+	s := c.Type + ": " + string(c.Kind)
+	if c.Value != "" {
+		s += " " + c.Value
+	}
+	switch {
+	case c.Kind == Renamed:
+		s += " to " + c.New
+	case c.Old != "" && c.New != "":
+		s += fmt.Sprintf(" from %s to %s", c.Old, c.New)
+	case c.Old != "":
+		s += fmt.Sprintf(" (%s)", c.Old)
+	case c.New != "":
+		s += fmt.Sprintf(" (%s)", c.New)
+	}
+	if c.Breaking {
+		s += " [breaking]"
+	}
+	return s
+}
+
+// typeInfo is an enum type and the configuration it is generated with.
+type typeInfo struct {
+	enumIota enum.EnumIota
+	cfg      config.EnumTypeConfig
+}
+
+// collect returns the enum types of the requests by name, and their names
+// in declaration order.
+func collect(reqs []enum.GenerationRequest) ([]string, map[string]typeInfo) {
+	var names []string
+	types := make(map[string]typeInfo)
+	for _, req := range reqs {
+		for _, enumIota := range req.GetEnumIotas() {
+			if _, ok := types[enumIota.Type]; !ok {
+				names = append(names, enumIota.Type)
+			}
+			types[enumIota.Type] = typeInfo{enumIota: enumIota, cfg: req.Configuration.GetEnumTypeConfig(enumIota.Type)}
+		}
+	}
+	return names, types
+}
+
+// Compare returns the changes from the enum types of the old requests to
+// those of the new ones, in the order the types and values are declared.
+func Compare(old, new []enum.GenerationRequest) []Change {
+	oldNames, oldTypes := collect(old)
+	newNames, newTypes := collect(new)
+	var changes []Change
+	for _, name := range oldNames {
+		n, ok := newTypes[name]
+		if !ok {
+			changes = append(changes, Change{Type: name, Kind: TypeRemoved, Breaking: true})
+			continue
+		}
+		changes = append(changes, compareType(oldTypes[name], n)...)
+	}
+	for _, name := range newNames {
+		if _, ok := oldTypes[name]; !ok {
+			changes = append(changes, Change{Type: name, Kind: TypeAdded})
+		}
+	}
+	return changes
+}
+
+// Breaking reports whether any of the changes is breaking.
+func Breaking(changes []Change) bool {
+	return slices.ContainsFunc(changes, func(c Change) bool { return c.Breaking })
+}
+
+func compareType(old, new typeInfo) []Change {
+	typeName := new.enumIota.Type
+	var changes []Change
+	if old.cfg.SerializationType != new.cfg.SerializationType {
+		changes = append(changes, Change{Type: typeName, Kind: SerializationChanged,
+			Old: serialization(old.cfg.SerializationType), New: serialization(new.cfg.SerializationType), Breaking: true})
+	}
+	// Objects hold both the name and the value
+	byValue := new.cfg.SerializationType != config.SerdeName
+	byName := new.cfg.SerializationType != config.SerdeValue
+
+	find := func(enums []enum.Enum, name string) (enum.Enum, bool) {
+		i := slices.IndexFunc(enums, func(e enum.Enum) bool { return e.Name == name })
+		if i < 0 {
+			return enum.Enum{}, false
+		}
+		return enums[i], true
+	}
+	var added []enum.Enum
+	for _, e := range new.enumIota.Enums {
+		if _, ok := find(old.enumIota.Enums, e.Name); !ok {
+			added = append(added, e)
+		}
+	}
+	for _, o := range old.enumIota.Enums {
+		n, ok := find(new.enumIota.Enums, o.Name)
+		if !ok {
+			// A constant added with the same value replaces the removed one
+			i := slices.IndexFunc(added, func(e enum.Enum) bool { return e.Literal() == o.Literal() })
+			if i < 0 {
+				changes = append(changes, Change{Type: typeName, Kind: Removed, Value: o.Name, Old: o.Literal(), Breaking: o.Valid})
+				continue
+			}
+			n = added[i]
+			added = slices.Delete(added, i, i+1)
+			changes = append(changes, Change{Type: typeName, Kind: Renamed, Value: o.Name, New: n.Name,
+				Breaking: o.Valid && byName && serializedName(o) != serializedName(n)})
+			continue
+		}
+		if o.Literal() != n.Literal() {
+			changes = append(changes, Change{Type: typeName, Kind: Renumbered, Value: o.Name,
+				Old: o.Literal(), New: n.Literal(), Breaking: o.Valid && byValue})
+		}
+		if serializedName(o) != serializedName(n) {
+			changes = append(changes, Change{Type: typeName, Kind: NameChanged, Value: o.Name,
+				Old: serializedName(o), New: serializedName(n), Breaking: o.Valid && byName})
+		}
+		if o.Valid && !n.Valid {
+			changes = append(changes, Change{Type: typeName, Kind: Invalidated, Value: o.Name, Breaking: true})
+		}
+	}
+	for _, e := range added {
+		changes = append(changes, Change{Type: typeName, Kind: Added, Value: e.Name, New: e.Literal()})
+	}
+	return changes
+}
+
+// serialization returns the name of a serialization type, as accepted by
+// the -serde directives.
+func serialization(t config.SerializationType) string {
+	switch t {
+	case config.SerdeValue:
+		return "value"
+	case config.SerdeObject:
+		return "object"
+	}
+	return "name"
+}
+
+// serializedName returns the name a value is serialized as.
+func serializedName(e enum.Enum) string {
+	switch {
+	case e.SerdeName != "":
+		return e.SerdeName
+	case len(e.Aliases) > 0:
+		return e.Aliases[0]
+	}
+	return e.Name
+}
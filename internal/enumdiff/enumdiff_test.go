@@ -0,0 +1,101 @@
+package enumdiff_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/internal/enumdiff"
+)
+
+func request(serde config.SerializationType, enums ...enum.Enum) []enum.GenerationRequest {
+	enumIota := enum.EnumIota{Type: "status", UnderlyingType: "int", Enums: enums}
+	return []enum.GenerationRequest{{
+		EnumIota:  enumIota,
+		EnumIotas: []enum.EnumIota{enumIota},
+		Configuration: config.Configuration{EnumTypeConfigs: map[string]config.EnumTypeConfig{
+			"status": {SerializationType: serde},
+		}},
+	}}
+}
+
+func value(name string, index int, aliases ...string) enum.Enum {
+	return enum.Enum{Name: name, Index: index, Valid: true, Aliases: aliases}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+	old := []enum.Enum{value("pending", 1, "Pending"), value("shipped", 2, "Shipped"), value("lost", 3)}
+	current := []enum.Enum{value("shipped", 1, "Shipped"), value("pending", 2, "Waiting"), value("missing", 3), value("returned", 4)}
+	tests := []struct {
+		name  string
+		serde config.SerializationType
+		want  []string
+	}{
+		{
+			name:  "by value",
+			serde: config.SerdeValue,
+			want: []string{
+				"status: renumbered pending from 1 to 2 [breaking]",
+				"status: serialized name changed pending from Pending to Waiting",
+				"status: renumbered shipped from 2 to 1 [breaking]",
+				"status: renamed lost to missing",
+				"status: added returned (4)",
+			},
+		},
+		{
+			name:  "by name",
+			serde: config.SerdeName,
+			want: []string{
+				"status: renumbered pending from 1 to 2",
+				"status: serialized name changed pending from Pending to Waiting [breaking]",
+				"status: renumbered shipped from 2 to 1",
+				"status: renamed lost to missing [breaking]",
+				"status: added returned (4)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			changes := enumdiff.Compare(request(tt.serde, old...), request(tt.serde, current...))
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Compare() =\n%q\nwant\n%q", got, tt.want)
+			}
+			if !enumdiff.Breaking(changes) {
+				t.Errorf("expected the changes to be breaking")
+			}
+		})
+	}
+}
+
+func TestCompare_Types(t *testing.T) {
+	t.Parallel()
+	old := request(config.SerdeName, value("pending", 1), enum.Enum{Name: "lost", Index: 2})
+	current := request(config.SerdeValue, value("pending", 1), value("shipped", 3))
+	current[0].EnumIotas = append(current[0].EnumIotas, enum.EnumIota{Type: "color", Enums: []enum.Enum{value("red", 0)}})
+	var got []string
+	for _, c := range enumdiff.Compare(old, current) {
+		got = append(got, c.String())
+	}
+	// Removing an invalid value breaks nothing
+	want := []string{
+		"status: serialization changed from name to value [breaking]",
+		"status: removed lost (2)",
+		"status: added shipped (3)",
+		"color: type added",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Compare() =\n%q\nwant\n%q", got, want)
+	}
+	if changes := enumdiff.Compare(current, old); !slices.ContainsFunc(changes, func(c enumdiff.Change) bool {
+		return c.Kind == enumdiff.TypeRemoved && c.Type == "color" && c.Breaking
+	}) {
+		t.Errorf("expected removing a type to be breaking, got %v", changes)
+	}
+}
@@ -0,0 +1,91 @@
+// Package naming derives the names of enum types and values shared by the
+// generators: the Go names the parsers of other formats declare, and the
+// names the writers of documents give to the generated types and values, so
+// that they all name them the same way.
+package naming
+
+import (
+	"go/build"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/donutnomad/goenums/enum"
+	gostrings "github.com/donutnomad/goenums/strings"
+)
+
+// TypeName returns the name of the wrapper type generated for an enum
+// type, the camel case singular of its name.
+func TypeName(enumType string) string {
+	if gostrings.IsPlural(enumType) {
+		enumType = gostrings.Singularise(enumType)
+	}
+	return gostrings.Camel(enumType)
+}
+
+// DisplayName returns the name String returns for e, its first alias or
+// its constant name.
+func DisplayName(e enum.Enum) string {
+	if len(e.Aliases) > 0 {
+		return e.Aliases[0]
+	}
+	return e.Name
+}
+
+// Identifier converts text such as "Order Status" or "in-transit" into a
+// camel case identifier such as "OrderStatus" or "InTransit". Words keep
+// their case after the first letter, so callers lowercase upper snake case
+// names such as "IN_TRANSIT" first.
+func Identifier(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = gostrings.Camel(w)
+	}
+	return strings.Join(words, "")
+}
+
+// PrefixCollidingNames prefixes the value names of enum types sharing a
+// value name with another type with their type name, since the values of
+// all types are constants of the same package, and prefixes the names that
+// are not identifiers, such as keywords.
+func PrefixCollidingNames(enumIotas []enum.EnumIota) {
+	count := make(map[string]int)
+	for _, enumIota := range enumIotas {
+		count[enumIota.Type]++
+		for _, e := range enumIota.Enums {
+			count[e.Name]++
+		}
+	}
+	for i, enumIota := range enumIotas {
+		if !slices.ContainsFunc(enumIota.Enums, func(e enum.Enum) bool { return count[e.Name] > 1 }) {
+			continue
+		}
+		for j, e := range enumIota.Enums {
+			enumIotas[i].Enums[j].Name = enumIota.Type + gostrings.Camel(e.Name)
+		}
+	}
+	for i, enumIota := range enumIotas {
+		for j, e := range enumIota.Enums {
+			if !token.IsIdentifier(e.Name) {
+				enumIotas[i].Enums[j].Name = enumIota.Type + gostrings.Camel(e.Name)
+			}
+		}
+	}
+}
+
+// DirectoryPackage returns the package of the Go files in the directory of
+// filename, or a package name derived from the directory name.
+func DirectoryPackage(filename string) string {
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return ""
+	}
+	if pkg, err := build.ImportDir(dir, 0); err == nil && pkg.Name != "" {
+		return pkg.Name
+	}
+	return strings.ToLower(Identifier(filepath.Base(dir)))
+}
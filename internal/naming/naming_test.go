@@ -0,0 +1,81 @@
+package naming_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/internal/naming"
+)
+
+func TestIdentifier(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Order Status", "OrderStatus"},
+		{"in-transit", "InTransit"},
+		{"httpStatus", "HttpStatus"},
+		{strings.ToLower("IN_TRANSIT"), "InTransit"},
+		{"--", ""},
+	}
+	for _, tt := range tests {
+		if got := naming.Identifier(tt.text); got != tt.want {
+			t.Errorf("Identifier(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestPrefixCollidingNames(t *testing.T) {
+	t.Parallel()
+	enumIotas := []enum.EnumIota{
+		{Type: "orderStatus", Enums: []enum.Enum{{Name: "pending"}, {Name: "shipped"}}},
+		{Type: "paymentStatus", Enums: []enum.Enum{{Name: "pending"}, {Name: "paid"}}},
+		{Type: "kind", Enums: []enum.Enum{{Name: "type"}, {Name: "other"}}},
+	}
+	naming.PrefixCollidingNames(enumIotas)
+	var got []string
+	for _, enumIota := range enumIotas {
+		for _, e := range enumIota.Enums {
+			got = append(got, e.Name)
+		}
+	}
+	want := "orderStatusPending orderStatusShipped paymentStatusPending paymentStatusPaid kindType other"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestDirectoryPackage(t *testing.T) {
+	t.Parallel()
+	if got := naming.DirectoryPackage("naming.go"); got != "naming" {
+		t.Errorf("expected the package of the Go files, got %q", got)
+	}
+	if got := naming.DirectoryPackage("testdata/order-api/api.yaml"); got != "orderapi" {
+		t.Errorf("expected a package named after the directory, got %q", got)
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	t.Parallel()
+	for enumType, want := range map[string]string{
+		"orderStatus": "OrderStatus",
+		"colors":      "Color",
+		"status":      "Status",
+	} {
+		if got := naming.TypeName(enumType); got != want {
+			t.Errorf("TypeName(%q) = %q, want %q", enumType, got, want)
+		}
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	t.Parallel()
+	if got := naming.DisplayName(enum.Enum{Name: "inTransit", Aliases: []string{"In Transit", "transit"}}); got != "In Transit" {
+		t.Errorf("expected the first alias, got %q", got)
+	}
+	if got := naming.DisplayName(enum.Enum{Name: "inTransit"}); got != "inTransit" {
+		t.Errorf("expected the constant name, got %q", got)
+	}
+}
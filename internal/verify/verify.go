@@ -0,0 +1,191 @@
+// Package verify checks that generated enum files match the version of the
+// goenums runtime their module requires.
+//
+// Generated files call into github.com/donutnomad/goenums/enums and record
+// the goenums version that produced them in their header. When a module's
+// go.mod requires a runtime with a different major or minor version, the
+// generated code may reference functions the runtime does not provide, or
+// rely on behaviour it no longer has. Check reports every such file so the
+// mismatch surfaces before the build breaks.
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// ModulePath is the module providing both the goenums command and the
+// enums runtime package.
+const ModulePath = "github.com/donutnomad/goenums"
+
+// ErrVerify is returned when the files to verify cannot be read.
+var ErrVerify = errors.New("failed to verify generated files")
+
+// generatedHeader matches the version in the header of generated files.
+var generatedHeader = regexp.MustCompile(`(?m)^// code generated by goenums (\S+) at `)
+
+// Mismatch is a generated file whose goenums version differs from the
+// runtime version required by its module.
+type Mismatch struct {
+	// Filename is the generated file
+	Filename string
+	// Generated is the goenums version that generated the file
+	Generated string
+	// GoMod is the go.mod file of the module containing the file
+	GoMod string
+	// Required is the runtime version required by GoMod, empty when the
+	// module does not require goenums at all
+	Required string
+}
+
+func (m Mismatch) String() string {
+	if m.Required == "" {
+		return fmt.Sprintf("%s: generated by goenums %s, but %s does not require %s",
+			m.Filename, m.Generated, m.GoMod, ModulePath)
+	}
+	return fmt.Sprintf("%s: generated by goenums %s, but %s requires %s %s",
+		m.Filename, m.Generated, m.GoMod, ModulePath, m.Required)
+}
+
+// Check verifies the generated files found in paths. Directories are
+// searched recursively, skipping hidden directories, vendor and testdata;
+// a trailing "/..." is accepted like in package patterns.
+// Files are compared by major and minor version, since patch releases
+// never change the API generated code depends on. Modules that replace
+// goenums, and goenums itself, are not checked.
+func Check(paths []string) ([]Mismatch, error) {
+	c := checker{modules: make(map[string]*module)}
+	var mismatches []Mismatch
+	for _, path := range paths {
+		if path = strings.TrimSuffix(path, "..."); path == "" {
+			path = "."
+		}
+		err := filepath.WalkDir(path, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				base := d.Name()
+				if name != path && (strings.HasPrefix(base, ".") || base == "vendor" || base == "testdata") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(name, ".go") {
+				return nil
+			}
+			m, err := c.checkFile(name)
+			if err != nil {
+				return err
+			}
+			if m != nil {
+				mismatches = append(mismatches, *m)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrVerify, err)
+		}
+	}
+	return mismatches, nil
+}
+
+// module is the goenums requirement of a go.mod file.
+type module struct {
+	path     string
+	required string
+	// skip is set for goenums itself and modules replacing it
+	skip bool
+}
+
+type checker struct {
+	// modules caches the module found for each directory
+	modules map[string]*module
+}
+
+func (c *checker) checkFile(name string) (*Mismatch, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	match := generatedHeader.FindSubmatch(b)
+	if match == nil {
+		return nil, nil
+	}
+	generated := strings.TrimSuffix(string(match[1]), ".")
+	mod, err := c.module(filepath.Dir(name))
+	if err != nil || mod == nil || mod.skip {
+		return nil, err
+	}
+	if mod.required != "" && semver.MajorMinor(mod.required) == semver.MajorMinor(generated) {
+		return nil, nil
+	}
+	return &Mismatch{
+		Filename:  name,
+		Generated: generated,
+		GoMod:     mod.path,
+		Required:  mod.required,
+	}, nil
+}
+
+// module returns the module of the nearest go.mod above dir, or nil when
+// there is none.
+func (c *checker) module(dir string) (*module, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if mod, ok := c.modules[dir]; ok {
+		return mod, nil
+	}
+	var mod *module
+	gomod := filepath.Join(dir, "go.mod")
+	b, err := os.ReadFile(gomod)
+	switch {
+	case err == nil:
+		mod, err = parseModule(gomod, b)
+		if err != nil {
+			return nil, err
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		if parent := filepath.Dir(dir); parent != dir {
+			if mod, err = c.module(parent); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, err
+	}
+	c.modules[dir] = mod
+	return mod, nil
+}
+
+func parseModule(gomod string, b []byte) (*module, error) {
+	f, err := modfile.Parse(gomod, b, nil)
+	if err != nil {
+		return nil, err
+	}
+	mod := &module{path: gomod}
+	if f.Module != nil && f.Module.Mod.Path == ModulePath {
+		mod.skip = true
+	}
+	for _, r := range f.Require {
+		if r.Mod.Path == ModulePath {
+			mod.required = r.Mod.Version
+		}
+	}
+	for _, r := range f.Replace {
+		if r.Old.Path == ModulePath {
+			mod.skip = true
+		}
+	}
+	return mod, nil
+}
@@ -0,0 +1,99 @@
+package verify_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/donutnomad/goenums/internal/verify"
+)
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func generated(version string) string {
+	return "// DO NOT EDIT.\n// code generated by goenums " + version + " at Jan  1 00:00:00.\n\npackage orders\n"
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		gomod     string
+		generated string
+		mismatch  bool
+		required  string
+	}{
+		{
+			name:      "same minor version",
+			gomod:     "module example.com/orders\n\nrequire github.com/donutnomad/goenums v0.4.2\n",
+			generated: "v0.4.0",
+		},
+		{
+			name:      "pseudo-version",
+			gomod:     "module example.com/orders\n\nrequire github.com/donutnomad/goenums v0.4.1-0.20250101000000-abcdefabcdef\n",
+			generated: "v0.4.0",
+		},
+		{
+			name:      "older runtime",
+			gomod:     "module example.com/orders\n\nrequire github.com/donutnomad/goenums v0.3.1\n",
+			generated: "v0.4.0",
+			mismatch:  true,
+			required:  "v0.3.1",
+		},
+		{
+			name:      "newer runtime",
+			gomod:     "module example.com/orders\n\nrequire github.com/donutnomad/goenums v0.5.0\n",
+			generated: "v0.4.0",
+			mismatch:  true,
+			required:  "v0.5.0",
+		},
+		{
+			name:      "not required",
+			gomod:     "module example.com/orders\n",
+			generated: "v0.4.0",
+			mismatch:  true,
+		},
+		{
+			name: "replaced",
+			gomod: "module example.com/orders\n\nrequire github.com/donutnomad/goenums v0.3.1\n\n" +
+				"replace github.com/donutnomad/goenums => ../goenums\n",
+			generated: "v0.4.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, "go.mod"), tt.gomod)
+			writeFile(t, filepath.Join(dir, "orders", "status_enums.go"), generated(tt.generated))
+			writeFile(t, filepath.Join(dir, "orders", "status.go"), "package orders\n")
+			writeFile(t, filepath.Join(dir, "testdata", "old_enums.go"), generated("v0.1.0"))
+
+			mismatches, err := verify.Check([]string{dir})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.mismatch {
+				if len(mismatches) != 1 {
+					t.Fatalf("expected 1 mismatch, got %v", mismatches)
+				}
+				m := mismatches[0]
+				if m.Required != tt.required || m.Generated != tt.generated || filepath.Base(m.Filename) != "status_enums.go" {
+					t.Errorf("unexpected mismatch %+v", m)
+				}
+				return
+			}
+			if len(mismatches) != 0 {
+				t.Errorf("expected no mismatches, got %v", mismatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/strings"
+)
+
+// listedFile is an input of goenums list and the enums found in it.
+type listedFile struct {
+	File string `json:"file"`
+	// Output is the Go file the enums would be generated to
+	Output string `json:"output,omitempty"`
+	// Error is why no enums could be generated from the file
+	Error string       `json:"error,omitempty"`
+	Enums []listedEnum `json:"enums,omitempty"`
+}
+
+type listedEnum struct {
+	Type           string `json:"type"`
+	UnderlyingType string `json:"underlyingType"`
+	// Handlers are the marshaling interfaces generated for the type
+	Handlers []string `json:"handlers"`
+	// Serialization is "name", "value" or "object"
+	Serialization string        `json:"serialization"`
+	Values        []listedValue `json:"values"`
+}
+
+type listedValue struct {
+	Name       string   `json:"name"`
+	Value      int      `json:"value"`
+	Aliases    []string `json:"aliases,omitempty"`
+	Invalid    bool     `json:"invalid,omitempty"`
+	Deprecated bool     `json:"deprecated,omitempty"`
+}
+
+// runList prints the enums goenums detects in the inputs given in args,
+// as text or with -json as JSON, without generating anything. It exits
+// with status 1 when an input yields no enums.
+func runList(ctx context.Context, cfg config.Configuration, args []string) {
+	// Keep stdout for the listing
+	configureLogging(os.Stderr, cfg)
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Print the enums as JSON")
+	_ = fs.Parse(args)
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	filenames, err := expandInputs(paths)
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "could not read inputs", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	files := listEnums(ctx, cfg, filenames)
+	if *asJSON {
+		err = writeListJSON(os.Stdout, files)
+	} else {
+		err = writeListText(os.Stdout, files)
+	}
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "could not list enums", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	for _, f := range files {
+		if f.Error != "" {
+			os.Exit(1)
+		}
+	}
+}
+
+// listEnums parses every file, recording the enums found or why there are
+// none.
+func listEnums(ctx context.Context, cfg config.Configuration, filenames []string) []listedFile {
+	files := make([]listedFile, 0, len(filenames))
+	for _, filename := range filenames {
+		f := listedFile{File: filename}
+		// Parsers record the configuration of the types they find
+		fileCfg := cfg
+		fileCfg.EnumTypeConfigs = maps.Clone(cfg.EnumTypeConfigs)
+		parser, _, err := newParser(ctx, fileCfg, filename)
+		if err == nil {
+			f, err = listFile(ctx, f, parser)
+		}
+		if err != nil {
+			f.Error = err.Error()
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+func listFile(ctx context.Context, f listedFile, parser enum.Parser) (listedFile, error) {
+	reqs, err := parser.Parse(ctx)
+	if err != nil {
+		return f, err
+	}
+	for _, req := range reqs {
+		if f.File != stdinFilename {
+			f.Output = filepath.Join(filepath.Dir(req.SourceFilename), req.OutputFilename+"_enums.go")
+		}
+		for _, enumIota := range req.GetEnumIotas() {
+			typeCfg := req.Configuration.GetEnumTypeConfig(enumIota.Type)
+			e := listedEnum{
+				Type:           enumIota.Type,
+				UnderlyingType: enumIota.UnderlyingType,
+				Handlers:       handlerNames(typeCfg.Handlers),
+				Serialization:  "name",
+			}
+			switch typeCfg.SerializationType {
+			case config.SerdeValue:
+				e.Serialization = "value"
+			case config.SerdeObject:
+				e.Serialization = "object"
+			}
+			for _, v := range enumIota.Enums {
+				e.Values = append(e.Values, listedValue{
+					Name:       v.Name,
+					Value:      v.Index,
+					Aliases:    v.Aliases,
+					Invalid:    !v.Valid,
+					Deprecated: v.Deprecated,
+				})
+			}
+			f.Enums = append(f.Enums, e)
+		}
+	}
+	return f, nil
+}
+
+// handlerNames returns the names of the enabled handlers, named after
+// their directives.
+func handlerNames(h config.Handlers) []string {
+	names := []string{}
+	for _, handler := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"json", h.JSON},
+		{"yaml", h.YAML},
+		{"text", h.Text},
+		{"binary", h.Binary},
+		{"sql", h.SQL},
+		{"sql/array", h.SQLArray},
+		{"avro", h.Avro},
+		{"mapstructure", h.Mapstructure},
+		{"http", h.HTTP},
+	} {
+		if handler.enabled {
+			names = append(names, handler.name)
+		}
+	}
+	return names
+}
+
+func writeListJSON(w io.Writer, files []listedFile) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(files)
+}
+
+func writeListText(w io.Writer, files []listedFile) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, f := range files {
+		if f.Error != "" {
+			fmt.Fprintf(tw, "%s: %s\n", f.File, f.Error)
+			continue
+		}
+		if f.Output != "" {
+			fmt.Fprintf(tw, "%s -> %s\n", f.File, f.Output)
+		} else {
+			fmt.Fprintf(tw, "%s\n", f.File)
+		}
+		for _, e := range f.Enums {
+			handlers := "none"
+			if len(e.Handlers) > 0 {
+				handlers = strings.Join(e.Handlers, ", ")
+			}
+			fmt.Fprintf(tw, "  %s %s (handlers: %s; serialized by %s)\n", e.Type, e.UnderlyingType, handlers, e.Serialization)
+			for _, v := range e.Values {
+				var notes []string
+				if len(v.Aliases) > 0 {
+					notes = append(notes, "aliases: "+strings.Join(v.Aliases, ", "))
+				}
+				if v.Invalid {
+					notes = append(notes, "invalid")
+				}
+				if v.Deprecated {
+					notes = append(notes, "deprecated")
+				}
+				fmt.Fprintf(tw, "    %s\t%d", v.Name, v.Value)
+				if len(notes) > 0 {
+					fmt.Fprintf(tw, "\t%s", strings.Join(notes, "; "))
+				}
+				fmt.Fprintln(tw)
+			}
+		}
+	}
+	return tw.Flush()
+}
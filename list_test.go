@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/goenums/generator/config"
+)
+
+func TestListEnums(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	color := filepath.Join(dir, "color.go")
+	writeFile(t, color, colorGo)
+	empty := filepath.Join(dir, "empty.go")
+	writeFile(t, empty, "package colors\n")
+
+	cfg := config.Configuration{Defaults: config.EnumTypeConfig{Handlers: config.Handlers{SQL: true}}}
+	files := listEnums(t.Context(), cfg, []string{color, empty})
+	if len(files) != 2 || files[0].Output != filepath.Join(dir, "color_enums.go") || files[1].Error == "" {
+		t.Fatalf("unexpected listing %+v", files)
+	}
+	e := files[0].Enums[0]
+	if e.Type != "color" || strings.Join(e.Handlers, ",") != "json,sql" || e.Serialization != "name" ||
+		len(e.Values) != 3 || !e.Values[0].Invalid || e.Values[1].Aliases[0] != "Red" {
+		t.Errorf("unexpected enum %+v", e)
+	}
+
+	var b bytes.Buffer
+	if err := writeListText(&b, files); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"  color int (handlers: json, sql; serialized by name)\n",
+		"    unknown  0  invalid\n    red      1  aliases: Red\n",
+		empty + ": ",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("expected listing to contain %q, got:\n%s", want, b.String())
+		}
+	}
+}
+
+func TestListEnums_URL(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("package: orders\nenums:\n  - type: status\n    values:\n      - {name: pending}\n      - {name: shipped}\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	files := listEnums(t.Context(), config.Configuration{}, []string{server.URL + "/contracts/status.enums.yaml"})
+	if len(files) != 1 || files[0].Error != "" || len(files[0].Enums) != 1 || len(files[0].Enums[0].Values) != 2 {
+		t.Fatalf("unexpected listing %+v", files)
+	}
+	if files[0].Output != "status_enums.go" {
+		t.Errorf("expected the output in the working directory, got %q", files[0].Output)
+	}
+}
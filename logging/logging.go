@@ -34,9 +34,50 @@ func ConfigureWithWriter(w io.Writer, verbose bool) {
 	if verbose {
 		level = slog.LevelDebug
 	}
-	handler := NewCustomTextHandler(w, &slog.HandlerOptions{Level: level})
-	logger := slog.New(handler)
-	slog.SetDefault(logger)
+	_ = ConfigureWithOptions(w, Options{Level: level})
+}
+
+// The formats logs are written in.
+const (
+	// FormatText writes the message and attributes on separate lines
+	FormatText = "text"
+	// FormatJSON writes one JSON object per record, for log collectors
+	FormatJSON = "json"
+)
+
+// Options configures the default logger.
+type Options struct {
+	// Level is the minimum level of the records written
+	Level slog.Level
+	// Format is FormatText, the default, or FormatJSON
+	Format string
+}
+
+// ConfigureWithOptions sets up the default slog logger writing to w at the
+// level and in the format of opts.
+func ConfigureWithOptions(w io.Writer, opts Options) error {
+	handlerOpts := &slog.HandlerOptions{Level: opts.Level}
+	var handler slog.Handler
+	switch opts.Format {
+	case "", FormatText:
+		handler = NewCustomTextHandler(w, handlerOpts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	default:
+		return fmt.Errorf("%w: unknown format %q: must be text or json", ErrLogging, opts.Format)
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// ParseLevel parses the name of a level: debug, info, warn or error,
+// optionally with an offset such as "error+2".
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return level, fmt.Errorf("%w: %w", ErrLogging, err)
+	}
+	return level, nil
 }
 
 // NewCustomTextHandler creates a text handler with custom formatting that omits
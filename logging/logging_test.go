@@ -2,6 +2,7 @@ package logging_test
 
 import (
 	"bytes"
+	"errors"
 	"log/slog"
 	"strings"
 	"testing"
@@ -111,3 +112,36 @@ func TestCustomHandler(t *testing.T) {
 		t.Error("handler should be enabled for info level")
 	}
 }
+
+// TestConfigureWithOptions is not parallel as it replaces the default logger.
+func TestConfigureWithOptions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := logging.ConfigureWithOptions(&buf, logging.Options{Level: slog.LevelWarn, Format: logging.FormatJSON}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slog.Default().Info("Info message")
+	slog.Default().Warn("Warn message", "file", "status.go")
+	output := buf.String()
+	if strings.Contains(output, "Info message") {
+		t.Errorf("unexpected info message below the level: %q", output)
+	}
+	if !strings.Contains(output, `"msg":"Warn message"`) || !strings.Contains(output, `"file":"status.go"`) {
+		t.Errorf("expected a JSON record, got: %q", output)
+	}
+
+	err := logging.ConfigureWithOptions(&buf, logging.Options{Format: "xml"})
+	if !errors.Is(err, logging.ErrLogging) {
+		t.Errorf("expected ErrLogging for an unknown format, got %v", err)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+	level, err := logging.ParseLevel("warn")
+	if err != nil || level != slog.LevelWarn {
+		t.Errorf("ParseLevel(warn) = %v, %v, want %v", level, err, slog.LevelWarn)
+	}
+	if _, err := logging.ParseLevel("loud"); !errors.Is(err, logging.ErrLogging) {
+		t.Errorf("expected ErrLogging for an unknown level, got %v", err)
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/donutnomad/goenums/generator/compat"
+	"github.com/donutnomad/goenums/strings"
+)
+
+// toolMigration is a go:generate line running stringer or enumer, and the
+// goenums -compat line replacing it.
+type toolMigration struct {
+	File string
+	// Line is the 1-based line of the directive in File
+	Line int
+	// Mode is the tool the line runs, "stringer" or "enumer"
+	Mode string
+	// Args are the arguments of the tool
+	Args []string
+}
+
+// Directive returns the go:generate line running goenums in place of the
+// tool.
+func (m toolMigration) Directive() string {
+	words := []string{"//go:generate", "goenums", "-compat=" + m.Mode}
+	for _, arg := range m.Args {
+		if strings.ContainsAny(arg, " \t\"") {
+			arg = strconv.Quote(arg)
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// migrationPlan is what goenums migrate found in its inputs.
+type migrationPlan struct {
+	Migrations []toolMigration
+	// Problems are the uses of other enum tools that cannot be migrated
+	// automatically
+	Problems []string
+}
+
+// generatedByTool matches the header stringer, enumer and go-enum write.
+var generatedByTool = regexp.MustCompile(`^// Code generated by (?:"(stringer|enumer) ([^"]*)"; |(go-enum) )DO NOT EDIT\.`)
+
+// runMigrate rewrites the go:generate lines running stringer or enumer in
+// the paths given in args to run goenums -compat, and regenerates their
+// files. With -n the rewrites are only printed. It exits with status 1
+// when a use of an enum tool could not be migrated.
+func runMigrate(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dryRun := fs.Bool("n", false, "Print the rewritten go:generate lines without changing anything")
+	_ = fs.Parse(args)
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	plan, err := planMigrations(paths)
+	if err != nil {
+		slog.Default().ErrorContext(ctx, "could not find enum tools", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	for _, m := range plan.Migrations {
+		fmt.Fprintf(os.Stdout, "%s:%d: %s\n", m.File, m.Line, m.Directive())
+	}
+	failed := len(plan.Problems) > 0
+	for _, problem := range plan.Problems {
+		slog.Default().WarnContext(ctx, problem)
+	}
+	if !*dryRun {
+		for _, err := range applyMigrations(ctx, plan.Migrations) {
+			slog.Default().ErrorContext(ctx, "could not migrate", slog.String("error", err.Error()))
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// planMigrations finds the go:generate lines running stringer or enumer
+// in the Go files of paths, which are searched recursively like by
+// goenums verify. Lines passing flags goenums does not accept, files
+// generated by go-enum and generated files no go:generate line of their
+// directory produces are reported as problems.
+func planMigrations(paths []string) (migrationPlan, error) {
+	var plan migrationPlan
+	// generated are the tool and arguments recorded in the headers of
+	// generated files, by file
+	generated := make(map[string][]string)
+	for _, root := range paths {
+		if root = strings.TrimSuffix(root, "..."); root == "" {
+			root = "."
+		}
+		err := filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				base := d.Name()
+				if name != root && (strings.HasPrefix(base, ".") || base == "vendor" || base == "testdata") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(name, ".go") {
+				return nil
+			}
+			b, err := os.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			if match := generatedByTool.FindSubmatch(firstLine(b)); match != nil {
+				if string(match[3]) != "" {
+					plan.Problems = append(plan.Problems, name+": generated by go-enum, "+
+						"whose types are declared in comments; declare their constants and convert them to goenums by hand")
+					return nil
+				}
+				generated[name] = append([]string{string(match[1])}, strings.Fields(string(match[2]))...)
+				return nil
+			}
+			migrations, problems := findMigrations(name, b)
+			plan.Migrations = append(plan.Migrations, migrations...)
+			plan.Problems = append(plan.Problems, problems...)
+			return nil
+		})
+		if err != nil {
+			return plan, err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(generated)) {
+		words := generated[name]
+		covered := slices.ContainsFunc(plan.Migrations, func(m toolMigration) bool {
+			return filepath.Dir(m.File) == filepath.Dir(name) && m.Mode == words[0] && slices.Equal(m.Args, words[1:])
+		})
+		if !covered {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("%s: generated by %q, but no go:generate line of its directory runs it; "+
+				"replace the command with goenums -compat=%s", name, strings.Join(words, " "), words[0]))
+		}
+	}
+	return plan, nil
+}
+
+// firstLine returns the first line of b.
+func firstLine(b []byte) []byte {
+	line, _, _ := bytes.Cut(b, []byte("\n"))
+	return bytes.TrimSpace(line)
+}
+
+// findMigrations returns the go:generate lines of the Go file name, holding
+// content, that run stringer or enumer, and the reasons the lines using
+// other flags cannot be migrated.
+func findMigrations(name string, content []byte) ([]toolMigration, []string) {
+	var migrations []toolMigration
+	var problems []string
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; s.Scan(); line++ {
+		rest, ok := strings.CutPrefix(s.Text(), "//go:generate ")
+		if !ok {
+			continue
+		}
+		words, err := splitGenerate(rest)
+		if err != nil {
+			continue
+		}
+		mode, args := generateTool(words)
+		switch mode {
+		case compat.ModeStringer, compat.ModeEnumer:
+		case "go-enum":
+			problems = append(problems, fmt.Sprintf("%s:%d: go-enum declares its types in comments; "+
+				"declare their constants and convert them to goenums by hand", name, line))
+			continue
+		default:
+			continue
+		}
+		if _, err := parseCompatFlags(mode, args, flag.ContinueOnError); err != nil {
+			problems = append(problems, fmt.Sprintf("%s:%d: %s: %v; convert the types to goenums directives instead",
+				name, line, mode, err))
+			continue
+		}
+		migrations = append(migrations, toolMigration{File: name, Line: line, Mode: mode, Args: args})
+	}
+	return migrations, problems
+}
+
+// splitGenerate splits the command of a go:generate line into words like
+// go generate does: at spaces, with double quoted strings unquoted.
+func splitGenerate(command string) ([]string, error) {
+	var words []string
+	for command = strings.TrimSpace(command); command != ""; command = strings.TrimLeft(command, " \t") {
+		if command[0] != '"' {
+			word, rest, _ := strings.Cut(command, " ")
+			words = append(words, word)
+			command = rest
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(command)
+		if err != nil {
+			return nil, err
+		}
+		word, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+		command = command[len(quoted):]
+	}
+	return words, nil
+}
+
+// generateTool returns the name of the tool a go:generate command runs,
+// directly, with "go run" or with "go tool", and its arguments.
+func generateTool(words []string) (string, []string) {
+	if len(words) > 1 && words[0] == "go" && (words[1] == "run" || words[1] == "tool") {
+		words = words[2:]
+		// Flags of go run precede the package
+		for len(words) > 0 && strings.HasPrefix(words[0], "-") {
+			words = words[1:]
+		}
+	}
+	if len(words) == 0 {
+		return "", nil
+	}
+	tool, _, _ := strings.Cut(path.Base(words[0]), "@")
+	return tool, words[1:]
+}
+
+// applyMigrations rewrites the go:generate lines of the migrations and
+// regenerates their files with goenums, in the directory of their source
+// like go generate would. It returns an error for every migration that
+// failed.
+func applyMigrations(ctx context.Context, migrations []toolMigration) []error {
+	var errs []error
+	byFile := make(map[string][]toolMigration)
+	for _, m := range migrations {
+		byFile[m.File] = append(byFile[m.File], m)
+	}
+	for _, name := range slices.Sorted(maps.Keys(byFile)) {
+		if err := rewriteGenerateLines(name, byFile[name]); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		dir := filepath.Dir(name)
+		for _, m := range byFile[name] {
+			opts, err := parseCompatFlags(m.Mode, m.Args, flag.ContinueOnError)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s:%d: %w", m.File, m.Line, err))
+				continue
+			}
+			for i, input := range opts.inputs {
+				opts.inputs[i] = filepath.Join(dir, input)
+			}
+			if opts.output != "" && !filepath.IsAbs(opts.output) {
+				opts.output = filepath.Join(dir, opts.output)
+			}
+			if err := compatGenerate(ctx, m.Mode, opts); err != nil {
+				errs = append(errs, fmt.Errorf("%s:%d: %w", m.File, m.Line, err))
+			}
+		}
+	}
+	return errs
+}
+
+// rewriteGenerateLines replaces the go:generate lines of the migrations in
+// the file name.
+func rewriteGenerateLines(name string, migrations []toolMigration) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	lines := bytes.SplitAfter(b, []byte("\n"))
+	for _, m := range migrations {
+		if m.Line > len(lines) {
+			return errors.New(name + ": file changed while migrating")
+		}
+		old := lines[m.Line-1]
+		lines[m.Line-1] = append([]byte(m.Directive()), old[len(bytes.TrimRight(old, "\r\n")):]...)
+	}
+	return os.WriteFile(name, bytes.Join(lines, nil), info.Mode().Perm())
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const pillGo = `package painkiller
+
+//go:generate go run golang.org/x/tools/cmd/stringer@latest -type=Pill "-trimprefix=Pill"
+//go:generate enumer -type=Pill -addprefix=X -output=pill_enumer.go
+
+type Pill int
+
+const (
+	PillPlacebo Pill = iota
+	PillAspirin
+)
+`
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	pill := filepath.Join(dir, "pill.go")
+	writeFile(t, pill, pillGo)
+	writeFile(t, filepath.Join(dir, "pill_string.go"),
+		"// Code generated by \"stringer -type=Pill -trimprefix=Pill\"; DO NOT EDIT.\n\npackage painkiller\n")
+	writeFile(t, filepath.Join(dir, "dose_string.go"),
+		"// Code generated by \"stringer -type=Dose\"; DO NOT EDIT.\n\npackage painkiller\n")
+	writeFile(t, filepath.Join(dir, "color_enum.go"),
+		"// Code generated by go-enum DO NOT EDIT.\n\npackage painkiller\n")
+
+	plan, err := planMigrations([]string{dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Migrations) != 1 {
+		t.Fatalf("expected 1 migration, got %+v", plan.Migrations)
+	}
+	m := plan.Migrations[0]
+	if m.File != pill || m.Line != 3 || m.Directive() != "//go:generate goenums -compat=stringer -type=Pill -trimprefix=Pill" {
+		t.Errorf("unexpected migration %+v: %s", m, m.Directive())
+	}
+	for _, want := range []string{"pill.go:4: enumer: ", "dose_string.go: ", "color_enum.go: "} {
+		if !slices.ContainsFunc(plan.Problems, func(p string) bool { return strings.Contains(p, want) }) {
+			t.Errorf("expected a problem containing %q, got %q", want, plan.Problems)
+		}
+	}
+
+	if errs := applyMigrations(t.Context(), plan.Migrations); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	b, err := os.ReadFile(pill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(pillGo, `go run golang.org/x/tools/cmd/stringer@latest -type=Pill "-trimprefix=Pill"`,
+		"goenums -compat=stringer -type=Pill -trimprefix=Pill", 1)
+	if string(b) != want {
+		t.Errorf("unexpected rewritten source:\n%s", b)
+	}
+	b, err = os.ReadFile(filepath.Join(dir, "pill_string.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "// Code generated by goenums -compat=stringer. DO NOT EDIT.") ||
+		!strings.Contains(string(b), `return "Placebo"`) {
+		t.Errorf("expected pill_string.go to be regenerated by goenums, got:\n%s", b)
+	}
+}
+
+func TestSplitGenerate(t *testing.T) {
+	t.Parallel()
+	words, err := splitGenerate(`  enumer  -type=Level "-trimprefix=Level " -json`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"enumer", "-type=Level", "-trimprefix=Level ", "-json"}; !slices.Equal(words, want) {
+		t.Errorf("splitGenerate() = %q, want %q", words, want)
+	}
+	if _, err := splitGenerate(`stringer "-type=Pill`); err == nil {
+		t.Errorf("expected an error for an unterminated quote")
+	}
+}
@@ -0,0 +1,223 @@
+// Package goenums is the programmatic entry point to goenums, for code
+// generators and build systems embedding it instead of running the command.
+//
+// Generate runs the same parsers and writers as the command line on the
+// given sources and returns the generated files in memory:
+//
+//	files, err := goenums.Generate(ctx, goenums.Options{
+//		Sources: []enum.Source{source.FromFile("orders/status.go")},
+//	})
+//	for _, f := range files {
+//		// f.Name is "orders/status_enums.go"
+//	}
+//
+// NewParser and NewWriters expose the choice of parser and writers for
+// callers running the pipeline themselves.
+package goenums
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"path/filepath"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator"
+	"github.com/donutnomad/goenums/generator/avro"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/generator/diagram"
+	"github.com/donutnomad/goenums/generator/gofile"
+	"github.com/donutnomad/goenums/generator/jsonschema"
+	"github.com/donutnomad/goenums/generator/migration"
+	"github.com/donutnomad/goenums/generator/openapi"
+	"github.com/donutnomad/goenums/generator/protofile"
+	"github.com/donutnomad/goenums/generator/spec"
+	"github.com/donutnomad/goenums/generator/xstate"
+)
+
+var (
+	// ErrUnsupportedInput is returned for sources no parser can read.
+	ErrUnsupportedInput = errors.New("unsupported input file")
+	// ErrUnsupportedOutput is returned for unknown output formats.
+	ErrUnsupportedOutput = errors.New("unsupported output format")
+	// ErrNoSources is returned when Generate is given no sources.
+	ErrNoSources = errors.New("no sources to generate")
+)
+
+// Options configures Generate.
+type Options struct {
+	// Sources are the inputs generated, each read by the parser chosen by
+	// the extension of its Filename: Go source, a spec, an OpenAPI
+	// document or a proto file. Filenames must be relative paths, as the
+	// generated files are named after them.
+	Sources []enum.Source
+	// Config configures the generation like the command line flags do;
+	// OutputFormat selects the outputs, "go" when empty.
+	Config config.Configuration
+	// FS holds the files the writers read back, such as the enum snapshots
+	// of earlier migrations; none when nil. It is never written to.
+	FS fs.FS
+}
+
+// GeneratedFile is a file Generate produced.
+type GeneratedFile struct {
+	// Name is the slash-separated path of the file, relative like the
+	// filename of the source it was generated from
+	Name string
+	// Content is the formatted content of the file
+	Content []byte
+}
+
+// Generate generates the outputs of every source in memory and returns the
+// generated files in lexical order of their names. Every source is
+// attempted and failures are returned together, each prefixed with the
+// filename of its source; no files are returned then.
+func Generate(ctx context.Context, opts Options) ([]GeneratedFile, error) {
+	if len(opts.Sources) == 0 {
+		return nil, ErrNoSources
+	}
+	fsys := file.NewOverlayFS(opts.FS)
+	var errs []error
+	for _, src := range opts.Sources {
+		if err := generateSource(ctx, opts.Config, src, fsys); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.Filename(), err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	names := fsys.Written()
+	files := make([]GeneratedFile, 0, len(names))
+	for _, name := range names {
+		b, err := fsys.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, GeneratedFile{Name: name, Content: b})
+	}
+	return files, nil
+}
+
+// generateSource parses src and writes its outputs to fsys.
+func generateSource(ctx context.Context, cfg config.Configuration, src enum.Source, fsys file.ReadCreateWriteFileFS) error {
+	// Parsers record the configuration of the types they find, which must
+	// not leak into the other sources
+	cfg.EnumTypeConfigs = maps.Clone(cfg.EnumTypeConfigs)
+	if cfg.EnumTypeConfigs == nil {
+		cfg.EnumTypeConfigs = make(map[string]config.EnumTypeConfig)
+	}
+	parser, isSpec, err := NewParser(cfg, src)
+	if err != nil {
+		return err
+	}
+	writers, err := NewWriters(cfg, isSpec, fsys, nil)
+	if err != nil {
+		return err
+	}
+	gen := generator.New(
+		generator.WithConfig(cfg),
+		generator.WithParser(parser),
+		generator.WithWriters(writers...))
+	return gen.ParseAndWrite(ctx)
+}
+
+// NewParser returns the parser for src, chosen by the extension of its
+// filename, and whether it is a spec, OpenAPI document or proto file
+// rather than Go source.
+func NewParser(cfg config.Configuration, src enum.Source) (enum.Parser, bool, error) {
+	switch ext := filepath.Ext(src.Filename()); {
+	case ext == ".go":
+		slog.Default().Debug("initializing go parser")
+		return gofile.NewParser(
+			gofile.WithParserConfiguration(cfg),
+			gofile.WithSource(src)), false, nil
+	case ext == ".yaml" || ext == ".yml" || ext == ".json":
+		if cfg.Stdout {
+			return nil, false, fmt.Errorf("%w: -stdout only supports Go sources", ErrUnsupportedInput)
+		}
+		if openapi.IsDocument(src) {
+			slog.Default().Debug("initializing openapi parser")
+			return openapi.NewParser(
+				openapi.WithParserConfiguration(cfg),
+				openapi.WithSource(src)), true, nil
+		}
+		slog.Default().Debug("initializing spec parser")
+		return spec.NewParser(
+			spec.WithParserConfiguration(cfg),
+			spec.WithSource(src)), true, nil
+	case ext == ".proto":
+		if cfg.Stdout {
+			return nil, false, fmt.Errorf("%w: -stdout only supports Go sources", ErrUnsupportedInput)
+		}
+		slog.Default().Debug("initializing proto parser")
+		return protofile.NewParser(
+			protofile.WithParserConfiguration(cfg),
+			protofile.WithSource(src)), true, nil
+	default:
+		return nil, false, fmt.Errorf("%w: only .go, .yaml, .yml, .json and .proto files are supported", ErrUnsupportedInput)
+	}
+}
+
+// NewWriters returns the writers of the outputs cfg selects, writing to
+// fsys, or to the disk when fsys is nil. Specs also get the writer of the
+// const block a Go source would declare. When out is not nil, the Go code
+// is written to it instead of a file. The Go code is followed by that of
+// the registered plugins, then of the exec plugins of cfg.
+func NewWriters(cfg config.Configuration, isSpec bool, fsys file.ReadCreateWriteFileFS, out io.Writer) ([]enum.Writer, error) {
+	if fsys == nil {
+		fsys = &file.OSReadWriteFileFS{}
+	}
+	var writers []enum.Writer
+	if isSpec {
+		// Specs also generate the const block a Go source would declare
+		writers = append(writers, spec.NewWriter(spec.WithWriterConfiguration(cfg), spec.WithFileSystem(fsys)))
+	}
+
+	formats := config.SplitList(cfg.OutputFormat)
+	if len(formats) == 0 {
+		formats = []string{"go"}
+	}
+	for _, format := range formats {
+		switch format {
+		case "go":
+			slog.Default().Debug("initializing gofile writer")
+			opts := []gofile.WriterOption{gofile.WithWriterConfiguration(cfg), gofile.WithFileSystem(fsys)}
+			opts = append(opts, gofile.WithPlugins(generator.Plugins()...))
+			for _, command := range cfg.Plugins {
+				opts = append(opts, gofile.WithPlugins(generator.NewExecPlugin(command)))
+			}
+			if out != nil {
+				opts = append(opts, gofile.WithOutput(out))
+			}
+			writers = append(writers, gofile.NewWriter(opts...))
+		case "jsonschema":
+			slog.Default().Debug("initializing jsonschema writer")
+			writers = append(writers, jsonschema.NewWriter(jsonschema.WithWriterConfiguration(cfg), jsonschema.WithFileSystem(fsys)))
+		case "openapi":
+			slog.Default().Debug("initializing openapi writer")
+			writers = append(writers, openapi.NewWriter(openapi.WithWriterConfiguration(cfg), openapi.WithFileSystem(fsys)))
+		case "avro":
+			slog.Default().Debug("initializing avro writer")
+			writers = append(writers, avro.NewWriter(avro.WithWriterConfiguration(cfg), avro.WithFileSystem(fsys)))
+		case "xstate":
+			slog.Default().Debug("initializing xstate writer")
+			writers = append(writers, xstate.NewWriter(xstate.WithWriterConfiguration(cfg), xstate.WithFileSystem(fsys)))
+		default:
+			return nil, fmt.Errorf("%w: %s: only go, jsonschema, openapi, avro and xstate outputs are supported", ErrUnsupportedOutput, format)
+		}
+	}
+	if len(cfg.Diagrams) > 0 {
+		slog.Default().Debug("initializing diagram writer")
+		writers = append(writers, diagram.NewWriter(diagram.WithWriterConfiguration(cfg), diagram.WithFileSystem(fsys)))
+	}
+	if cfg.MigrationsDir != "" {
+		slog.Default().Debug("initializing migration writer")
+		writers = append(writers, migration.NewWriter(migration.WithWriterConfiguration(cfg), migration.WithFileSystem(fsys)))
+	}
+	return writers, nil
+}
@@ -0,0 +1,119 @@
+package goenums_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/pkg/goenums"
+	"github.com/donutnomad/goenums/source"
+)
+
+var sources = file.NewOverlayFS(fstest.MapFS{
+	"orders/status.go": &fstest.MapFile{Data: []byte(`package orders
+
+// goenums: -json
+type status int
+
+const (
+	unknown status = iota // invalid
+	pending               // Pending
+	shipped               // Shipped
+)
+`)},
+	"shapes/shape.enums.yaml": &fstest.MapFile{Data: []byte(`package: shapes
+enums:
+  - type: shape
+    values:
+      - {name: circle}
+      - {name: square}
+`)},
+	"notes/readme.txt": &fstest.MapFile{Data: []byte("not an enum source\n")},
+})
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		files    []string
+		output   string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "go source",
+			files:    []string{"orders/status.go"},
+			expected: []string{"orders/status_enums.go"},
+		},
+		{
+			name:     "spec and go source",
+			files:    []string{"shapes/shape.enums.yaml", "orders/status.go"},
+			expected: []string{"orders/status_enums.go", "shapes/shape_consts.go", "shapes/shape_enums.go"},
+		},
+		{
+			name:     "several outputs",
+			files:    []string{"orders/status.go"},
+			output:   "go,jsonschema",
+			expected: []string{"orders/status.schema.json", "orders/status_enums.go"},
+		},
+		{
+			name:  "unsupported input",
+			files: []string{"notes/readme.txt", "orders/status.go"},
+			err:   goenums.ErrUnsupportedInput,
+		},
+		{
+			name:   "unsupported output",
+			files:  []string{"orders/status.go"},
+			output: "cobol",
+			err:    goenums.ErrUnsupportedOutput,
+		},
+		{
+			name: "no sources",
+			err:  goenums.ErrNoSources,
+		},
+	}
+	t.Cleanup(func() {
+		if written := sources.Written(); len(written) > 0 {
+			t.Errorf("expected the sources to be left unchanged, got %q written", written)
+		}
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var srcs []enum.Source
+			for _, name := range tt.files {
+				srcs = append(srcs, source.FromFileSystem(sources, name))
+			}
+			files, err := goenums.Generate(t.Context(), goenums.Options{
+				Sources: srcs,
+				Config:  config.Configuration{OutputFormat: tt.output},
+			})
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var names []string
+			for _, f := range files {
+				names = append(names, f.Name)
+			}
+			if !slices.Equal(names, tt.expected) {
+				t.Fatalf("got files %q, want %q", names, tt.expected)
+			}
+			for _, f := range files {
+				if strings.HasSuffix(f.Name, ".go") && !strings.Contains(strings.ToLower(string(f.Content)), "code generated by goenums") {
+					t.Errorf("%s: expected generated code, got %q", f.Name, f.Content)
+				}
+			}
+		})
+	}
+}
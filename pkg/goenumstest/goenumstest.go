@@ -0,0 +1,135 @@
+// Package goenumstest runs goenums against testdata inputs and compares
+// the generated files with golden copies, for authors of plugins and
+// customized writers testing their output the way goenums tests its own.
+//
+// Golden files are kept next to the inputs, named after the generated
+// files with a ".golden" suffix. Running the tests with -update writes them
+// from the current output instead of comparing:
+//
+//	func TestAudit(t *testing.T) {
+//		goenumstest.Run(t, "testdata", config.Configuration{}, "orders/status.go")
+//	}
+//
+//	$ go test -run TestAudit -update
+//
+// The package registers the -update flag, which test packages importing it
+// must not declare themselves.
+package goenumstest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/pkg/goenums"
+	"github.com/donutnomad/goenums/source"
+	"github.com/donutnomad/goenums/strings"
+)
+
+// GoldenSuffix is appended to the names of the generated files to name
+// their golden copies.
+const GoldenSuffix = ".golden"
+
+var update = flag.Bool("update", false, "Write the golden files of goenumstest from the generated files")
+
+// Update reports whether the golden files are written instead of compared,
+// as they are when the tests run with -update.
+func Update() bool {
+	return *update
+}
+
+// generatedHeader matches the line of the generated Go header naming the
+// version and time of the generation, which change between runs.
+var generatedHeader = regexp.MustCompile(`(?m)^// code generated by goenums .* at .*$`)
+
+// Normalize returns content with what changes from one run to the next,
+// the version and time of the generated header, left out, so that it can
+// be compared with a golden file.
+func Normalize(content []byte) []byte {
+	return generatedHeader.ReplaceAll(content, []byte("// code generated by goenums."))
+}
+
+// Generate generates inputs, paths relative to fsys, with cfg like
+// goenums.Generate does, and returns the generated files with their
+// content normalized. It fails the test when generation fails.
+func Generate(t testing.TB, fsys fs.FS, cfg config.Configuration, inputs ...string) []goenums.GeneratedFile {
+	t.Helper()
+	overlay := file.NewOverlayFS(fsys)
+	sources := make([]enum.Source, len(inputs))
+	for i, input := range inputs {
+		sources[i] = source.FromFileSystem(overlay, filepath.ToSlash(input))
+	}
+	files, err := goenums.Generate(t.Context(), goenums.Options{
+		Sources: sources,
+		Config:  cfg,
+		FS:      fsys,
+	})
+	if err != nil {
+		t.Fatalf("goenumstest: generating %s: %v", strings.Join(inputs, ", "), err)
+	}
+	for i := range files {
+		files[i].Content = Normalize(files[i].Content)
+	}
+	return files
+}
+
+// Compare compares every file with its golden copy under dir, reporting
+// the first line that differs, or writes the golden copies with -update.
+func Compare(t testing.TB, dir string, files []goenums.GeneratedFile) {
+	t.Helper()
+	for _, f := range files {
+		golden := filepath.Join(dir, filepath.FromSlash(f.Name)+GoldenSuffix)
+		if Update() {
+			if err := os.WriteFile(golden, f.Content, 0o644); err != nil {
+				t.Fatalf("goenumstest: updating %s: %v", golden, err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Errorf("goenumstest: %v; run the tests with -update to create it", err)
+			continue
+		}
+		if msg := diff(Normalize(want), f.Content); msg != "" {
+			t.Errorf("goenumstest: %s differs from %s, run the tests with -update to accept it:\n%s", f.Name, golden, msg)
+		}
+	}
+}
+
+// Run generates inputs, paths relative to dir, with cfg and compares the
+// generated files with their golden copies under dir.
+func Run(t testing.TB, dir string, cfg config.Configuration, inputs ...string) {
+	t.Helper()
+	Compare(t, dir, Generate(t, os.DirFS(dir), cfg, inputs...))
+}
+
+// diff describes the first line where got differs from want, or returns
+// an empty string when they are equal.
+func diff(want, got []byte) string {
+	if bytes.Equal(want, got) {
+		return ""
+	}
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g || i >= len(wantLines) || i >= len(gotLines) {
+			return fmt.Sprintf("line %d:\n\twant: %q\n\tgot:  %q", i+1, w, g)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package goenumstest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/donutnomad/goenums/generator/config"
+	"github.com/donutnomad/goenums/pkg/goenums"
+	"github.com/donutnomad/goenums/pkg/goenumstest"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	goenumstest.Run(t, "testdata", config.Configuration{}, "orders/status.go")
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	got := string(goenumstest.Normalize([]byte("// DO NOT EDIT.\n// code generated by goenums v1.2.3 at Oct 16 10:00:00.\npackage orders\n")))
+	want := "// DO NOT EDIT.\n// code generated by goenums.\npackage orders\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// recordingTB records the errors reported instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *recordingTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+	if goenumstest.Update() {
+		t.Skip("golden files are being updated")
+	}
+	files := goenumstest.Generate(t, fstest.MapFS{
+		"orders/status.go": &fstest.MapFile{Data: []byte("package orders\n\ntype status int\n\nconst (\n\tunknown status = iota // invalid\n\tpending\n)\n")},
+	}, config.Configuration{}, "orders/status.go")
+	tests := []struct {
+		name  string
+		files []goenums.GeneratedFile
+		want  string
+	}{
+		{
+			name:  "different content",
+			files: []goenums.GeneratedFile{{Name: "orders/status_enums.go", Content: files[0].Content}},
+			want:  "line ",
+		},
+		{
+			name:  "missing golden file",
+			files: []goenums.GeneratedFile{{Name: "orders/missing_enums.go", Content: []byte("package orders\n")}},
+			want:  "-update to create it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tb := &recordingTB{TB: t}
+			goenumstest.Compare(tb, "testdata", tt.files)
+			if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], tt.want) {
+				t.Errorf("got errors %q, want one containing %q", tb.errors, tt.want)
+			}
+		})
+	}
+}
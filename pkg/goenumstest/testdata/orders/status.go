@@ -0,0 +1,10 @@
+package orders
+
+// goenums: -json
+type status int
+
+const (
+	unknown status = iota // invalid
+	pending               // Pending
+	shipped               // Shipped
+)
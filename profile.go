@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/donutnomad/goenums/generator/config"
+)
+
+// startProfiling starts the CPU profile and the execution trace cfg asks
+// for. The returned function stops them and writes the memory profile; it
+// must be called once generation is done.
+func startProfiling(cfg config.Configuration) (func(), error) {
+	var stops []func() error
+	stop := func() {
+		for i := len(stops) - 1; i >= 0; i-- {
+			if err := stops[i](); err != nil {
+				slog.Default().Error("could not write profile", slog.String("error", err.Error()))
+			}
+		}
+	}
+	if cfg.CPUProfile != "" {
+		f, err := os.Create(cfg.CPUProfile)
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("cpu profile: %w", err)
+		}
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+	if cfg.Trace != "" {
+		f, err := os.Create(cfg.Trace)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		if err := trace.Start(f); err != nil {
+			stop()
+			return nil, errors.Join(fmt.Errorf("trace: %w", err), f.Close())
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+	if cfg.MemProfile != "" {
+		name := cfg.MemProfile
+		stops = append(stops, func() error {
+			f, err := os.Create(name)
+			if err != nil {
+				return err
+			}
+			// Collect garbage so the profile shows the live heap
+			runtime.GC()
+			return errors.Join(pprof.Lookup("allocs").WriteTo(f, 0), f.Close())
+		})
+	}
+	return stop, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/donutnomad/goenums/generator/config"
+)
+
+func TestStartProfiling(t *testing.T) {
+	// Not parallel: only one CPU profile and trace can run at a time
+	dir := t.TempDir()
+	cfg := config.Configuration{
+		CPUProfile: filepath.Join(dir, "cpu.out"),
+		MemProfile: filepath.Join(dir, "mem.out"),
+		Trace:      filepath.Join(dir, "trace.out"),
+	}
+	stop, err := startProfiling(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stop()
+	for _, name := range []string{cfg.CPUProfile, cfg.MemProfile, cfg.Trace} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+			continue
+		}
+		if info.Size() == 0 {
+			t.Errorf("expected %s to not be empty", name)
+		}
+	}
+
+	cfg = config.Configuration{CPUProfile: filepath.Join(dir, "missing", "cpu.out")}
+	if _, err := startProfiling(cfg); err == nil {
+		t.Errorf("expected an error for an uncreatable profile")
+	}
+}
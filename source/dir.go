@@ -0,0 +1,146 @@
+package source
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+)
+
+var (
+	// ErrReadDirSource is returned when there is an error listing or reading
+	// the files of a directory source.
+	ErrReadDirSource = errors.New("failed to read directory source")
+)
+
+// FromDir creates a new directory-based Source implementation over the
+// regular files in the directory at path and, when recursive is set, in its
+// subdirectories. Hidden directories, vendor and testdata are never
+// descended into, matching the goenums command line.
+func FromDir(path string, recursive bool) *DirSource {
+	return &DirSource{
+		Path:      path,
+		Recursive: recursive,
+		FS:        &file.OSReadWriteFileFS{},
+	}
+}
+
+// DirSource implements Source for a tree of files. Its files are listed in
+// lexical order, so the same tree always yields the same sources in the
+// same order.
+type DirSource struct {
+	// Path is the filesystem path to the directory
+	Path string
+	// Recursive includes the files of subdirectories
+	Recursive bool
+	// Exclude are glob patterns, in path.Match syntax, leaving out the
+	// files and directories they match. A pattern is matched against the
+	// slash-separated path relative to Path and against the base name, so
+	// "*_test.go" and "internal/*" both work.
+	Exclude []string
+	// FS lists the directories and reads the files listed. It walks
+	// through fs.WalkDir, so any fs.FS that can read directories works,
+	// such as an fstest.MapFS or a file.OverlayFS.
+	FS file.ReadStatFS
+}
+
+// Files returns the paths of the files of the directory, in lexical order.
+func (ds *DirSource) Files() ([]string, error) {
+	for _, pattern := range ds.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %s: exclude %q: %w", ErrReadDirSource, ds.Path, pattern, err)
+		}
+	}
+	var files []string
+	err := fs.WalkDir(ds.FS, ds.Path, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == ds.Path {
+			return nil
+		}
+		excluded := ds.excluded(name)
+		if d.IsDir() {
+			base := d.Name()
+			if !ds.Recursive || excluded || strings.HasPrefix(base, ".") || base == "vendor" || base == "testdata" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !excluded && d.Type().IsRegular() {
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrReadDirSource, ds.Path, err)
+	}
+	return files, nil
+}
+
+// excluded reports whether an Exclude pattern matches the file name.
+func (ds *DirSource) excluded(name string) bool {
+	rel, err := filepath.Rel(ds.Path, name)
+	if err != nil {
+		rel = name
+	}
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range ds.Exclude {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Sources returns a FileSource for every file of the directory, in the
+// order of Files. Parsers read a single file, so embedders parse each of
+// these to process a whole tree.
+func (ds *DirSource) Sources() ([]enum.Source, error) {
+	files, err := ds.Files()
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]enum.Source, 0, len(files))
+	for _, name := range files {
+		sources = append(sources, FromFileSystem(ds.FS, name))
+	}
+	return sources, nil
+}
+
+// Content reads the files of the directory and returns their contents
+// joined in the order of Files, each ending with a newline. It fulfills the
+// Source interface for formats whose documents can be concatenated; Go
+// files cannot, and are parsed one by one through Sources instead.
+func (ds *DirSource) Content() ([]byte, error) {
+	sources, err := ds.Sources()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for _, src := range sources {
+		b, err := src.Content()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReadDirSource, err)
+		}
+		buf.Write(b)
+		if len(b) > 0 && b[len(b)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Filename returns the path of the directory.
+func (ds *DirSource) Filename() string {
+	return ds.Path
+}
@@ -0,0 +1,160 @@
+package source_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/donutnomad/goenums/source"
+)
+
+func TestDirSource_Files(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"b.go":                "b",
+		"a.go":                "a",
+		"a_test.go":           "test",
+		"sub/c.go":            "c",
+		"sub/deep/d.go":       "d",
+		"internal/e.go":       "e",
+		".hidden/f.go":        "f",
+		"vendor/g.go":         "g",
+		"testdata/h.go":       "h",
+		"sub/deep/d_test.go":  "test",
+		"internal/sub/i.yaml": "i",
+	} {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rel := func(files []string) []string {
+		var names []string
+		for _, name := range files {
+			r, err := filepath.Rel(dir, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, filepath.ToSlash(r))
+		}
+		return names
+	}
+
+	tests := []struct {
+		name      string
+		recursive bool
+		exclude   []string
+		expected  []string
+	}{
+		{
+			name:     "top level only",
+			expected: []string{"a.go", "a_test.go", "b.go"},
+		},
+		{
+			name:      "recursive",
+			recursive: true,
+			expected: []string{"a.go", "a_test.go", "b.go", "internal/e.go", "internal/sub/i.yaml",
+				"sub/c.go", "sub/deep/d.go", "sub/deep/d_test.go"},
+		},
+		{
+			name:      "excluding names and directories",
+			recursive: true,
+			exclude:   []string{"*_test.go", "internal"},
+			expected:  []string{"a.go", "b.go", "sub/c.go", "sub/deep/d.go"},
+		},
+		{
+			name:      "excluding relative paths",
+			recursive: true,
+			exclude:   []string{"sub/*"},
+			expected:  []string{"a.go", "a_test.go", "b.go", "internal/e.go", "internal/sub/i.yaml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			src := source.FromDir(dir, tt.recursive)
+			src.Exclude = tt.exclude
+			files, err := src.Files()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := rel(files); !slices.Equal(got, tt.expected) {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("content", func(t *testing.T) {
+		t.Parallel()
+		src := source.FromDir(dir, false)
+		src.Exclude = []string{"*_test.go"}
+		if src.Filename() != dir {
+			t.Errorf("expected filename %q, got %q", dir, src.Filename())
+		}
+		content, err := src.Content()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(content) != "a\nb\n" {
+			t.Errorf("got %q, want %q", content, "a\nb\n")
+		}
+		sources, err := src.Sources()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(sources) != 2 || sources[1].Filename() != filepath.Join(dir, "b.go") {
+			t.Errorf("unexpected sources %v", sources)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		if _, err := source.FromDir(filepath.Join(dir, "missing"), true).Files(); !errors.Is(err, source.ErrReadDirSource) {
+			t.Errorf("expected ErrReadDirSource for a missing directory, got %v", err)
+		}
+		src := source.FromDir(dir, true)
+		src.Exclude = []string{"["}
+		if _, err := src.Files(); !errors.Is(err, source.ErrReadDirSource) {
+			t.Errorf("expected ErrReadDirSource for a malformed pattern, got %v", err)
+		}
+	})
+}
+
+func TestDirSource_FS(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"pkg/b.go":          {Data: []byte("b")},
+		"pkg/a.go":          {Data: []byte("a")},
+		"pkg/sub/c.go":      {Data: []byte("c")},
+		"pkg/.hidden/d.go":  {Data: []byte("d")},
+		"pkg/testdata/e.go": {Data: []byte("e")},
+		"pkg/sub/c_test.go": {Data: []byte("test")},
+		"other/f.go":        {Data: []byte("f")},
+	}
+	src := &source.DirSource{Path: "pkg", Recursive: true, Exclude: []string{"*_test.go"}, FS: fsys}
+	files, err := src.Files()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"pkg/a.go", "pkg/b.go", "pkg/sub/c.go"}
+	if !slices.Equal(files, expected) {
+		t.Errorf("got %q, want %q", files, expected)
+	}
+	content, err := src.Content()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != "a\nb\nc\n" {
+		t.Errorf("got %q, want %q", content, "a\nb\nc\n")
+	}
+	if _, err := (&source.DirSource{Path: "missing", FS: fsys}).Files(); !errors.Is(err, source.ErrReadDirSource) {
+		t.Errorf("expected ErrReadDirSource for a missing directory, got %v", err)
+	}
+}
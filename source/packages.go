@@ -0,0 +1,160 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/donutnomad/goenums/enum"
+	"github.com/donutnomad/goenums/file"
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	// ErrLoadPackages is returned when the packages of a packages source
+	// cannot be loaded.
+	ErrLoadPackages = errors.New("failed to load packages")
+)
+
+// packagesMode loads the syntax and the type information of the packages,
+// so their constants are resolved like the compiler resolves them.
+const packagesMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+// FromPackages creates a new Source implementation over the Go packages
+// matching the patterns, as accepted by go list, such as "./..." or an
+// import path. The packages are loaded with golang.org/x/tools/go/packages,
+// once, on first use.
+func FromPackages(patterns ...string) *PackagesSource {
+	return &PackagesSource{Patterns: patterns}
+}
+
+// PackagesSource implements Source for Go packages loaded with their syntax
+// trees and type information.
+type PackagesSource struct {
+	// Patterns are the package patterns loaded
+	Patterns []string
+	// Dir is the directory the patterns are resolved in; the current
+	// directory when empty
+	Dir string
+	// Tags are the build tags selecting the files loaded
+	Tags []string
+
+	pkgs []*packages.Package
+}
+
+// Load loads the packages, or returns those already loaded. Type errors do
+// not fail loading, as enum sources commonly use methods not generated yet;
+// they are reported in the Errors of each package.
+func (ps *PackagesSource) Load(ctx context.Context) ([]*packages.Package, error) {
+	if ps.pkgs != nil {
+		return ps.pkgs, nil
+	}
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    packagesMode,
+		Dir:     ps.Dir,
+	}
+	if len(ps.Tags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(ps.Tags, ",")}
+	}
+	pkgs, err := packages.Load(cfg, ps.Patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrLoadPackages, ps.Filename(), err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("%w: %s: no packages matched", ErrLoadPackages, ps.Filename())
+	}
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			if e.Kind == packages.ListError {
+				return nil, fmt.Errorf("%w: %s: %w", ErrLoadPackages, pkg.PkgPath, e)
+			}
+		}
+	}
+	ps.pkgs = pkgs
+	return pkgs, nil
+}
+
+// Sources returns a PackageFileSource for every Go file of the packages, in
+// the order go list reports them. The gofile parser evaluates the constants
+// of these sources with the types already loaded.
+func (ps *PackagesSource) Sources(ctx context.Context) ([]enum.Source, error) {
+	pkgs, err := ps.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var sources []enum.Source
+	for _, pkg := range pkgs {
+		syntax := make(map[string]*ast.File, len(pkg.Syntax))
+		for _, f := range pkg.Syntax {
+			syntax[pkg.Fset.File(f.Pos()).Name()] = f
+		}
+		for _, name := range pkg.GoFiles {
+			sources = append(sources, &PackageFileSource{
+				FileSource: FromFileSystem(&file.OSReadWriteFileFS{}, name),
+				pkg:        pkg,
+				syntax:     syntax[name],
+			})
+		}
+	}
+	return sources, nil
+}
+
+// Content reads the Go files of the packages and returns their contents
+// joined in the order of Sources. It fulfills the Source interface; the
+// files of a package cannot be parsed joined, so parsers are given each of
+// Sources instead.
+func (ps *PackagesSource) Content() ([]byte, error) {
+	sources, err := ps.Sources(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for _, src := range sources {
+		b, err := src.Content()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+		if len(b) > 0 && b[len(b)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Filename returns the patterns of the packages, separated by spaces.
+func (ps *PackagesSource) Filename() string {
+	return strings.Join(ps.Patterns, " ")
+}
+
+// PackageFileSource is a Go file of a loaded package. It reads like a
+// FileSource, and carries the syntax tree and type information loaded
+// with the package.
+type PackageFileSource struct {
+	*FileSource
+	pkg    *packages.Package
+	syntax *ast.File
+}
+
+// Package returns the package the file belongs to.
+func (s *PackageFileSource) Package() *packages.Package {
+	return s.pkg
+}
+
+// Syntax returns the syntax tree of the file, or nil when the package was
+// loaded without it, such as for files go list could not parse.
+func (s *PackageFileSource) Syntax() *ast.File {
+	return s.syntax
+}
+
+// Types returns the type-checked package the file belongs to, whose scope
+// holds the evaluated constants of every file of the package.
+func (s *PackageFileSource) Types() *types.Package {
+	return s.pkg.Types
+}
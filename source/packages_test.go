@@ -0,0 +1,69 @@
+package source_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/goenums/source"
+)
+
+func TestPackagesSource(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":         "module example.com/shop\n\ngo 1.24\n",
+		"status.go":      "package shop\n\ntype status int\n\nconst (\n\tpending status = iota\n\tshipped\n)\n",
+		"orders/kind.go": "package orders\n\nconst retail = 1\n",
+	}
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src := source.FromPackages("./...")
+	src.Dir = dir
+	if src.Filename() != "./..." {
+		t.Errorf("expected filename %q, got %q", "./...", src.Filename())
+	}
+	sources, err := src.Sources(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, s := range sources {
+		names = append(names, filepath.Base(s.Filename()))
+		ps, ok := s.(*source.PackageFileSource)
+		if !ok {
+			t.Fatalf("expected a PackageFileSource, got %T", s)
+		}
+		if ps.Types() == nil || ps.Syntax() == nil {
+			t.Errorf("%s: expected types and syntax to be loaded", s.Filename())
+		}
+	}
+	slices.Sort(names)
+	if want := []string{"kind.go", "status.go"}; !slices.Equal(names, want) {
+		t.Errorf("got sources %q, want %q", names, want)
+	}
+	content, err := src.Content()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(content), "package shop") || !strings.Contains(string(content), "package orders") {
+		t.Errorf("expected the content of every file, got %q", content)
+	}
+
+	missing := source.FromPackages("./missing")
+	missing.Dir = dir
+	if _, err := missing.Load(t.Context()); !errors.Is(err, source.ErrLoadPackages) {
+		t.Errorf("expected ErrLoadPackages for a missing package, got %v", err)
+	}
+}
@@ -17,28 +17,46 @@ func FromReader(reader io.Reader) *ReaderSource {
 	return &ReaderSource{reader: reader}
 }
 
+// FromNamedReader is like FromReader, with name identifying the source
+// instead of "reader", such as "-" for standard input.
+func FromNamedReader(reader io.Reader, name string) *ReaderSource {
+	return &ReaderSource{reader: reader, name: name}
+}
+
 // ReaderSource implements Source for io.Reader content sources.
 // It enables parsing enum definitions from any input that implements
 // the io.Reader interface, such as network connections, string buffers,
 // or custom data streams.
 type ReaderSource struct {
 	reader io.Reader
+	name   string
+	// content is kept once read, since the reader cannot be read again
+	content []byte
 }
 
 // Content reads the entire content from the underlying reader
 // and returns it as a byte slice. This method consumes the reader,
-// so the reader cannot be read from again.
+// so later calls return the content read by the first one, letting the
+// source be parsed more than once like a file.
 func (rs *ReaderSource) Content() ([]byte, error) {
+	if rs.content != nil {
+		return rs.content, nil
+	}
 	b, err := io.ReadAll(rs.reader)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrReadSource, err)
 	}
+	rs.content = b
 	return b, nil
 }
 
-// Filename returns a generic identifier for this source.
-// Since reader sources typically don't have associated filenames,
-// this returns the constant string "reader" to identify the source type.
+// Filename returns the name of the source given to FromNamedReader, or
+// otherwise a generic identifier. Since reader sources typically don't have
+// associated filenames, this is the constant string "reader" to identify
+// the source type.
 func (rs *ReaderSource) Filename() string {
+	if rs.name != "" {
+		return rs.name
+	}
 	return "reader"
 }
@@ -60,6 +60,19 @@ func TestReaderSource_Content(t *testing.T) {
 	}
 }
 
+func TestReaderSource_Named(t *testing.T) {
+	t.Parallel()
+	src := source.FromNamedReader(strings.NewReader("package main"), "-")
+	if src.Filename() != "-" {
+		t.Errorf("expected filename %q, got %q", "-", src.Filename())
+	}
+	for range 2 {
+		if content, err := src.Content(); err != nil || string(content) != "package main" {
+			t.Errorf("expected the content on every call, got %q, %v", content, err)
+		}
+	}
+}
+
 func TestFileSource_Content(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -8,6 +8,8 @@
 // Current implementations include:
 //   - FileSource: Retrieves content from the provided filesystem
 //   - ReaderSource: Obtains content from an io.Reader
+//   - DirSource: Lists the files of a directory tree, in a stable order
+//   - PackagesSource: Loads Go packages with their syntax and types
 //
 // Using this abstraction, parsers can focus solely on the transformation of
 // content into enum representations, without concern for the content's origin.
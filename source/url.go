@@ -0,0 +1,115 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+var (
+	// ErrReadURLSource is returned when there is an error fetching the
+	// source URL.
+	ErrReadURLSource = errors.New("failed to read URL source")
+)
+
+const (
+	// DefaultURLTimeout is the time allowed to fetch a URL source when its
+	// Timeout is zero.
+	DefaultURLTimeout = 30 * time.Second
+)
+
+// IsURL reports whether name is an http or https URL rather than a path.
+func IsURL(name string) bool {
+	u, err := url.Parse(name)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// FromURL creates a new URL-based Source implementation that fetches enum
+// definitions from an http or https URL, such as a spec file shared in a
+// contracts repository.
+func FromURL(rawURL string) *URLSource {
+	return &URLSource{URL: rawURL}
+}
+
+// URLSource implements Source for content fetched over HTTP. The content
+// is fetched once and kept, letting the source be parsed more than once
+// like a file.
+type URLSource struct {
+	// URL is the address the content is fetched from
+	URL string
+	// Name is the filename identifying the source, which generated files
+	// are written next to; the last element of the URL path when empty
+	Name string
+	// Client fetches the URL; http.DefaultClient when nil
+	Client *http.Client
+	// Timeout bounds the fetch; DefaultURLTimeout when zero
+	Timeout time.Duration
+	// content is kept once fetched
+	content []byte
+}
+
+// Fetch fetches the content of the URL, or returns the content already
+// fetched. It stops when ctx is done or Timeout elapses, and fails on
+// responses other than 2xx and on content larger than MaxFileSize.
+func (us *URLSource) Fetch(ctx context.Context) ([]byte, error) {
+	if us.content != nil {
+		return us.content, nil
+	}
+	timeout := us.Timeout
+	if timeout == 0 {
+		timeout = DefaultURLTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, us.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrReadURLSource, us.URL, err)
+	}
+	client := us.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadURLSource, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %s: %s", ErrReadURLSource, us.URL, resp.Status)
+	}
+	// Read one byte past the limit to tell a large body from one that fits
+	b, err := io.ReadAll(io.LimitReader(resp.Body, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrReadURLSource, us.URL, err)
+	}
+	if len(b) > MaxFileSize {
+		return nil, fmt.Errorf("%w: %s exceeds maximum allowed size of %d bytes",
+			ErrReadURLSource, us.URL, MaxFileSize)
+	}
+	us.content = b
+	return b, nil
+}
+
+// Content fetches the content of the URL like Fetch, bounded only by
+// Timeout. Call Fetch first to stop fetching with a context.
+func (us *URLSource) Content() ([]byte, error) {
+	return us.Fetch(context.Background())
+}
+
+// Filename returns the Name of the source, or the last element of the URL
+// path, so the extension selects the parser and generated files are
+// written to the current directory.
+func (us *URLSource) Filename() string {
+	if us.Name != "" {
+		return us.Name
+	}
+	if u, err := url.Parse(us.URL); err == nil && path.Base(u.Path) != "/" && path.Base(u.Path) != "." {
+		return path.Base(u.Path)
+	}
+	return "url"
+}
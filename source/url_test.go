@@ -0,0 +1,107 @@
+package source_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/donutnomad/goenums/source"
+)
+
+func TestURLSource(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/contracts/status.enums.yaml", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte("package: orders\n"))
+	})
+	mux.HandleFunc("/slow.enums.yaml", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	mux.HandleFunc("/large.enums.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("#", source.MaxFileSize+1)))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	src := source.FromURL(server.URL + "/contracts/status.enums.yaml?ref=main")
+	if src.Filename() != "status.enums.yaml" {
+		t.Errorf("expected filename %q, got %q", "status.enums.yaml", src.Filename())
+	}
+	for range 2 {
+		content, err := src.Content()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(content) != "package: orders\n" {
+			t.Errorf("got %q, want %q", content, "package: orders\n")
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected the content to be fetched once, got %d requests", requests.Load())
+	}
+
+	tests := []struct {
+		name string
+		src  *source.URLSource
+		ctx  func() context.Context
+	}{
+		{
+			name: "not found",
+			src:  source.FromURL(server.URL + "/missing.enums.yaml"),
+		},
+		{
+			name: "timeout",
+			src:  &source.URLSource{URL: server.URL + "/slow.enums.yaml", Timeout: 10 * time.Millisecond},
+		},
+		{
+			name: "cancelled",
+			src:  source.FromURL(server.URL + "/slow.enums.yaml"),
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+		},
+		{
+			name: "too large",
+			src:  source.FromURL(server.URL + "/large.enums.yaml"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx()
+			}
+			if _, err := tt.src.Fetch(ctx); !errors.Is(err, source.ErrReadURLSource) {
+				t.Errorf("expected ErrReadURLSource, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	t.Parallel()
+	for name, want := range map[string]bool{
+		"https://example.com/status.enums.yaml": true,
+		"http://localhost:8080/status.proto":    true,
+		"status.enums.yaml":                     false,
+		"HEAD:./status.go":                      false,
+		"file:///tmp/status.go":                 false,
+	} {
+		if got := source.IsURL(name); got != want {
+			t.Errorf("IsURL(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
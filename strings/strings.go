@@ -323,6 +323,12 @@ func Contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
 
+// ContainsAny reports whether any Unicode code points in chars are within s.
+// This is a wrapper around strings.ContainsAny.
+func ContainsAny(s, chars string) bool {
+	return strings.ContainsAny(s, chars)
+}
+
 // TrimSpace returns a slice of the string with all leading
 // and trailing white space removed.
 // This is a wrapper around strings.TrimSpace.
@@ -337,6 +343,20 @@ func TrimSuffix(s, suffix string) string {
 	return strings.TrimSuffix(s, suffix)
 }
 
+// CutPrefix returns s without the provided leading prefix string
+// and reports whether it found the prefix.
+// This is a wrapper around strings.CutPrefix.
+func CutPrefix(s, prefix string) (string, bool) {
+	return strings.CutPrefix(s, prefix)
+}
+
+// Cut slices s around the first instance of sep, returning the text
+// before and after sep and reporting whether sep appears in s.
+// This is a wrapper around strings.Cut.
+func Cut(s, sep string) (before, after string, found bool) {
+	return strings.Cut(s, sep)
+}
+
 // Split slices s into all substrings separated by sep and returns them.
 // This is a wrapper around strings.Split.
 func Split(s, sep string) []string {
@@ -369,6 +389,13 @@ func HasSuffix(s, suffix string) bool {
 	return strings.HasSuffix(s, suffix)
 }
 
+// EqualFold reports whether s and t are equal under simple Unicode
+// case-folding.
+// This is a wrapper around strings.EqualFold.
+func EqualFold(s, t string) bool {
+	return strings.EqualFold(s, t)
+}
+
 // Index returns the index of the first instance of sep in s,
 // or -1 if sep is not present in s.
 // This is a wrapper around strings.Index.
@@ -568,6 +595,64 @@ func Pluralise(s string) string {
 	return matchCasing(s, plural)
 }
 
+// PluraliseWith pluralises s like Pluralise, except when the last word of s
+// is a key or a value of plurals, which maps lowercase words to their
+// plurals. Such a word is replaced by its plural, cased like the word, so
+// that plurals overrides words Pluralise gets wrong, such as the "schema"
+// of "tableSchema".
+func PluraliseWith(s string, plurals map[string]string) string {
+	prefix, word := lastWord(s)
+	lower := strings.ToLower(word)
+	if plural, ok := plurals[lower]; ok {
+		return prefix + matchCasing(word, plural)
+	}
+	for _, plural := range plurals {
+		if plural == lower {
+			return s
+		}
+	}
+	return Pluralise(s)
+}
+
+// SingulariseWith returns the singular of s like Singularise, except when
+// the last word of s is a key or a value of plurals, which maps lowercase
+// words to their plurals.
+func SingulariseWith(s string, plurals map[string]string) string {
+	prefix, word := lastWord(s)
+	lower := strings.ToLower(word)
+	if _, ok := plurals[lower]; ok {
+		return s
+	}
+	for singular, plural := range plurals {
+		if plural == lower {
+			return prefix + matchCasing(word, singular)
+		}
+	}
+	if !IsPlural(s) {
+		return s
+	}
+	return Singularise(s)
+}
+
+// lastWord splits s before its last word, which follows the last
+// underscore, hyphen or space, or starts at the last capital beginning a
+// camelCase word, so that "HTTPStatus" ends with "Status".
+func lastWord(s string) (prefix, word string) {
+	runes := []rune(s)
+	start := 0
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ':
+			start = i + 1
+		case i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			start = i
+		}
+	}
+	return string(runes[:start]), string(runes[start:])
+}
+
 func isPlural(s string) bool {
 	if isIrregularPlural(s) {
 		return true
@@ -623,6 +708,29 @@ func Lower1stCharacter(s string) string {
 	return string(c) + s[1:]
 }
 
+// Snake converts a camelCase or PascalCase identifier to snake_case.
+// Runs of capitals are treated as a single word, so "HTTPStatus" becomes "http_status".
+func Snake(s string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' &&
+				(unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+					(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // Integer defines a type constraint for all integer types.
 // This interface uses Go 1.18+ type constraints to represent any integer type,
 // including both signed and unsigned variants of all sizes.
@@ -664,28 +772,9 @@ func Ify(v any) string {
 	case bool:
 		return strconv.FormatBool(v)
 	case time.Time:
-		return v.Format(time.RFC3339)
+		return ifyTime(v)
 	case time.Duration:
-		hrs := v.Hours()
-		mins := v.Minutes()
-		secs := v.Seconds()
-		var b strings.Builder
-		if hrs != 0 {
-			b.WriteString("time.Hour * ")
-			b.WriteString(strconv.FormatFloat(float64(hrs), 'f', -1, 64))
-			return b.String()
-		}
-		if mins != 0 {
-			b.WriteString("time.Minute * ")
-			b.WriteString(strconv.FormatFloat(float64(mins), 'f', -1, 64))
-			return b.String()
-		}
-		if secs != 0 {
-			b.WriteString("time.Second * ")
-			b.WriteString(strconv.FormatFloat(float64(secs), 'f', -1, 64))
-			return b.String()
-		}
-		return b.String()
+		return ifyDuration(v)
 	case fmt.Stringer:
 		return `"` + v.String() + `"`
 	default:
@@ -693,6 +782,43 @@ func Ify(v any) string {
 	}
 }
 
+// durationUnits are the units durations are written in, largest first.
+var durationUnits = []struct {
+	name string
+	d    time.Duration
+}{
+	{"time.Hour", time.Hour},
+	{"time.Minute", time.Minute},
+	{"time.Second", time.Second},
+	{"time.Millisecond", time.Millisecond},
+	{"time.Microsecond", time.Microsecond},
+	{"time.Nanosecond", time.Nanosecond},
+}
+
+// ifyDuration writes d as an integer multiple of the largest unit dividing
+// it, so "90m" becomes "90 * time.Minute".
+func ifyDuration(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	for _, u := range durationUnits {
+		if d%u.d == 0 {
+			return strconv.FormatInt(int64(d/u.d), 10) + " * " + u.name
+		}
+	}
+	return strconv.FormatInt(int64(d), 10)
+}
+
+// ifyTime writes t as a time.Date call, in UTC or the fixed offset of t.
+func ifyTime(t time.Time) string {
+	loc := "time.UTC"
+	if _, offset := t.Zone(); offset != 0 {
+		loc = fmt.Sprintf("time.FixedZone(\"\", %d)", offset)
+	}
+	return fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
 // IfiableNumeric is a generic version that can be used when type is known at compile time
 func IfiableNumeric[T Number](n T) string {
 	f := float64(n)
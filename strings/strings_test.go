@@ -510,12 +510,12 @@ func TestIfy(t *testing.T) {
 		{
 			name:     "zero duration",
 			input:    time.Duration(0),
-			expected: "",
+			expected: "0",
 		},
 		{
 			name:     "negative duration",
 			input:    -1 * time.Hour,
-			expected: "time.Hour * -1",
+			expected: "-1 * time.Hour",
 		},
 		{
 			name:     "complex struct",
@@ -543,32 +543,52 @@ func TestIfyTime(t *testing.T) {
 		t.Parallel()
 		testTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
 		got := strings.Ify(testTime)
-		expected := "2023-01-01T12:00:00Z"
+		expected := "time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)"
+		if got != expected {
+			t.Errorf("Ify(time.Time) = %q, want %q", got, expected)
+		}
+	})
+
+	t.Run("time.Time with offset", func(t *testing.T) {
+		t.Parallel()
+		testTime := time.Date(2024, 6, 2, 15, 4, 5, 500, time.FixedZone("CEST", 2*60*60))
+		got := strings.Ify(testTime)
+		expected := `time.Date(2024, time.June, 2, 15, 4, 5, 500, time.FixedZone("", 7200))`
 		if got != expected {
 			t.Errorf("Ify(time.Time) = %q, want %q", got, expected)
 		}
 	})
 
-	// Test time.Duration - the Ify function converts everything to hours if > 0
+	// Test time.Duration - the Ify function uses the largest unit dividing the duration
 	durationTests := []struct {
 		name     string
 		input    time.Duration
-		contains string // We'll check if the result contains this string
+		expected string
 	}{
 		{
 			name:     "hours",
 			input:    2 * time.Hour,
-			contains: "time.Hour",
+			expected: "2 * time.Hour",
 		},
 		{
-			name:     "minutes converted to hours",
-			input:    30 * time.Minute,
-			contains: "time.Hour", // 30 minutes = 0.5 hours
+			name:     "minutes",
+			input:    90 * time.Minute,
+			expected: "90 * time.Minute",
 		},
 		{
-			name:     "seconds converted to hours",
+			name:     "seconds",
 			input:    45 * time.Second,
-			contains: "time.Hour", // 45 seconds = 0.0125 hours
+			expected: "45 * time.Second",
+		},
+		{
+			name:     "fractional seconds",
+			input:    1500 * time.Millisecond,
+			expected: "1500 * time.Millisecond",
+		},
+		{
+			name:     "nanoseconds",
+			input:    7,
+			expected: "7 * time.Nanosecond",
 		},
 	}
 
@@ -576,8 +596,8 @@ func TestIfyTime(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			got := strings.Ify(tt.input)
-			if !strings.Contains(got, tt.contains) {
-				t.Errorf("Ify(%v) = %q, expected to contain %q", tt.input, got, tt.contains)
+			if got != tt.expected {
+				t.Errorf("Ify(%v) = %q, want %q", tt.input, got, tt.expected)
 			}
 		})
 	}
@@ -794,6 +814,35 @@ func TestPluralise(t *testing.T) {
 	}
 }
 
+func TestPluraliseWith(t *testing.T) {
+	t.Parallel()
+	plurals := map[string]string{"schema": "schemata", "status": "statii"}
+	tests := []struct {
+		input    string
+		plural   string
+		singular string
+	}{
+		{input: "schema", plural: "schemata", singular: "schema"},
+		{input: "TableSchema", plural: "TableSchemata", singular: "TableSchema"},
+		{input: "tableSchemata", plural: "tableSchemata", singular: "tableSchema"},
+		{input: "HTTPStatus", plural: "HTTPStatii", singular: "HTTPStatus"},
+		{input: "http_statii", plural: "http_statii", singular: "http_status"},
+		{input: "orderIndex", plural: "orderIndexes", singular: "orderIndex"},
+		{input: "colors", plural: "colors", singular: "color"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if got := strings.PluraliseWith(tt.input, plurals); got != tt.plural {
+				t.Errorf("PluraliseWith(%q) = %q, want %q", tt.input, got, tt.plural)
+			}
+			if got := strings.SingulariseWith(tt.input, plurals); got != tt.singular {
+				t.Errorf("SingulariseWith(%q) = %q, want %q", tt.input, got, tt.singular)
+			}
+		})
+	}
+}
+
 func TestCamel(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -834,6 +883,56 @@ func TestCamel(t *testing.T) {
 	}
 }
 
+func TestSnake(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "lower camel",
+			input:    "orderStatus",
+			expected: "order_status",
+		},
+		{
+			name:     "pascal case",
+			input:    "TokenRequestStatus",
+			expected: "token_request_status",
+		},
+		{
+			name:     "acronym prefix",
+			input:    "HTTPStatus",
+			expected: "http_status",
+		},
+		{
+			name:     "digits",
+			input:    "step1Initialized",
+			expected: "step1_initialized",
+		},
+		{
+			name:     "already snake",
+			input:    "order_status",
+			expected: "order_status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := strings.Snake(tt.input)
+			if got != tt.expected {
+				t.Errorf("Snake(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestLower1stCharacter(t *testing.T) {
 	t.Parallel()
 	tests := []struct {